@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// importTransaction is a transaction of an import request, and of a line of a JSONL import file.
+type importTransaction struct {
+	BEEF   string   `json:"beef"`
+	Topics []string `json:"topics"`
+}
+
+// importResult is the outcome of an imported transaction.
+type importResult struct {
+	TxID  string `json:"txid"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// beefFlags collects the BEEFs given by repeating the -beef flag.
+type beefFlags []string
+
+func (b *beefFlags) String() string { return strings.Join(*b, ",") }
+
+func (b *beefFlags) Set(value string) error {
+	*b = append(*b, value)
+	return nil
+}
+
+func main() {
+	if err := execute(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func execute() error {
+	var beefs beefFlags
+	flag.Var(&beefs, "beef", "Hexadecimal BEEF of a transaction to import, may be repeated")
+	url := flag.String("url", "http://localhost:3000", "Base URL of the overlay node")
+	token := flag.String("token", "", "Admin bearer token of the overlay node")
+	topics := flag.String("topics", "", "Comma-separated topics of the transactions, used for the JSONL lines without topics")
+	file := flag.String("file", "", "Path to a JSONL file holding one {\"beef\": ..., \"topics\": [...]} transaction per line")
+	batchSize := flag.Int("batch-size", 100, "Number of transactions imported per request, at most 1000")
+	flag.Parse()
+
+	var defaultTopics []string
+	if *topics != "" {
+		defaultTopics = strings.Split(*topics, ",")
+	}
+
+	transactions := make([]importTransaction, 0, len(beefs))
+	for _, beef := range beefs {
+		transactions = append(transactions, importTransaction{BEEF: beef, Topics: defaultTopics})
+	}
+	if *file != "" {
+		read, err := readImportFile(*file, defaultTopics)
+		if err != nil {
+			return fmt.Errorf("read import file op failed: %w", err)
+		}
+		transactions = append(transactions, read...)
+	}
+	if *token == "" {
+		return errors.New("admin bearer token is required, use -token")
+	}
+	if len(transactions) == 0 {
+		return errors.New("no transactions to import, use -beef or -file")
+	}
+	if *batchSize < 1 || *batchSize > 1000 {
+		return errors.New("batch size must be between 1 and 1000")
+	}
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	imported, failed := 0, 0
+	// The node orders the transactions of a request by dependency, the requests are sent in the order of
+	// the input so the parents of a transaction must come first when they belong to an earlier request.
+	for start := 0; start < len(transactions); start += *batchSize {
+		end := min(start+*batchSize, len(transactions))
+		results, err := importBatch(client, *url, *token, transactions[start:end])
+		if err != nil {
+			return fmt.Errorf("import op failed for transactions %d to %d: %w", start+1, end, err)
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				failed++
+				fmt.Printf("transaction %d (%s) rejected: %s: %s\n", start+i+1, result.TxID, result.Error.Code, result.Error.Message)
+				continue
+			}
+			imported++
+		}
+	}
+
+	fmt.Printf("Imported %d transactions, %d rejected\n", imported, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d transactions rejected", failed)
+	}
+	return nil
+}
+
+// readImportFile reads the transactions of a JSONL file, falling back to the default topics
+// for the lines without topics. Blank lines are skipped.
+func readImportFile(path string, defaultTopics []string) ([]importTransaction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var transactions []importTransaction
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var tx importTransaction
+		if err := json.Unmarshal([]byte(text), &tx); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(tx.Topics) == 0 {
+			tx.Topics = defaultTopics
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, scanner.Err()
+}
+
+// importBatch sends the transactions to the admin import endpoint and returns the outcome of each of them.
+func importBatch(client *http.Client, url, token string, transactions []importTransaction) ([]importResult, error) {
+	body, err := json.Marshal(map[string]any{"transactions": transactions})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(url, "/")+"/api/v1/admin/import", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", res.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var response struct {
+		Results []importResult `json:"results"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
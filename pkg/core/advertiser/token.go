@@ -0,0 +1,222 @@
+package advertiser
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// Topics the SHIP and SLAP advertisements are submitted to.
+const (
+	SHIPTopic = "tm_ship"
+	SLAPTopic = "tm_slap"
+)
+
+// ErrInvalidAdvertisement is returned when a locking script is not a SHIP or SLAP advertisement token.
+var ErrInvalidAdvertisement = errors.New("invalid advertisement")
+
+// ErrUnknownProtocol is returned for protocols other than SHIP and SLAP.
+var ErrUnknownProtocol = errors.New("unknown advertisement protocol")
+
+// TopicForProtocol returns the topic advertisements of the protocol are submitted to.
+func TopicForProtocol(protocol overlay.Protocol) (string, error) {
+	switch protocol {
+	case overlay.ProtocolSHIP:
+		return SHIPTopic, nil
+	case overlay.ProtocolSLAP:
+		return SLAPTopic, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownProtocol, protocol)
+	}
+}
+
+// KeyID is the key identifier the advertisement locking keys and signatures are derived with.
+const KeyID = "1"
+
+// WalletProtocol returns the wallet protocol the locking key and the signature of the
+// advertisement tokens of the protocol are derived with.
+func WalletProtocol(protocol overlay.Protocol) wallet.Protocol {
+	name := "Service Lookup Availability"
+	if protocol == overlay.ProtocolSHIP {
+		name = "Service Host Interconnect"
+	}
+	return wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: name}
+}
+
+// TokenCounterparty is the counterparty the advertisement locking keys and signatures are
+// derived for. Deriving them for anyone lets every node verify that a token was created by the
+// identity key it carries, without knowing any secret.
+var TokenCounterparty = wallet.Counterparty{Type: wallet.CounterpartyTypeAnyone}
+
+// ErrInvalidSignature is returned when an advertisement token is not signed by its identity key.
+var ErrInvalidSignature = errors.New("invalid advertisement signature")
+
+var topicOrServiceNamePattern = regexp.MustCompile(`^(tm|ls)_[a-z]+(_[a-z]+)*$`)
+
+// advertisableSchemes are the URI schemes an advertised domain can use.
+var advertisableSchemes = []string{"https", "https+bsvauth", "https+bsvauth+smf", "https+bsvauth+scrypt-offchain", "https+rtt", "wss"}
+
+// DecodeAdvertisement decodes the PushDrop advertisement token held by the locking script.
+// The token locks the output to a key derived from the identity key of the advertiser and
+// pushes the protocol, the identity key, the domain, the topic or service name and a signature
+// over these fields. Only the layout of the token is checked here, the signature is not verified.
+func DecodeAdvertisement(s *script.Script) (*Advertisement, error) {
+	t, err := decodeToken(s)
+	if err != nil {
+		return nil, err
+	}
+	return t.advertisement(), nil
+}
+
+// VerifyAdvertisement decodes the advertisement token held by the locking script and verifies
+// that both its signature and its locking key were derived from the identity key it carries.
+func VerifyAdvertisement(ctx context.Context, s *script.Script) (*Advertisement, error) {
+	t, err := decodeToken(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(t.fields) < 5 {
+		return nil, fmt.Errorf("%w: token is not signed", ErrInvalidSignature)
+	}
+	signature, err := ec.FromDER(t.fields[4])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	anyone, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypeAnyone})
+	if err != nil {
+		return nil, err
+	}
+	args := wallet.EncryptionArgs{
+		ProtocolID:   WalletProtocol(t.protocol()),
+		KeyID:        KeyID,
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: t.identityKey},
+	}
+
+	verified, err := anyone.VerifySignature(ctx, wallet.VerifySignatureArgs{
+		EncryptionArgs: args,
+		Data:           slices.Concat(t.fields[:4]...),
+		Signature:      signature,
+	}, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	} else if !verified.Valid {
+		return nil, ErrInvalidSignature
+	}
+
+	expected, err := anyone.GetPublicKey(ctx, wallet.GetPublicKeyArgs{EncryptionArgs: args}, "")
+	if err != nil {
+		return nil, err
+	}
+	if !expected.PublicKey.IsEqual(t.lockingKey) {
+		return nil, fmt.Errorf("%w: locking key is not linked to the identity key", ErrInvalidSignature)
+	}
+	return t.advertisement(), nil
+}
+
+// IsValidTopicOrServiceName reports whether the name is a valid topic (tm_) or lookup service (ls_)
+// name: at most 50 characters, lowercase letters separated by single underscores.
+func IsValidTopicOrServiceName(name string) bool {
+	return len(name) <= 50 && topicOrServiceNamePattern.MatchString(name)
+}
+
+// IsAdvertisableURI reports whether the domain can be advertised: an absolute URI with one of
+// the supported secure schemes that does not point to localhost.
+func IsAdvertisableURI(domain string) bool {
+	u, err := url.Parse(domain)
+	if err != nil || !slices.Contains(advertisableSchemes, u.Scheme) {
+		return false
+	}
+	host := u.Hostname()
+	return host != "" && host != "localhost"
+}
+
+// token is a decoded PushDrop advertisement token.
+type token struct {
+	lockingKey  *ec.PublicKey
+	identityKey *ec.PublicKey
+	fields      [][]byte
+}
+
+func (t *token) protocol() overlay.Protocol {
+	return overlay.Protocol(t.fields[0])
+}
+
+func (t *token) advertisement() *Advertisement {
+	return &Advertisement{
+		Protocol:       t.protocol(),
+		IdentityKey:    hex.EncodeToString(t.fields[1]),
+		Domain:         string(t.fields[2]),
+		TopicOrService: string(t.fields[3]),
+	}
+}
+
+// decodeToken decodes a PushDrop script with the lock placed before the fields:
+// <pubkey> OP_CHECKSIG <field>... followed by OP_DROP/OP_2DROP, and checks the advertisement fields.
+func decodeToken(s *script.Script) (*token, error) {
+	if s == nil {
+		return nil, fmt.Errorf("%w: missing locking script", ErrInvalidAdvertisement)
+	}
+	chunks, err := s.Chunks()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAdvertisement, err)
+	}
+	if len(chunks) < 3 || chunks[1].Op != script.OpCHECKSIG {
+		return nil, fmt.Errorf("%w: not a PushDrop script", ErrInvalidAdvertisement)
+	}
+	t := &token{}
+	if t.lockingKey, err = ec.PublicKeyFromBytes(chunks[0].Data); err != nil {
+		return nil, fmt.Errorf("%w: invalid locking key: %w", ErrInvalidAdvertisement, err)
+	}
+
+	dropped := false
+	for _, chunk := range chunks[2:] {
+		if chunk.Op == script.OpDROP || chunk.Op == script.Op2DROP {
+			dropped = true
+			break
+		}
+		if chunk.Op > script.OpPUSHDATA4 && (chunk.Op < script.Op1NEGATE || chunk.Op > script.Op16 || chunk.Op == script.OpRESERVED) {
+			return nil, fmt.Errorf("%w: unexpected opcode in fields", ErrInvalidAdvertisement)
+		}
+		t.fields = append(t.fields, chunkData(chunk))
+	}
+	if !dropped {
+		return nil, fmt.Errorf("%w: fields are never dropped", ErrInvalidAdvertisement)
+	}
+	if len(t.fields) < 4 {
+		return nil, fmt.Errorf("%w: expected at least 4 fields, got %d", ErrInvalidAdvertisement, len(t.fields))
+	}
+
+	if _, err := TopicForProtocol(t.protocol()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAdvertisement, err)
+	}
+	if t.identityKey, err = ec.PublicKeyFromBytes(t.fields[1]); err != nil {
+		return nil, fmt.Errorf("%w: invalid identity key: %w", ErrInvalidAdvertisement, err)
+	}
+	return t, nil
+}
+
+// chunkData returns the bytes pushed by the chunk, expanding minimally encoded pushes.
+func chunkData(chunk *script.ScriptChunk) []byte {
+	switch {
+	case len(chunk.Data) > 0:
+		return chunk.Data
+	case chunk.Op == script.Op0:
+		return []byte{0}
+	case chunk.Op == script.Op1NEGATE:
+		return []byte{0x81}
+	case chunk.Op >= script.Op1 && chunk.Op <= script.Op16:
+		return []byte{chunk.Op - script.Op1 + 1}
+	default:
+		return []byte{}
+	}
+}
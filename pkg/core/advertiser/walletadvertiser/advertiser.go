@@ -0,0 +1,273 @@
+// Package walletadvertiser provides an advertiser.Advertiser creating and revoking SHIP and SLAP
+// advertisements through a BRC-100 wallet, keeping track of them through the engine storage.
+package walletadvertiser
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/advertiser"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/pushdrop"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// AdvertisementSatoshis is the amount locked in every advertisement output.
+const AdvertisementSatoshis = 1
+
+// unlockingScriptLength is the length of a PushDrop unlocking script: a single signature push.
+const unlockingScriptLength = 73
+
+// ErrNoAdvertisements is returned when there is nothing to create or revoke.
+var ErrNoAdvertisements = errors.New("no advertisements given")
+
+// Config configures an Advertiser.
+type Config struct {
+	// Wallet derives the advertisement keys and builds the transactions. Required.
+	Wallet Wallet
+	// Storage is the engine storage the advertisements are admitted into, used to find them again. Required.
+	Storage engine.Storage
+	// HostingURL is the domain advertised for every topic and service. Required.
+	HostingURL string
+	// Originator is passed to every wallet call.
+	Originator string
+}
+
+// Advertiser creates SHIP and SLAP advertisements as PushDrop tokens signed with the identity key
+// of the wallet. The tokens it finds and revokes are the unspent outputs of the tm_ship and
+// tm_slap topics held in the engine storage that carry the same identity key.
+type Advertiser struct {
+	wallet      Wallet
+	storage     engine.Storage
+	hostingURL  string
+	originator  string
+	identityKey []byte
+	pushDrop    *pushdrop.PushDropTemplate
+}
+
+// New returns an Advertiser for the configuration, resolving the identity key of the wallet.
+func New(ctx context.Context, cfg Config) (*Advertiser, error) {
+	if cfg.Wallet == nil {
+		return nil, errors.New("wallet is required")
+	}
+	if cfg.Storage == nil {
+		return nil, errors.New("storage is required")
+	}
+	if cfg.HostingURL == "" {
+		return nil, errors.New("hosting URL is required")
+	}
+
+	identity, err := cfg.Wallet.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, cfg.Originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity key: %w", err)
+	}
+
+	return &Advertiser{
+		wallet:      cfg.Wallet,
+		storage:     cfg.Storage,
+		hostingURL:  cfg.HostingURL,
+		originator:  cfg.Originator,
+		identityKey: identity.PublicKey.Compressed(),
+		pushDrop:    &pushdrop.PushDropTemplate{Wallet: keyWallet{keys: cfg.Wallet}, Originator: cfg.Originator},
+	}, nil
+}
+
+// IdentityKey returns the hex encoded identity key advertisements are signed with.
+func (a *Advertiser) IdentityKey() string {
+	return hex.EncodeToString(a.identityKey)
+}
+
+// CreateAdvertisements creates a single transaction holding one advertisement output per entry,
+// tagged with the topics of the advertised protocols.
+func (a *Advertiser) CreateAdvertisements(adsData []*advertiser.AdvertisementData) (overlay.TaggedBEEF, error) {
+	ctx := context.Background()
+	if len(adsData) == 0 {
+		return overlay.TaggedBEEF{}, ErrNoAdvertisements
+	}
+
+	outputs := make([]wallet.CreateActionOutput, 0, len(adsData))
+	topics := make([]string, 0, 2)
+	for _, ad := range adsData {
+		topic, err := advertiser.TopicForProtocol(ad.Protocol)
+		if err != nil {
+			return overlay.TaggedBEEF{}, err
+		}
+		if !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+
+		lockingScript, err := a.lock(ctx, ad.Protocol, ad.TopicOrServiceName)
+		if err != nil {
+			return overlay.TaggedBEEF{}, fmt.Errorf("failed to lock %s advertisement for %s: %w", ad.Protocol, ad.TopicOrServiceName, err)
+		}
+		outputs = append(outputs, wallet.CreateActionOutput{
+			LockingScript:     lockingScript.Bytes(),
+			Satoshis:          AdvertisementSatoshis,
+			OutputDescription: fmt.Sprintf("%s advertisement of %s", ad.Protocol, ad.TopicOrServiceName),
+		})
+	}
+
+	result, err := a.wallet.CreateAction(ctx, wallet.CreateActionArgs{
+		Description: "SHIP/SLAP advertisements",
+		Outputs:     outputs,
+		Options:     &wallet.CreateActionOptions{RandomizeOutputs: &engine.FALSE},
+	}, a.originator)
+	if err != nil {
+		return overlay.TaggedBEEF{}, fmt.Errorf("failed to create advertisement transaction: %w", err)
+	}
+	if len(result.Tx) == 0 {
+		return overlay.TaggedBEEF{}, errors.New("wallet did not return the advertisement transaction")
+	}
+	return overlay.TaggedBEEF{Beef: result.Tx, Topics: topics}, nil
+}
+
+// FindAllAdvertisements returns the unspent advertisements of the protocol made with the identity key of the wallet.
+func (a *Advertiser) FindAllAdvertisements(protocol overlay.Protocol) ([]*advertiser.Advertisement, error) {
+	topic, err := advertiser.TopicForProtocol(protocol)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := a.storage.FindUTXOsForTopic(context.Background(), topic, 0, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s advertisements: %w", protocol, err)
+	}
+
+	identityKey := a.IdentityKey()
+	ads := make([]*advertiser.Advertisement, 0, len(outputs))
+	for _, output := range outputs {
+		ad, err := advertiser.DecodeAdvertisement(output.Script)
+		if err != nil || ad.Protocol != protocol || ad.IdentityKey != identityKey {
+			continue
+		}
+		ad.Beef = output.Beef
+		ad.OutputIndex = output.Outpoint.Index
+		ads = append(ads, ad)
+	}
+	return ads, nil
+}
+
+// RevokeAdvertisements spends the advertisements in a single transaction, tagged with the topics
+// of their protocols so the topic managers remove them.
+func (a *Advertiser) RevokeAdvertisements(advertisements []*advertiser.Advertisement) (overlay.TaggedBEEF, error) {
+	ctx := context.Background()
+	if len(advertisements) == 0 {
+		return overlay.TaggedBEEF{}, ErrNoAdvertisements
+	}
+
+	inputBEEF := transaction.NewBeefV2()
+	inputs := make([]wallet.CreateActionInput, 0, len(advertisements))
+	topics := make([]string, 0, 2)
+	for _, ad := range advertisements {
+		topic, err := advertiser.TopicForProtocol(ad.Protocol)
+		if err != nil {
+			return overlay.TaggedBEEF{}, err
+		}
+		if !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+
+		beef, tx, _, err := transaction.ParseBeef(ad.Beef)
+		if err != nil {
+			return overlay.TaggedBEEF{}, fmt.Errorf("invalid advertisement BEEF: %w", err)
+		} else if tx == nil {
+			return overlay.TaggedBEEF{}, fmt.Errorf("advertisement BEEF does not identify its transaction")
+		}
+		if err := inputBEEF.MergeBeef(beef); err != nil {
+			return overlay.TaggedBEEF{}, err
+		}
+		inputs = append(inputs, wallet.CreateActionInput{
+			Outpoint:              transaction.Outpoint{Txid: *tx.TxID(), Index: ad.OutputIndex},
+			UnlockingScriptLength: unlockingScriptLength,
+			InputDescription:      fmt.Sprintf("revoke %s advertisement of %s", ad.Protocol, ad.TopicOrService),
+		})
+	}
+	inputBEEFBytes, err := inputBEEF.Bytes()
+	if err != nil {
+		return overlay.TaggedBEEF{}, err
+	}
+
+	result, err := a.wallet.CreateAction(ctx, wallet.CreateActionArgs{
+		Description: "Revoke SHIP/SLAP advertisements",
+		InputBEEF:   inputBEEFBytes,
+		Inputs:      inputs,
+	}, a.originator)
+	if err != nil {
+		return overlay.TaggedBEEF{}, fmt.Errorf("failed to create revocation transaction: %w", err)
+	}
+	if result.SignableTransaction == nil {
+		return overlay.TaggedBEEF{}, errors.New("wallet did not return a signable revocation transaction")
+	}
+
+	_, tx, _, err := transaction.ParseBeef(result.SignableTransaction.Tx)
+	if err != nil {
+		return overlay.TaggedBEEF{}, fmt.Errorf("invalid signable revocation transaction: %w", err)
+	} else if tx == nil {
+		return overlay.TaggedBEEF{}, errors.New("signable revocation transaction is missing")
+	}
+
+	spends := make(map[uint32]wallet.SignActionSpend, len(advertisements))
+	for vin, ad := range advertisements {
+		unlockingScript, err := a.unlock(ctx, ad.Protocol, tx, uint32(vin))
+		if err != nil {
+			return overlay.TaggedBEEF{}, fmt.Errorf("failed to unlock %s advertisement of %s: %w", ad.Protocol, ad.TopicOrService, err)
+		}
+		spends[uint32(vin)] = wallet.SignActionSpend{UnlockingScript: unlockingScript.Bytes()}
+	}
+
+	signed, err := a.wallet.SignAction(ctx, wallet.SignActionArgs{
+		Reference: result.SignableTransaction.Reference,
+		Spends:    spends,
+	}, a.originator)
+	if err != nil {
+		return overlay.TaggedBEEF{}, fmt.Errorf("failed to sign revocation transaction: %w", err)
+	}
+	return overlay.TaggedBEEF{Beef: signed.Tx, Topics: topics}, nil
+}
+
+// lock returns the locking script of the advertisement of the topic or service: a PushDrop
+// token signed by, and locked to a key derived from, the identity key of the wallet.
+func (a *Advertiser) lock(ctx context.Context, protocol overlay.Protocol, topicOrService string) (*script.Script, error) {
+	fields := [][]byte{[]byte(protocol), a.identityKey, []byte(a.hostingURL), []byte(topicOrService)}
+	return a.pushDrop.Lock(ctx, fields, advertiser.WalletProtocol(protocol), advertiser.KeyID, advertiser.TokenCounterparty, true, true, true)
+}
+
+// unlock returns the unlocking script spending the advertisement of the protocol at the input.
+// The PushDrop unlocker of the SDK is not used as it hashes the signature hash again and leaves
+// the sighash flag out of the signature, which script verification rejects.
+func (a *Advertiser) unlock(ctx context.Context, protocol overlay.Protocol, tx *transaction.Transaction, vin uint32) (*script.Script, error) {
+	sigHash, err := tx.CalcInputSignatureHash(vin, sighash.AllForkID)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := a.wallet.CreateSignature(ctx, wallet.CreateSignatureArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID:   advertiser.WalletProtocol(protocol),
+			KeyID:        advertiser.KeyID,
+			Counterparty: advertiser.TokenCounterparty,
+		},
+		HashToDirectlySign: sigHash,
+	}, a.originator)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockingScript := &script.Script{}
+	if err := unlockingScript.AppendPushData(append(sig.Signature.Serialize(), byte(sighash.AllForkID))); err != nil {
+		return nil, err
+	}
+	return unlockingScript, nil
+}
+
+// ParseAdvertisement decodes the advertisement held by the locking script.
+func (a *Advertiser) ParseAdvertisement(outputScript *script.Script) (*advertiser.Advertisement, error) {
+	return advertiser.DecodeAdvertisement(outputScript)
+}
+
+var _ advertiser.Advertiser = (*Advertiser)(nil)
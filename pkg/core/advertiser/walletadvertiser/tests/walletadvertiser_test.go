@@ -0,0 +1,167 @@
+package walletadvertiser_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/advertiser"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/advertiser/walletadvertiser"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/discovery"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/stretchr/testify/require"
+)
+
+const hostingURL = "https://overlay.example.com"
+
+func newEngine(storage engine.Storage) *engine.Engine {
+	return engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			advertiser.SHIPTopic: discovery.NewSHIPTopicManager(),
+			advertiser.SLAPTopic: discovery.NewSLAPTopicManager(),
+		},
+		Storage: storage,
+	})
+}
+
+func newAdvertiser(t *testing.T, storage engine.Storage) *walletadvertiser.Advertiser {
+	t.Helper()
+
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	wallet, err := walletadvertiser.NewLocalWallet(key)
+	require.NoError(t, err)
+
+	sut, err := walletadvertiser.New(context.Background(), walletadvertiser.Config{
+		Wallet:     wallet,
+		Storage:    storage,
+		HostingURL: hostingURL,
+	})
+	require.NoError(t, err)
+	return sut
+}
+
+func TestAdvertiser_CreateAdvertisements_ShouldBeFoundOnceAdmitted(t *testing.T) {
+	// given
+	ctx := context.Background()
+	storage := memstorage.New()
+	e := newEngine(storage)
+	sut := newAdvertiser(t, storage)
+
+	// when
+	taggedBEEF, err := sut.CreateAdvertisements([]*advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+		{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_example"},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []string{advertiser.SHIPTopic, advertiser.SLAPTopic}, taggedBEEF.Topics)
+
+	_, err = e.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	ships, err := sut.FindAllAdvertisements(overlay.ProtocolSHIP)
+	require.NoError(t, err)
+	require.Len(t, ships, 1)
+	require.Equal(t, overlay.ProtocolSHIP, ships[0].Protocol)
+	require.Equal(t, sut.IdentityKey(), ships[0].IdentityKey)
+	require.Equal(t, hostingURL, ships[0].Domain)
+	require.Equal(t, "tm_example", ships[0].TopicOrService)
+	require.Equal(t, taggedBEEF.Beef, ships[0].Beef)
+
+	slaps, err := sut.FindAllAdvertisements(overlay.ProtocolSLAP)
+	require.NoError(t, err)
+	require.Len(t, slaps, 1)
+	require.Equal(t, "ls_example", slaps[0].TopicOrService)
+}
+
+func TestAdvertiser_FindAllAdvertisements_ShouldIgnoreOtherIdentities(t *testing.T) {
+	// given
+	ctx := context.Background()
+	storage := memstorage.New()
+	e := newEngine(storage)
+	other := newAdvertiser(t, storage)
+	sut := newAdvertiser(t, storage)
+
+	taggedBEEF, err := other.CreateAdvertisements([]*advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+	})
+	require.NoError(t, err)
+	_, err = e.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	// when
+	ads, err := sut.FindAllAdvertisements(overlay.ProtocolSHIP)
+
+	// then
+	require.NoError(t, err)
+	require.Empty(t, ads)
+}
+
+func TestAdvertiser_RevokeAdvertisements_ShouldSpendAdvertisements(t *testing.T) {
+	// given
+	ctx := context.Background()
+	storage := memstorage.New()
+	e := newEngine(storage)
+	sut := newAdvertiser(t, storage)
+
+	taggedBEEF, err := sut.CreateAdvertisements([]*advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+		{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_example"},
+	})
+	require.NoError(t, err)
+	_, err = e.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	ships, err := sut.FindAllAdvertisements(overlay.ProtocolSHIP)
+	require.NoError(t, err)
+	slaps, err := sut.FindAllAdvertisements(overlay.ProtocolSLAP)
+	require.NoError(t, err)
+
+	// when
+	revocation, err := sut.RevokeAdvertisements(append(ships, slaps...))
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []string{advertiser.SHIPTopic, advertiser.SLAPTopic}, revocation.Topics)
+
+	_, err = e.Submit(ctx, revocation, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	ships, err = sut.FindAllAdvertisements(overlay.ProtocolSHIP)
+	require.NoError(t, err)
+	require.Empty(t, ships)
+
+	slaps, err = sut.FindAllAdvertisements(overlay.ProtocolSLAP)
+	require.NoError(t, err)
+	require.Empty(t, slaps)
+}
+
+func TestAdvertiser_ParseAdvertisement_ShouldRejectNonAdvertisementScript(t *testing.T) {
+	// given
+	sut := newAdvertiser(t, memstorage.New())
+
+	// when
+	ad, err := sut.ParseAdvertisement(&script.Script{script.OpTRUE})
+
+	// then
+	require.ErrorIs(t, err, advertiser.ErrInvalidAdvertisement)
+	require.Nil(t, ad)
+}
+
+func TestAdvertiser_CreateAdvertisements_ShouldFail_WhenProtocolUnknown(t *testing.T) {
+	// given
+	sut := newAdvertiser(t, memstorage.New())
+
+	// when
+	_, err := sut.CreateAdvertisements([]*advertiser.AdvertisementData{
+		{Protocol: "UNKNOWN", TopicOrServiceName: "tm_example"},
+	})
+
+	// then
+	require.ErrorIs(t, err, advertiser.ErrUnknownProtocol)
+}
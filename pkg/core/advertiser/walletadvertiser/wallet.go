@@ -0,0 +1,195 @@
+package walletadvertiser
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// Wallet is the subset of a BRC-100 wallet used by the advertiser: key operations to derive
+// and sign the advertisement tokens, and actions to build the transactions carrying them.
+type Wallet interface {
+	wallet.KeyOperations
+	CreateAction(ctx context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error)
+	SignAction(ctx context.Context, args wallet.SignActionArgs, originator string) (*wallet.SignActionResult, error)
+}
+
+// keyWallet adapts the key operations of a Wallet to the wallet.Interface expected by the
+// PushDrop template, which only derives keys and signs. Any other wallet call panics.
+type keyWallet struct {
+	wallet.Interface
+	keys wallet.KeyOperations
+}
+
+func (k keyWallet) GetPublicKey(ctx context.Context, args wallet.GetPublicKeyArgs, originator string) (*wallet.GetPublicKeyResult, error) {
+	return k.keys.GetPublicKey(ctx, args, originator)
+}
+
+func (k keyWallet) Encrypt(ctx context.Context, args wallet.EncryptArgs, originator string) (*wallet.EncryptResult, error) {
+	return k.keys.Encrypt(ctx, args, originator)
+}
+
+func (k keyWallet) Decrypt(ctx context.Context, args wallet.DecryptArgs, originator string) (*wallet.DecryptResult, error) {
+	return k.keys.Decrypt(ctx, args, originator)
+}
+
+func (k keyWallet) CreateHMAC(ctx context.Context, args wallet.CreateHMACArgs, originator string) (*wallet.CreateHMACResult, error) {
+	return k.keys.CreateHMAC(ctx, args, originator)
+}
+
+func (k keyWallet) VerifyHMAC(ctx context.Context, args wallet.VerifyHMACArgs, originator string) (*wallet.VerifyHMACResult, error) {
+	return k.keys.VerifyHMAC(ctx, args, originator)
+}
+
+func (k keyWallet) CreateSignature(ctx context.Context, args wallet.CreateSignatureArgs, originator string) (*wallet.CreateSignatureResult, error) {
+	return k.keys.CreateSignature(ctx, args, originator)
+}
+
+func (k keyWallet) VerifySignature(ctx context.Context, args wallet.VerifySignatureArgs, originator string) (*wallet.VerifySignatureResult, error) {
+	return k.keys.VerifySignature(ctx, args, originator)
+}
+
+// ErrUnknownReference is returned by LocalWallet.SignAction for references it did not hand out.
+var ErrUnknownReference = errors.New("unknown signable transaction reference")
+
+// LocalWallet is an offline stand-in for a BRC-100 wallet. It derives keys and signs with a
+// private key held in memory, but it neither funds nor broadcasts transactions: the transactions
+// it creates spend only the inputs they are given, have no change and pay no fee. It is meant for
+// development and tests, with an engine that does not broadcast.
+type LocalWallet struct {
+	*wallet.ProtoWallet
+
+	mu      sync.Mutex
+	pending map[string]*transaction.Transaction
+}
+
+// NewLocalWallet returns a LocalWallet using the private key as its identity key.
+func NewLocalWallet(key *ec.PrivateKey) (*LocalWallet, error) {
+	if key == nil {
+		return nil, errors.New("private key is required")
+	}
+	pw, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypePrivateKey, PrivateKey: key})
+	if err != nil {
+		return nil, err
+	}
+	return &LocalWallet{
+		ProtoWallet: pw,
+		pending:     make(map[string]*transaction.Transaction),
+	}, nil
+}
+
+// CreateAction builds a transaction spending the inputs, whose source transactions must be
+// present in the input BEEF, into the outputs. When every input carries its unlocking script
+// the transaction is returned right away, otherwise it is kept until SignAction provides them.
+func (w *LocalWallet) CreateAction(ctx context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error) {
+	tx := transaction.NewTransaction()
+	if args.Version != 0 {
+		tx.Version = args.Version
+	}
+	tx.LockTime = args.LockTime
+
+	if len(args.Inputs) > 0 {
+		beef, err := transaction.NewBeefFromBytes(args.InputBEEF)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input BEEF: %w", err)
+		}
+		for i, in := range args.Inputs {
+			source := beef.FindTransaction(in.Outpoint.Txid.String())
+			if source == nil || int(in.Outpoint.Index) >= len(source.Outputs) {
+				return nil, fmt.Errorf("input %d: source output %s not found in input BEEF", i, in.Outpoint.String())
+			}
+			input := &transaction.TransactionInput{
+				SourceTXID:        &in.Outpoint.Txid,
+				SourceTxOutIndex:  in.Outpoint.Index,
+				SourceTransaction: source,
+				SequenceNumber:    transaction.DefaultSequenceNumber,
+			}
+			if in.SequenceNumber != 0 {
+				input.SequenceNumber = in.SequenceNumber
+			}
+			if len(in.UnlockingScript) > 0 {
+				input.UnlockingScript = script.NewFromBytes(in.UnlockingScript)
+			}
+			tx.AddInput(input)
+		}
+	}
+	for _, out := range args.Outputs {
+		tx.AddOutput(&transaction.TransactionOutput{
+			LockingScript: script.NewFromBytes(out.LockingScript),
+			Satoshis:      out.Satoshis,
+		})
+	}
+
+	atomicBEEF, err := tx.AtomicBEEF(false)
+	if err != nil {
+		return nil, err
+	}
+	if signed(tx) {
+		return &wallet.CreateActionResult{Txid: *tx.TxID(), Tx: atomicBEEF}, nil
+	}
+
+	reference := make([]byte, 16)
+	if _, err := rand.Read(reference); err != nil {
+		return nil, err
+	}
+	w.mu.Lock()
+	w.pending[hex.EncodeToString(reference)] = tx
+	w.mu.Unlock()
+
+	return &wallet.CreateActionResult{
+		SignableTransaction: &wallet.SignableTransaction{Tx: atomicBEEF, Reference: reference},
+	}, nil
+}
+
+// SignAction completes a transaction created by CreateAction with the unlocking scripts of its inputs.
+func (w *LocalWallet) SignAction(ctx context.Context, args wallet.SignActionArgs, originator string) (*wallet.SignActionResult, error) {
+	key := hex.EncodeToString(args.Reference)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	tx, ok := w.pending[key]
+	if !ok {
+		return nil, ErrUnknownReference
+	}
+
+	for vin, spend := range args.Spends {
+		if int(vin) >= len(tx.Inputs) {
+			return nil, fmt.Errorf("spend for unknown input %d", vin)
+		}
+		tx.Inputs[vin].UnlockingScript = script.NewFromBytes(spend.UnlockingScript)
+		if spend.SequenceNumber != 0 {
+			tx.Inputs[vin].SequenceNumber = spend.SequenceNumber
+		}
+	}
+	if !signed(tx) {
+		return nil, errors.New("every input must be unlocked")
+	}
+
+	atomicBEEF, err := tx.AtomicBEEF(false)
+	if err != nil {
+		return nil, err
+	}
+	delete(w.pending, key)
+
+	return &wallet.SignActionResult{Txid: *tx.TxID(), Tx: atomicBEEF}, nil
+}
+
+func signed(tx *transaction.Transaction) bool {
+	for _, input := range tx.Inputs {
+		if input.UnlockingScript == nil || len(*input.UnlockingScript) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+var _ Wallet = (*LocalWallet)(nil)
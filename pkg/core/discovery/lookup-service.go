@@ -0,0 +1,205 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/advertiser"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Names of the lookup services answering queries about SHIP and SLAP advertisements.
+const (
+	SHIPLookupService = "ls_ship"
+	SLAPLookupService = "ls_slap"
+)
+
+// FindAll is the query matching every advertisement.
+const FindAll = "findAll"
+
+// ErrInvalidQuery is returned for queries the lookup service does not understand.
+var ErrInvalidQuery = errors.New("invalid lookup query")
+
+// SHIPQuery selects SHIP advertisements. Every field set must match; Topics matches
+// advertisements of any of the listed topics.
+type SHIPQuery struct {
+	Domain      string   `json:"domain,omitempty"`
+	Topics      []string `json:"topics,omitempty"`
+	IdentityKey string   `json:"identityKey,omitempty"`
+}
+
+// SLAPQuery selects SLAP advertisements. Every field set must match.
+type SLAPQuery struct {
+	Domain      string `json:"domain,omitempty"`
+	Service     string `json:"service,omitempty"`
+	IdentityKey string `json:"identityKey,omitempty"`
+}
+
+const shipLookupServiceDocumentation = `# SHIP Lookup Service
+
+Finds the SHIP advertisements admitted by the tm_ship topic manager, i.e. the nodes hosting a topic.
+
+The query is either the string "findAll" or an object whose fields must all match:
+- "domain": the advertised domain,
+- "topics": a list of topics, any of which may be advertised,
+- "identityKey": the hex encoded identity key of the advertiser.
+
+Example: {"topics": ["tm_example"]}
+`
+
+const slapLookupServiceDocumentation = `# SLAP Lookup Service
+
+Finds the SLAP advertisements admitted by the tm_slap topic manager, i.e. the nodes providing a lookup service.
+
+The query is either the string "findAll" or an object whose fields must all match:
+- "domain": the advertised domain,
+- "service": the lookup service name,
+- "identityKey": the hex encoded identity key of the advertiser.
+
+Example: {"service": "ls_example"}
+`
+
+// LookupService answers queries about the advertisements of a single protocol. The admitted
+// advertisements are read back from the engine storage, which already tracks their admission,
+// spending and eviction, so the notifications of the engine require no bookkeeping.
+type LookupService struct {
+	name          string
+	protocol      overlay.Protocol
+	storage       engine.Storage
+	documentation string
+	metadata      overlay.MetaData
+}
+
+// NewSHIPLookupService returns the ls_ship lookup service reading advertisements from the storage.
+func NewSHIPLookupService(storage engine.Storage) *LookupService {
+	return &LookupService{
+		name:          SHIPLookupService,
+		protocol:      overlay.ProtocolSHIP,
+		storage:       storage,
+		documentation: shipLookupServiceDocumentation,
+		metadata: overlay.MetaData{
+			Name:        "SHIP Lookup Service",
+			Description: "Finds the overlay nodes hosting a topic.",
+		},
+	}
+}
+
+// NewSLAPLookupService returns the ls_slap lookup service reading advertisements from the storage.
+func NewSLAPLookupService(storage engine.Storage) *LookupService {
+	return &LookupService{
+		name:          SLAPLookupService,
+		protocol:      overlay.ProtocolSLAP,
+		storage:       storage,
+		documentation: slapLookupServiceDocumentation,
+		metadata: overlay.MetaData{
+			Name:        "SLAP Lookup Service",
+			Description: "Finds the overlay nodes providing a lookup service.",
+		},
+	}
+}
+
+func (l *LookupService) OutputAdmittedByTopic(ctx context.Context, payload *engine.OutputAdmittedByTopic) error {
+	return nil
+}
+
+func (l *LookupService) OutputSpent(ctx context.Context, payload *engine.OutputSpent) error {
+	return nil
+}
+
+func (l *LookupService) OutputNoLongerRetainedInHistory(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	return nil
+}
+
+func (l *LookupService) OutputEvicted(ctx context.Context, outpoint *transaction.Outpoint) error {
+	return nil
+}
+
+func (l *LookupService) OutputBlockHeightUpdated(ctx context.Context, txid *chainhash.Hash, blockHeight uint32, blockIndex uint64) error {
+	return nil
+}
+
+// Lookup returns a formula answer listing the unspent advertisements matching the query.
+func (l *LookupService) Lookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	if question.Service != l.name {
+		return nil, fmt.Errorf("%w: %s cannot answer queries for %s", ErrInvalidQuery, l.name, question.Service)
+	}
+	match, err := l.parseQuery(question.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	topic, err := advertiser.TopicForProtocol(l.protocol)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := l.storage.FindUTXOsForTopic(ctx, topic, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	answer := &lookup.LookupAnswer{Type: lookup.AnswerTypeFormula, Formulas: []lookup.LookupFormula{}}
+	for _, output := range outputs {
+		ad, err := advertiser.DecodeAdvertisement(output.Script)
+		if err != nil || ad.Protocol != l.protocol || !match(ad) {
+			continue
+		}
+		answer.Formulas = append(answer.Formulas, lookup.LookupFormula{Outpoint: &output.Outpoint})
+	}
+	return answer, nil
+}
+
+// parseQuery returns the predicate selecting the advertisements requested by the query.
+func (l *LookupService) parseQuery(query json.RawMessage) (func(*advertiser.Advertisement) bool, error) {
+	var findAll string
+	if err := json.Unmarshal(query, &findAll); err == nil {
+		if findAll != FindAll {
+			return nil, fmt.Errorf("%w: unknown query %q", ErrInvalidQuery, findAll)
+		}
+		return func(*advertiser.Advertisement) bool { return true }, nil
+	}
+
+	if l.protocol == overlay.ProtocolSHIP {
+		var q SHIPQuery
+		if err := json.Unmarshal(query, &q); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+		}
+		return func(ad *advertiser.Advertisement) bool {
+			return matches(q.Domain, ad.Domain) &&
+				matches(q.IdentityKey, ad.IdentityKey) &&
+				(len(q.Topics) == 0 || slices.Contains(q.Topics, ad.TopicOrService))
+		}, nil
+	}
+
+	var q SLAPQuery
+	if err := json.Unmarshal(query, &q); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+	return func(ad *advertiser.Advertisement) bool {
+		return matches(q.Domain, ad.Domain) &&
+			matches(q.IdentityKey, ad.IdentityKey) &&
+			matches(q.Service, ad.TopicOrService)
+	}, nil
+}
+
+// matches reports whether the value satisfies the filter; an empty filter matches everything.
+func matches(filter, value string) bool {
+	return filter == "" || filter == value
+}
+
+func (l *LookupService) GetDocumentation() string {
+	return l.documentation
+}
+
+func (l *LookupService) GetMetaData() *overlay.MetaData {
+	metadata := l.metadata
+	return &metadata
+}
+
+var _ engine.LookupService = (*LookupService)(nil)
@@ -0,0 +1,285 @@
+package discovery_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/advertiser"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/advertiser/walletadvertiser"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/discovery"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const hostingURL = "https://overlay.example.com"
+
+// createAdvertisements returns the tagged BEEF of the advertisements made by a new identity for the domain.
+func createAdvertisements(t *testing.T, domain string, ads ...*advertiser.AdvertisementData) (overlay.TaggedBEEF, *walletadvertiser.Advertiser) {
+	t.Helper()
+
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	wallet, err := walletadvertiser.NewLocalWallet(key)
+	require.NoError(t, err)
+	adv, err := walletadvertiser.New(context.Background(), walletadvertiser.Config{
+		Wallet:     wallet,
+		Storage:    memstorage.New(),
+		HostingURL: domain,
+	})
+	require.NoError(t, err)
+
+	taggedBEEF, err := adv.CreateAdvertisements(ads)
+	require.NoError(t, err)
+	return taggedBEEF, adv
+}
+
+// withTransaction rebuilds the tagged BEEF after the transaction was modified.
+func withTransaction(t *testing.T, taggedBEEF overlay.TaggedBEEF, modify func(tx *transaction.Transaction)) []byte {
+	t.Helper()
+
+	_, tx, _, err := transaction.ParseBeef(taggedBEEF.Beef)
+	require.NoError(t, err)
+	modify(tx)
+	beef, err := tx.AtomicBEEF(false)
+	require.NoError(t, err)
+	return beef
+}
+
+func newEngine(storage engine.Storage) *engine.Engine {
+	return engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			advertiser.SHIPTopic: discovery.NewSHIPTopicManager(),
+			advertiser.SLAPTopic: discovery.NewSLAPTopicManager(),
+		},
+		LookupServices: map[string]engine.LookupService{
+			discovery.SHIPLookupService: discovery.NewSHIPLookupService(storage),
+			discovery.SLAPLookupService: discovery.NewSLAPLookupService(storage),
+		},
+		Storage: storage,
+	})
+}
+
+func TestTopicManager_IdentifyAdmissibleOutputs_ShouldAdmitValidAdvertisementsOfItsProtocol(t *testing.T) {
+	// given
+	ctx := context.Background()
+	taggedBEEF, _ := createAdvertisements(t, hostingURL,
+		&advertiser.AdvertisementData{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_example"},
+		&advertiser.AdvertisementData{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+	)
+
+	// when
+	ship, shipErr := discovery.NewSHIPTopicManager().IdentifyAdmissibleOutputs(ctx, taggedBEEF.Beef, nil)
+	slap, slapErr := discovery.NewSLAPTopicManager().IdentifyAdmissibleOutputs(ctx, taggedBEEF.Beef, nil)
+
+	// then
+	require.NoError(t, shipErr)
+	require.Equal(t, []uint32{1}, ship.OutputsToAdmit)
+	require.Empty(t, ship.CoinsToRetain)
+
+	require.NoError(t, slapErr)
+	require.Equal(t, []uint32{0}, slap.OutputsToAdmit)
+}
+
+func TestTopicManager_IdentifyAdmissibleOutputs_ShouldRejectInvalidAdvertisements(t *testing.T) {
+	tests := map[string]struct {
+		domain string
+		ad     *advertiser.AdvertisementData
+	}{
+		"localhost domain": {
+			domain: "https://localhost:8080",
+			ad:     &advertiser.AdvertisementData{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+		},
+		"insecure domain": {
+			domain: "http://overlay.example.com",
+			ad:     &advertiser.AdvertisementData{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+		},
+		"lookup service advertised as topic": {
+			domain: hostingURL,
+			ad:     &advertiser.AdvertisementData{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "ls_example"},
+		},
+		"invalid topic name": {
+			domain: hostingURL,
+			ad:     &advertiser.AdvertisementData{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_Example-1"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			taggedBEEF, _ := createAdvertisements(t, tc.domain, tc.ad)
+
+			// when
+			admit, err := discovery.NewSHIPTopicManager().IdentifyAdmissibleOutputs(context.Background(), taggedBEEF.Beef, nil)
+
+			// then
+			require.NoError(t, err)
+			require.Empty(t, admit.OutputsToAdmit)
+		})
+	}
+}
+
+func TestTopicManager_IdentifyAdmissibleOutputs_ShouldRejectTamperedAdvertisement(t *testing.T) {
+	// given
+	taggedBEEF, _ := createAdvertisements(t, hostingURL,
+		&advertiser.AdvertisementData{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+	)
+	beef := withTransaction(t, taggedBEEF, func(tx *transaction.Transaction) {
+		chunks, err := tx.Outputs[0].LockingScript.Chunks()
+		require.NoError(t, err)
+		chunks[4].Data = []byte("https://attacker.example.com")
+		chunks[4].Op = byte(len(chunks[4].Data))
+		tx.Outputs[0].LockingScript, err = script.NewScriptFromScriptOps(chunks)
+		require.NoError(t, err)
+	})
+
+	// when
+	admit, err := discovery.NewSHIPTopicManager().IdentifyAdmissibleOutputs(context.Background(), beef, nil)
+
+	// then
+	require.NoError(t, err)
+	require.Empty(t, admit.OutputsToAdmit)
+}
+
+func TestTopicManager_IdentifyAdmissibleOutputs_ShouldFail_WhenBEEFInvalid(t *testing.T) {
+	// when
+	_, err := discovery.NewSHIPTopicManager().IdentifyAdmissibleOutputs(context.Background(), []byte{0x01}, nil)
+
+	// then
+	require.Error(t, err)
+}
+
+func TestLookupService_Lookup_ShouldFindHostsOfTopic(t *testing.T) {
+	// given
+	ctx := context.Background()
+	storage := memstorage.New()
+	e := newEngine(storage)
+
+	example, _ := createAdvertisements(t, hostingURL,
+		&advertiser.AdvertisementData{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+	)
+	other, _ := createAdvertisements(t, "https://other.example.com",
+		&advertiser.AdvertisementData{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_other"},
+	)
+	for _, taggedBEEF := range []overlay.TaggedBEEF{example, other} {
+		_, err := e.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+		require.NoError(t, err)
+	}
+
+	// when
+	answer, err := e.Lookup(ctx, &lookup.LookupQuestion{
+		Service: discovery.SHIPLookupService,
+		Query:   json.RawMessage(`{"topics": ["tm_example"]}`),
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, lookup.AnswerTypeOutputList, answer.Type)
+	require.Len(t, answer.Outputs, 1)
+	require.Equal(t, example.Beef, answer.Outputs[0].Beef)
+	require.Equal(t, uint32(0), answer.Outputs[0].OutputIndex)
+}
+
+func TestLookupService_Lookup_ShouldFindProvidersOfService(t *testing.T) {
+	// given
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := discovery.NewSLAPLookupService(storage)
+	e := newEngine(storage)
+
+	taggedBEEF, adv := createAdvertisements(t, hostingURL,
+		&advertiser.AdvertisementData{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_example"},
+		&advertiser.AdvertisementData{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_other"},
+	)
+	_, err := e.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	queries := map[string]struct {
+		query    string
+		expected []uint32
+	}{
+		"by service":      {query: `{"service": "ls_other"}`, expected: []uint32{1}},
+		"by identity key": {query: `{"identityKey": "` + adv.IdentityKey() + `"}`, expected: []uint32{0, 1}},
+		"by domain":       {query: `{"domain": "https://unknown.example.com"}`, expected: nil},
+		"find all":        {query: `"findAll"`, expected: []uint32{0, 1}},
+	}
+	for name, tc := range queries {
+		t.Run(name, func(t *testing.T) {
+			// when
+			answer, err := sut.Lookup(ctx, &lookup.LookupQuestion{Service: discovery.SLAPLookupService, Query: json.RawMessage(tc.query)})
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, lookup.AnswerTypeFormula, answer.Type)
+
+			var actual []uint32
+			for _, formula := range answer.Formulas {
+				actual = append(actual, formula.Outpoint.Index)
+			}
+			require.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestLookupService_Lookup_ShouldNotFindRevokedAdvertisements(t *testing.T) {
+	// given
+	ctx := context.Background()
+	storage := memstorage.New()
+	e := newEngine(storage)
+
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	wallet, err := walletadvertiser.NewLocalWallet(key)
+	require.NoError(t, err)
+	adv, err := walletadvertiser.New(ctx, walletadvertiser.Config{Wallet: wallet, Storage: storage, HostingURL: hostingURL})
+	require.NoError(t, err)
+
+	taggedBEEF, err := adv.CreateAdvertisements([]*advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+	})
+	require.NoError(t, err)
+	_, err = e.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	ads, err := adv.FindAllAdvertisements(overlay.ProtocolSHIP)
+	require.NoError(t, err)
+	revocation, err := adv.RevokeAdvertisements(ads)
+	require.NoError(t, err)
+	_, err = e.Submit(ctx, revocation, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	// when
+	answer, err := e.Lookup(ctx, &lookup.LookupQuestion{
+		Service: discovery.SHIPLookupService,
+		Query:   json.RawMessage(`"findAll"`),
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Empty(t, answer.Outputs)
+}
+
+func TestLookupService_Lookup_ShouldFail_WhenQueryInvalid(t *testing.T) {
+	sut := discovery.NewSHIPLookupService(memstorage.New())
+
+	queries := map[string]*lookup.LookupQuestion{
+		"unknown string query": {Service: discovery.SHIPLookupService, Query: json.RawMessage(`"everything"`)},
+		"malformed query":      {Service: discovery.SHIPLookupService, Query: json.RawMessage(`{"topics": "tm_example"}`)},
+		"other service":        {Service: discovery.SLAPLookupService, Query: json.RawMessage(`"findAll"`)},
+	}
+	for name, question := range queries {
+		t.Run(name, func(t *testing.T) {
+			// when
+			answer, err := sut.Lookup(context.Background(), question)
+
+			// then
+			require.ErrorIs(t, err, discovery.ErrInvalidQuery)
+			require.Nil(t, answer)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package discovery provides the SHIP and SLAP topic managers and lookup services through which
+// overlay nodes advertise the topics they host and the lookup services they provide.
+package discovery
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/advertiser"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const shipTopicManagerDocumentation = `# SHIP Topic Manager
+
+Admits Service Host Interconnect Protocol (SHIP) advertisements: PushDrop tokens announcing that
+the node at a domain hosts a topic.
+
+An output is admitted when its token:
+- carries the SHIP protocol, a valid identity key, the domain and the topic name,
+- is signed by the identity key and locked to a key linked to it,
+- advertises an https (or other secure scheme) domain other than localhost,
+- advertises a topic name starting with "tm_".
+
+Spending an advertisement revokes it.
+`
+
+const slapTopicManagerDocumentation = `# SLAP Topic Manager
+
+Admits Service Lookup Availability Protocol (SLAP) advertisements: PushDrop tokens announcing that
+the node at a domain provides a lookup service.
+
+An output is admitted when its token:
+- carries the SLAP protocol, a valid identity key, the domain and the service name,
+- is signed by the identity key and locked to a key linked to it,
+- advertises an https (or other secure scheme) domain other than localhost,
+- advertises a lookup service name starting with "ls_".
+
+Spending an advertisement revokes it.
+`
+
+// TopicManager admits the valid advertisement tokens of a single protocol.
+type TopicManager struct {
+	protocol      overlay.Protocol
+	prefix        string
+	documentation string
+	metadata      overlay.MetaData
+}
+
+// NewSHIPTopicManager returns the topic manager of the tm_ship topic.
+func NewSHIPTopicManager() *TopicManager {
+	return &TopicManager{
+		protocol:      overlay.ProtocolSHIP,
+		prefix:        "tm_",
+		documentation: shipTopicManagerDocumentation,
+		metadata: overlay.MetaData{
+			Name:        "SHIP Topic Manager",
+			Description: "Manages SHIP tokens advertising the topics hosted by overlay nodes.",
+		},
+	}
+}
+
+// NewSLAPTopicManager returns the topic manager of the tm_slap topic.
+func NewSLAPTopicManager() *TopicManager {
+	return &TopicManager{
+		protocol:      overlay.ProtocolSLAP,
+		prefix:        "ls_",
+		documentation: slapTopicManagerDocumentation,
+		metadata: overlay.MetaData{
+			Name:        "SLAP Topic Manager",
+			Description: "Manages SLAP tokens advertising the lookup services provided by overlay nodes.",
+		},
+	}
+}
+
+// IdentifyAdmissibleOutputs admits every output holding a valid advertisement of the protocol.
+// Spent advertisements are never retained.
+func (m *TopicManager) IdentifyAdmissibleOutputs(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+	_, tx, _, err := transaction.ParseBeef(beef)
+	if err != nil {
+		return overlay.AdmittanceInstructions{}, err
+	} else if tx == nil {
+		return overlay.AdmittanceInstructions{}, engine.ErrInvalidBeef
+	}
+
+	admit := overlay.AdmittanceInstructions{}
+	for vout, output := range tx.Outputs {
+		ad, err := advertiser.VerifyAdvertisement(ctx, output.LockingScript)
+		if err != nil {
+			continue
+		}
+		if ad.Protocol != m.protocol ||
+			!advertiser.IsAdvertisableURI(ad.Domain) ||
+			!advertiser.IsValidTopicOrServiceName(ad.TopicOrService) ||
+			!strings.HasPrefix(ad.TopicOrService, m.prefix) {
+			slog.Debug("rejected advertisement", "protocol", ad.Protocol, "domain", ad.Domain, "topicOrService", ad.TopicOrService)
+			continue
+		}
+		admit.OutputsToAdmit = append(admit.OutputsToAdmit, uint32(vout))
+	}
+	return admit, nil
+}
+
+// IdentifyNeededInputs returns no inputs, advertisements do not depend on previous outputs.
+func (m *TopicManager) IdentifyNeededInputs(ctx context.Context, beef []byte) ([]*transaction.Outpoint, error) {
+	return nil, nil
+}
+
+func (m *TopicManager) GetDocumentation() string {
+	return m.documentation
+}
+
+func (m *TopicManager) GetMetaData() *overlay.MetaData {
+	metadata := m.metadata
+	return &metadata
+}
+
+var _ engine.TopicManager = (*TopicManager)(nil)
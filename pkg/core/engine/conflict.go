@@ -0,0 +1,359 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/spv"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Conflict records a transaction rejected because it spends an output of a topic that is
+// already spent by an admitted transaction. Whichever of the two gets a merkle proof first
+// wins: when it is the competing transaction, the admitted one is rolled back in its favor.
+type Conflict struct {
+	Topic string
+	// Outpoint is the output of the topic spent by both transactions.
+	Outpoint transaction.Outpoint
+	// AdmittedTxid is the transaction admitted into the topic spending the output.
+	AdmittedTxid chainhash.Hash
+	// CompetingTxid is the rejected transaction spending the same output.
+	CompetingTxid chainhash.Hash
+	// CompetingBeef is the BEEF the competing transaction was submitted with.
+	CompetingBeef []byte
+	// CreatedAt is the time at which the conflict was recorded.
+	CreatedAt time.Time
+}
+
+// ConflictStorage persists the conflicts between the transactions spending the same outputs.
+type ConflictStorage interface {
+	// Inserts the conflict, inserting a conflict already recorded for the same topic, outpoint and competing transaction is a no-op
+	InsertConflict(ctx context.Context, conflict *Conflict) error
+
+	// Finds the conflicts of the topic ordered by topic, outpoint and competing transaction, an empty topic matches every topic
+	FindConflicts(ctx context.Context, topic string) ([]*Conflict, error)
+
+	// Finds the conflicts in which the transaction is either the admitted or the competing one
+	FindConflictsForTransaction(ctx context.Context, txid *chainhash.Hash) ([]*Conflict, error)
+
+	// Deletes the conflicts over the output of the topic and returns how many were deleted
+	DeleteConflicts(ctx context.Context, topic string, outpoint *transaction.Outpoint) (int, error)
+}
+
+// recordConflict records that the submitted transaction competes with the admitted spender of
+// the output. Failures are logged only, as the submission is rejected either way.
+func (e *Engine) recordConflict(ctx context.Context, output *Output, txid *chainhash.Hash, beef []byte) {
+	if e.Conflicts == nil || output.SpendingTxid == nil {
+		return
+	}
+	err := e.Conflicts.InsertConflict(ctx, &Conflict{
+		Topic:         output.Topic,
+		Outpoint:      output.Outpoint,
+		AdmittedTxid:  *output.SpendingTxid,
+		CompetingTxid: *txid,
+		CompetingBeef: beef,
+		CreatedAt:     time.Now(),
+	})
+	if err != nil {
+		slog.Error("failed to record conflict", "topic", output.Topic, "outpoint", output.Outpoint.String(), "txid", txid, "error", err)
+	}
+}
+
+// ListConflicts returns the recorded conflicts of the topic, or of every topic when the topic
+// is empty. It returns an empty list when conflicts are not persisted.
+func (e *Engine) ListConflicts(ctx context.Context, topic string) ([]*Conflict, error) {
+	if e.Conflicts == nil {
+		return []*Conflict{}, nil
+	}
+	conflicts, err := e.Conflicts.FindConflicts(ctx, topic)
+	if err != nil {
+		slog.Error("failed to find conflicts", "topic", topic, "error", err)
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// conflictResubmitAttempts is how many times the proven competing transaction is submitted in place
+// of the rolled back spenders before the resolution fails, waiting conflictResubmitBackoff longer
+// after every failed attempt.
+const (
+	conflictResubmitAttempts = 3
+	conflictResubmitBackoff  = 100 * time.Millisecond
+)
+
+// resolveConflicts settles the conflicts of the transaction that just got a merkle proof. When it
+// is the admitted spender, the competing transactions can no longer be mined and their conflicts
+// are dropped. When it is a competing spender, the admitted spender and everything built on it in
+// the topic are rolled back, and the proven transaction is submitted in its place. The proven
+// transaction is verified before anything is rolled back, and its inputs stay locked until it is
+// admitted, so no other submission can spend the contested outputs in between. The conflicts are
+// dropped only once it is admitted: when the resolution fails, handling the merkle proof again
+// resumes it, the rollback of the spenders already rolled back being a no-op.
+func (e *Engine) resolveConflicts(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath) error {
+	if e.Conflicts == nil {
+		return nil
+	}
+	conflicts, err := e.Conflicts.FindConflictsForTransaction(ctx, txid)
+	if err != nil {
+		slog.Error("failed to find conflicts for transaction", "txid", txid, "error", err)
+		return err
+	}
+
+	var lost []*Conflict
+	for _, conflict := range conflicts {
+		if conflict.AdmittedTxid.Equal(*txid) {
+			slog.Info("admitted transaction proven, dropping conflict", "topic", conflict.Topic, "outpoint", conflict.Outpoint.String(), "competingTxid", conflict.CompetingTxid)
+			if err := e.deleteConflicts(ctx, conflict); err != nil {
+				return err
+			}
+		} else {
+			lost = append(lost, conflict)
+		}
+	}
+	if len(lost) == 0 {
+		return nil
+	}
+
+	topics := make([]string, 0, len(lost))
+	for _, conflict := range lost {
+		if !slices.Contains(topics, conflict.Topic) {
+			topics = append(topics, conflict.Topic)
+		}
+	}
+
+	winner, err := attachProof(lost[0].CompetingBeef, proof)
+	if err != nil {
+		slog.Error("failed to attach merkle proof to competing transaction", "txid", txid, "error", err)
+		return err
+	}
+	if valid, err := spv.Verify(winner, e.ChainTracker, nil); err != nil {
+		slog.Error("SPV verification of proven competing transaction failed", "txid", txid, "error", err)
+		return err
+	} else if !valid {
+		slog.Error("invalid proven competing transaction", "txid", txid, "error", ErrInvalidTransaction)
+		return ErrInvalidTransaction
+	}
+	beef, err := winner.AtomicBEEF(false)
+	if err != nil {
+		slog.Error("failed to get BEEF of proven competing transaction", "txid", txid, "error", err)
+		return err
+	}
+
+	inpoints := make([]*transaction.Outpoint, 0, len(winner.Inputs)+len(lost))
+	for _, input := range winner.Inputs {
+		inpoints = append(inpoints, &transaction.Outpoint{Txid: *input.SourceTXID, Index: input.SourceTxOutIndex})
+	}
+	for _, conflict := range lost {
+		inpoints = append(inpoints, &conflict.Outpoint)
+	}
+	unlock, err := e.lockOutpoints(ctx, inpoints)
+	if err != nil {
+		slog.Error("failed to lock contested outputs", "txid", txid, "error", err)
+		return err
+	}
+	defer unlock()
+
+	if err := e.rollBackConflicts(ctx, lost); err != nil {
+		return err
+	}
+	if err := e.resubmitWinner(ctx, txid, overlay.TaggedBEEF{Beef: beef, Topics: topics}); err != nil {
+		return err
+	}
+	for _, conflict := range lost {
+		slog.Info("competing transaction proven, replaced admitted transaction", "topic", conflict.Topic, "outpoint", conflict.Outpoint.String(), "admittedTxid", conflict.AdmittedTxid, "competingTxid", txid)
+		if err := e.deleteConflicts(ctx, conflict); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resubmitWinner submits the proven competing transaction in place of the rolled back spenders,
+// retrying up to conflictResubmitAttempts times. The caller holds the locks of its inputs.
+func (e *Engine) resubmitWinner(ctx context.Context, txid *chainhash.Hash, taggedBEEF overlay.TaggedBEEF) error {
+	for attempt := 1; ; attempt++ {
+		_, err := e.submit(ctx, taggedBEEF, SubmitModeHistorical, nil, false)
+		if err == nil {
+			return nil
+		}
+		slog.Error("failed to submit proven competing transaction", "txid", txid, "topics", taggedBEEF.Topics, "attempt", attempt, "error", err)
+		if attempt == conflictResubmitAttempts {
+			return err
+		}
+		select {
+		case <-time.After(time.Duration(attempt) * conflictResubmitBackoff):
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		}
+	}
+}
+
+func (e *Engine) deleteConflicts(ctx context.Context, conflict *Conflict) error {
+	if _, err := e.Conflicts.DeleteConflicts(ctx, conflict.Topic, &conflict.Outpoint); err != nil {
+		slog.Error("failed to delete conflicts", "topic", conflict.Topic, "outpoint", conflict.Outpoint.String(), "error", err)
+		return err
+	}
+	return nil
+}
+
+// rollBackConflicts rolls back, per topic and within one storage transaction, the admitted
+// spenders of the contested outputs, whose locks the caller holds. The lookup services are told
+// about the evicted and restored outputs once the rollback is committed. The rolled back
+// transactions stay recorded as applied, so submitting them again is a no-op.
+func (e *Engine) rollBackConflicts(ctx context.Context, conflicts []*Conflict) error {
+	for _, conflict := range conflicts {
+		rollback := &conflictRollback{topic: conflict.Topic, visited: make(map[chainhash.Hash]struct{})}
+		if err := e.rollBackConflict(ctx, conflict, rollback); err != nil {
+			return err
+		}
+		e.notifyRollback(ctx, rollback)
+	}
+	return nil
+}
+
+// conflictRollback collects the outputs of a topic evicted and restored by a rollback.
+type conflictRollback struct {
+	topic    string
+	visited  map[chainhash.Hash]struct{}
+	evicted  []*Output
+	restored []*Output
+}
+
+func (e *Engine) rollBackConflict(ctx context.Context, conflict *Conflict, rollback *conflictRollback) (err error) {
+	storageTx, err := e.Storage.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to begin storage transaction", "topic", conflict.Topic, "error", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := storageTx.Rollback(); rollbackErr != nil {
+				slog.Error("failed to roll back storage transaction", "topic", conflict.Topic, "error", rollbackErr)
+			}
+		}
+	}()
+
+	inputs := []*transaction.Outpoint{&conflict.Outpoint}
+	if err := e.rollBackTransaction(ctx, storageTx, &conflict.AdmittedTxid, inputs, rollback); err != nil {
+		slog.Error("failed to roll back admitted transaction", "topic", conflict.Topic, "txid", conflict.AdmittedTxid, "error", err)
+		return err
+	}
+	if err := storageTx.Commit(); err != nil {
+		slog.Error("failed to commit storage transaction", "topic", conflict.Topic, "error", err)
+		return err
+	}
+	return nil
+}
+
+// rollBackTransaction removes the outputs of the transaction from the topic, after rolling back
+// every transaction of the topic that spent them, and marks the outputs it spent as unspent again.
+// The inputs list the outputs spent by the transaction that are not recorded as consumed by it.
+func (e *Engine) rollBackTransaction(ctx context.Context, storage StorageOperations, txid *chainhash.Hash, inputs []*transaction.Outpoint, rollback *conflictRollback) error {
+	if _, ok := rollback.visited[*txid]; ok {
+		return nil
+	}
+	rollback.visited[*txid] = struct{}{}
+
+	outputs, err := storage.FindOutputsForTransaction(ctx, txid, false)
+	if err != nil {
+		return err
+	}
+	for _, output := range outputs {
+		if output.Topic != rollback.topic {
+			continue
+		}
+		for _, consumer := range output.ConsumedBy {
+			if err := e.rollBackTransaction(ctx, storage, &consumer.Txid, nil, rollback); err != nil {
+				return err
+			}
+		}
+		inputs = append(inputs, output.OutputsConsumed...)
+		if err := storage.DeleteOutput(ctx, &output.Outpoint, output.Topic); err != nil {
+			return err
+		}
+		rollback.evicted = append(rollback.evicted, output)
+	}
+
+	for _, outpoint := range inputs {
+		input, err := storage.FindOutput(ctx, outpoint, &rollback.topic, nil, true)
+		if err != nil {
+			return err
+		} else if input == nil || input.SpendingTxid == nil || !input.SpendingTxid.Equal(*txid) {
+			continue
+		}
+		restored := *input
+		restored.Spent = false
+		restored.SpendingTxid = nil
+		restored.SpentAt = time.Time{}
+		restored.ConsumedBy = make([]*transaction.Outpoint, 0, len(input.ConsumedBy))
+		for _, consumer := range input.ConsumedBy {
+			if !consumer.Txid.Equal(*txid) {
+				restored.ConsumedBy = append(restored.ConsumedBy, consumer)
+			}
+		}
+		if err := storage.DeleteOutput(ctx, &input.Outpoint, input.Topic); err != nil {
+			return err
+		} else if err := storage.InsertOutput(ctx, &restored); err != nil {
+			return err
+		}
+		rollback.restored = append(rollback.restored, &restored)
+	}
+	return nil
+}
+
+// notifyRollback evicts the rolled back outputs from the lookup services and admits the outputs
+// spent by them again, and publishes the matching events. Failures are logged only, as the
+// rollback is already committed.
+func (e *Engine) notifyRollback(ctx context.Context, rollback *conflictRollback) {
+	for _, output := range rollback.evicted {
+		for _, l := range e.LookupServices {
+			if err := l.OutputEvicted(ctx, &output.Outpoint); err != nil {
+				slog.Error("failed to evict rolled back output from lookup service", "topic", rollback.topic, "outpoint", output.Outpoint.String(), "error", err)
+			}
+		}
+	}
+	for _, output := range rollback.restored {
+		if slices.ContainsFunc(rollback.evicted, func(evicted *Output) bool { return evicted.Outpoint == output.Outpoint }) {
+			continue
+		}
+		for _, l := range e.LookupServices {
+			if err := l.OutputAdmittedByTopic(ctx, &OutputAdmittedByTopic{
+				Topic:         rollback.topic,
+				Outpoint:      &output.Outpoint,
+				Satoshis:      output.Satoshis,
+				LockingScript: output.Script,
+				AtomicBEEF:    output.Beef,
+			}); err != nil {
+				slog.Error("failed to re-admit restored output in lookup service", "topic", rollback.topic, "outpoint", output.Outpoint.String(), "error", err)
+			}
+		}
+	}
+
+	events := make([]Event, 0, len(rollback.evicted)+len(rollback.restored))
+	for _, output := range rollback.evicted {
+		events = append(events, Event{Type: EventOutputEvicted, Topic: rollback.topic, Outpoint: output.Outpoint})
+	}
+	for _, output := range rollback.restored {
+		if !slices.ContainsFunc(rollback.evicted, func(evicted *Output) bool { return evicted.Outpoint == output.Outpoint }) {
+			events = append(events, Event{Type: EventOutputAdmitted, Topic: rollback.topic, Outpoint: output.Outpoint})
+		}
+	}
+	e.publishEvents(ctx, events...)
+}
+
+// attachProof returns the transaction of the BEEF carrying the merkle proof.
+func attachProof(beef []byte, proof *transaction.MerklePath) (*transaction.Transaction, error) {
+	_, tx, _, err := transaction.ParseBeef(beef)
+	if err != nil {
+		return nil, err
+	} else if tx == nil {
+		return nil, ErrInvalidBeef
+	}
+	tx.MerklePath = proof
+	return tx, nil
+}
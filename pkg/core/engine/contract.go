@@ -15,15 +15,68 @@ import (
 // migrating the engine code.
 type OverlayEngineProvider interface {
 	Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, onSteakReady OnSteakReady) (overlay.Steak, error)
+	SubmitAsync(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode) (*SubmitJob, error)
+	FindSubmitJob(ctx context.Context, id string) (*SubmitJob, error)
+	SubmitBatch(ctx context.Context, taggedBEEFs []overlay.TaggedBEEF, mode SumbitMode) []*SubmitBatchResult
 	Lookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error)
 	GetUTXOHistory(ctx context.Context, output *Output, historySelector func(beef []byte, outputIndex uint32, currentDepth uint32) bool, currentDepth uint32) (*Output, error)
 	SyncAdvertisements(ctx context.Context) error
 	StartGASPSync(ctx context.Context) error
 	ProvideForeignSyncResponse(ctx context.Context, initialRequest *core.GASPInitialRequest, topic string) (*core.GASPInitialResponse, error)
 	ProvideForeignGASPNode(ctx context.Context, graphId, outpoint *transaction.Outpoint, topic string) (*core.GASPNode, error)
+	ProvideForeignSyncReply(ctx context.Context, initialResponse *core.GASPInitialResponse, topic string) (*core.GASPInitialReply, error)
+	SubmitForeignGASPNode(ctx context.Context, node *core.GASPNode, topic string) (*core.GASPNodeResponse, error)
+	ListSyncCheckpoints(ctx context.Context, topic string) ([]*SyncCheckpoint, error)
+	ResetSyncCheckpoints(ctx context.Context, topic, peer string) (int, error)
 	ListTopicManagers() map[string]*overlay.MetaData
 	ListLookupServiceProviders() map[string]*overlay.MetaData
 	GetDocumentationForLookupServiceProvider(provider string) (string, error)
 	GetDocumentationForTopicManager(provider string) (string, error)
 	HandleNewMerkleProof(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath) error
+	HandleReorg(ctx context.Context, fromHeight uint32) (*ReorgResult, error)
+	EvictOutput(ctx context.Context, outpoint *transaction.Outpoint, includeHistory bool, actor, reason string) (*Eviction, error)
+	Prune(ctx context.Context, dryRun bool) ([]*PruneReport, error)
+	PruneTopic(ctx context.Context, topic string, dryRun bool) (*PruneReport, error)
+	SubscribeEvents(topics []string, after *uint64) (*EventSubscription, error)
+	RegisterWebhook(ctx context.Context, topic, url, secret string) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ListWebhooks(ctx context.Context) ([]*Webhook, error)
+	ListWebhookDeliveries(ctx context.Context, webhookID string, status WebhookDeliveryStatus) ([]*WebhookDelivery, error)
+	ListOutboxEntries(ctx context.Context, txid *chainhash.Hash, status OutboxStatus) ([]*OutboxEntry, error)
+}
+
+// GASPSyncScheduler is implemented by engines able to sync their topics with peers in the background.
+// RunGASPSyncScheduler blocks until the context is done.
+type GASPSyncScheduler interface {
+	RunGASPSyncScheduler(ctx context.Context)
+}
+
+// HistoryPruner is implemented by engines able to enforce the retention policies of their topics
+// in the background. RunHistoryPruner blocks until the context is done.
+type HistoryPruner interface {
+	RunHistoryPruner(ctx context.Context)
+}
+
+// WebhookDispatcher is implemented by engines able to deliver the events of their topics to webhooks
+// in the background. RunWebhookDispatcher blocks until the context is done.
+type WebhookDispatcher interface {
+	RunWebhookDispatcher(ctx context.Context)
+}
+
+// OutboxDispatcher is implemented by engines able to retry the failed broadcasts and propagations of
+// the submitted transactions in the background. RunOutboxDispatcher blocks until the context is done.
+type OutboxDispatcher interface {
+	RunOutboxDispatcher(ctx context.Context)
+}
+
+// PaymentAcceptor is implemented by engines able to accept the payments of the requests to the overlay,
+// see Engine.AcceptPayment.
+type PaymentAcceptor interface {
+	AcceptPayment(ctx context.Context, payment *Payment) (*PaymentReceipt, error)
+}
+
+// Closer is implemented by engines holding resources on behalf of their clients, such as the subscriptions
+// to their events, that must be released before the server stops. Close must not block on the clients.
+type Closer interface {
+	Close()
 }
@@ -0,0 +1,31 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+)
+
+// engineState holds what an engine builds up while running. It sits behind a pointer allocated by
+// NewEngine, so the copies of an engine share it and every engine guards it with locks of its own.
+type engineState struct {
+	// syncLimiterMu guards syncLimiter, created on first use with the GASPSyncConcurrency of the engine.
+	syncLimiterMu sync.Mutex
+	// syncLimiter caps the sync sessions running at once, see GASPSyncConcurrency.
+	syncLimiter chan struct{}
+	// peerHealthMu guards peerHealth, the outcome of the recent syncs of each topic with each peer.
+	peerHealthMu sync.Mutex
+	peerHealth   map[peerHealthKey]*PeerHealth
+	// lockedOutpointsMu guards lockedOutpoints, the outpoints spent by the submissions being applied,
+	// each mapped to a channel closed once the submission releases it.
+	lockedOutpointsMu sync.Mutex
+	lockedOutpoints   map[string]chan struct{}
+	// foreignGASPMu guards foreignGASP, the GASP instance of each topic completing the graphs pushed by peers.
+	foreignGASPMu sync.Mutex
+	foreignGASP   map[string]*core.GASP
+}
+
+// state returns the running state of the engine, allocated by NewEngine.
+func (e *Engine) state() *engineState {
+	return e.runtime
+}
@@ -1,9 +1,7 @@
 package engine
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -20,6 +18,7 @@ import (
 	"github.com/bsv-blockchain/go-sdk/spv"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+	"github.com/bsv-blockchain/go-sdk/util"
 )
 
 var TRUE = true
@@ -30,6 +29,9 @@ type SumbitMode string
 var (
 	SubmitModeHistorical SumbitMode = "historical-tx"
 	SubmitModeCurrent    SumbitMode = "current-tx"
+	// SubmitModeDryRun verifies the transaction and asks the topic managers what they would admit,
+	// without storing, broadcasting or propagating anything.
+	SubmitModeDryRun SumbitMode = "dry-run"
 )
 
 type SyncConfigurationType int
@@ -44,8 +46,23 @@ type SyncConfiguration struct {
 	Type        SyncConfigurationType
 	Peers       []string
 	Concurrency int
+	// Bidirectional makes the sync of the topic push the UTXOs missing on a peer to it,
+	// on top of pulling the UTXOs missing locally from the peer.
+	Bidirectional bool
+	// Interval is how often the scheduler syncs the topic with its peers in the background,
+	// the topic is synced only on request when it is zero.
+	Interval time.Duration
 }
 
+const (
+	// DefaultForeignGASPGraphTimeout is how long a graph pushed by a peer is kept incomplete while the peer
+	// pushes none of its nodes when ForeignGASPGraphTimeout is not set.
+	DefaultForeignGASPGraphTimeout = 10 * time.Minute
+	// DefaultForeignGASPMaxGraphs caps the graphs pushed by peers kept incomplete per topic when
+	// ForeignGASPMaxGraphs is not set.
+	DefaultForeignGASPMaxGraphs = 256
+)
+
 type OnSteakReady func(steak *overlay.Steak)
 
 type LookupResolverProvider interface {
@@ -58,27 +75,113 @@ type GASPProvider interface {
 	Sync(ctx context.Context) error
 }
 
+// Engine admits the transactions submitted to its topics and answers the lookups of its services.
+// An Engine must be created by NewEngine.
 type Engine struct {
-	Managers                map[string]TopicManager
-	LookupServices          map[string]LookupService
-	Storage                 Storage
-	ChainTracker            chaintracker.ChainTracker
-	HostingURL              string
-	SHIPTrackers            []string
-	SLAPTrackers            []string
-	Broadcaster             transaction.Broadcaster
-	Advertiser              advertiser.Advertiser
-	SyncConfiguration       map[string]SyncConfiguration
-	LogTime                 bool
-	LogPrefix               string
+	Managers          map[string]TopicManager
+	LookupServices    map[string]LookupService
+	Storage           Storage
+	ChainTracker      chaintracker.ChainTracker
+	HostingURL        string
+	SHIPTrackers      []string
+	SLAPTrackers      []string
+	Broadcaster       transaction.Broadcaster
+	Advertiser        advertiser.Advertiser
+	SyncConfiguration map[string]SyncConfiguration
+	LogTime           bool
+	LogPrefix         string
+	// ErrorOnBroadcastFailure makes Submit fail, without admitting anything, when the transaction cannot
+	// be broadcast. When not set the transaction is admitted and the broadcast is retried from the Outbox.
 	ErrorOnBroadcastFailure bool
-	BroadcastFacilitator    topic.Facilitator
-	LookupResolver          LookupResolverProvider
-	GASPProvider            GASPProvider
+	// BroadcastFacilitator sends the submitted transactions to the other overlay nodes hosting their topics.
+	// Defaults to an HTTPS facilitator sending them through the HTTPClient.
+	BroadcastFacilitator topic.Facilitator
+	// HTTPClient sends the requests of the engine to the other overlay nodes: the GASP syncs, and the lookups
+	// and submissions propagating the transactions. Defaults to http.DefaultClient. Nodes requiring mutual
+	// authentication only accept the requests of a client authenticating them, see mutualauth.Transport.
+	HTTPClient     util.HTTPClient
+	LookupResolver LookupResolverProvider
+	// GASPProvider, when set, runs every sync session instead of a GASP created per topic and peer.
+	GASPProvider GASPProvider
+	// GASPSyncConcurrency caps how many topic and peer sync sessions run at once, sessions run
+	// one at a time when it is not set.
+	GASPSyncConcurrency int
+	// SyncCheckpoints persists when each topic was last synced with each peer, so a sync only
+	// requests what the peer admitted since. Defaults to the Storage when it implements
+	// SyncCheckpointStorage, when nil every sync requests the whole UTXO set of the peer.
+	SyncCheckpoints SyncCheckpointStorage
+	// Conflicts persists the transactions rejected for spending outputs already spent by admitted
+	// ones, so the admitted spender can be replaced when the competing one is mined first. Defaults
+	// to the Storage when it implements ConflictStorage, when nil conflicts are not resolved.
+	Conflicts ConflictStorage
+	// Evictions persists the audit log of the outputs purged by EvictOutput. Defaults to the
+	// Storage when it implements EvictionAuditStorage, when nil outputs cannot be evicted.
+	Evictions EvictionAuditStorage
+	// Events hands what happens to the outputs of the topics to subscribers, see SubscribeEvents.
+	// Defaults to an EventBus retaining DefaultEventBufferSize events, when nil events are discarded.
+	Events *EventBus
+	// ConfiguredWebhooks receive the admitted and spent outputs of their topics on top of the webhooks
+	// registered through RegisterWebhook. They cannot be deleted, and need a Webhooks storage to be delivered.
+	ConfiguredWebhooks []Webhook
+	// Webhooks persists the registered webhooks and the queue of their deliveries, see RunWebhookDispatcher.
+	// Defaults to the Storage when it implements WebhookStorage, when nil no webhook is delivered.
+	Webhooks WebhookStorage
+	// WebhookClient posts the deliveries. Defaults to a client timing out after DefaultWebhookTimeout.
+	WebhookClient *http.Client
+	// WebhookConcurrency is how many webhooks are delivered to at once. Defaults to DefaultWebhookConcurrency.
+	WebhookConcurrency int
+	// WebhookMaxAttempts is how many times a delivery is attempted before it is moved to the dead-letter
+	// queue. Defaults to DefaultWebhookMaxAttempts.
+	WebhookMaxAttempts int
+	// WebhookBackoff is the wait before the first retry of a delivery, doubled with every failure up to
+	// WebhookMaxBackoff. Defaults to DefaultWebhookBackoff and DefaultWebhookMaxBackoff.
+	WebhookBackoff    time.Duration
+	WebhookMaxBackoff time.Duration
+	// WebhookPollInterval is how often the dispatcher looks for due deliveries. Defaults to DefaultWebhookPollInterval.
+	WebhookPollInterval time.Duration
+	// Outbox persists the broadcasts and propagations of the submitted transactions, so the failed ones
+	// are retried by RunOutboxDispatcher and their status can be listed. The propagations are first
+	// attempted by RunOutboxDispatcher too. Defaults to the Storage when it implements OutboxStorage,
+	// when nil propagations are attempted by Submit and failed broadcasts and propagations are only logged.
+	Outbox OutboxStorage
+	// OutboxMaxAttempts is how many times a broadcast or propagation is attempted before it is marked
+	// failed. Defaults to DefaultOutboxMaxAttempts.
+	OutboxMaxAttempts int
+	// OutboxBackoff is the wait before the first retry of a broadcast or propagation, doubled with every
+	// failure up to OutboxMaxBackoff. Defaults to DefaultOutboxBackoff and DefaultOutboxMaxBackoff.
+	OutboxBackoff    time.Duration
+	OutboxMaxBackoff time.Duration
+	// OutboxPollInterval is how often the dispatcher looks for due outbox entries. Defaults to DefaultOutboxPollInterval.
+	OutboxPollInterval time.Duration
+	// SubmitJobs runs the submissions queued by SubmitAsync. Defaults to a queue of DefaultSubmitJobWorkers
+	// workers accepting DefaultSubmitJobCapacity waiting submissions, when nil submissions cannot be queued.
+	SubmitJobs *SubmitJobQueue
+	// RetentionPolicies tells, per topic, which spent outputs are kept as history, see PruneTopic.
+	// Topics without a policy keep their history until a later transaction removes it.
+	RetentionPolicies map[string]RetentionPolicy
+	// GASPSyncJitter is the fraction of the sync interval of a topic by which the scheduler randomly
+	// shortens or extends each wait, so peers do not sync in lockstep. Defaults to DefaultGASPSyncJitter.
+	GASPSyncJitter float64
+	// GASPSyncMaxBackoff caps how long the scheduler skips a failing peer. Defaults to DefaultGASPSyncMaxBackoff.
+	GASPSyncMaxBackoff time.Duration
+	// ForeignGASPGraphTimeout is how long a graph pushed by a peer syncing a topic is kept incomplete while
+	// the peer pushes none of its nodes, before it is discarded. Defaults to DefaultForeignGASPGraphTimeout.
+	ForeignGASPGraphTimeout time.Duration
+	// ForeignGASPMaxGraphs caps, per topic, the graphs pushed by peers kept incomplete at once, further
+	// graphs being refused with core.ErrTooManyGraphs. Defaults to DefaultForeignGASPMaxGraphs.
+	ForeignGASPMaxGraphs int
+	// Payments persists the receipts of the payments accepted by AcceptPayment. Defaults to the Storage
+	// when it implements PaymentReceiptStorage, when nil payments cannot be accepted.
+	Payments PaymentReceiptStorage
+	// runtime is the state the engine builds up while running, allocated by NewEngine.
+	runtime *engineState
 	// Logger				  Logger //TODO: Implement Logger Interface
 }
 
+// NewEngine returns an engine configured by cfg, with the defaults of the optional fields resolved
+// and its running state allocated.
 func NewEngine(cfg Engine) *Engine {
+	cfg.runtime = &engineState{}
 	if cfg.SyncConfiguration == nil {
 		cfg.SyncConfiguration = make(map[string]SyncConfiguration)
 	}
@@ -91,6 +194,42 @@ func NewEngine(cfg Engine) *Engine {
 	if cfg.LookupResolver == nil {
 		cfg.LookupResolver = NewLookupResolver()
 	}
+	if cfg.SyncCheckpoints == nil {
+		if checkpoints, ok := cfg.Storage.(SyncCheckpointStorage); ok {
+			cfg.SyncCheckpoints = checkpoints
+		}
+	}
+	if cfg.Conflicts == nil {
+		if conflicts, ok := cfg.Storage.(ConflictStorage); ok {
+			cfg.Conflicts = conflicts
+		}
+	}
+	if cfg.Evictions == nil {
+		if evictions, ok := cfg.Storage.(EvictionAuditStorage); ok {
+			cfg.Evictions = evictions
+		}
+	}
+	if cfg.Webhooks == nil {
+		if webhooks, ok := cfg.Storage.(WebhookStorage); ok {
+			cfg.Webhooks = webhooks
+		}
+	}
+	if cfg.Outbox == nil {
+		if outbox, ok := cfg.Storage.(OutboxStorage); ok {
+			cfg.Outbox = outbox
+		}
+	}
+	if cfg.Payments == nil {
+		if payments, ok := cfg.Storage.(PaymentReceiptStorage); ok {
+			cfg.Payments = payments
+		}
+	}
+	if cfg.Events == nil {
+		cfg.Events = NewEventBus(DefaultEventBufferSize)
+	}
+	if cfg.SubmitJobs == nil {
+		cfg.SubmitJobs = NewSubmitJobQueue(DefaultSubmitJobWorkers, DefaultSubmitJobCapacity, DefaultSubmitJobRetention)
+	}
 
 	for name, manager := range cfg.Managers {
 		config := cfg.SyncConfiguration[name]
@@ -127,6 +266,14 @@ func NewEngine(cfg Engine) *Engine {
 	return &cfg
 }
 
+// httpClient returns the client sending the requests to the other overlay nodes, see HTTPClient.
+func (e *Engine) httpClient() util.HTTPClient {
+	if e.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return e.HTTPClient
+}
+
 var ErrUnknownTopic = errors.New("unknown-topic")
 var ErrInvalidBeef = errors.New("invalid-beef")
 var ErrInvalidTransaction = errors.New("invalid-transaction")
@@ -134,6 +281,12 @@ var ErrMissingInput = errors.New("missing-input")
 var ErrInputSpent = errors.New("input-spent")
 
 func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, onSteakReady OnSteakReady) (overlay.Steak, error) {
+	return e.submit(ctx, taggedBEEF, mode, onSteakReady, true)
+}
+
+// submit applies the submission, locking its inputs first unless lockInputs is false, in which case
+// the caller must already hold the locks of every input of the transaction.
+func (e *Engine) submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, onSteakReady OnSteakReady, lockInputs bool) (overlay.Steak, error) {
 	start := time.Now()
 	for _, topic := range taggedBEEF.Topics {
 		if _, ok := e.Managers[topic]; !ok {
@@ -146,14 +299,14 @@ func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode
 	beef, tx, txid, err := transaction.ParseBeef(taggedBEEF.Beef)
 	if err != nil {
 		slog.Error("failed to parse BEEF in Submit", "error", err)
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBeef, err)
 	} else if tx == nil {
 		slog.Error("invalid BEEF in Submit - tx is nil", "error", ErrInvalidBeef)
 		return nil, ErrInvalidBeef
 	}
 	if valid, err := spv.Verify(tx, e.ChainTracker, nil); err != nil {
 		slog.Error("SPV verification failed in Submit", "txid", txid, "error", err)
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTransaction, err)
 	} else if !valid {
 		slog.Error("invalid transaction in Submit", "txid", txid, "error", ErrInvalidTransaction)
 		return nil, ErrInvalidTransaction
@@ -170,6 +323,18 @@ func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode
 			Index: input.SourceTxOutIndex,
 		})
 	}
+	// The inputs stay locked until the transaction is applied to every topic, so a concurrent
+	// submission spending any of them waits and then finds them spent. A dry run applies nothing.
+	unlock := func() {}
+	if mode != SubmitModeDryRun && lockInputs {
+		unlock, err = e.lockOutpoints(ctx, inpoints)
+		if err != nil {
+			slog.Error("failed to lock inputs in Submit", "txid", txid, "error", err)
+			return nil, err
+		}
+		defer unlock()
+	}
+
 	dupeTopics := make(map[string]struct{}, len(taggedBEEF.Topics))
 	for _, topic := range taggedBEEF.Topics {
 		if exists, err := e.Storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{
@@ -191,6 +356,13 @@ func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode
 				return nil, err
 			}
 			for vin, output := range outputs {
+				if output != nil && output.Spent {
+					slog.Error("input already spent by another transaction", "txid", txid, "topic", topic, "outpoint", output.Outpoint.String(), "error", ErrInputSpent)
+					if mode != SubmitModeDryRun {
+						e.recordConflict(ctx, output, txid, taggedBEEF.Beef)
+					}
+					return nil, ErrInputSpent
+				}
 				if output != nil {
 					previousCoins[uint32(vin)] = &transaction.TransactionOutput{
 						LockingScript: output.Script,
@@ -236,162 +408,77 @@ func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode
 		}
 	}
 
-	for _, topic := range taggedBEEF.Topics {
-		if _, ok := dupeTopics[topic]; ok {
-			continue
-		}
-		if err := e.Storage.MarkUTXOsAsSpent(ctx, inpoints, topic, txid); err != nil {
-			slog.Error("failed to mark UTXOs as spent", "topic", topic, "txid", txid, "error", err)
-			return nil, err
-		}
-		for vin, outpoint := range inpoints {
-			for _, l := range e.LookupServices {
-				if err := l.OutputSpent(ctx, &OutputSpent{
-					Outpoint:           outpoint,
-					Topic:              topic,
-					SpendingTxid:       txid,
-					InputIndex:         uint32(vin),
-					UnlockingScript:    tx.Inputs[vin].UnlockingScript,
-					SequenceNumber:     tx.Inputs[vin].SequenceNumber,
-					SpendingAtomicBEEF: taggedBEEF.Beef,
-				}); err != nil {
-					slog.Error("failed to notify lookup service about spent output", "topic", topic, "txid", txid, "error", err)
-					return nil, err
-				}
-			}
+	if mode == SubmitModeDryRun {
+		if onSteakReady != nil {
+			onSteakReady(&steak)
 		}
+		return steak, nil
 	}
-	slog.Debug("UTXOs marked as spent", "duration", time.Since(start))
-	start = time.Now()
+
+	// The transaction is broadcast before any storage write, so when ErrorOnBroadcastFailure is set a
+	// broadcast failure leaves nothing behind and the transaction can simply be submitted again.
+	// Otherwise the transaction is admitted and the broadcast is retried from the outbox.
+	var outbox []*OutboxEntry
 	if mode != SubmitModeHistorical && e.Broadcaster != nil {
+		var broadcastErr error
 		if _, failure := e.Broadcaster.Broadcast(tx); failure != nil {
 			slog.Error("failed to broadcast transaction", "txid", txid, "error", failure)
-			return nil, failure
-		}
-	}
-
-	if onSteakReady != nil {
-		onSteakReady(&steak)
-	}
-
-	for _, topic := range taggedBEEF.Topics {
-		if _, ok := dupeTopics[topic]; ok {
-			continue
-		}
-		admit := steak[topic]
-		outputsConsumed := make([]*Output, 0, len(admit.CoinsToRetain))
-		outpointsConsumed := make([]*transaction.Outpoint, 0, len(admit.CoinsToRetain))
-		for vin, output := range topicInputs[topic] {
-			for _, coin := range admit.CoinsToRetain {
-				if vin == coin {
-					outputsConsumed = append(outputsConsumed, output)
-					outpointsConsumed = append(outpointsConsumed, &output.Outpoint)
-					delete(topicInputs[topic], vin)
-					break
-				}
+			if e.ErrorOnBroadcastFailure {
+				return nil, failure
 			}
+			broadcastErr = failure
 		}
+		outbox = append(outbox, e.newOutboxEntry(OutboxBroadcast, txid, nil, taggedBEEF.Beef, broadcastErr))
+	}
 
-		for vin, output := range topicInputs[topic] {
-			if err := e.deleteUTXODeep(ctx, output); err != nil {
-				slog.Error("failed to delete UTXO deep", "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
-				return nil, err
-			}
-			admit.CoinsRemoved = append(admit.CoinsRemoved, uint32(vin))
-		}
-
-		newOutpoints := make([]*transaction.Outpoint, 0, len(admit.OutputsToAdmit))
-		for _, vout := range admit.OutputsToAdmit {
-			out := tx.Outputs[vout]
-			output := &Output{
-				Outpoint: transaction.Outpoint{
-					Txid:  *txid,
-					Index: uint32(vout),
-				},
-				Script:          out.LockingScript,
-				Satoshis:        out.Satoshis,
-				Topic:           topic,
-				OutputsConsumed: outpointsConsumed,
-				Beef:            taggedBEEF.Beef,
-				AncillaryTxids:  admit.AncillaryTxids,
-				AncillaryBeef:   ancillaryBeefs[topic],
-			}
-			if tx.MerklePath != nil {
-				output.BlockHeight = tx.MerklePath.BlockHeight
-				for _, leaf := range tx.MerklePath.Path[0] {
-					if leaf.Hash != nil && leaf.Hash.Equal(output.Outpoint.Txid) {
-						output.BlockIdx = leaf.Offset
-						break
-					}
-				}
-			}
-			if err := e.Storage.InsertOutput(ctx, output); err != nil {
-				slog.Error("failed to insert output", "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
-				return nil, err
+	// With an Outbox the propagation is queued together with the first topic applied and attempted
+	// by the dispatcher, so it is not lost when the node stops right after the commit. Otherwise it
+	// is attempted once the transaction is applied.
+	var releventTopics []string
+	if e.Advertiser != nil && mode != SubmitModeHistorical {
+		for _, topic := range taggedBEEF.Topics {
+			if _, ok := dupeTopics[topic]; ok {
+				continue
 			}
-			newOutpoints = append(newOutpoints, &output.Outpoint)
-			for _, l := range e.LookupServices {
-				if err := l.OutputAdmittedByTopic(ctx, &OutputAdmittedByTopic{
-					Topic:         topic,
-					Outpoint:      &output.Outpoint,
-					Satoshis:      output.Satoshis,
-					LockingScript: output.Script,
-					AtomicBEEF:    taggedBEEF.Beef,
-				}); err != nil {
-					slog.Error("failed to notify lookup service about admitted output", "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
-					return nil, err
-				}
+			if steak[topic].OutputsToAdmit != nil || steak[topic].CoinsToRetain != nil {
+				releventTopics = append(releventTopics, topic)
 			}
 		}
-		slog.Debug("outputs added", "duration", time.Since(start))
-		start = time.Now()
-		for _, output := range outputsConsumed {
-			output.ConsumedBy = append(output.ConsumedBy, newOutpoints...)
-
-			if err := e.Storage.UpdateConsumedBy(ctx, &output.Outpoint, output.Topic, output.ConsumedBy); err != nil {
-				slog.Error("failed to update consumed by", "topic", output.Topic, "outpoint", output.Outpoint.String(), "error", err)
-				return nil, err
-			}
-		}
-		slog.Debug("consumed by references updated", "duration", time.Since(start))
-		start = time.Now()
-		if err := e.Storage.InsertAppliedTransaction(ctx, &overlay.AppliedTransaction{
-			Txid:  txid,
-			Topic: topic,
-		}); err != nil {
-			slog.Error("failed to insert applied transaction", "topic", topic, "txid", txid, "error", err)
-			return nil, err
-		}
-		slog.Debug("transaction applied", "duration", time.Since(start))
 	}
-	if e.Advertiser == nil || mode == SubmitModeHistorical {
-		return steak, nil
+	if len(releventTopics) > 0 && e.Outbox != nil {
+		outbox = append(outbox, newQueuedOutboxEntry(OutboxPropagation, txid, releventTopics, taggedBEEF.Beef))
 	}
 
-	releventTopics := make([]string, 0, len(taggedBEEF.Topics))
-	for topic, steak := range steak {
-		if steak.OutputsToAdmit == nil && steak.CoinsToRetain == nil {
+	for _, topic := range taggedBEEF.Topics {
+		if _, ok := dupeTopics[topic]; ok {
 			continue
 		}
-		if _, ok := dupeTopics[topic]; !ok {
-			releventTopics = append(releventTopics, topic)
+		if err := e.applyTopic(ctx, &topicApplication{
+			topic:         topic,
+			tx:            tx,
+			txid:          txid,
+			beef:          taggedBEEF.Beef,
+			inpoints:      inpoints,
+			inputs:        topicInputs[topic],
+			admit:         steak[topic],
+			ancillaryBeef: ancillaryBeefs[topic],
+			outbox:        outbox,
+		}); err != nil {
+			return nil, err
 		}
+		outbox = nil
 	}
-	if len(releventTopics) == 0 {
-		return steak, nil
-	}
+	unlock()
+	e.enqueueOutboxEntries(ctx, outbox...)
 
-	broadcasterCfg := &topic.BroadcasterConfig{}
-	if len(e.SLAPTrackers) > 0 {
-		broadcasterCfg.Resolver = lookup.NewLookupResolver(&lookup.LookupResolver{
-			SLAPTrackers: e.SLAPTrackers,
-		})
+	if onSteakReady != nil {
+		onSteakReady(&steak)
 	}
 
-	if broadcaster, err := topic.NewBroadcaster(releventTopics, broadcasterCfg); err != nil {
-		slog.Error("failed to create broadcaster for propagation", "topics", releventTopics, "error", err)
-	} else if _, failure := broadcaster.BroadcastCtx(ctx, tx); failure != nil {
-		slog.Error("failed to propagate transaction to other nodes", "txid", txid, "error", failure)
+	if len(releventTopics) > 0 && e.Outbox == nil {
+		if err := e.propagate(ctx, tx, releventTopics); err != nil {
+			slog.Error("failed to propagate transaction to other nodes", "txid", txid, "error", err)
+		}
 	}
 	return steak, nil
 }
@@ -575,94 +662,13 @@ func (e *Engine) SyncAdvertisements(ctx context.Context) error {
 	return nil
 }
 
-func (e *Engine) StartGASPSync(ctx context.Context) error {
-	for topic := range e.SyncConfiguration {
-		syncEndpoints, ok := e.SyncConfiguration[topic]
-		if !ok {
-			continue
-		}
-
-		if syncEndpoints.Type == SyncConfigurationSHIP {
-			e.LookupResolver.SetSLAPTrackers(e.SLAPTrackers)
-
-			query, err := json.Marshal(map[string]any{"topics": []string{topic}})
-			if err != nil {
-				slog.Error("failed to marshal query for GASP sync", "topic", topic, "error", err)
-				return err
-			}
-
-			timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-			defer cancel()
-			lookupAnswer, err := e.LookupResolver.Query(timeoutCtx, &lookup.LookupQuestion{Service: "ls_ship", Query: query})
-			if err != nil {
-				slog.Error("failed to query lookup resolver for GASP sync", "topic", topic, "error", err)
-				return err
-			}
-
-			if lookupAnswer.Type == lookup.AnswerTypeOutputList {
-				endpointSet := make(map[string]struct{}, len(lookupAnswer.Outputs))
-				for _, output := range lookupAnswer.Outputs {
-					tx, err := transaction.NewTransactionFromBEEF(output.Beef)
-					if err != nil {
-						slog.Error("failed to parse advertisement output BEEF", "topic", topic, "error", err)
-						continue
-					}
-
-					advertisement, err := e.Advertiser.ParseAdvertisement(tx.Outputs[output.OutputIndex].LockingScript)
-					if err != nil {
-						slog.Error("failed to parse advertisement from locking script", "topic", topic, "error", err)
-						continue
-					}
-
-					if advertisement != nil && advertisement.Protocol == "SHIP" {
-						endpointSet[advertisement.Domain] = struct{}{}
-					}
-				}
-
-				syncEndpoints.Peers = make([]string, 0, len(endpointSet))
-				for endpoint := range endpointSet {
-					if endpoint != e.HostingURL {
-						syncEndpoints.Peers = append(syncEndpoints.Peers, endpoint)
-					}
-				}
-			}
-		}
-
-		if len(syncEndpoints.Peers) > 0 {
-			peers := make([]string, 0, len(syncEndpoints.Peers))
-			for _, peer := range syncEndpoints.Peers {
-				if peer != e.HostingURL {
-					peers = append(peers, peer)
-				}
-			}
-
-			for _, peer := range peers {
-				logPrefix := "[GASP Sync of " + topic + " with " + peer + "]"
-
-				if e.GASPProvider == nil {
-					e.GASPProvider = core.NewGASP(core.GASPParams{
-						Storage: NewOverlayGASPStorage(topic, e, nil),
-						Remote: &OverlayGASPRemote{
-							EndpointUrl: peer,
-							Topic:       topic,
-							HttpClient:  http.DefaultClient,
-						},
-						LogPrefix:      &logPrefix,
-						Unidirectional: true,
-						Concurrency:    syncEndpoints.Concurrency,
-					})
-				}
-
-				if err := e.GASPProvider.Sync(ctx); err != nil {
-					slog.Error("failed to sync with peer", "topic", topic, "peer", peer, "error", err)
-				}
-			}
-		}
-	}
-	return nil
-}
-
+// ProvideForeignSyncResponse lists the UTXOs of the topic admitted since the time of the request.
+// The response carries the same time, so a bidirectional peer replies with the UTXOs it admitted
+// over the same period.
 func (e *Engine) ProvideForeignSyncResponse(ctx context.Context, initialRequest *core.GASPInitialRequest, topic string) (*core.GASPInitialResponse, error) {
+	// The time is taken before the query, so the outputs admitted while it runs are listed again
+	// to a peer syncing from it next time. It is on the local clock, the one the query compares with.
+	since := uint32(time.Now().Unix())
 	if utxos, err := e.Storage.FindUTXOsForTopic(ctx, topic, initialRequest.Since, false); err != nil {
 		slog.Error("failed to find UTXOs for topic in ProvideForeignSyncResponse", "topic", topic, "error", err)
 		return nil, err
@@ -673,10 +679,75 @@ func (e *Engine) ProvideForeignSyncResponse(ctx context.Context, initialRequest
 		}
 		return &core.GASPInitialResponse{
 			UTXOList: utxoList,
+			Since:    since,
 		}, nil
 	}
 }
 
+// ProvideForeignSyncReply answers the initial response of a peer syncing the topic with the UTXOs
+// known locally since the time of the response that the peer did not list.
+func (e *Engine) ProvideForeignSyncReply(ctx context.Context, initialResponse *core.GASPInitialResponse, topic string) (*core.GASPInitialReply, error) {
+	if _, ok := e.Managers[topic]; !ok {
+		slog.Error("unknown topic in ProvideForeignSyncReply", "topic", topic, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+	gasp := core.NewGASP(core.GASPParams{Storage: NewOverlayGASPStorage(topic, e, nil)})
+	reply, err := gasp.GetInitialReply(ctx, initialResponse)
+	if err != nil {
+		slog.Error("failed to build initial reply in ProvideForeignSyncReply", "topic", topic, "error", err)
+		return nil, err
+	}
+	return reply, nil
+}
+
+// SubmitForeignGASPNode appends a node pushed by a peer syncing the topic to its graph and returns
+// the inputs the peer has to push next. Once all of them are pushed the graph is validated and
+// its transactions are submitted in historical mode.
+func (e *Engine) SubmitForeignGASPNode(ctx context.Context, node *core.GASPNode, topic string) (*core.GASPNodeResponse, error) {
+	if _, ok := e.Managers[topic]; !ok {
+		slog.Error("unknown topic in SubmitForeignGASPNode", "topic", topic, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+	response, err := e.foreignGASPFor(topic).SubmitNode(ctx, node)
+	if err != nil {
+		slog.Error("failed to submit foreign GASP node", "topic", topic, "error", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// foreignGASPFor returns the GASP instance of the topic completing the graphs pushed by peers.
+// The instance outlives single requests, as a graph is pushed one node per request, so the graphs
+// it holds are bounded by ForeignGASPGraphTimeout and ForeignGASPMaxGraphs.
+func (e *Engine) foreignGASPFor(topic string) *core.GASP {
+	state := e.state()
+	state.foreignGASPMu.Lock()
+	defer state.foreignGASPMu.Unlock()
+	if state.foreignGASP == nil {
+		state.foreignGASP = make(map[string]*core.GASP)
+	}
+	gasp, ok := state.foreignGASP[topic]
+	if !ok {
+		logPrefix := "[GASP Submissions of " + topic + "]"
+		graphTimeout := e.ForeignGASPGraphTimeout
+		if graphTimeout <= 0 {
+			graphTimeout = DefaultForeignGASPGraphTimeout
+		}
+		maxGraphs := e.ForeignGASPMaxGraphs
+		if maxGraphs <= 0 {
+			maxGraphs = DefaultForeignGASPMaxGraphs
+		}
+		gasp = core.NewGASP(core.GASPParams{
+			Storage:      NewOverlayGASPStorage(topic, e, nil),
+			LogPrefix:    &logPrefix,
+			GraphTimeout: graphTimeout,
+			MaxGraphs:    maxGraphs,
+		})
+		state.foreignGASP[topic] = gasp
+	}
+	return gasp
+}
+
 func (e *Engine) ProvideForeignGASPNode(ctx context.Context, graphId *transaction.Outpoint, outpoint *transaction.Outpoint, topic string) (*core.GASPNode, error) {
 	var hydrator func(ctx context.Context, output *Output) (*core.GASPNode, error)
 	hydrator = func(ctx context.Context, output *Output) (*core.GASPNode, error) {
@@ -719,53 +790,6 @@ func (e *Engine) ProvideForeignGASPNode(ctx context.Context, graphId *transactio
 	}
 }
 
-func (e *Engine) deleteUTXODeep(ctx context.Context, output *Output) error {
-	if len(output.ConsumedBy) == 0 {
-		if err := e.Storage.DeleteOutput(ctx, &output.Outpoint, output.Topic); err != nil {
-			slog.Error("failed to delete output in deleteUTXODeep", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
-			return err
-		}
-		for _, l := range e.LookupServices {
-			if err := l.OutputNoLongerRetainedInHistory(ctx, &output.Outpoint, output.Topic); err != nil {
-				slog.Error("failed to notify lookup service about output removal", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
-				return err
-			}
-		}
-	}
-	if len(output.OutputsConsumed) == 0 {
-		return nil
-	}
-
-	for _, outpoint := range output.OutputsConsumed {
-		staleOutput, err := e.Storage.FindOutput(ctx, outpoint, &output.Topic, nil, false)
-		if err != nil {
-			slog.Error("failed to find stale output in deleteUTXODeep", "outpoint", outpoint.String(), "topic", output.Topic, "error", err)
-			return err
-		} else if staleOutput == nil {
-			continue
-		}
-		if len(staleOutput.ConsumedBy) > 0 {
-			consumedBy := staleOutput.ConsumedBy
-			staleOutput.ConsumedBy = make([]*transaction.Outpoint, 0, len(consumedBy))
-			for _, outpoint := range consumedBy {
-				if !bytes.Equal(outpoint.TxBytes(), output.Outpoint.TxBytes()) {
-					staleOutput.ConsumedBy = append(staleOutput.ConsumedBy, outpoint)
-				}
-			}
-			if err := e.Storage.UpdateConsumedBy(ctx, &staleOutput.Outpoint, staleOutput.Topic, staleOutput.ConsumedBy); err != nil {
-				slog.Error("failed to update consumed by in deleteUTXODeep", "outpoint", staleOutput.Outpoint.String(), "topic", staleOutput.Topic, "error", err)
-				return err
-			}
-		}
-
-		if err := e.deleteUTXODeep(ctx, staleOutput); err != nil {
-			slog.Error("failed recursive deleteUTXODeep", "outpoint", staleOutput.Outpoint.String(), "topic", staleOutput.Topic, "error", err)
-			return err
-		}
-	}
-	return nil
-}
-
 func (e *Engine) updateInputProofs(ctx context.Context, tx *transaction.Transaction, txid chainhash.Hash, proof *transaction.MerklePath) (err error) {
 	if tx.MerklePath != nil {
 		tx.MerklePath = proof
@@ -910,8 +934,9 @@ func (e *Engine) HandleNewMerkleProof(ctx context.Context, txid *chainhash.Hash,
 				return err
 			}
 		}
+		e.publishEvents(ctx, blockHeightEvents(outputs, blockHeight, *blockIdx)...)
 	}
-	return nil
+	return e.resolveConflicts(ctx, txid, proof)
 }
 
 func (e *Engine) ListTopicManagers() map[string]*overlay.MetaData {
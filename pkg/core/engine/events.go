@@ -0,0 +1,267 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultEventBufferSize is the number of recent events the engine retains for subscribers
+	// resuming from a cursor when Events is not set.
+	DefaultEventBufferSize = 1024
+	// eventSubscriberBuffer is the number of events a subscriber may lag behind before it is dropped.
+	eventSubscriberBuffer = 256
+)
+
+var (
+	// ErrEventCursorExpired is returned when subscribing from a cursor whose following events are
+	// no longer retained, or which was never published.
+	ErrEventCursorExpired = errors.New("event-cursor-expired")
+	// ErrEventSubscriberTooSlow is reported by a subscription dropped for not keeping up with the events.
+	ErrEventSubscriberTooSlow = errors.New("event-subscriber-too-slow")
+	// ErrEventBusClosed is returned when subscribing to a closed event bus.
+	ErrEventBusClosed = errors.New("event-bus-closed")
+)
+
+// EventType tells what happened to the outputs of a topic.
+type EventType string
+
+const (
+	EventOutputAdmitted     EventType = "output-admitted"
+	EventOutputSpent        EventType = "output-spent"
+	EventOutputEvicted      EventType = "output-evicted"
+	EventBlockHeightUpdated EventType = "block-height-updated"
+	EventSyncFinished       EventType = "sync-finished"
+)
+
+// Event is published by the engine once a change of a topic is stored.
+type Event struct {
+	// Cursor identifies the event, the cursors of successive events are increasing.
+	Cursor uint64
+	Type   EventType
+	Topic  string
+	// Outpoint is the output the event is about, zero for sync-finished events.
+	Outpoint transaction.Outpoint
+	// SpendingTxid is the transaction spending the output, set for output-spent events.
+	SpendingTxid *chainhash.Hash
+	// BlockHeight and BlockIdx locate the transaction of the output, set for block-height-updated
+	// events. Both are zero when the transaction was downgraded to unproven by a reorg.
+	BlockHeight uint32
+	BlockIdx    uint64
+	// Peer is the peer the topic was synced with, set for sync-finished events.
+	Peer string
+	// Error is why the sync failed, empty when the sync completed.
+	Error     string
+	CreatedAt time.Time
+}
+
+// EventBus hands the events published by the engine to its subscribers, and retains the most
+// recent ones so a subscriber can resume from the cursor of the last event it received.
+// An EventBus is safe for concurrent use, and a nil EventBus discards every event.
+type EventBus struct {
+	mu          sync.Mutex
+	capacity    int
+	events      []Event
+	last        uint64
+	subscribers map[*EventSubscription]struct{}
+	closed      bool
+}
+
+// NewEventBus returns an event bus retaining the given number of recent events.
+func NewEventBus(capacity int) *EventBus {
+	return &EventBus{
+		capacity:    max(capacity, 0),
+		subscribers: make(map[*EventSubscription]struct{}),
+	}
+}
+
+// Publish assigns a cursor to every event and hands them to the subscribers of their topic.
+// Subscribers not keeping up are dropped with ErrEventSubscriberTooSlow.
+func (b *EventBus) Publish(events ...Event) {
+	if b == nil || len(events) == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	now := time.Now()
+	for _, event := range events {
+		b.last++
+		event.Cursor = b.last
+		event.CreatedAt = now
+		b.retain(event)
+		for sub := range b.subscribers {
+			if !sub.matches(event) {
+				continue
+			}
+			select {
+			case sub.events <- event:
+			default:
+				sub.drop(ErrEventSubscriberTooSlow)
+			}
+		}
+	}
+}
+
+// retain keeps the event among the recent ones. The caller must hold the lock.
+func (b *EventBus) retain(event Event) {
+	if b.capacity == 0 {
+		return
+	}
+	b.events = append(b.events, event)
+	if len(b.events) >= 2*b.capacity {
+		b.events = append(make([]Event, 0, 2*b.capacity), b.events[len(b.events)-b.capacity:]...)
+	}
+}
+
+// retained returns the recent events, oldest first. The caller must hold the lock.
+func (b *EventBus) retained() []Event {
+	if len(b.events) > b.capacity {
+		return b.events[len(b.events)-b.capacity:]
+	}
+	return b.events
+}
+
+// Subscribe returns a subscription to the events of the topics, or of every topic when none is
+// given. When after is set, the retained events following that cursor are delivered first; it
+// returns ErrEventCursorExpired when some of them are no longer retained. When after is nil,
+// only the events published from now on are delivered.
+func (b *EventBus) Subscribe(topics []string, after *uint64) (*EventSubscription, error) {
+	if b == nil {
+		return nil, ErrEventBusClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrEventBusClosed
+	}
+
+	var replay []Event
+	if after != nil {
+		retained := b.retained()
+		oldest := b.last - uint64(len(retained)) + 1
+		if *after > b.last || *after+1 < oldest {
+			return nil, ErrEventCursorExpired
+		}
+		replay = retained[len(retained)-int(b.last-*after):]
+	}
+
+	sub := &EventSubscription{bus: b, events: make(chan Event, eventSubscriberBuffer+len(replay))}
+	if len(topics) > 0 {
+		sub.topics = make(map[string]struct{}, len(topics))
+		for _, topic := range topics {
+			sub.topics[topic] = struct{}{}
+		}
+	}
+	for _, event := range replay {
+		if sub.matches(event) {
+			sub.events <- event
+		}
+	}
+	b.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+// Close ends every subscription. Events published afterwards are discarded.
+func (b *EventBus) Close() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for sub := range b.subscribers {
+		sub.drop(nil)
+	}
+}
+
+// EventSubscription delivers the events of the topics it subscribed to, in cursor order.
+type EventSubscription struct {
+	bus    *EventBus
+	topics map[string]struct{}
+	events chan Event
+	err    error
+	done   bool
+}
+
+// Events returns the channel delivering the events. It is closed once the subscription ends.
+func (s *EventSubscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns why the subscription ended, nil while it is active or when it was closed.
+func (s *EventSubscription) Err() error {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	return s.err
+}
+
+// Close ends the subscription. It may be called more than once.
+func (s *EventSubscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.drop(nil)
+}
+
+func (s *EventSubscription) matches(event Event) bool {
+	if s.topics == nil {
+		return true
+	}
+	_, ok := s.topics[event.Topic]
+	return ok
+}
+
+// drop ends the subscription with the error. The caller must hold the lock of the bus.
+func (s *EventSubscription) drop(err error) {
+	if s.done {
+		return
+	}
+	s.done = true
+	s.err = err
+	delete(s.bus.subscribers, s)
+	close(s.events)
+}
+
+// blockHeightEvents returns the block-height-updated events of the outputs of a transaction.
+func blockHeightEvents(outputs []*Output, blockHeight uint32, blockIdx uint64) []Event {
+	events := make([]Event, 0, len(outputs))
+	for _, output := range outputs {
+		events = append(events, Event{
+			Type:        EventBlockHeightUpdated,
+			Topic:       output.Topic,
+			Outpoint:    output.Outpoint,
+			BlockHeight: blockHeight,
+			BlockIdx:    blockIdx,
+		})
+	}
+	return events
+}
+
+// publishEvents publishes the events on the event bus, and queues the admitted and spent outputs
+// among them for delivery to the webhooks of their topics.
+func (e *Engine) publishEvents(ctx context.Context, events ...Event) {
+	e.Events.Publish(events...)
+	e.enqueueWebhookDeliveries(ctx, events)
+}
+
+// SubscribeEvents returns a subscription to the events of the topics, see EventBus.Subscribe.
+func (e *Engine) SubscribeEvents(topics []string, after *uint64) (*EventSubscription, error) {
+	return e.Events.Subscribe(topics, after)
+}
+
+// Close ends the subscriptions to the events of the engine, so the clients streaming them are let go
+// when the server shuts down. Events published afterwards are discarded.
+func (e *Engine) Close() {
+	e.Events.Close()
+}
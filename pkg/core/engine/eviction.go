@@ -0,0 +1,216 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ErrEvictionAuditUnavailable is returned by EvictOutput when the engine has no EvictionAuditStorage,
+// as outputs are never evicted without leaving an audit entry behind.
+var ErrEvictionAuditUnavailable = errors.New("eviction-audit-unavailable")
+
+// EvictedOutput is an output of a topic removed by an eviction.
+type EvictedOutput struct {
+	Outpoint transaction.Outpoint `json:"outpoint"`
+	Topic    string               `json:"topic"`
+}
+
+// Eviction is the audit entry of an output purged from every topic, for instance to comply
+// with a legal takedown request.
+type Eviction struct {
+	// Outpoint is the output requested to be evicted.
+	Outpoint transaction.Outpoint
+	// IncludeHistory tells whether the outputs the evicted output was built from were evicted too.
+	IncludeHistory bool
+	// Evicted lists every output removed from storage, per topic.
+	Evicted []EvictedOutput
+	// Actor identifies who requested the eviction.
+	Actor string
+	// Reason explains why the output was evicted.
+	Reason string
+	// CreatedAt is the time at which the eviction was recorded.
+	CreatedAt time.Time
+}
+
+// EvictionAuditStorage persists the audit log of the evictions. The log is append only.
+type EvictionAuditStorage interface {
+	// Appends the eviction to the audit log, recorded evictions are never updated or deleted
+	InsertEviction(ctx context.Context, eviction *Eviction) error
+
+	// Finds every recorded eviction ordered by the time it was recorded
+	FindEvictions(ctx context.Context) ([]*Eviction, error)
+}
+
+// EvictionTransaction is implemented by the storage transactions able to record evictions, so the audit
+// entry of an eviction is recorded together with the purge. Evictions recorded within a rolled back
+// transaction are dropped.
+type EvictionTransaction interface {
+	// Appends the eviction to the audit log as part of the transaction
+	InsertEviction(ctx context.Context, eviction *Eviction) error
+}
+
+// EvictOutput purges the output from storage across every topic and tells every lookup service
+// to forget it through OutputEvicted. When includeHistory is set, the outputs the evicted output
+// was built from are purged too, recursively within each topic. Outputs of other topics spending
+// the evicted ones are kept. The eviction is recorded in the audit log together with the actor and
+// the reason before the purge is committed, and the lookup services are told about it afterwards.
+// It returns ErrNotFound when the output is not stored in any topic.
+func (e *Engine) EvictOutput(ctx context.Context, outpoint *transaction.Outpoint, includeHistory bool, actor, reason string) (*Eviction, error) {
+	if e.Evictions == nil {
+		return nil, ErrEvictionAuditUnavailable
+	}
+	unlock, err := e.lockOutpoints(ctx, []*transaction.Outpoint{outpoint})
+	if err != nil {
+		slog.Error("failed to lock evicted output", "outpoint", outpoint.String(), "error", err)
+		return nil, err
+	}
+	defer unlock()
+
+	eviction := &Eviction{
+		Outpoint:       *outpoint,
+		IncludeHistory: includeHistory,
+		Evicted:        make([]EvictedOutput, 0),
+		Actor:          actor,
+		Reason:         reason,
+	}
+	if err := e.purgeOutput(ctx, eviction); err != nil {
+		return nil, err
+	}
+	slog.Info("output evicted", "outpoint", outpoint.String(), "evicted", len(eviction.Evicted), "actor", actor, "reason", reason)
+	return eviction, nil
+}
+
+// purgeOutput deletes the outputs of the eviction within one storage transaction and records the
+// eviction before committing it, within the same transaction when the Evictions are kept by the
+// Storage. Once committed, the outputs are evicted from the lookup services, whose failures are
+// logged only as the outputs are purged by then.
+func (e *Engine) purgeOutput(ctx context.Context, eviction *Eviction) (err error) {
+	outpoint := &eviction.Outpoint
+	storageTx, err := e.Storage.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to begin storage transaction in EvictOutput", "outpoint", outpoint.String(), "error", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := storageTx.Rollback(); rollbackErr != nil {
+				slog.Error("failed to roll back storage transaction in EvictOutput", "outpoint", outpoint.String(), "error", rollbackErr)
+			}
+		}
+	}()
+
+	outputs, err := storageTx.FindOutputsForTransaction(ctx, &outpoint.Txid, false)
+	if err != nil {
+		slog.Error("failed to find outputs in EvictOutput", "outpoint", outpoint.String(), "error", err)
+		return err
+	}
+	for _, output := range outputs {
+		if output.Outpoint.Index != outpoint.Index {
+			continue
+		}
+		if err := e.evictOutput(ctx, storageTx, output, eviction); err != nil {
+			slog.Error("failed to evict output", "outpoint", outpoint.String(), "topic", output.Topic, "error", err)
+			return err
+		}
+	}
+	if len(eviction.Evicted) == 0 {
+		return ErrNotFound
+	}
+
+	eviction.CreatedAt = time.Now()
+	var evictions EvictionTransaction = e.Evictions
+	if evictionTx, ok := e.evictionTransaction(storageTx); ok {
+		evictions = evictionTx
+	}
+	if err := evictions.InsertEviction(ctx, eviction); err != nil {
+		slog.Error("failed to record eviction", "outpoint", outpoint.String(), "actor", eviction.Actor, "reason", eviction.Reason, "error", err)
+		return err
+	}
+
+	if err := storageTx.Commit(); err != nil {
+		slog.Error("failed to commit storage transaction in EvictOutput", "outpoint", outpoint.String(), "error", err)
+		return err
+	}
+
+	notified := make(map[transaction.Outpoint]struct{}, len(eviction.Evicted))
+	for _, evicted := range eviction.Evicted {
+		if _, ok := notified[evicted.Outpoint]; ok {
+			continue
+		}
+		notified[evicted.Outpoint] = struct{}{}
+		for _, l := range e.LookupServices {
+			if err := l.OutputEvicted(ctx, &evicted.Outpoint); err != nil {
+				slog.Error("failed to evict output from lookup service", "outpoint", evicted.Outpoint.String(), "error", err)
+			}
+		}
+	}
+
+	events := make([]Event, 0, len(eviction.Evicted))
+	for _, evicted := range eviction.Evicted {
+		events = append(events, Event{Type: EventOutputEvicted, Topic: evicted.Topic, Outpoint: evicted.Outpoint})
+	}
+	e.publishEvents(ctx, events...)
+	return nil
+}
+
+// evictionTransaction returns the storage transaction as an EvictionTransaction when the evictions
+// are kept by the Storage the transaction belongs to.
+func (e *Engine) evictionTransaction(storageTx StorageTransaction) (EvictionTransaction, bool) {
+	if evictions, ok := e.Storage.(EvictionAuditStorage); !ok || evictions != e.Evictions {
+		return nil, false
+	}
+	evictionTx, ok := storageTx.(EvictionTransaction)
+	return evictionTx, ok
+}
+
+// evictOutput deletes the output from its topic and either evicts the outputs it consumed as well
+// or, when the history is kept, forgets that they were consumed by it.
+func (e *Engine) evictOutput(ctx context.Context, storage StorageOperations, output *Output, eviction *Eviction) error {
+	if err := storage.DeleteOutput(ctx, &output.Outpoint, output.Topic); err != nil {
+		return err
+	}
+	eviction.Evicted = append(eviction.Evicted, EvictedOutput{Outpoint: output.Outpoint, Topic: output.Topic})
+
+	for _, outpoint := range output.OutputsConsumed {
+		input, err := storage.FindOutput(ctx, outpoint, &output.Topic, nil, false)
+		if err != nil {
+			return err
+		} else if input == nil {
+			continue
+		}
+		if eviction.IncludeHistory {
+			if err := e.evictOutput(ctx, storage, input, eviction); err != nil {
+				return err
+			}
+			continue
+		}
+		consumedBy := make([]*transaction.Outpoint, 0, len(input.ConsumedBy))
+		for _, consumer := range input.ConsumedBy {
+			if *consumer != output.Outpoint {
+				consumedBy = append(consumedBy, consumer)
+			}
+		}
+		if err := storage.UpdateConsumedBy(ctx, &input.Outpoint, input.Topic, consumedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListEvictions returns the audit log of the evictions. It returns an empty list when evictions
+// are not persisted.
+func (e *Engine) ListEvictions(ctx context.Context) ([]*Eviction, error) {
+	if e.Evictions == nil {
+		return []*Eviction{}, nil
+	}
+	evictions, err := e.Evictions.FindEvictions(ctx)
+	if err != nil {
+		slog.Error("failed to find evictions", "error", err)
+		return nil, err
+	}
+	return evictions, nil
+}
@@ -4,12 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
-	"io"
+	"fmt"
 	"log/slog"
 	"net/http"
 
 	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/bsv-blockchain/go-sdk/util"
 )
@@ -25,7 +25,7 @@ func (r *OverlayGASPRemote) GetInitialResponse(ctx context.Context, request *cor
 	if err := json.NewEncoder(&buf).Encode(request); err != nil {
 		slog.Error("failed to encode GASP initial request", "endpoint", r.EndpointUrl, "topic", r.Topic, "error", err)
 		return nil, err
-	} else if req, err := http.NewRequest("POST", r.EndpointUrl+"/requestSyncResponse", io.NopCloser(&buf)); err != nil {
+	} else if req, err := http.NewRequestWithContext(ctx, "POST", r.EndpointUrl+"/requestSyncResponse", &buf); err != nil {
 		slog.Error("failed to create HTTP request for GASP initial response", "endpoint", r.EndpointUrl, "topic", r.Topic, "error", err)
 		return nil, err
 	} else {
@@ -38,14 +38,18 @@ func (r *OverlayGASPRemote) GetInitialResponse(ctx context.Context, request *cor
 			if resp.StatusCode != http.StatusOK {
 				return nil, &util.HTTPError{
 					StatusCode: resp.StatusCode,
-					Err:        err,
+					Err:        fmt.Errorf("unexpected response status from /requestSyncResponse"),
 				}
 			}
-			result := &core.GASPInitialResponse{}
+			result := &syncResponse{}
 			if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
 				return nil, err
 			}
-			return result, nil
+			utxoList, err := result.UTXOList.outpoints()
+			if err != nil {
+				return nil, err
+			}
+			return &core.GASPInitialResponse{UTXOList: utxoList, Since: result.Since}, nil
 		}
 	}
 }
@@ -58,7 +62,7 @@ func (r *OverlayGASPRemote) RequestNode(ctx context.Context, graphID *transactio
 		Metadata:    metadata,
 	}); err != nil {
 		return nil, err
-	} else if req, err := http.NewRequest("POST", r.EndpointUrl+"/requestForeignGASPNode", bytes.NewReader(j)); err != nil {
+	} else if req, err := http.NewRequestWithContext(ctx, "POST", r.EndpointUrl+"/requestForeignGASPNode", bytes.NewReader(j)); err != nil {
 		return nil, err
 	} else {
 		req.Header.Set("Content-Type", "application/json")
@@ -70,22 +74,112 @@ func (r *OverlayGASPRemote) RequestNode(ctx context.Context, graphID *transactio
 			if resp.StatusCode != http.StatusOK {
 				return nil, &util.HTTPError{
 					StatusCode: resp.StatusCode,
-					Err:        err,
+					Err:        fmt.Errorf("unexpected response status from /requestForeignGASPNode"),
 				}
 			}
 			result := &core.GASPNode{}
 			if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
 				return nil, err
 			}
+			if result.Proof != nil && *result.Proof == "" {
+				// The overlay API sends an empty proof for unmined transactions.
+				result.Proof = nil
+			}
 			return result, nil
 		}
 	}
 }
 
+// GetInitialReply sends the initial response of the local node to the remote, which replies with
+// the UTXOs it knows of that the local node did not list.
 func (r *OverlayGASPRemote) GetInitialReply(ctx context.Context, response *core.GASPInitialResponse) (*core.GASPInitialReply, error) {
-	return nil, errors.New("not-implemented")
+	result := &syncReply{}
+	if err := r.post(ctx, "/requestSyncReply", &syncResponse{
+		UTXOList: newUTXOItems(response.UTXOList),
+		Since:    response.Since,
+	}, result); err != nil {
+		slog.Error("failed to request GASP initial reply", "endpoint", r.EndpointUrl, "topic", r.Topic, "error", err)
+		return nil, err
+	}
+	utxoList, err := result.UTXOList.outpoints()
+	if err != nil {
+		return nil, err
+	}
+	return &core.GASPInitialReply{UTXOList: utxoList}, nil
 }
 
+// SubmitNode pushes the node to the remote, which responds with the inputs of the node it
+// needs before the graph can be completed.
 func (r *OverlayGASPRemote) SubmitNode(ctx context.Context, node *core.GASPNode) (*core.GASPNodeResponse, error) {
-	return nil, errors.New("not-implemented")
+	result := &core.GASPNodeResponse{}
+	if err := r.post(ctx, "/submitGASPNode", node, result); err != nil {
+		slog.Error("failed to submit GASP node", "endpoint", r.EndpointUrl, "topic", r.Topic, "error", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// post sends the JSON encoded body to the path of the remote endpoint and decodes the JSON response into the result.
+func (r *OverlayGASPRemote) post(ctx context.Context, path string, body any, result any) error {
+	j, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.EndpointUrl+path, bytes.NewReader(j))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-BSV-Topic", r.Topic)
+	resp, err := r.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return &util.HTTPError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("unexpected response status from %s", path),
+		}
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// utxoItem is the representation of an outpoint in the sync endpoints of the overlay API.
+type utxoItem struct {
+	Txid string `json:"txid"`
+	Vout uint32 `json:"vout"`
+}
+
+type utxoItems []utxoItem
+
+func newUTXOItems(outpoints []*transaction.Outpoint) utxoItems {
+	items := make(utxoItems, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		items = append(items, utxoItem{Txid: outpoint.Txid.String(), Vout: outpoint.Index})
+	}
+	return items
+}
+
+func (items utxoItems) outpoints() ([]*transaction.Outpoint, error) {
+	outpoints := make([]*transaction.Outpoint, 0, len(items))
+	for _, item := range items {
+		txid, err := chainhash.NewHashFromHex(item.Txid)
+		if err != nil {
+			return nil, err
+		}
+		outpoints = append(outpoints, &transaction.Outpoint{Txid: *txid, Index: item.Vout})
+	}
+	return outpoints, nil
+}
+
+// syncResponse is the body of the initial response returned by /requestSyncResponse and sent to /requestSyncReply.
+type syncResponse struct {
+	UTXOList utxoItems `json:"UTXOList"`
+	Since    uint32    `json:"since"`
+}
+
+// syncReply is the body of the initial reply returned by /requestSyncReply.
+type syncReply struct {
+	UTXOList utxoItems `json:"UTXOList"`
 }
@@ -194,6 +194,12 @@ func (s *OverlayGASPStorage) AppendToGraph(ctx context.Context, gaspTx *core.GAS
 			Txid:     txid,
 			Children: []*GraphNode{},
 		}
+		if spentBy == nil && (gaspTx.GraphID.Txid != *txid || gaspTx.GraphID.Index != gaspTx.OutputIndex) {
+			// Nodes pushed by a remote do not name their spender, it is the node of the graph spending them.
+			if spentBy = s.findSpender(gaspTx.GraphID, &transaction.Outpoint{Txid: *txid, Index: gaspTx.OutputIndex}); spentBy == nil {
+				return ErrMissingInput
+			}
+		}
 		if spentBy == nil {
 			if _, ok := s.tempGraphNodeRefs.LoadOrStore(gaspTx.GraphID.String(), newGraphNode); !ok {
 				s.tempGraphNodeCount++
@@ -218,6 +224,29 @@ func (s *OverlayGASPStorage) AppendToGraph(ctx context.Context, gaspTx *core.GAS
 	}
 }
 
+// findSpender returns the key of the node of the graph whose transaction spends the outpoint,
+// or nil when no node of the graph spends it.
+func (s *OverlayGASPStorage) findSpender(graphID *transaction.Outpoint, outpoint *transaction.Outpoint) (spentBy *transaction.Outpoint) {
+	s.tempGraphNodeRefs.Range(func(nodeId, graphRef any) bool {
+		node := graphRef.(*GraphNode)
+		if !node.GraphID.Equal(graphID) {
+			return true
+		}
+		tx, err := transaction.NewTransactionFromHex(node.RawTx)
+		if err != nil {
+			return true
+		}
+		for _, input := range tx.Inputs {
+			if input.SourceTXID.Equal(outpoint.Txid) && input.SourceTxOutIndex == outpoint.Index {
+				spentBy, _ = transaction.OutpointFromString(nodeId.(string))
+				return false
+			}
+		}
+		return true
+	})
+	return spentBy
+}
+
 func (s *OverlayGASPStorage) ValidateGraphAnchor(ctx context.Context, graphID *transaction.Outpoint) error {
 	if rootNode, ok := s.tempGraphNodeRefs.Load(graphID.String()); !ok {
 		return ErrMissingInput
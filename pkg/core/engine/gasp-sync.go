@@ -0,0 +1,215 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// GASPSyncPeerResult is the outcome of the sync session of a topic with a peer.
+type GASPSyncPeerResult struct {
+	Topic string
+	Peer  string
+	// Err is the error that stopped the session, nil when the session completed.
+	Err error
+	core.GASPSyncResult
+}
+
+// GASPSyncReport lists the outcome of every sync session, ordered by topic and peer.
+type GASPSyncReport struct {
+	Results []*GASPSyncPeerResult
+}
+
+// gaspSyncSession is a sync of a topic with a single peer.
+type gaspSyncSession struct {
+	topic  string
+	peer   string
+	config SyncConfiguration
+}
+
+// StartGASPSync syncs every configured topic with its peers, see SyncWithPeers.
+func (e *Engine) StartGASPSync(ctx context.Context) error {
+	_, err := e.SyncWithPeers(ctx)
+	return err
+}
+
+// SyncWithPeers runs a GASP sync session per topic and peer. Each session keeps its temporary
+// graphs in its own OverlayGASPStorage, and at most GASPSyncConcurrency sessions run at once.
+// A failed session does not stop the others, its error is part of the report. An error is
+// returned only when the peers of a topic cannot be resolved, in which case nothing is synced.
+func (e *Engine) SyncWithPeers(ctx context.Context) (*GASPSyncReport, error) {
+	topics := make([]string, 0, len(e.SyncConfiguration))
+	for topic := range e.SyncConfiguration {
+		topics = append(topics, topic)
+	}
+	slices.Sort(topics)
+	return e.syncTopics(ctx, topics, false)
+}
+
+// syncTopics runs the sync sessions of the topics. When skipBackingOff is set, the peers still
+// backing off from their failures are left out of the sessions.
+func (e *Engine) syncTopics(ctx context.Context, topics []string, skipBackingOff bool) (*GASPSyncReport, error) {
+	var sessions []gaspSyncSession
+	now := time.Now()
+	for _, topic := range topics {
+		config := e.SyncConfiguration[topic]
+		peers, err := e.resolveSyncPeers(ctx, topic, config)
+		if err != nil {
+			return nil, err
+		}
+		for _, peer := range peers {
+			if skipBackingOff && e.isPeerBackingOff(topic, peer, now) {
+				slog.Debug("skipping GASP sync with peer backing off", "topic", topic, "peer", peer)
+				continue
+			}
+			sessions = append(sessions, gaspSyncSession{topic: topic, peer: peer, config: config})
+		}
+	}
+
+	limiter := e.gaspSyncLimiter()
+	report := &GASPSyncReport{Results: make([]*GASPSyncPeerResult, len(sessions))}
+
+	var wg sync.WaitGroup
+	for i, session := range sessions {
+		wg.Add(1)
+		limiter <- struct{}{}
+		go func() {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			result := e.syncWithPeer(ctx, session)
+			e.recordPeerHealth(session, result)
+			event := Event{Type: EventSyncFinished, Topic: session.topic, Peer: session.peer}
+			if result.Err != nil {
+				event.Error = result.Err.Error()
+			}
+			e.publishEvents(ctx, event)
+			report.Results[i] = result
+		}()
+	}
+	wg.Wait()
+	return report, nil
+}
+
+// gaspSyncLimiter returns the limiter capping the sync sessions running at once, shared by the
+// syncs requested through StartGASPSync and the ones run by the scheduler.
+func (e *Engine) gaspSyncLimiter() chan struct{} {
+	state := e.state()
+	state.syncLimiterMu.Lock()
+	defer state.syncLimiterMu.Unlock()
+	if state.syncLimiter == nil {
+		state.syncLimiter = make(chan struct{}, max(e.GASPSyncConcurrency, 1))
+	}
+	return state.syncLimiter
+}
+
+// resolveSyncPeers returns the peers to sync the topic with. For SHIP configured topics the peers
+// are the hosts advertising the topic, otherwise the configured peers. The hosting URL is skipped.
+func (e *Engine) resolveSyncPeers(ctx context.Context, topic string, config SyncConfiguration) ([]string, error) {
+	peers := config.Peers
+	if config.Type == SyncConfigurationSHIP {
+		e.LookupResolver.SetSLAPTrackers(e.SLAPTrackers)
+
+		query, err := json.Marshal(map[string]any{"topics": []string{topic}})
+		if err != nil {
+			slog.Error("failed to marshal query for GASP sync", "topic", topic, "error", err)
+			return nil, err
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		lookupAnswer, err := e.LookupResolver.Query(timeoutCtx, &lookup.LookupQuestion{Service: "ls_ship", Query: query})
+		cancel()
+		if err != nil {
+			slog.Error("failed to query lookup resolver for GASP sync", "topic", topic, "error", err)
+			return nil, err
+		}
+
+		if lookupAnswer.Type == lookup.AnswerTypeOutputList {
+			endpointSet := make(map[string]struct{}, len(lookupAnswer.Outputs))
+			for _, output := range lookupAnswer.Outputs {
+				tx, err := transaction.NewTransactionFromBEEF(output.Beef)
+				if err != nil {
+					slog.Error("failed to parse advertisement output BEEF", "topic", topic, "error", err)
+					continue
+				}
+
+				advertisement, err := e.Advertiser.ParseAdvertisement(tx.Outputs[output.OutputIndex].LockingScript)
+				if err != nil {
+					slog.Error("failed to parse advertisement from locking script", "topic", topic, "error", err)
+					continue
+				}
+
+				if advertisement != nil && advertisement.Protocol == "SHIP" {
+					endpointSet[advertisement.Domain] = struct{}{}
+				}
+			}
+
+			peers = make([]string, 0, len(endpointSet))
+			for endpoint := range endpointSet {
+				peers = append(peers, endpoint)
+			}
+			slices.Sort(peers)
+		}
+	}
+
+	resolved := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		if peer != e.HostingURL {
+			resolved = append(resolved, peer)
+		}
+	}
+	return resolved, nil
+}
+
+// syncWithPeer runs the sync session and records the checkpoint of the peer when it completes
+// without errors.
+func (e *Engine) syncWithPeer(ctx context.Context, session gaspSyncSession) *GASPSyncPeerResult {
+	result := &GASPSyncPeerResult{Topic: session.topic, Peer: session.peer}
+	logPrefix := "[GASP Sync of " + session.topic + " with " + session.peer + "]"
+	since := e.syncCheckpointSince(ctx, session.topic, session.peer)
+
+	gaspProvider := e.GASPProvider
+	if gaspProvider == nil {
+		gaspProvider = core.NewGASP(core.GASPParams{
+			Storage: NewOverlayGASPStorage(session.topic, e, nil),
+			Remote: &OverlayGASPRemote{
+				EndpointUrl: session.peer,
+				Topic:       session.topic,
+				HttpClient:  e.httpClient(),
+			},
+			LastInteraction: since,
+			LogPrefix:       &logPrefix,
+			Unidirectional:  !session.config.Bidirectional,
+			Concurrency:     session.config.Concurrency,
+		})
+	}
+
+	result.Err = gaspProvider.Sync(ctx)
+	reporter, reported := gaspProvider.(interface{ Result() core.GASPSyncResult })
+	if reported {
+		result.GASPSyncResult = reporter.Result()
+	}
+	if result.Err != nil {
+		slog.Error("failed to sync with peer", "topic", session.topic, "peer", session.peer, "error", result.Err)
+		return result
+	}
+	// The checkpoint is the time at which the peer listed its UTXOs, on its own clock, as the
+	// peer compares it with the time it admitted its outputs at. A provider that does not report
+	// it leaves the checkpoint unchanged, and so does a sync failing for some UTXOs or graphs, so
+	// the next sync requests them again.
+	if reported && len(result.Errors) == 0 {
+		e.saveSyncCheckpoint(ctx, session.topic, session.peer, result.Since)
+	}
+	slog.Info("synced with peer", "topic", session.topic, "peer", session.peer,
+		"nodesFetched", result.NodesFetched, "graphsFinalized", result.GraphsFinalized,
+		"graphsDiscarded", result.GraphsDiscarded, "errors", len(result.Errors))
+	return result
+}
@@ -0,0 +1,24 @@
+package engine
+
+import (
+	"context"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// identityKeyContextKey is the context key of the identity key of the authenticated caller.
+type identityKeyContextKey struct{}
+
+// WithIdentityKey returns a copy of the context carrying the identity key of the caller, authenticated
+// by the mutual authentication of the overlay node. The context is passed down to the topic managers,
+// lookup services and GASP storage so they can make identity-based decisions.
+func WithIdentityKey(ctx context.Context, identityKey *ec.PublicKey) context.Context {
+	return context.WithValue(ctx, identityKeyContextKey{}, identityKey)
+}
+
+// IdentityKeyFromContext returns the identity key of the authenticated caller carried by the context,
+// false when the caller is anonymous.
+func IdentityKeyFromContext(ctx context.Context) (*ec.PublicKey, bool) {
+	identityKey, ok := ctx.Value(identityKeyContextKey{}).(*ec.PublicKey)
+	return identityKey, ok && identityKey != nil
+}
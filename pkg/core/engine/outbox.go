@@ -0,0 +1,302 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/overlay/topic"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultOutboxMaxAttempts is how many times an outbox entry is attempted when OutboxMaxAttempts is not set.
+	DefaultOutboxMaxAttempts = 10
+	// DefaultOutboxBackoff is the wait before the first retry of an outbox entry when OutboxBackoff is not set.
+	DefaultOutboxBackoff = 5 * time.Second
+	// DefaultOutboxMaxBackoff caps the wait between the retries of an outbox entry when OutboxMaxBackoff is not set.
+	DefaultOutboxMaxBackoff = 30 * time.Minute
+	// DefaultOutboxPollInterval is how often the dispatcher looks for due outbox entries when OutboxPollInterval is not set.
+	DefaultOutboxPollInterval = time.Second
+	// outboxBatchSize is the number of due outbox entries attempted per poll.
+	outboxBatchSize = 100
+)
+
+// ErrOutboxStorageUnavailable is returned by the outbox operations when the engine has no OutboxStorage.
+var ErrOutboxStorageUnavailable = errors.New("outbox-storage-unavailable")
+
+// OutboxKind tells what an outbox entry does with its transaction.
+type OutboxKind string
+
+const (
+	// OutboxBroadcast entries broadcast the transaction to the network through the Broadcaster.
+	OutboxBroadcast OutboxKind = "broadcast"
+	// OutboxPropagation entries propagate the transaction to the overlay nodes hosting its topics,
+	// as found through the SHIP advertisements.
+	OutboxPropagation OutboxKind = "propagation"
+)
+
+// OutboxStatus tells where an outbox entry is in its lifecycle.
+type OutboxStatus string
+
+const (
+	// OutboxPending entries are attempted once they are due.
+	OutboxPending OutboxStatus = "pending"
+	// OutboxCompleted entries succeeded.
+	OutboxCompleted OutboxStatus = "completed"
+	// OutboxFailed entries exhausted their attempts and are no longer retried.
+	OutboxFailed OutboxStatus = "failed"
+)
+
+// OutboxEntry is a broadcast or a propagation of a submitted transaction, together with the outcome of its attempts.
+type OutboxEntry struct {
+	ID   string
+	Kind OutboxKind
+	Txid chainhash.Hash
+	// Topics are the topics the transaction is propagated for, empty for broadcasts.
+	Topics []string
+	// Beef is the BEEF of the transaction.
+	Beef   []byte
+	Status OutboxStatus
+	// Attempts is the number of attempts made so far.
+	Attempts int
+	// NextAttemptAt is when the entry is attempted next while pending.
+	NextAttemptAt time.Time
+	// LastError is why the last attempt failed, empty once completed.
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OutboxStorage persists the broadcasts and propagations of the submitted transactions until they succeed.
+type OutboxStorage interface {
+	// Inserts the entries
+	InsertOutboxEntries(ctx context.Context, entries []*OutboxEntry) error
+
+	// Updates the status, attempts, next attempt, last error and update time of the entry
+	UpdateOutboxEntry(ctx context.Context, entry *OutboxEntry) error
+
+	// Finds at most limit pending entries whose next attempt is not after the given time, ordered by their next attempt
+	FindDueOutboxEntries(ctx context.Context, before time.Time, limit int) ([]*OutboxEntry, error)
+
+	// Finds the entries of the transaction with the status ordered by the time they were queued,
+	// a nil txid or an empty status matches every entry
+	FindOutboxEntries(ctx context.Context, txid *chainhash.Hash, status OutboxStatus) ([]*OutboxEntry, error)
+}
+
+// OutboxTransaction is implemented by the storage transactions able to queue outbox entries, so the
+// broadcasts and propagations of a submitted transaction are queued together with the topic it is
+// applied to. Entries queued within a rolled back transaction are dropped.
+type OutboxTransaction interface {
+	// Inserts the entries as part of the transaction
+	InsertOutboxEntries(ctx context.Context, entries []*OutboxEntry) error
+}
+
+// ListOutboxEntries returns the broadcasts and propagations of the transaction, or of every transaction
+// when the txid is nil, limited to the entries with the status when it is not empty.
+func (e *Engine) ListOutboxEntries(ctx context.Context, txid *chainhash.Hash, status OutboxStatus) ([]*OutboxEntry, error) {
+	if e.Outbox == nil {
+		return nil, ErrOutboxStorageUnavailable
+	}
+	return e.Outbox.FindOutboxEntries(ctx, txid, status)
+}
+
+// newOutboxEntry returns the entry recording the first attempt of the kind, made inline by Submit.
+// A failed attempt leaves the entry pending for a retry.
+func (e *Engine) newOutboxEntry(kind OutboxKind, txid *chainhash.Hash, topics []string, beef []byte, attemptErr error) *OutboxEntry {
+	now := time.Now()
+	entry := &OutboxEntry{
+		ID:            randomID(),
+		Kind:          kind,
+		Txid:          *txid,
+		Topics:        topics,
+		Beef:          beef,
+		Status:        OutboxCompleted,
+		Attempts:      1,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if attemptErr != nil {
+		e.recordOutboxFailure(entry, attemptErr)
+	}
+	return entry
+}
+
+// newQueuedOutboxEntry returns the entry of the kind left for the dispatcher to attempt first.
+func newQueuedOutboxEntry(kind OutboxKind, txid *chainhash.Hash, topics []string, beef []byte) *OutboxEntry {
+	now := time.Now()
+	return &OutboxEntry{
+		ID:            randomID(),
+		Kind:          kind,
+		Txid:          *txid,
+		Topics:        topics,
+		Beef:          beef,
+		Status:        OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// outboxTransaction returns the storage transaction as an OutboxTransaction when the outbox entries
+// are kept by the Storage the transaction belongs to.
+func (e *Engine) outboxTransaction(storageTx StorageTransaction) (OutboxTransaction, bool) {
+	if outbox, ok := e.Storage.(OutboxStorage); !ok || e.Outbox == nil || outbox != e.Outbox {
+		return nil, false
+	}
+	outboxTx, ok := storageTx.(OutboxTransaction)
+	return outboxTx, ok
+}
+
+// enqueueOutboxEntries stores the entries so the pending ones are attempted by the dispatcher.
+// The entries are dropped when the engine has no OutboxStorage.
+func (e *Engine) enqueueOutboxEntries(ctx context.Context, entries ...*OutboxEntry) {
+	if e.Outbox == nil || len(entries) == 0 {
+		return
+	}
+	if err := e.Outbox.InsertOutboxEntries(ctx, entries); err != nil {
+		slog.Error("failed to queue outbox entries", "txid", entries[0].Txid, "error", err)
+	}
+}
+
+// DispatchOutbox attempts the pending outbox entries that are due. A failed entry is retried with an
+// exponential backoff until it runs out of attempts and is marked failed. It returns the number of
+// entries attempted.
+func (e *Engine) DispatchOutbox(ctx context.Context) (int, error) {
+	if e.Outbox == nil {
+		return 0, ErrOutboxStorageUnavailable
+	}
+	due, err := e.Outbox.FindDueOutboxEntries(ctx, time.Now(), outboxBatchSize)
+	if err != nil {
+		slog.Error("failed to find due outbox entries", "error", err)
+		return 0, err
+	}
+
+	for _, entry := range due {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		entry.Attempts++
+		if err := e.attemptOutboxEntry(ctx, entry); err != nil {
+			e.recordOutboxFailure(entry, err)
+		} else {
+			entry.Status = OutboxCompleted
+			entry.LastError = ""
+		}
+		entry.UpdatedAt = time.Now()
+		if err := e.Outbox.UpdateOutboxEntry(ctx, entry); err != nil {
+			slog.Error("failed to update outbox entry", "entry", entry.ID, "error", err)
+			return 0, err
+		}
+	}
+	return len(due), nil
+}
+
+// recordOutboxFailure records the failed attempt in the entry, marking it failed once it ran out of
+// attempts and scheduling its retry otherwise.
+func (e *Engine) recordOutboxFailure(entry *OutboxEntry, err error) {
+	entry.LastError = err.Error()
+	maxAttempts := e.OutboxMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultOutboxMaxAttempts
+	}
+	if entry.Attempts >= maxAttempts {
+		slog.Warn("outbox entry ran out of attempts", "kind", entry.Kind, "txid", entry.Txid, "attempts", entry.Attempts, "error", err)
+		entry.Status = OutboxFailed
+		return
+	}
+	backoff := e.OutboxBackoff
+	if backoff <= 0 {
+		backoff = DefaultOutboxBackoff
+	}
+	maxBackoff := e.OutboxMaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultOutboxMaxBackoff
+	}
+	entry.Status = OutboxPending
+	entry.NextAttemptAt = time.Now().Add(doublingBackoff(backoff, maxBackoff, entry.Attempts))
+}
+
+// attemptOutboxEntry broadcasts or propagates the transaction of the entry.
+func (e *Engine) attemptOutboxEntry(ctx context.Context, entry *OutboxEntry) error {
+	tx, err := transaction.NewTransactionFromBEEF(entry.Beef)
+	if err != nil {
+		return fmt.Errorf("failed to parse BEEF: %w", err)
+	}
+	switch entry.Kind {
+	case OutboxBroadcast:
+		if e.Broadcaster == nil {
+			return errors.New("no broadcaster configured")
+		}
+		if _, failure := e.Broadcaster.BroadcastCtx(ctx, tx); failure != nil {
+			return failure
+		}
+		return nil
+	case OutboxPropagation:
+		return e.propagate(ctx, tx, entry.Topics)
+	default:
+		return fmt.Errorf("unknown outbox entry kind %q", entry.Kind)
+	}
+}
+
+// propagate sends the transaction to the other overlay nodes hosting the topics.
+func (e *Engine) propagate(ctx context.Context, tx *transaction.Transaction, topics []string) error {
+	client := e.httpClient()
+	resolverCfg := &lookup.LookupResolver{
+		Facilitator: &lookup.HTTPSOverlayLookupFacilitator{Client: client},
+	}
+	if len(e.SLAPTrackers) > 0 {
+		resolverCfg.SLAPTrackers = e.SLAPTrackers
+	}
+	broadcasterCfg := &topic.BroadcasterConfig{
+		Facilitator: e.BroadcastFacilitator,
+		Resolver:    lookup.NewLookupResolver(resolverCfg),
+	}
+	if broadcasterCfg.Facilitator == nil {
+		broadcasterCfg.Facilitator = &topic.HTTPSOverlayBroadcastFacilitator{Client: client}
+	}
+
+	broadcaster, err := topic.NewBroadcaster(topics, broadcasterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create broadcaster for propagation: %w", err)
+	}
+	if _, failure := broadcaster.BroadcastCtx(ctx, tx); failure != nil {
+		return failure
+	}
+	return nil
+}
+
+// RunOutboxDispatcher retries the due broadcasts and propagations in the background, and blocks until
+// the context is done. It returns right away when the engine has no OutboxStorage.
+func (e *Engine) RunOutboxDispatcher(ctx context.Context) {
+	if e.Outbox == nil {
+		return
+	}
+	interval := e.OutboxPollInterval
+	if interval <= 0 {
+		interval = DefaultOutboxPollInterval
+	}
+	slog.Info("dispatching outbox entries", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stopped dispatching outbox entries")
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			attempted, err := e.DispatchOutbox(ctx)
+			if err != nil || attempted < outboxBatchSize {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// lockOutpoints blocks until none of the outpoints is locked by another submission and then
+// locks all of them at once, so submissions spending the same outputs are applied one after
+// the other while the others run concurrently. Locking the whole set at once means two
+// submissions never wait on each other in a cycle. The returned function releases the locks,
+// it may be called more than once. An error is returned only when the context is done first.
+func (e *Engine) lockOutpoints(ctx context.Context, outpoints []*transaction.Outpoint) (func(), error) {
+	keys := make([]string, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		keys = append(keys, outpoint.String())
+	}
+
+	state := e.state()
+	for {
+		state.lockedOutpointsMu.Lock()
+		if state.lockedOutpoints == nil {
+			state.lockedOutpoints = make(map[string]chan struct{})
+		}
+		var held chan struct{}
+		for _, key := range keys {
+			if released, ok := state.lockedOutpoints[key]; ok {
+				held = released
+				break
+			}
+		}
+		if held == nil {
+			released := make(chan struct{})
+			for _, key := range keys {
+				state.lockedOutpoints[key] = released
+			}
+			state.lockedOutpointsMu.Unlock()
+
+			var once sync.Once
+			return func() {
+				once.Do(func() {
+					state.lockedOutpointsMu.Lock()
+					for _, key := range keys {
+						delete(state.lockedOutpoints, key)
+					}
+					state.lockedOutpointsMu.Unlock()
+					close(released)
+				})
+			}, nil
+		}
+		state.lockedOutpointsMu.Unlock()
+
+		select {
+		case <-held:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
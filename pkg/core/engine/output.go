@@ -1,17 +1,23 @@
 package engine
 
 import (
+	"time"
+
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 )
 
 type Output struct {
-	Outpoint        transaction.Outpoint    `json:"-"`
-	Topic           string                  `json:"topic"`
-	Script          *script.Script          `json:"-"`
-	Satoshis        uint64                  `json:"satoshis"`
-	Spent           bool                    `json:"spent"`
+	Outpoint transaction.Outpoint `json:"-"`
+	Topic    string               `json:"topic"`
+	Script   *script.Script       `json:"-"`
+	Satoshis uint64               `json:"satoshis"`
+	Spent    bool                 `json:"spent"`
+	// SpendingTxid is the transaction spending the output within its topic, nil while unspent.
+	SpendingTxid *chainhash.Hash `json:"spendingTxid,omitempty"`
+	// SpentAt is the time at which the output was marked as spent, zero while unspent.
+	SpentAt         time.Time               `json:"spentAt"`
 	OutputsConsumed []*transaction.Outpoint `json:""`
 	ConsumedBy      []*transaction.Outpoint
 	BlockHeight     uint32
@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/spv"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ErrPaymentStorageUnavailable is returned by AcceptPayment when the engine has no PaymentReceiptStorage,
+// as payments are never accepted without a receipt preventing them from being redeemed twice.
+var ErrPaymentStorageUnavailable = errors.New("payment-storage-unavailable")
+
+// ErrInvalidPayment is returned by AcceptPayment when the payment transaction cannot be parsed,
+// fails SPV verification or cannot be broadcast.
+var ErrInvalidPayment = errors.New("invalid-payment")
+
+// ErrInsufficientPayment is returned by AcceptPayment when the payment transaction locks less than
+// the price of the request by the expected locking script.
+var ErrInsufficientPayment = errors.New("insufficient-payment")
+
+// ErrPaymentAlreadyRedeemed is returned by AcceptPayment, and by PaymentReceiptStorage.InsertPaymentReceipt,
+// when a receipt of the payment transaction is already recorded.
+var ErrPaymentAlreadyRedeemed = errors.New("payment-already-redeemed")
+
+// Payment is a transaction paying for a request to the overlay, see AcceptPayment.
+type Payment struct {
+	// Transaction is the AtomicBEEF, or BEEF, of the payment transaction.
+	Transaction []byte
+	// LockingScript is the script the price must be locked by, usually a P2PKH of a key derived for the request.
+	LockingScript *script.Script
+	// Satoshis is the price of the request.
+	Satoshis uint64
+	// Route identifies what is paid for, for instance the path of the request.
+	Route string
+	// Payer is the hex-encoded identity key of the payer.
+	Payer string
+	// DerivationPrefix and DerivationSuffix derive the key of the LockingScript, so the overlay can spend the payment.
+	DerivationPrefix string
+	DerivationSuffix string
+}
+
+// PaymentReceipt is the record of an accepted payment.
+type PaymentReceipt struct {
+	// Txid identifies the payment transaction. A transaction pays for a single request.
+	Txid chainhash.Hash
+	// Satoshis is the amount locked by the expected locking script, at least the price of the request.
+	Satoshis uint64
+	// Route identifies what was paid for.
+	Route string
+	// Payer is the hex-encoded identity key of the payer.
+	Payer string
+	// DerivationPrefix and DerivationSuffix derive the key the payment is locked by.
+	DerivationPrefix string
+	DerivationSuffix string
+	// CreatedAt is the time at which the payment was accepted.
+	CreatedAt time.Time
+}
+
+// PaymentReceiptStorage persists the receipts of the accepted payments.
+type PaymentReceiptStorage interface {
+	// Stores the receipt, returns ErrPaymentAlreadyRedeemed when a receipt of the same transaction is already stored
+	InsertPaymentReceipt(ctx context.Context, receipt *PaymentReceipt) error
+
+	// Finds every stored receipt ordered by the time the payment was accepted
+	FindPaymentReceipts(ctx context.Context) ([]*PaymentReceipt, error)
+}
+
+// AcceptPayment verifies the payment transaction against the ChainTracker, checks that it locks at least
+// the price of the request by the expected locking script, broadcasts it and records its receipt. A payment
+// transaction is accepted once, it returns ErrPaymentAlreadyRedeemed when it is attached again.
+func (e *Engine) AcceptPayment(ctx context.Context, payment *Payment) (*PaymentReceipt, error) {
+	if e.Payments == nil {
+		return nil, ErrPaymentStorageUnavailable
+	}
+	_, tx, txid, err := transaction.ParseBeef(payment.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayment, err)
+	} else if tx == nil {
+		return nil, fmt.Errorf("%w: the BEEF does not contain the payment transaction", ErrInvalidPayment)
+	}
+	if valid, err := spv.Verify(tx, e.ChainTracker, nil); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayment, err)
+	} else if !valid {
+		return nil, fmt.Errorf("%w: the payment transaction failed SPV verification", ErrInvalidPayment)
+	}
+
+	var paid uint64
+	for _, output := range tx.Outputs {
+		if output.LockingScript != nil && payment.LockingScript != nil && bytes.Equal(*output.LockingScript, *payment.LockingScript) {
+			paid += output.Satoshis
+		}
+	}
+	if paid < payment.Satoshis {
+		return nil, fmt.Errorf("%w: %d satoshis paid out of %d", ErrInsufficientPayment, paid, payment.Satoshis)
+	}
+
+	if e.Broadcaster != nil {
+		if _, failure := e.Broadcaster.Broadcast(tx); failure != nil {
+			slog.Error("failed to broadcast payment transaction", "txid", txid, "error", failure)
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPayment, failure)
+		}
+	}
+
+	receipt := &PaymentReceipt{
+		Txid:             *txid,
+		Satoshis:         paid,
+		Route:            payment.Route,
+		Payer:            payment.Payer,
+		DerivationPrefix: payment.DerivationPrefix,
+		DerivationSuffix: payment.DerivationSuffix,
+		CreatedAt:        time.Now(),
+	}
+	if err := e.Payments.InsertPaymentReceipt(ctx, receipt); err != nil {
+		if !errors.Is(err, ErrPaymentAlreadyRedeemed) {
+			slog.Error("payment broadcast but its receipt could not be recorded", "txid", txid, "route", payment.Route, "error", err)
+		}
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// ListPaymentReceipts returns the receipts of the accepted payments. It returns an empty list when
+// payments are not enabled.
+func (e *Engine) ListPaymentReceipts(ctx context.Context) ([]*PaymentReceipt, error) {
+	if e.Payments == nil {
+		return []*PaymentReceipt{}, nil
+	}
+	receipts, err := e.Payments.FindPaymentReceipts(ctx)
+	if err != nil {
+		slog.Error("failed to find payment receipts", "error", err)
+		return nil, err
+	}
+	return receipts, nil
+}
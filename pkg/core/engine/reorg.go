@@ -0,0 +1,234 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ReorgResult is the outcome of re-verifying the stored merkle proofs after a reorg.
+type ReorgResult struct {
+	// Checked is the number of proven transactions whose proofs were re-verified.
+	Checked int
+	// Downgraded lists, ordered by block height, the transactions whose proofs are no longer
+	// valid and which were downgraded to unproven.
+	Downgraded []chainhash.Hash
+}
+
+// HandleReorg re-verifies against the ChainTracker the merkle proofs of the transactions mined at
+// or above the block height, which is the height of the first block that may have been orphaned.
+// A transaction whose proof no longer matches the chain is downgraded to unproven: its outputs lose
+// their block position, the orphaned proof is stripped from its BEEF which gets the stored transactions
+// it spends instead, and the lookup services are notified with a zero block height. The orphaned proof
+// is stripped from the BEEFs of the unproven transactions spending its outputs as well, keeping the
+// ancestry they carry. Each downgrade is written within one storage transaction, and the failures of
+// the lookup services to handle it are only logged. The transactions get their proofs back once they are mined again and
+// HandleNewMerkleProof is called.
+func (e *Engine) HandleReorg(ctx context.Context, fromHeight uint32) (*ReorgResult, error) {
+	outputs, err := e.Storage.FindOutputsSinceBlockHeight(ctx, fromHeight, true)
+	if err != nil {
+		slog.Error("failed to find outputs mined since block height in HandleReorg", "fromHeight", fromHeight, "error", err)
+		return nil, err
+	}
+
+	var txids []chainhash.Hash
+	byTxid := make(map[chainhash.Hash][]*Output)
+	for _, output := range outputs {
+		txid := output.Outpoint.Txid
+		if _, ok := byTxid[txid]; !ok {
+			txids = append(txids, txid)
+		}
+		byTxid[txid] = append(byTxid[txid], output)
+	}
+
+	result := &ReorgResult{Checked: len(txids), Downgraded: make([]chainhash.Hash, 0)}
+	for _, txid := range txids {
+		if valid, err := e.isProofValid(byTxid[txid][0]); err != nil {
+			slog.Error("failed to verify merkle proof in HandleReorg", "txid", txid, "error", err)
+			return nil, err
+		} else if valid {
+			continue
+		}
+		if err := e.downgradeTransaction(ctx, &txid, byTxid[txid]); err != nil {
+			slog.Error("failed to downgrade transaction in HandleReorg", "txid", txid, "error", err)
+			return nil, err
+		}
+		slog.Info("merkle proof orphaned, transaction downgraded to unproven", "txid", txid, "blockHeight", byTxid[txid][0].BlockHeight)
+		result.Downgraded = append(result.Downgraded, txid)
+	}
+	return result, nil
+}
+
+// isProofValid reports whether the merkle proof in the BEEF of the output still matches the chain.
+// An output recorded as mined without a proof in its BEEF is reported as invalid.
+func (e *Engine) isProofValid(output *Output) (bool, error) {
+	_, tx, _, err := transaction.ParseBeef(output.Beef)
+	if err != nil {
+		return false, err
+	} else if tx == nil {
+		return false, ErrInvalidBeef
+	} else if tx.MerklePath == nil {
+		return false, nil
+	}
+	root, err := tx.MerklePath.ComputeRoot(&output.Outpoint.Txid)
+	if err != nil {
+		return false, err
+	}
+	return e.ChainTracker.IsValidRootForHeight(root, tx.MerklePath.BlockHeight)
+}
+
+// downgradeTransaction removes the merkle proof from the transaction and the block position from
+// its outputs, and rebuilds the BEEFs of the unproven transactions spending them, within one storage
+// transaction. The lookup services and subscribers are notified once it is committed.
+func (e *Engine) downgradeTransaction(ctx context.Context, txid *chainhash.Hash, outputs []*Output) (err error) {
+	storageTx, err := e.Storage.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to begin storage transaction in downgradeTransaction", "txid", txid, "error", err)
+		return err
+	}
+
+	finished := false
+	defer func() {
+		if err != nil && !finished {
+			if rollbackErr := storageTx.Rollback(); rollbackErr != nil {
+				slog.Error("failed to roll back storage transaction in downgradeTransaction", "txid", txid, "error", rollbackErr)
+			}
+		}
+	}()
+
+	beef, err := e.unprovenBEEF(ctx, storageTx, outputs[0].Beef, txid)
+	if err != nil {
+		return err
+	}
+	if err := storageTx.UpdateTransactionBEEF(ctx, txid, beef); err != nil {
+		slog.Error("failed to update transaction BEEF in downgradeTransaction", "txid", txid, "error", err)
+		return err
+	}
+	for _, output := range outputs {
+		if err := storageTx.UpdateOutputBlockHeight(ctx, &output.Outpoint, output.Topic, 0, 0, output.AncillaryBeef); err != nil {
+			slog.Error("failed to reset output block height", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+			return err
+		}
+	}
+
+	rebuilt := map[chainhash.Hash]struct{}{*txid: {}}
+	for _, output := range outputs {
+		for _, consumer := range output.ConsumedBy {
+			if err := e.rebuildSpendingBEEF(ctx, storageTx, &consumer.Txid, txid, rebuilt); err != nil {
+				return err
+			}
+		}
+	}
+
+	finished = true
+	if err := storageTx.Commit(); err != nil {
+		slog.Error("failed to commit storage transaction in downgradeTransaction", "txid", txid, "error", err)
+		return err
+	}
+
+	for _, l := range e.LookupServices {
+		if err := l.OutputBlockHeightUpdated(ctx, txid, 0, 0); err != nil {
+			slog.Error("failed to notify lookup service about block height reset", "txid", txid, "error", err)
+		}
+	}
+	e.publishEvents(ctx, blockHeightEvents(outputs, 0, 0)...)
+	return nil
+}
+
+// rebuildSpendingBEEF strips the proof of the downgraded transaction from the BEEF of the unproven
+// transaction and, recursively, from the BEEFs of the unproven transactions spending its outputs.
+// Proven transactions do not embed the transactions they spend and are left untouched.
+func (e *Engine) rebuildSpendingBEEF(ctx context.Context, storageTx StorageTransaction, txid, downgraded *chainhash.Hash, rebuilt map[chainhash.Hash]struct{}) error {
+	if _, ok := rebuilt[*txid]; ok {
+		return nil
+	}
+	rebuilt[*txid] = struct{}{}
+
+	outputs, err := storageTx.FindOutputsForTransaction(ctx, txid, true)
+	if err != nil {
+		slog.Error("failed to find spending outputs in rebuildSpendingBEEF", "txid", txid, "error", err)
+		return err
+	} else if len(outputs) == 0 || outputs[0].BlockHeight > 0 {
+		return nil
+	}
+	beef, err := e.unprovenBEEF(ctx, storageTx, outputs[0].Beef, downgraded)
+	if err != nil {
+		return err
+	}
+	if err := storageTx.UpdateTransactionBEEF(ctx, txid, beef); err != nil {
+		slog.Error("failed to update transaction BEEF in rebuildSpendingBEEF", "txid", txid, "error", err)
+		return err
+	}
+
+	for _, output := range outputs {
+		for _, consumer := range output.ConsumedBy {
+			if err := e.rebuildSpendingBEEF(ctx, storageTx, &consumer.Txid, downgraded, rebuilt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unprovenBEEF returns the atomic BEEF with the proof of the downgraded transaction stripped. The
+// ancestry carried by the BEEF is kept, and the parents it lacks once the proof is stripped are merged
+// from the stored transactions they are spent from. Parents that are not stored are left out, so the
+// BEEF may be partial when the downgraded transaction spends outputs of no topic.
+func (e *Engine) unprovenBEEF(ctx context.Context, storageTx StorageTransaction, beef []byte, downgraded *chainhash.Hash) ([]byte, error) {
+	_, tx, _, err := transaction.ParseBeef(beef)
+	if err != nil {
+		return nil, err
+	} else if tx == nil {
+		return nil, ErrInvalidBeef
+	}
+	if err := e.mergeStoredParents(ctx, storageTx, tx, downgraded, make(map[chainhash.Hash]struct{})); err != nil {
+		return nil, err
+	}
+	return tx.AtomicBEEF(true)
+}
+
+// mergeStoredParents walks the unproven ancestry of the transaction, stripping the proof of the
+// downgraded transaction and setting the stored transactions as the parents missing from it.
+func (e *Engine) mergeStoredParents(ctx context.Context, storageTx StorageTransaction, tx *transaction.Transaction, downgraded *chainhash.Hash, visited map[chainhash.Hash]struct{}) error {
+	txid := *tx.TxID()
+	if _, ok := visited[txid]; ok {
+		return nil
+	}
+	visited[txid] = struct{}{}
+	if txid.Equal(*downgraded) {
+		tx.MerklePath = nil
+	} else if tx.MerklePath != nil {
+		return nil
+	}
+
+	for _, input := range tx.Inputs {
+		if input.SourceTXID == nil {
+			return errors.New("missing source txid")
+		}
+		if input.SourceTransaction == nil {
+			outpoint := &transaction.Outpoint{Txid: *input.SourceTXID, Index: input.SourceTxOutIndex}
+			source, err := storageTx.FindOutput(ctx, outpoint, nil, nil, true)
+			if err != nil {
+				slog.Error("failed to find source output in mergeStoredParents", "outpoint", outpoint.String(), "error", err)
+				return err
+			} else if source == nil || len(source.Beef) == 0 {
+				continue
+			}
+			if _, sourceTx, _, err := transaction.ParseBeef(source.Beef); err != nil {
+				slog.Error("failed to parse source BEEF in mergeStoredParents", "outpoint", outpoint.String(), "error", err)
+				return err
+			} else if sourceTx == nil {
+				continue
+			} else {
+				input.SourceTransaction = sourceTx
+			}
+		}
+		if err := e.mergeStoredParents(ctx, storageTx, input.SourceTransaction, downgraded, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
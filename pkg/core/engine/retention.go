@@ -0,0 +1,299 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ErrNoRetentionPolicy is returned when pruning a topic without a retention policy.
+var ErrNoRetentionPolicy = errors.New("no-retention-policy")
+
+// PruneReason tells which rule of the retention policy an output was pruned by.
+type PruneReason string
+
+const (
+	PruneReasonUnspentOnly    PruneReason = "unspent-only"
+	PruneReasonAncestryDepth  PruneReason = "ancestry-depth"
+	PruneReasonSpentRetention PruneReason = "spent-retention"
+)
+
+// RetentionPolicy tells which spent outputs of a topic are kept as history. Unspent outputs are
+// never pruned. A policy without any rule keeps the whole history.
+type RetentionPolicy struct {
+	// UnspentOnly prunes every spent output, keeping no history at all.
+	UnspentOnly bool
+	// MaxAncestryDepth prunes the spent outputs more than the given number of transactions away
+	// from every unspent output of the topic, including those no unspent output descends from.
+	// Zero keeps the whole ancestry.
+	MaxAncestryDepth int
+	// SpentRetention prunes the spent outputs spent for longer than the duration. Outputs spent
+	// before the spending time was recorded are not pruned by this rule. Zero keeps them forever.
+	SpentRetention time.Duration
+	// Interval is how often the pruner enforces the policy in the background, the topic is
+	// pruned only on request when it is zero.
+	Interval time.Duration
+}
+
+// PrunedOutput is a spent output removed, or to be removed, from the history of a topic.
+type PrunedOutput struct {
+	Outpoint transaction.Outpoint
+	Reason   PruneReason
+}
+
+// PruneReport lists the outputs pruned from the history of a topic. In dry-run mode the
+// outputs are only reported, nothing is removed.
+type PruneReport struct {
+	Topic  string
+	DryRun bool
+	Pruned []PrunedOutput
+}
+
+// Prune enforces the retention policy of every topic, see PruneTopic. The reports are ordered by topic.
+func (e *Engine) Prune(ctx context.Context, dryRun bool) ([]*PruneReport, error) {
+	topics := make([]string, 0, len(e.RetentionPolicies))
+	for topic := range e.RetentionPolicies {
+		topics = append(topics, topic)
+	}
+	slices.Sort(topics)
+
+	reports := make([]*PruneReport, 0, len(topics))
+	for _, topic := range topics {
+		report, err := e.PruneTopic(ctx, topic, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// PruneTopic removes from storage the spent outputs of the topic its retention policy no longer
+// retains, and tells every lookup service through OutputNoLongerRetainedInHistory once removed.
+// The report lists the outputs actually removed, or every candidate when dryRun is set, in which
+// case the outputs are only reported. It returns ErrNoRetentionPolicy when the
+// topic has no retention policy.
+func (e *Engine) PruneTopic(ctx context.Context, topic string, dryRun bool) (*PruneReport, error) {
+	policy, ok := e.RetentionPolicies[topic]
+	if !ok {
+		return nil, ErrNoRetentionPolicy
+	}
+
+	pruned, err := e.findPrunedOutputs(ctx, topic, policy, time.Now())
+	if err != nil {
+		slog.Error("failed to find outputs to prune", "topic", topic, "error", err)
+		return nil, err
+	}
+	report := &PruneReport{Topic: topic, DryRun: dryRun, Pruned: make([]PrunedOutput, 0, len(pruned))}
+	for _, p := range pruned {
+		report.Pruned = append(report.Pruned, PrunedOutput{Outpoint: p.output.Outpoint, Reason: p.reason})
+	}
+	if dryRun || len(pruned) == 0 {
+		return report, nil
+	}
+
+	outputs := make([]*Output, 0, len(pruned))
+	reasons := make(map[transaction.Outpoint]PruneReason, len(pruned))
+	for _, p := range pruned {
+		outputs = append(outputs, p.output)
+		reasons[p.output.Outpoint] = p.reason
+	}
+	deleted, err := e.pruneOutputs(ctx, topic, outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Pruned = make([]PrunedOutput, 0, len(deleted))
+	for _, output := range deleted {
+		report.Pruned = append(report.Pruned, PrunedOutput{Outpoint: output.Outpoint, Reason: reasons[output.Outpoint]})
+		for _, l := range e.LookupServices {
+			if err := l.OutputNoLongerRetainedInHistory(ctx, &output.Outpoint, output.Topic); err != nil {
+				slog.Error("failed to notify lookup service about pruned output", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+			}
+		}
+	}
+	slog.Info("history pruned", "topic", topic, "pruned", len(deleted))
+	return report, nil
+}
+
+type prunedOutput struct {
+	output *Output
+	reason PruneReason
+}
+
+// findPrunedOutputs returns the spent outputs of the topic not retained by the policy, ordered
+// by the time they were admitted.
+func (e *Engine) findPrunedOutputs(ctx context.Context, topic string, policy RetentionPolicy, now time.Time) ([]prunedOutput, error) {
+	if !policy.UnspentOnly && policy.MaxAncestryDepth <= 0 && policy.SpentRetention <= 0 {
+		return nil, nil
+	}
+	spent, err := e.Storage.FindSpentOutputsForTopic(ctx, topic, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var depths map[transaction.Outpoint]int
+	if !policy.UnspentOnly && policy.MaxAncestryDepth > 0 {
+		if depths, err = e.ancestryDepths(ctx, topic, spent); err != nil {
+			return nil, err
+		}
+	}
+
+	var pruned []prunedOutput
+	for _, output := range spent {
+		switch {
+		case policy.UnspentOnly:
+			pruned = append(pruned, prunedOutput{output: output, reason: PruneReasonUnspentOnly})
+		case policy.SpentRetention > 0 && !output.SpentAt.IsZero() && now.Sub(output.SpentAt) > policy.SpentRetention:
+			pruned = append(pruned, prunedOutput{output: output, reason: PruneReasonSpentRetention})
+		case policy.MaxAncestryDepth > 0:
+			if depth, ok := depths[output.Outpoint]; !ok || depth > policy.MaxAncestryDepth {
+				pruned = append(pruned, prunedOutput{output: output, reason: PruneReasonAncestryDepth})
+			}
+		}
+	}
+	return pruned, nil
+}
+
+// ancestryDepths returns, for every spent output an unspent output of the topic descends from,
+// the fewest transactions between them. The inputs of an unspent output are at depth one.
+func (e *Engine) ancestryDepths(ctx context.Context, topic string, spent []*Output) (map[transaction.Outpoint]int, error) {
+	byOutpoint := make(map[transaction.Outpoint]*Output, len(spent))
+	for _, output := range spent {
+		byOutpoint[output.Outpoint] = output
+	}
+	utxos, err := e.Storage.FindUTXOsForTopic(ctx, topic, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	depths := make(map[transaction.Outpoint]int, len(spent))
+	level := utxos
+	for depth := 1; len(level) > 0; depth++ {
+		var next []*Output
+		for _, output := range level {
+			for _, outpoint := range output.OutputsConsumed {
+				input, ok := byOutpoint[*outpoint]
+				if !ok {
+					continue
+				}
+				if _, seen := depths[*outpoint]; seen {
+					continue
+				}
+				depths[*outpoint] = depth
+				next = append(next, input)
+			}
+		}
+		level = next
+	}
+	return depths, nil
+}
+
+// pruneOutputs deletes the outputs of the topic within one storage transaction, and forgets them
+// in the outputs they were built from when those are kept. Outputs no longer spent, for instance
+// after a conflict resolution restored them in the meantime, are left untouched. It returns the
+// outputs it deleted.
+func (e *Engine) pruneOutputs(ctx context.Context, topic string, outputs []*Output) (deleted []*Output, err error) {
+	outpoints := make([]*transaction.Outpoint, 0, len(outputs))
+	for _, output := range outputs {
+		outpoints = append(outpoints, &output.Outpoint)
+	}
+	unlock, err := e.lockOutpoints(ctx, outpoints)
+	if err != nil {
+		slog.Error("failed to lock pruned outputs", "topic", topic, "error", err)
+		return nil, err
+	}
+	defer unlock()
+
+	storageTx, err := e.Storage.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to begin storage transaction in PruneTopic", "topic", topic, "error", err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := storageTx.Rollback(); rollbackErr != nil {
+				slog.Error("failed to roll back storage transaction in PruneTopic", "topic", topic, "error", rollbackErr)
+			}
+		}
+	}()
+
+	for _, output := range outputs {
+		stored, err := storageTx.FindOutput(ctx, &output.Outpoint, &topic, &TRUE, false)
+		if err != nil {
+			slog.Error("failed to find pruned output", "outpoint", output.Outpoint.String(), "topic", topic, "error", err)
+			return nil, err
+		} else if stored == nil {
+			continue
+		}
+		if err := storageTx.DeleteOutput(ctx, &stored.Outpoint, topic); err != nil {
+			slog.Error("failed to delete pruned output", "outpoint", stored.Outpoint.String(), "topic", topic, "error", err)
+			return nil, err
+		}
+		deleted = append(deleted, stored)
+		for _, outpoint := range stored.OutputsConsumed {
+			input, err := storageTx.FindOutput(ctx, outpoint, &topic, nil, false)
+			if err != nil {
+				return nil, err
+			} else if input == nil {
+				continue
+			}
+			consumedBy := make([]*transaction.Outpoint, 0, len(input.ConsumedBy))
+			for _, consumer := range input.ConsumedBy {
+				if *consumer != stored.Outpoint {
+					consumedBy = append(consumedBy, consumer)
+				}
+			}
+			if err := storageTx.UpdateConsumedBy(ctx, &input.Outpoint, topic, consumedBy); err != nil {
+				slog.Error("failed to update consumed by of pruned output input", "outpoint", input.Outpoint.String(), "topic", topic, "error", err)
+				return nil, err
+			}
+		}
+	}
+
+	if err := storageTx.Commit(); err != nil {
+		slog.Error("failed to commit storage transaction in PruneTopic", "topic", topic, "error", err)
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// RunHistoryPruner enforces the retention policy of every topic configured with an interval in
+// the background, and blocks until the context is done.
+func (e *Engine) RunHistoryPruner(ctx context.Context) {
+	var wg sync.WaitGroup
+	for topic, policy := range e.RetentionPolicies {
+		if policy.Interval <= 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.scheduleTopicPrune(ctx, topic, policy.Interval)
+		}()
+	}
+	wg.Wait()
+}
+
+func (e *Engine) scheduleTopicPrune(ctx context.Context, topic string, interval time.Duration) {
+	slog.Info("scheduling history pruning", "topic", topic, "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stopped scheduling history pruning", "topic", topic)
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := e.PruneTopic(ctx, topic, false); err != nil {
+			slog.Error("failed to run scheduled history pruning", "topic", topic, "error", err)
+		}
+	}
+}
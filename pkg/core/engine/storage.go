@@ -11,34 +11,65 @@ import (
 
 var ErrNotFound = fmt.Errorf("not-found")
 
+// Storage persists the outputs admitted by the engine. Writes issued directly on the
+// Storage are applied immediately, writes issued through a transaction returned by
+// Begin are applied atomically on Commit.
 type Storage interface {
+	StorageOperations
+
+	// Begins a unit of work, all writes made through the returned transaction are applied together on Commit or not at all
+	Begin(ctx context.Context) (StorageTransaction, error)
+}
+
+// StorageTransaction is a unit of work started by Storage.Begin. Reads made through
+// the transaction observe its own uncommitted writes. A transaction must be finished
+// with exactly one Commit or Rollback and cannot be used afterwards.
+type StorageTransaction interface {
+	StorageOperations
+
+	// Applies every write made through the transaction
+	Commit() error
+
+	// Discards every write made through the transaction
+	Rollback() error
+}
+
+// StorageOperations are the reads and writes shared by Storage and StorageTransaction.
+type StorageOperations interface {
 	// Adds a new output to storage
 	InsertOutput(ctx context.Context, utxo *Output) error
 
-	// Finds an output from storage
+	// Finds an output from storage, returns nil without an error when the output is not found
 	FindOutput(ctx context.Context, outpoint *transaction.Outpoint, topic *string, spent *bool, includeBEEF bool) (*Output, error)
 
+	// Finds outputs within a topic, the result is aligned with the outpoints and holds nil for missing outputs
 	FindOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spent *bool, includeBEEF bool) ([]*Output, error)
 
 	// Finds outputs with a matching transaction ID from storage
 	FindOutputsForTransaction(ctx context.Context, txid *chainhash.Hash, includeBEEF bool) ([]*Output, error)
 
-	// Finds current UTXOs that have been admitted into a given topic
+	// Finds current UTXOs that have been admitted into a given topic at or after the since unix timestamp (0 means all)
 	FindUTXOsForTopic(ctx context.Context, topic string, since uint32, includeBEEF bool) ([]*Output, error)
 
+	// Finds the spent outputs of a given topic, ordered by the time they were admitted
+	FindSpentOutputsForTopic(ctx context.Context, topic string, includeBEEF bool) ([]*Output, error)
+
+	// Finds outputs across all topics mined in a block at or above the block height, ordered by block height
+	FindOutputsSinceBlockHeight(ctx context.Context, blockHeight uint32, includeBEEF bool) ([]*Output, error)
+
 	// Deletes an output from storage
 	DeleteOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error
 
-	// Updates UTXOs as spent
+	// Updates UTXOs as spent, outpoints not stored for the topic are ignored
 	MarkUTXOsAsSpent(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spendTxid *chainhash.Hash) error
 
-	// Updates which outputs are consumed by this output
+	// Updates which outputs are consumed by this output, returns ErrNotFound when the output is not found
 	UpdateConsumedBy(ctx context.Context, outpoint *transaction.Outpoint, topic string, consumedBy []*transaction.Outpoint) error
 
-	// Updates the beef data for a transaction
+	// Updates the beef data for a transaction, returns ErrNotFound when the transaction has no outputs
 	UpdateTransactionBEEF(ctx context.Context, txid *chainhash.Hash, beef []byte) error
 
-	// Updates the block height on an output
+	// Updates the block height on an output, returns ErrNotFound when the output is not found
 	UpdateOutputBlockHeight(ctx context.Context, outpoint *transaction.Outpoint, topic string, blockHeight uint32, blockIndex uint64, ancillaryBeef []byte) error
 
 	// Inserts record of the applied transaction
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// SubmitBatchResult is the outcome of a transaction of a batch submitted with SubmitBatch.
+type SubmitBatchResult struct {
+	// Txid is the transaction, nil when its BEEF cannot be parsed.
+	Txid *chainhash.Hash
+	// Steak is what the topics admitted, nil when the submission failed.
+	Steak overlay.Steak
+	// Err is why the submission failed.
+	Err error
+}
+
+// SubmitBatch submits the tagged BEEFs one at a time, each transaction after the transactions of the
+// batch it spends, and returns the outcome of every submission in the order of the batch. A failed
+// submission does not stop the batch, the transactions spending it are still submitted and fail or
+// succeed on their own.
+func (e *Engine) SubmitBatch(ctx context.Context, taggedBEEFs []overlay.TaggedBEEF, mode SumbitMode) []*SubmitBatchResult {
+	results := make([]*SubmitBatchResult, len(taggedBEEFs))
+	txs := make([]*transaction.Transaction, len(taggedBEEFs))
+	batch := make(map[chainhash.Hash]int, len(taggedBEEFs))
+	for i, taggedBEEF := range taggedBEEFs {
+		results[i] = &SubmitBatchResult{}
+		_, tx, txid, err := transaction.ParseBeef(taggedBEEF.Beef)
+		if err != nil {
+			results[i].Err = err
+			continue
+		} else if tx == nil {
+			results[i].Err = ErrInvalidBeef
+			continue
+		}
+		results[i].Txid = txid
+		txs[i] = tx
+		if _, ok := batch[*txid]; !ok {
+			batch[*txid] = i
+		}
+	}
+
+	for _, i := range submitBatchOrder(txs, batch) {
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Steak, results[i].Err = e.Submit(ctx, taggedBEEFs[i], mode, nil)
+		if results[i].Err != nil {
+			slog.Error("failed to submit transaction of batch", "txid", results[i].Txid, "error", results[i].Err)
+		}
+	}
+	return results
+}
+
+// submitBatchOrder returns the indexes of the parsed transactions of the batch ordered so that every
+// transaction comes after the transactions of the batch it spends, and in the order of the batch otherwise.
+// The transactions whose BEEF cannot be parsed are left out.
+func submitBatchOrder(txs []*transaction.Transaction, batch map[chainhash.Hash]int) []int {
+	parents := make([]int, len(txs))
+	dependents := make(map[int][]int)
+	for i, tx := range txs {
+		if tx == nil {
+			continue
+		}
+		spent := make(map[int]struct{})
+		for _, input := range tx.Inputs {
+			parent, ok := batch[*input.SourceTXID]
+			if !ok || parent == i {
+				continue
+			}
+			if _, ok := spent[parent]; !ok {
+				spent[parent] = struct{}{}
+				dependents[parent] = append(dependents[parent], i)
+				parents[i]++
+			}
+		}
+	}
+
+	order := make([]int, 0, len(txs))
+	ordered := make([]bool, len(txs))
+	for i, tx := range txs {
+		ordered[i] = tx == nil
+	}
+	for {
+		next := -1
+		for i := range txs {
+			if !ordered[i] && parents[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// Only transactions spending each other are left, which valid transactions cannot do.
+			next = slices.Index(ordered, false)
+		}
+		if next < 0 {
+			break
+		}
+		ordered[next] = true
+		order = append(order, next)
+		for _, dependent := range dependents[next] {
+			parents[dependent]--
+		}
+	}
+	return order
+}
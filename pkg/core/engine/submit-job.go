@@ -0,0 +1,204 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+const (
+	// DefaultSubmitJobWorkers is the number of asynchronous submissions processed at once by the
+	// queue created when SubmitJobs is not set.
+	DefaultSubmitJobWorkers = 4
+	// DefaultSubmitJobCapacity is the number of asynchronous submissions waiting for a worker
+	// accepted by the queue created when SubmitJobs is not set.
+	DefaultSubmitJobCapacity = 1024
+	// DefaultSubmitJobRetention is how long the queue created when SubmitJobs is not set keeps the
+	// outcome of a finished submission.
+	DefaultSubmitJobRetention = time.Hour
+)
+
+var (
+	// ErrSubmitJobsUnavailable is returned by the asynchronous submission operations when the engine has no SubmitJobs queue.
+	ErrSubmitJobsUnavailable = errors.New("submit-jobs-unavailable")
+	// ErrSubmitQueueFull is returned by SubmitAsync when as many submissions as the queue accepts wait for a worker.
+	ErrSubmitQueueFull = errors.New("submit-queue-full")
+)
+
+// SubmitJobStatus tells where an asynchronous submission is in its lifecycle.
+type SubmitJobStatus string
+
+const (
+	// SubmitJobQueued jobs wait for a free worker.
+	SubmitJobQueued SubmitJobStatus = "queued"
+	// SubmitJobProcessing jobs are being submitted.
+	SubmitJobProcessing SubmitJobStatus = "processing"
+	// SubmitJobCompleted jobs were submitted, their Steak is set.
+	SubmitJobCompleted SubmitJobStatus = "completed"
+	// SubmitJobFailed jobs were rejected, their Err is set.
+	SubmitJobFailed SubmitJobStatus = "failed"
+)
+
+// SubmitJob is a submission accepted by SubmitAsync, together with its outcome once finished.
+type SubmitJob struct {
+	ID     string
+	Status SubmitJobStatus
+	Topics []string
+	// Steak is what the topics admitted, set once completed.
+	Steak overlay.Steak
+	// Err is why the submission failed, set once failed.
+	Err       error
+	CreatedAt time.Time
+	// StartedAt is when a worker picked the job, zero while queued.
+	StartedAt time.Time
+	// CompletedAt is when the job finished, zero until completed or failed.
+	CompletedAt time.Time
+}
+
+// submitTask is a queued job together with the submission it runs.
+type submitTask struct {
+	job *SubmitJob
+	run func() (overlay.Steak, error)
+}
+
+// SubmitJobQueue runs the asynchronous submissions on a bounded pool of workers and keeps their
+// outcome for a while after they finish. The workers and their queue are created with the first submission.
+// A SubmitJobQueue is safe for concurrent use.
+type SubmitJobQueue struct {
+	mu        sync.Mutex
+	start     sync.Once
+	workers   int
+	capacity  int
+	retention time.Duration
+	tasks     chan submitTask
+	jobs      map[string]*SubmitJob
+}
+
+// NewSubmitJobQueue returns a queue processing the given number of submissions at once, accepting
+// up to capacity submissions waiting for a worker, and keeping the outcome of the finished ones for
+// the retention period.
+func NewSubmitJobQueue(workers, capacity int, retention time.Duration) *SubmitJobQueue {
+	return &SubmitJobQueue{
+		workers:   max(workers, 1),
+		capacity:  max(capacity, 1),
+		retention: retention,
+		jobs:      make(map[string]*SubmitJob),
+	}
+}
+
+// enqueue queues the submission, failing with ErrSubmitQueueFull when the queue is full.
+func (q *SubmitJobQueue) enqueue(topics []string, run func() (overlay.Steak, error)) (*SubmitJob, error) {
+	q.start.Do(func() {
+		q.tasks = make(chan submitTask, q.capacity)
+		for range q.workers {
+			go q.work()
+		}
+	})
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	q.prune(now)
+	job := &SubmitJob{
+		ID:        randomID(),
+		Status:    SubmitJobQueued,
+		Topics:    topics,
+		CreatedAt: now,
+	}
+	select {
+	case q.tasks <- submitTask{job: job, run: run}:
+	default:
+		return nil, ErrSubmitQueueFull
+	}
+	q.jobs[job.ID] = job
+	return cloneSubmitJob(job), nil
+}
+
+// work processes the queued submissions one at a time, forever.
+func (q *SubmitJobQueue) work() {
+	for task := range q.tasks {
+		q.mu.Lock()
+		task.job.Status = SubmitJobProcessing
+		task.job.StartedAt = time.Now()
+		q.mu.Unlock()
+
+		steak, err := task.run()
+
+		q.mu.Lock()
+		task.job.CompletedAt = time.Now()
+		if err != nil {
+			task.job.Status = SubmitJobFailed
+			task.job.Err = err
+		} else {
+			task.job.Status = SubmitJobCompleted
+			task.job.Steak = steak
+		}
+		q.mu.Unlock()
+	}
+}
+
+// job returns a copy of the job, or ErrNotFound when it is unknown or its outcome is no longer kept.
+func (q *SubmitJobQueue) job(id string) (*SubmitJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune(time.Now())
+	job, ok := q.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return cloneSubmitJob(job), nil
+}
+
+// prune forgets the jobs finished longer than the retention period ago. The caller holds the lock.
+func (q *SubmitJobQueue) prune(now time.Time) {
+	for id, job := range q.jobs {
+		if !job.CompletedAt.IsZero() && now.Sub(job.CompletedAt) > q.retention {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// cloneSubmitJob returns a copy of the job safe to hand out while the queue updates the original.
+func cloneSubmitJob(job *SubmitJob) *SubmitJob {
+	clone := *job
+	return &clone
+}
+
+// SubmitAsync queues the submission of the tagged BEEF and returns its job right away, see FindSubmitJob
+// for its outcome. Submissions to unknown topics are rejected without being queued. The submission
+// outlives the context, keeping only its values, and keeps the tagged BEEF until a worker runs it,
+// so the caller must not reuse its buffers.
+func (e *Engine) SubmitAsync(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode) (*SubmitJob, error) {
+	if e.SubmitJobs == nil {
+		return nil, ErrSubmitJobsUnavailable
+	}
+	for _, topic := range taggedBEEF.Topics {
+		if _, ok := e.Managers[topic]; !ok {
+			slog.Error("unknown topic in SubmitAsync", "topic", topic, "error", ErrUnknownTopic)
+			return nil, ErrUnknownTopic
+		}
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	job, err := e.SubmitJobs.enqueue(taggedBEEF.Topics, func() (overlay.Steak, error) {
+		return e.Submit(ctx, taggedBEEF, mode, nil)
+	})
+	if err != nil {
+		slog.Error("failed to queue submission", "error", err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// FindSubmitJob returns the job queued by SubmitAsync, or ErrNotFound when it is unknown or finished
+// longer than the retention period of the queue ago.
+func (e *Engine) FindSubmitJob(ctx context.Context, id string) (*SubmitJob, error) {
+	if e.SubmitJobs == nil {
+		return nil, ErrSubmitJobsUnavailable
+	}
+	return e.SubmitJobs.job(id)
+}
@@ -0,0 +1,255 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// topicApplication describes everything needed to apply a submitted transaction to a single topic.
+type topicApplication struct {
+	topic         string
+	tx            *transaction.Transaction
+	txid          *chainhash.Hash
+	beef          []byte
+	inpoints      []*transaction.Outpoint
+	inputs        map[uint32]*Output
+	admit         *overlay.AdmittanceInstructions
+	ancillaryBeef []byte
+	// outbox are the broadcasts and propagations of the transaction queued together with the topic.
+	outbox []*OutboxEntry
+}
+
+// applyTopic applies the transaction to a single topic within one storage transaction, so either
+// every storage write of the topic is committed or none is. The lookup services are told about the
+// spent, admitted and no longer retained outputs only once the storage transaction is committed,
+// so they never hear about writes that are rolled back. The outbox entries are queued within the
+// same storage transaction when the Outbox is the Storage, and right after the commit otherwise.
+func (e *Engine) applyTopic(ctx context.Context, a *topicApplication) (err error) {
+	start := time.Now()
+	storageTx, err := e.Storage.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to begin storage transaction", "topic", a.topic, "txid", a.txid, "error", err)
+		return err
+	}
+
+	finished := false
+	defer func() {
+		if err != nil && !finished {
+			if rollbackErr := storageTx.Rollback(); rollbackErr != nil {
+				slog.Error("failed to roll back storage transaction", "topic", a.topic, "txid", a.txid, "error", rollbackErr)
+			}
+		}
+	}()
+
+	if err := storageTx.MarkUTXOsAsSpent(ctx, a.inpoints, a.topic, a.txid); err != nil {
+		slog.Error("failed to mark UTXOs as spent", "topic", a.topic, "txid", a.txid, "error", err)
+		return err
+	}
+	spent := make([]*Output, 0, len(a.inputs))
+	for vin := range a.inpoints {
+		if output, ok := a.inputs[uint32(vin)]; ok {
+			spent = append(spent, output)
+		}
+	}
+	slog.Debug("UTXOs marked as spent", "duration", time.Since(start))
+	start = time.Now()
+
+	outputsConsumed := make([]*Output, 0, len(a.admit.CoinsToRetain))
+	outpointsConsumed := make([]*transaction.Outpoint, 0, len(a.admit.CoinsToRetain))
+	for vin, output := range a.inputs {
+		for _, coin := range a.admit.CoinsToRetain {
+			if vin == coin {
+				outputsConsumed = append(outputsConsumed, output)
+				outpointsConsumed = append(outpointsConsumed, &output.Outpoint)
+				delete(a.inputs, vin)
+				break
+			}
+		}
+	}
+
+	var noLongerRetained []*Output
+	for vin, output := range a.inputs {
+		if err := e.deleteUTXODeep(ctx, storageTx, output, &noLongerRetained); err != nil {
+			slog.Error("failed to delete UTXO deep", "topic", a.topic, "outpoint", output.Outpoint.String(), "error", err)
+			return err
+		}
+		a.admit.CoinsRemoved = append(a.admit.CoinsRemoved, uint32(vin))
+	}
+
+	admitted := make([]*Output, 0, len(a.admit.OutputsToAdmit))
+	newOutpoints := make([]*transaction.Outpoint, 0, len(a.admit.OutputsToAdmit))
+	for _, vout := range a.admit.OutputsToAdmit {
+		out := a.tx.Outputs[vout]
+		output := &Output{
+			Outpoint: transaction.Outpoint{
+				Txid:  *a.txid,
+				Index: uint32(vout),
+			},
+			Script:          out.LockingScript,
+			Satoshis:        out.Satoshis,
+			Topic:           a.topic,
+			OutputsConsumed: outpointsConsumed,
+			Beef:            a.beef,
+			AncillaryTxids:  a.admit.AncillaryTxids,
+			AncillaryBeef:   a.ancillaryBeef,
+		}
+		if a.tx.MerklePath != nil {
+			output.BlockHeight = a.tx.MerklePath.BlockHeight
+			for _, leaf := range a.tx.MerklePath.Path[0] {
+				if leaf.Hash != nil && leaf.Hash.Equal(output.Outpoint.Txid) {
+					output.BlockIdx = leaf.Offset
+					break
+				}
+			}
+		}
+		if err := storageTx.InsertOutput(ctx, output); err != nil {
+			slog.Error("failed to insert output", "topic", a.topic, "outpoint", output.Outpoint.String(), "error", err)
+			return err
+		}
+		admitted = append(admitted, output)
+		newOutpoints = append(newOutpoints, &output.Outpoint)
+	}
+	slog.Debug("outputs added", "duration", time.Since(start))
+	start = time.Now()
+
+	for _, output := range outputsConsumed {
+		output.ConsumedBy = append(output.ConsumedBy, newOutpoints...)
+
+		if err := storageTx.UpdateConsumedBy(ctx, &output.Outpoint, output.Topic, output.ConsumedBy); err != nil {
+			slog.Error("failed to update consumed by", "topic", output.Topic, "outpoint", output.Outpoint.String(), "error", err)
+			return err
+		}
+	}
+	slog.Debug("consumed by references updated", "duration", time.Since(start))
+	start = time.Now()
+
+	if err := storageTx.InsertAppliedTransaction(ctx, &overlay.AppliedTransaction{
+		Txid:  a.txid,
+		Topic: a.topic,
+	}); err != nil {
+		slog.Error("failed to insert applied transaction", "topic", a.topic, "txid", a.txid, "error", err)
+		return err
+	}
+
+	outbox := a.outbox
+	if outboxTx, ok := e.outboxTransaction(storageTx); ok && len(outbox) > 0 {
+		if err := outboxTx.InsertOutboxEntries(ctx, outbox); err != nil {
+			slog.Error("failed to queue outbox entries", "topic", a.topic, "txid", a.txid, "error", err)
+			return err
+		}
+		outbox = nil
+	}
+
+	finished = true
+	if err := storageTx.Commit(); err != nil {
+		slog.Error("failed to commit storage transaction", "topic", a.topic, "txid", a.txid, "error", err)
+		return err
+	}
+	slog.Debug("transaction applied", "duration", time.Since(start))
+
+	e.enqueueOutboxEntries(ctx, outbox...)
+	e.notifyApplied(ctx, a, spent, admitted, noLongerRetained)
+	return nil
+}
+
+// notifyApplied tells the lookup services about the outputs spent, admitted and no longer retained
+// in history by the transaction applied to the topic, and publishes the matching events. Failures
+// are logged only, as the topic is already applied.
+func (e *Engine) notifyApplied(ctx context.Context, a *topicApplication, spent, admitted, noLongerRetained []*Output) {
+	for vin, outpoint := range a.inpoints {
+		for _, l := range e.LookupServices {
+			if err := l.OutputSpent(ctx, &OutputSpent{
+				Outpoint:           outpoint,
+				Topic:              a.topic,
+				SpendingTxid:       a.txid,
+				InputIndex:         uint32(vin),
+				UnlockingScript:    a.tx.Inputs[vin].UnlockingScript,
+				SequenceNumber:     a.tx.Inputs[vin].SequenceNumber,
+				SpendingAtomicBEEF: a.beef,
+			}); err != nil {
+				slog.Error("failed to notify lookup service about spent output", "topic", a.topic, "txid", a.txid, "error", err)
+			}
+		}
+	}
+	for _, output := range admitted {
+		for _, l := range e.LookupServices {
+			if err := l.OutputAdmittedByTopic(ctx, &OutputAdmittedByTopic{
+				Topic:         a.topic,
+				Outpoint:      &output.Outpoint,
+				Satoshis:      output.Satoshis,
+				LockingScript: output.Script,
+				AtomicBEEF:    a.beef,
+			}); err != nil {
+				slog.Error("failed to notify lookup service about admitted output", "topic", a.topic, "outpoint", output.Outpoint.String(), "error", err)
+			}
+		}
+	}
+
+	events := make([]Event, 0, len(spent)+len(admitted))
+	for _, output := range spent {
+		events = append(events, Event{Type: EventOutputSpent, Topic: a.topic, Outpoint: output.Outpoint, SpendingTxid: a.txid})
+	}
+	for _, output := range admitted {
+		events = append(events, Event{Type: EventOutputAdmitted, Topic: a.topic, Outpoint: output.Outpoint})
+	}
+	e.publishEvents(ctx, events...)
+
+	for _, output := range noLongerRetained {
+		for _, l := range e.LookupServices {
+			if err := l.OutputNoLongerRetainedInHistory(ctx, &output.Outpoint, output.Topic); err != nil {
+				slog.Error("failed to notify lookup service about output removal", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+			}
+		}
+	}
+}
+
+// deleteUTXODeep removes the output and, recursively, every output it consumed that is no
+// longer needed by any other output. Removed outputs are appended to removed, so the caller
+// can notify the lookup services once the deletion is durable.
+func (e *Engine) deleteUTXODeep(ctx context.Context, storage StorageOperations, output *Output, removed *[]*Output) error {
+	if len(output.ConsumedBy) == 0 {
+		if err := storage.DeleteOutput(ctx, &output.Outpoint, output.Topic); err != nil {
+			slog.Error("failed to delete output in deleteUTXODeep", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+			return err
+		}
+		*removed = append(*removed, output)
+	}
+	if len(output.OutputsConsumed) == 0 {
+		return nil
+	}
+
+	for _, outpoint := range output.OutputsConsumed {
+		staleOutput, err := storage.FindOutput(ctx, outpoint, &output.Topic, nil, false)
+		if err != nil {
+			slog.Error("failed to find stale output in deleteUTXODeep", "outpoint", outpoint.String(), "topic", output.Topic, "error", err)
+			return err
+		} else if staleOutput == nil {
+			continue
+		}
+		if len(staleOutput.ConsumedBy) > 0 {
+			consumedBy := staleOutput.ConsumedBy
+			staleOutput.ConsumedBy = make([]*transaction.Outpoint, 0, len(consumedBy))
+			for _, outpoint := range consumedBy {
+				if !bytes.Equal(outpoint.TxBytes(), output.Outpoint.TxBytes()) {
+					staleOutput.ConsumedBy = append(staleOutput.ConsumedBy, outpoint)
+				}
+			}
+			if err := storage.UpdateConsumedBy(ctx, &staleOutput.Outpoint, staleOutput.Topic, staleOutput.ConsumedBy); err != nil {
+				slog.Error("failed to update consumed by in deleteUTXODeep", "outpoint", staleOutput.Outpoint.String(), "topic", staleOutput.Topic, "error", err)
+				return err
+			}
+		}
+
+		if err := e.deleteUTXODeep(ctx, storage, staleOutput, removed); err != nil {
+			slog.Error("failed recursive deleteUTXODeep", "outpoint", staleOutput.Outpoint.String(), "topic", staleOutput.Topic, "error", err)
+			return err
+		}
+	}
+	return nil
+}
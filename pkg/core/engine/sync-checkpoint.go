@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SyncCheckpoint records the last successful GASP sync of a topic with a peer.
+type SyncCheckpoint struct {
+	Topic string
+	Peer  string
+	// Since is the unix timestamp, on the clock of the peer, at which the peer listed its UTXOs
+	// during the last successful sync. The next sync with the peer only requests the UTXOs the
+	// peer admitted at or after it.
+	Since uint32
+	// UpdatedAt is the time at which the checkpoint was recorded.
+	UpdatedAt time.Time
+}
+
+// SyncCheckpointStorage persists the GASP sync checkpoints of the engine.
+type SyncCheckpointStorage interface {
+	// Finds the checkpoint of the topic and peer, returns nil without an error when there is none
+	FindSyncCheckpoint(ctx context.Context, topic, peer string) (*SyncCheckpoint, error)
+
+	// Finds the checkpoints of the topic ordered by topic and peer, an empty topic matches every topic
+	FindSyncCheckpoints(ctx context.Context, topic string) ([]*SyncCheckpoint, error)
+
+	// Inserts the checkpoint or replaces the existing checkpoint of its topic and peer
+	UpsertSyncCheckpoint(ctx context.Context, checkpoint *SyncCheckpoint) error
+
+	// Deletes the checkpoints of the topic and peer and returns how many were deleted,
+	// an empty topic or peer matches every topic or peer
+	DeleteSyncCheckpoints(ctx context.Context, topic, peer string) (int, error)
+}
+
+// syncCheckpointSince returns the timestamp to sync the topic with the peer from, which is
+// zero when checkpoints are not persisted or the peer was never synced successfully.
+func (e *Engine) syncCheckpointSince(ctx context.Context, topic, peer string) uint32 {
+	if e.SyncCheckpoints == nil {
+		return 0
+	}
+	checkpoint, err := e.SyncCheckpoints.FindSyncCheckpoint(ctx, topic, peer)
+	if err != nil {
+		slog.Error("failed to find sync checkpoint, syncing from the beginning", "topic", topic, "peer", peer, "error", err)
+		return 0
+	}
+	if checkpoint == nil {
+		return 0
+	}
+	return checkpoint.Since
+}
+
+// saveSyncCheckpoint records a successful sync of the topic with the peer that listed its UTXOs at since.
+func (e *Engine) saveSyncCheckpoint(ctx context.Context, topic, peer string, since uint32) {
+	if e.SyncCheckpoints == nil {
+		return
+	}
+	err := e.SyncCheckpoints.UpsertSyncCheckpoint(ctx, &SyncCheckpoint{
+		Topic:     topic,
+		Peer:      peer,
+		Since:     since,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		slog.Error("failed to save sync checkpoint", "topic", topic, "peer", peer, "error", err)
+	}
+}
+
+// ListSyncCheckpoints returns the GASP sync checkpoints of the topic, or of every topic when
+// the topic is empty. It returns an empty list when checkpoints are not persisted.
+func (e *Engine) ListSyncCheckpoints(ctx context.Context, topic string) ([]*SyncCheckpoint, error) {
+	if e.SyncCheckpoints == nil {
+		return []*SyncCheckpoint{}, nil
+	}
+	checkpoints, err := e.SyncCheckpoints.FindSyncCheckpoints(ctx, topic)
+	if err != nil {
+		slog.Error("failed to find sync checkpoints", "topic", topic, "error", err)
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// ResetSyncCheckpoints deletes the GASP sync checkpoints matching the topic and peer, so the
+// next sync with the matching peers requests their whole UTXO set again. An empty topic or
+// peer matches every topic or peer. It returns how many checkpoints were deleted.
+func (e *Engine) ResetSyncCheckpoints(ctx context.Context, topic, peer string) (int, error) {
+	if e.SyncCheckpoints == nil {
+		return 0, nil
+	}
+	deleted, err := e.SyncCheckpoints.DeleteSyncCheckpoints(ctx, topic, peer)
+	if err != nil {
+		slog.Error("failed to delete sync checkpoints", "topic", topic, "peer", peer, "error", err)
+		return 0, err
+	}
+	return deleted, nil
+}
@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultGASPSyncJitter is the fraction of the sync interval used as jitter when GASPSyncJitter is not set.
+	DefaultGASPSyncJitter = 0.1
+	// DefaultGASPSyncMaxBackoff caps the backoff of failing peers when GASPSyncMaxBackoff is not set.
+	DefaultGASPSyncMaxBackoff = time.Hour
+)
+
+// PeerHealth tracks the outcome of the recent syncs of a topic with a peer.
+type PeerHealth struct {
+	Topic string
+	Peer  string
+	// ConsecutiveFailures is the number of syncs failed since the last successful one.
+	ConsecutiveFailures int
+	LastSuccess         time.Time
+	LastFailure         time.Time
+	LastError           string
+	// RetryAfter is the time until which the scheduler skips the peer, zero when the peer is healthy.
+	RetryAfter time.Time
+}
+
+type peerHealthKey struct {
+	topic string
+	peer  string
+}
+
+// RunGASPSyncScheduler syncs every topic configured with an interval with its peers in the
+// background, and blocks until the context is done. The first sync of a topic starts after a
+// random delay within the jitter of its interval, so nodes started together do not sync in
+// lockstep. A peer failing to sync is skipped for twice the interval of the topic, doubled with
+// every further consecutive failure and capped at GASPSyncMaxBackoff.
+func (e *Engine) RunGASPSyncScheduler(ctx context.Context) {
+	var wg sync.WaitGroup
+	for topic, config := range e.SyncConfiguration {
+		if config.Interval <= 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.scheduleTopicSync(ctx, topic, config.Interval)
+		}()
+	}
+	wg.Wait()
+}
+
+func (e *Engine) scheduleTopicSync(ctx context.Context, topic string, interval time.Duration) {
+	slog.Info("scheduling GASP sync", "topic", topic, "interval", interval)
+	delay := time.Duration(rand.Float64() * e.gaspSyncJitter() * float64(interval))
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			slog.Info("stopped scheduling GASP sync", "topic", topic)
+			return
+		case <-timer.C:
+		}
+
+		if _, err := e.syncTopics(ctx, []string{topic}, true); err != nil {
+			slog.Error("failed to run scheduled GASP sync", "topic", topic, "error", err)
+		}
+		delay = e.jitter(interval)
+	}
+}
+
+// jitter randomly shortens or extends the interval by up to the jitter fraction of it.
+func (e *Engine) jitter(interval time.Duration) time.Duration {
+	spread := e.gaspSyncJitter() * float64(interval)
+	return interval + time.Duration((rand.Float64()*2-1)*spread)
+}
+
+func (e *Engine) gaspSyncJitter() float64 {
+	if e.GASPSyncJitter <= 0 {
+		return DefaultGASPSyncJitter
+	}
+	return min(e.GASPSyncJitter, 1)
+}
+
+// backoff returns how long a peer with the given number of consecutive failures is skipped.
+func (e *Engine) backoff(interval time.Duration, failures int) time.Duration {
+	maxBackoff := e.GASPSyncMaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultGASPSyncMaxBackoff
+	}
+	backoff := interval
+	for i := 0; i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// isPeerBackingOff reports whether the peer failed to sync the topic recently enough to be skipped.
+func (e *Engine) isPeerBackingOff(topic, peer string, now time.Time) bool {
+	state := e.state()
+	state.peerHealthMu.Lock()
+	defer state.peerHealthMu.Unlock()
+	health, ok := state.peerHealth[peerHealthKey{topic: topic, peer: peer}]
+	return ok && now.Before(health.RetryAfter)
+}
+
+// recordPeerHealth updates the health of the peer with the outcome of the sync session.
+func (e *Engine) recordPeerHealth(session gaspSyncSession, result *GASPSyncPeerResult) {
+	state := e.state()
+	state.peerHealthMu.Lock()
+	defer state.peerHealthMu.Unlock()
+	if state.peerHealth == nil {
+		state.peerHealth = make(map[peerHealthKey]*PeerHealth)
+	}
+	key := peerHealthKey{topic: session.topic, peer: session.peer}
+	health, ok := state.peerHealth[key]
+	if !ok {
+		health = &PeerHealth{Topic: session.topic, Peer: session.peer}
+		state.peerHealth[key] = health
+	}
+
+	now := time.Now()
+	if result.Err == nil {
+		health.ConsecutiveFailures = 0
+		health.LastSuccess = now
+		health.RetryAfter = time.Time{}
+		return
+	}
+	health.ConsecutiveFailures++
+	health.LastFailure = now
+	health.LastError = result.Err.Error()
+	if session.config.Interval > 0 {
+		health.RetryAfter = now.Add(e.backoff(session.config.Interval, health.ConsecutiveFailures))
+	}
+}
+
+// ListPeerHealth returns the health of every peer synced so far, ordered by topic and peer.
+func (e *Engine) ListPeerHealth() []*PeerHealth {
+	state := e.state()
+	state.peerHealthMu.Lock()
+	defer state.peerHealthMu.Unlock()
+	healths := make([]*PeerHealth, 0, len(state.peerHealth))
+	for _, health := range state.peerHealth {
+		copied := *health
+		healths = append(healths, &copied)
+	}
+	slices.SortFunc(healths, func(a, b *PeerHealth) int {
+		if c := strings.Compare(a.Topic, b.Topic); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Peer, b.Peer)
+	})
+	return healths
+}
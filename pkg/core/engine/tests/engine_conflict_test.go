@@ -0,0 +1,217 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// conflictFixture is an admitted spend of a topic output, a transaction admitted on top of it
+// and a competing spend of the same output rejected by the engine.
+type conflictFixture struct {
+	parent    *transaction.Transaction
+	admitted  *transaction.Transaction
+	child     *transaction.Transaction
+	competing *transaction.Transaction
+}
+
+func givenConflict(t *testing.T, sut *engine.Engine) *conflictFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	chain := givenChain(3)
+	parent, admitted, child := chain[0], chain[1], chain[2]
+	competing := givenSpend(parent, 901)
+
+	submitHistorical(t, sut, chain...)
+	_, err := sut.Submit(ctx, toTaggedBEEF(t, competing), engine.SubmitModeHistorical, nil)
+	require.ErrorIs(t, err, engine.ErrInputSpent)
+
+	return &conflictFixture{parent: parent, admitted: admitted, child: child, competing: competing}
+}
+
+func proofFor(tx *transaction.Transaction) *transaction.MerklePath {
+	return &transaction.MerklePath{
+		BlockHeight: 814435,
+		Path:        [][]*transaction.PathElement{{{Hash: tx.TxID(), Offset: 0}}},
+	}
+}
+
+func TestEngine_Submit_ShouldRecordConflict_WhenInputSpentByAdmittedTransaction(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 0))
+
+	// when:
+	fixture := givenConflict(t, sut)
+
+	// then:
+	conflicts, err := sut.ListConflicts(ctx, "test-topic")
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	require.Equal(t, transaction.Outpoint{Txid: *fixture.parent.TxID(), Index: 0}, conflicts[0].Outpoint)
+	require.Equal(t, *fixture.admitted.TxID(), conflicts[0].AdmittedTxid)
+	require.Equal(t, *fixture.competing.TxID(), conflicts[0].CompetingTxid)
+	require.Equal(t, toTaggedBEEF(t, fixture.competing).Beef, conflicts[0].CompetingBeef)
+}
+
+func TestEngine_HandleNewMerkleProof_ShouldReplaceAdmittedSpend_WhenCompetingSpendIsProven(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := newTokenEngine(storage, 0)
+	fixture := givenConflict(t, sut)
+
+	recorder := &lookupServiceRecorder{}
+	sut.LookupServices = map[string]engine.LookupService{"test-lookup": recorder.lookupService(nil)}
+
+	// when:
+	err := sut.HandleNewMerkleProof(ctx, fixture.competing.TxID(), proofFor(fixture.competing))
+
+	// then:
+	require.NoError(t, err)
+
+	admittedOutpoint := transaction.Outpoint{Txid: *fixture.admitted.TxID(), Index: 0}
+	childOutpoint := transaction.Outpoint{Txid: *fixture.child.TxID(), Index: 0}
+	parentOutpoint := transaction.Outpoint{Txid: *fixture.parent.TxID(), Index: 0}
+	competingOutpoint := transaction.Outpoint{Txid: *fixture.competing.TxID(), Index: 0}
+	require.ElementsMatch(t, []transaction.Outpoint{admittedOutpoint, childOutpoint}, recorder.evicted)
+	require.Equal(t, []transaction.Outpoint{parentOutpoint, competingOutpoint}, recorder.admitted)
+	require.Equal(t, []transaction.Outpoint{parentOutpoint}, recorder.spent)
+
+	for _, outpoint := range []transaction.Outpoint{admittedOutpoint, childOutpoint} {
+		output, err := storage.FindOutput(ctx, &outpoint, nil, nil, false)
+		require.NoError(t, err)
+		require.Nil(t, output)
+	}
+
+	parent, err := storage.FindOutput(ctx, &parentOutpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.True(t, parent.Spent)
+	require.Equal(t, fixture.competing.TxID(), parent.SpendingTxid)
+	require.Equal(t, []*transaction.Outpoint{&competingOutpoint}, parent.ConsumedBy)
+
+	competing, err := storage.FindOutput(ctx, &competingOutpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.False(t, competing.Spent)
+	require.Equal(t, uint32(814435), competing.BlockHeight)
+
+	conflicts, err := sut.ListConflicts(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+}
+
+func TestEngine_HandleNewMerkleProof_ShouldDropConflict_WhenAdmittedSpendIsProven(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := newTokenEngine(storage, 0)
+	fixture := givenConflict(t, sut)
+
+	// when:
+	err := sut.HandleNewMerkleProof(ctx, fixture.admitted.TxID(), proofFor(fixture.admitted))
+
+	// then:
+	require.NoError(t, err)
+
+	conflicts, err := sut.ListConflicts(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+
+	admitted, err := storage.FindOutput(ctx, &transaction.Outpoint{Txid: *fixture.admitted.TxID(), Index: 0}, nil, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, admitted)
+	require.Equal(t, uint32(814435), admitted.BlockHeight)
+
+	competing, err := storage.FindOutput(ctx, &transaction.Outpoint{Txid: *fixture.competing.TxID(), Index: 0}, nil, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, competing)
+}
+
+// failAdmitting makes the topic manager of the engine fail the given number of times to identify the
+// admissible outputs of the transaction, admitting them as the token engine does afterwards.
+func failAdmitting(sut *engine.Engine, tx *transaction.Transaction, failures int) {
+	manager := sut.Managers["test-topic"].(fakeManager)
+	identify := manager.identifyAdmissibleOutputsFunc
+	manager.identifyAdmissibleOutputsFunc = func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+		_, _, txid, err := transaction.ParseBeef(beef)
+		if err == nil && txid.Equal(*tx.TxID()) && failures > 0 {
+			failures--
+			return overlay.AdmittanceInstructions{}, errors.New("topic manager unavailable")
+		}
+		return identify(ctx, beef, previousCoins)
+	}
+	sut.Managers["test-topic"] = manager
+}
+
+func TestEngine_HandleNewMerkleProof_ShouldRetrySubmittingCompetingSpend_WhenSubmissionFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := newTokenEngine(storage, 0)
+	fixture := givenConflict(t, sut)
+	failAdmitting(sut, fixture.competing, 1)
+
+	// when:
+	err := sut.HandleNewMerkleProof(ctx, fixture.competing.TxID(), proofFor(fixture.competing))
+
+	// then:
+	require.NoError(t, err)
+
+	competing, err := storage.FindOutput(ctx, &transaction.Outpoint{Txid: *fixture.competing.TxID(), Index: 0}, nil, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, competing)
+
+	conflicts, err := sut.ListConflicts(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+}
+
+func TestEngine_HandleNewMerkleProof_ShouldKeepConflictUntilCompetingSpendIsSubmitted_WhenSubmissionKeepsFailing(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := newTokenEngine(storage, 0)
+	fixture := givenConflict(t, sut)
+	failAdmitting(sut, fixture.competing, 3)
+	competingOutpoint := transaction.Outpoint{Txid: *fixture.competing.TxID(), Index: 0}
+
+	// when:
+	err := sut.HandleNewMerkleProof(ctx, fixture.competing.TxID(), proofFor(fixture.competing))
+
+	// then:
+	require.Error(t, err)
+
+	conflicts, err := sut.ListConflicts(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+
+	competing, err := storage.FindOutput(ctx, &competingOutpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, competing)
+
+	// when:
+	err = sut.HandleNewMerkleProof(ctx, fixture.competing.TxID(), proofFor(fixture.competing))
+
+	// then:
+	require.NoError(t, err)
+
+	competing, err = storage.FindOutput(ctx, &competingOutpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, competing)
+	require.Equal(t, uint32(814435), competing.BlockHeight)
+
+	parent, err := storage.FindOutput(ctx, &transaction.Outpoint{Txid: *fixture.parent.TxID(), Index: 0}, nil, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, fixture.competing.TxID(), parent.SpendingTxid)
+
+	conflicts, err = sut.ListConflicts(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+}
@@ -0,0 +1,172 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// receiveEvents drains the events already delivered to the subscription.
+func receiveEvents(t *testing.T, sub *engine.EventSubscription) []engine.Event {
+	t.Helper()
+
+	var events []engine.Event
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func cursor(value uint64) *uint64 { return &value }
+
+func TestEventBus_Subscribe_ShouldReplayEventsAfterCursorOfTopics(t *testing.T) {
+	// given:
+	sut := engine.NewEventBus(10)
+	sut.Publish(
+		engine.Event{Type: engine.EventOutputAdmitted, Topic: "tm_a"},
+		engine.Event{Type: engine.EventOutputAdmitted, Topic: "tm_b"},
+		engine.Event{Type: engine.EventOutputSpent, Topic: "tm_a"},
+	)
+
+	// when:
+	sub, err := sut.Subscribe([]string{"tm_a"}, cursor(1))
+
+	// then:
+	require.NoError(t, err)
+	defer sub.Close()
+
+	sut.Publish(
+		engine.Event{Type: engine.EventOutputEvicted, Topic: "tm_b"},
+		engine.Event{Type: engine.EventOutputEvicted, Topic: "tm_a"},
+	)
+	events := receiveEvents(t, sub)
+	require.Len(t, events, 2)
+	require.Equal(t, uint64(3), events[0].Cursor)
+	require.Equal(t, engine.EventOutputSpent, events[0].Type)
+	require.Equal(t, uint64(5), events[1].Cursor)
+	require.Equal(t, engine.EventOutputEvicted, events[1].Type)
+	require.False(t, events[1].CreatedAt.IsZero())
+}
+
+func TestEventBus_Subscribe_ShouldDeliverOnlyNewEvents_WhenCursorNotGiven(t *testing.T) {
+	// given:
+	sut := engine.NewEventBus(10)
+	sut.Publish(engine.Event{Type: engine.EventOutputAdmitted, Topic: "tm_a"})
+
+	// when:
+	sub, err := sut.Subscribe(nil, nil)
+
+	// then:
+	require.NoError(t, err)
+	defer sub.Close()
+
+	sut.Publish(engine.Event{Type: engine.EventSyncFinished, Topic: "tm_b", Peer: "https://peer.example.com"})
+	events := receiveEvents(t, sub)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(2), events[0].Cursor)
+	require.Equal(t, "https://peer.example.com", events[0].Peer)
+}
+
+func TestEventBus_Subscribe_ShouldReturnError(t *testing.T) {
+	tests := map[string]struct {
+		cursor      *uint64
+		closed      bool
+		expectedErr error
+	}{
+		"cursor events are no longer retained": {
+			cursor:      cursor(1),
+			expectedErr: engine.ErrEventCursorExpired,
+		},
+		"cursor was never published": {
+			cursor:      cursor(10),
+			expectedErr: engine.ErrEventCursorExpired,
+		},
+		"event bus is closed": {
+			closed:      true,
+			expectedErr: engine.ErrEventBusClosed,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			sut := engine.NewEventBus(2)
+			for range 5 {
+				sut.Publish(engine.Event{Type: engine.EventOutputAdmitted, Topic: "tm_a"})
+			}
+			if tc.closed {
+				sut.Close()
+			}
+
+			// when:
+			sub, err := sut.Subscribe(nil, tc.cursor)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, sub)
+		})
+	}
+}
+
+func TestEventBus_Publish_ShouldDropSubscriber_WhenTooSlow(t *testing.T) {
+	// given:
+	sut := engine.NewEventBus(0)
+	sub, err := sut.Subscribe(nil, nil)
+	require.NoError(t, err)
+
+	// when:
+	for range 1000 {
+		sut.Publish(engine.Event{Type: engine.EventOutputAdmitted, Topic: "tm_a"})
+	}
+
+	// then:
+	events := receiveEvents(t, sub)
+	require.NotEmpty(t, events)
+	require.Less(t, len(events), 1000)
+	_, open := <-sub.Events()
+	require.False(t, open)
+	require.ErrorIs(t, sub.Err(), engine.ErrEventSubscriberTooSlow)
+}
+
+func TestEngine_ShouldPublishEvents_WhenOutputsAreAdmittedSpentAndEvicted(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, parentOutpoint, childOutpoint := givenParentWithChild(t, memstorage.New())
+
+	sub, err := sut.SubscribeEvents([]string{"test-topic"}, cursor(0))
+	require.NoError(t, err)
+	defer sub.Close()
+
+	// when:
+	_, err = sut.EvictOutput(ctx, &childOutpoint, false, "admin", "takedown request")
+
+	// then:
+	require.NoError(t, err)
+
+	type published struct {
+		eventType engine.EventType
+		outpoint  transaction.Outpoint
+	}
+	var actual []published
+	for _, event := range receiveEvents(t, sub) {
+		require.Equal(t, "test-topic", event.Topic)
+		actual = append(actual, published{eventType: event.Type, outpoint: event.Outpoint})
+	}
+	require.Equal(t, []published{
+		{eventType: engine.EventOutputAdmitted, outpoint: parentOutpoint},
+		{eventType: engine.EventOutputSpent, outpoint: parentOutpoint},
+		{eventType: engine.EventOutputAdmitted, outpoint: childOutpoint},
+		{eventType: engine.EventOutputEvicted, outpoint: childOutpoint},
+	}, actual)
+}
@@ -0,0 +1,185 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// givenParentWithChild returns an engine holding a topic output and a transaction of the topic
+// spending it, together with the outpoints of both.
+func givenParentWithChild(t *testing.T, storage engine.Storage) (*engine.Engine, transaction.Outpoint, transaction.Outpoint) {
+	t.Helper()
+
+	sut := newTokenEngine(storage, 0)
+	chain := givenChain(2)
+	submitHistorical(t, sut, chain...)
+	return sut, transaction.Outpoint{Txid: *chain[0].TxID(), Index: 0}, transaction.Outpoint{Txid: *chain[1].TxID(), Index: 0}
+}
+
+func TestEngine_EvictOutput_ShouldPurgeOutputAndRecordAudit(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, parentOutpoint, childOutpoint := givenParentWithChild(t, storage)
+
+	recorder := &lookupServiceRecorder{}
+	sut.LookupServices = map[string]engine.LookupService{"test-lookup": recorder.lookupService(nil)}
+
+	// when:
+	eviction, err := sut.EvictOutput(ctx, &childOutpoint, false, "admin", "takedown request")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []engine.EvictedOutput{{Outpoint: childOutpoint, Topic: "test-topic"}}, eviction.Evicted)
+	require.Equal(t, []transaction.Outpoint{childOutpoint}, recorder.evicted)
+
+	child, err := storage.FindOutput(ctx, &childOutpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, child)
+
+	parent, err := storage.FindOutput(ctx, &parentOutpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, parent)
+	require.Empty(t, parent.ConsumedBy)
+
+	evictions, err := sut.ListEvictions(ctx)
+	require.NoError(t, err)
+	require.Len(t, evictions, 1)
+	require.Equal(t, childOutpoint, evictions[0].Outpoint)
+	require.Equal(t, "admin", evictions[0].Actor)
+	require.Equal(t, "takedown request", evictions[0].Reason)
+	require.False(t, evictions[0].CreatedAt.IsZero())
+}
+
+func TestEngine_EvictOutput_ShouldPurgeHistory_WhenRequested(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, parentOutpoint, childOutpoint := givenParentWithChild(t, storage)
+
+	recorder := &lookupServiceRecorder{}
+	sut.LookupServices = map[string]engine.LookupService{"test-lookup": recorder.lookupService(nil)}
+
+	// when:
+	eviction, err := sut.EvictOutput(ctx, &childOutpoint, true, "admin", "takedown request")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []engine.EvictedOutput{
+		{Outpoint: childOutpoint, Topic: "test-topic"},
+		{Outpoint: parentOutpoint, Topic: "test-topic"},
+	}, eviction.Evicted)
+	require.Equal(t, []transaction.Outpoint{childOutpoint, parentOutpoint}, recorder.evicted)
+
+	for _, outpoint := range []transaction.Outpoint{childOutpoint, parentOutpoint} {
+		output, err := storage.FindOutput(ctx, &outpoint, nil, nil, false)
+		require.NoError(t, err)
+		require.Nil(t, output)
+	}
+}
+
+func TestEngine_EvictOutput_ShouldPurgeOutput_WhenLookupServiceFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, _, childOutpoint := givenParentWithChild(t, storage)
+
+	var evicted []transaction.Outpoint
+	sut.LookupServices = map[string]engine.LookupService{
+		"test-lookup": fakeLookupService{
+			outputEvictedFunc: func(ctx context.Context, outpoint *transaction.Outpoint) error {
+				child, err := storage.FindOutput(ctx, outpoint, nil, nil, false)
+				require.NoError(t, err)
+				require.Nil(t, child, "The lookup service was told about the eviction before it was committed")
+				evicted = append(evicted, *outpoint)
+				return errors.New("lookup service failure")
+			},
+		},
+	}
+
+	// when:
+	eviction, err := sut.EvictOutput(ctx, &childOutpoint, false, "admin", "takedown request")
+
+	// then:
+	require.NoError(t, err)
+	require.NotNil(t, eviction)
+	require.Equal(t, []transaction.Outpoint{childOutpoint}, evicted)
+
+	child, err := storage.FindOutput(ctx, &childOutpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, child)
+
+	evictions, err := sut.ListEvictions(ctx)
+	require.NoError(t, err)
+	require.Len(t, evictions, 1)
+}
+
+// failingEvictionAuditStorage is an eviction audit log unable to record evictions.
+type failingEvictionAuditStorage struct {
+	engine.EvictionAuditStorage
+	err error
+}
+
+func (s failingEvictionAuditStorage) InsertEviction(ctx context.Context, eviction *engine.Eviction) error {
+	return s.err
+}
+
+func TestEngine_EvictOutput_ShouldReturnError(t *testing.T) {
+	errAudit := errors.New("audit failure")
+	tests := map[string]struct {
+		outpoint    func(child transaction.Outpoint) transaction.Outpoint
+		withoutLog  bool
+		failingLog  bool
+		expectedErr error
+	}{
+		"output is not stored": {
+			outpoint: func(child transaction.Outpoint) transaction.Outpoint {
+				return transaction.Outpoint{Txid: child.Txid, Index: 1}
+			},
+			expectedErr: engine.ErrNotFound,
+		},
+		"eviction audit storage is not configured": {
+			outpoint:    func(child transaction.Outpoint) transaction.Outpoint { return child },
+			withoutLog:  true,
+			expectedErr: engine.ErrEvictionAuditUnavailable,
+		},
+		"eviction cannot be recorded": {
+			outpoint:    func(child transaction.Outpoint) transaction.Outpoint { return child },
+			failingLog:  true,
+			expectedErr: errAudit,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			ctx := context.Background()
+			storage := memstorage.New()
+			sut, _, childOutpoint := givenParentWithChild(t, storage)
+			if tc.withoutLog {
+				sut.Evictions = nil
+			}
+			if tc.failingLog {
+				sut.Evictions = failingEvictionAuditStorage{EvictionAuditStorage: memstorage.New(), err: errAudit}
+			}
+			outpoint := tc.outpoint(childOutpoint)
+
+			// when:
+			eviction, err := sut.EvictOutput(ctx, &outpoint, false, "admin", "takedown request")
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, eviction)
+
+			child, err := storage.FindOutput(ctx, &childOutpoint, nil, nil, false)
+			require.NoError(t, err)
+			require.NotNil(t, child)
+		})
+	}
+}
@@ -287,6 +287,10 @@ func TestEngine_HandleNewMerkleProof(t *testing.T) {
 	})
 }
 
+func (m *mockHandleMerkleProofStorage) Begin(ctx context.Context) (engine.StorageTransaction, error) {
+	return nil, errors.New("not implemented")
+}
+
 // Mock storage for HandleNewMerkleProof tests
 type mockHandleMerkleProofStorage struct {
 	findOutputsForTransactionFunc func(ctx context.Context, txid *chainhash.Hash, includeBEEF bool) ([]*engine.Output, error)
@@ -337,6 +341,12 @@ func (m *mockHandleMerkleProofStorage) FindTransactionsCreatingUtxos(ctx context
 func (m *mockHandleMerkleProofStorage) FindUTXOsForTopic(ctx context.Context, topic string, since uint32, includeBEEF bool) ([]*engine.Output, error) {
 	return nil, nil
 }
+func (m *mockHandleMerkleProofStorage) FindSpentOutputsForTopic(ctx context.Context, topic string, includeBEEF bool) ([]*engine.Output, error) {
+	return nil, nil
+}
+func (m *mockHandleMerkleProofStorage) FindOutputsSinceBlockHeight(ctx context.Context, blockHeight uint32, includeBEEF bool) ([]*engine.Output, error) {
+	return nil, nil
+}
 func (m *mockHandleMerkleProofStorage) FindOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spent *bool, includeBEEF bool) ([]*engine.Output, error) {
 	return nil, nil
 }
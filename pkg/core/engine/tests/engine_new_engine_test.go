@@ -16,6 +16,8 @@ func TestEngine_NewEngine_ShouldInitializeFields_WhenNilProvided(t *testing.T) {
 		LookupServices:    map[string]engine.LookupService{},
 		SyncConfiguration: map[string]engine.SyncConfiguration{},
 		LookupResolver:    engine.NewLookupResolver(),
+		Events:            engine.NewEventBus(engine.DefaultEventBufferSize),
+		SubmitJobs:        engine.NewSubmitJobQueue(engine.DefaultSubmitJobWorkers, engine.DefaultSubmitJobCapacity, engine.DefaultSubmitJobRetention),
 	}
 
 	// when:
@@ -23,7 +25,7 @@ func TestEngine_NewEngine_ShouldInitializeFields_WhenNilProvided(t *testing.T) {
 
 	// then:
 	require.NotNil(t, actual)
-	require.Equal(t, expected, actual)
+	require.EqualExportedValues(t, expected, actual)
 }
 
 func TestEngine_NewEngine_ShouldMergeTrackers_WhenManagerIsShipType(t *testing.T) {
@@ -0,0 +1,198 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedBroadcaster answers the broadcasts with the failures in order, and succeeds once they run out.
+type scriptedBroadcaster struct {
+	failures []string
+	attempts int
+}
+
+func (b *scriptedBroadcaster) Broadcast(tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	return b.BroadcastCtx(context.Background(), tx)
+}
+
+func (b *scriptedBroadcaster) BroadcastCtx(ctx context.Context, tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	b.attempts++
+	if b.attempts <= len(b.failures) {
+		return nil, &transaction.BroadcastFailure{Description: b.failures[b.attempts-1]}
+	}
+	return &transaction.BroadcastSuccess{Txid: tx.TxID().String()}, nil
+}
+
+func TestEngine_Submit_ShouldAdmitAndQueueBroadcast_WhenBroadcastFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	recorder := &lookupServiceRecorder{}
+	sut, storage, taggedBEEF, _ := givenSubmitWithStoredInput(t, recorder.lookupService(nil))
+	sut.Outbox = storage
+	sut.Broadcaster = &scriptedBroadcaster{failures: []string{"broadcast failure"}}
+	txid := parseBEEFToTx(t, taggedBEEF.Beef).TxID()
+
+	// when:
+	steak, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, steak["test-topic"].OutputsToAdmit)
+
+	entries, err := sut.ListOutboxEntries(ctx, txid, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, engine.OutboxBroadcast, entries[0].Kind)
+	require.Equal(t, engine.OutboxPending, entries[0].Status)
+	require.Equal(t, 1, entries[0].Attempts)
+	require.Equal(t, "broadcast failure", entries[0].LastError)
+	require.Equal(t, taggedBEEF.Beef, entries[0].Beef)
+}
+
+func TestEngine_Submit_ShouldRecordCompletedBroadcast(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, storage, taggedBEEF, _ := givenSubmitWithStoredInput(t, (&lookupServiceRecorder{}).lookupService(nil))
+	sut.Outbox = storage
+	sut.Broadcaster = &scriptedBroadcaster{}
+
+	// when:
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+
+	pending, err := sut.ListOutboxEntries(ctx, nil, engine.OutboxPending)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	completed, err := sut.ListOutboxEntries(ctx, nil, engine.OutboxCompleted)
+	require.NoError(t, err)
+	require.Len(t, completed, 1)
+	require.Equal(t, 1, completed[0].Attempts)
+	require.Empty(t, completed[0].LastError)
+}
+
+func TestEngine_Submit_ShouldQueuePropagationForDispatcher(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, storage, taggedBEEF, _ := givenSubmitWithStoredInput(t, (&lookupServiceRecorder{}).lookupService(nil))
+	sut.Outbox = storage
+	sut.Advertiser = fakeAdvertiser{}
+	txid := parseBEEFToTx(t, taggedBEEF.Beef).TxID()
+
+	// when:
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+
+	entries, err := sut.ListOutboxEntries(ctx, txid, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, engine.OutboxPropagation, entries[0].Kind)
+	require.Equal(t, engine.OutboxPending, entries[0].Status)
+	require.Equal(t, []string{"test-topic"}, entries[0].Topics)
+	require.Zero(t, entries[0].Attempts)
+	require.False(t, entries[0].NextAttemptAt.After(time.Now()))
+}
+
+func TestEngine_Submit_ShouldNotQueueOutboxEntries_WhenTopicIsRolledBack(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, storage, taggedBEEF, _ := givenSubmitWithStoredInput(t, (&lookupServiceRecorder{}).lookupService(nil))
+	sut.Storage = &failingAppliedStorage{Storage: storage, failures: 1}
+	sut.Outbox = storage
+	sut.Advertiser = fakeAdvertiser{}
+	sut.Broadcaster = &scriptedBroadcaster{failures: []string{"broadcast failure"}}
+
+	// when:
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.Error(t, err)
+
+	entries, err := sut.ListOutboxEntries(ctx, nil, "")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestEngine_DispatchOutbox_ShouldRetryBroadcastUntilItSucceeds(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, storage, taggedBEEF, _ := givenSubmitWithStoredInput(t, (&lookupServiceRecorder{}).lookupService(nil))
+	sut.Outbox = storage
+	sut.OutboxBackoff = time.Millisecond
+	broadcaster := &scriptedBroadcaster{failures: []string{"first failure", "second failure"}}
+	sut.Broadcaster = broadcaster
+
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	// when:
+	var entries []*engine.OutboxEntry
+	for range 2 {
+		time.Sleep(10 * time.Millisecond)
+		_, err = sut.DispatchOutbox(ctx)
+		require.NoError(t, err)
+
+		entries, err = sut.ListOutboxEntries(ctx, nil, "")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	}
+
+	// then:
+	require.Equal(t, 3, broadcaster.attempts)
+	require.Equal(t, engine.OutboxCompleted, entries[0].Status)
+	require.Equal(t, 3, entries[0].Attempts)
+	require.Empty(t, entries[0].LastError)
+}
+
+func TestEngine_DispatchOutbox_ShouldMarkBroadcastFailed_WhenAttemptsRunOut(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, storage, taggedBEEF, _ := givenSubmitWithStoredInput(t, (&lookupServiceRecorder{}).lookupService(nil))
+	sut.Outbox = storage
+	sut.OutboxBackoff = time.Millisecond
+	sut.OutboxMaxAttempts = 2
+	sut.Broadcaster = &scriptedBroadcaster{failures: []string{"first failure", "second failure", "third failure"}}
+
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+
+	// when:
+	attempted, err := sut.DispatchOutbox(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, attempted)
+
+	failed, err := sut.ListOutboxEntries(ctx, nil, engine.OutboxFailed)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	require.Equal(t, 2, failed[0].Attempts)
+	require.Equal(t, "second failure", failed[0].LastError)
+
+	attempted, err = sut.DispatchOutbox(ctx)
+	require.NoError(t, err)
+	require.Zero(t, attempted)
+}
+
+func TestEngine_Outbox_ShouldReturnError_WhenStorageUnavailable(t *testing.T) {
+	// given:
+	sut := engine.NewEngine(engine.Engine{})
+
+	// when:
+	_, listErr := sut.ListOutboxEntries(context.Background(), nil, "")
+	_, dispatchErr := sut.DispatchOutbox(context.Background())
+
+	// then:
+	require.ErrorIs(t, listErr, engine.ErrOutboxStorageUnavailable)
+	require.ErrorIs(t, dispatchErr, engine.ErrOutboxStorageUnavailable)
+}
@@ -0,0 +1,131 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+// givenPayment returns an engine accepting payments into an in-memory storage, together with a payment
+// of the given price by a transaction locking 999 satoshis by its expected locking script.
+func givenPayment(t *testing.T, price uint64) (*engine.Engine, *engine.Payment, *transaction.Transaction) {
+	t.Helper()
+
+	sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 0))
+	tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	beef, err := tx.AtomicBEEF(false)
+	require.NoError(t, err)
+	return sut, &engine.Payment{
+		Transaction:      beef,
+		LockingScript:    tx.Outputs[0].LockingScript,
+		Satoshis:         price,
+		Route:            "/api/v1/submit",
+		Payer:            "payer",
+		DerivationPrefix: "prefix",
+		DerivationSuffix: "suffix",
+	}, tx
+}
+
+func TestEngine_AcceptPayment_ShouldBroadcastAndRecordReceipt(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, payment, tx := givenPayment(t, 500)
+	broadcaster := &scriptedBroadcaster{}
+	sut.Broadcaster = broadcaster
+
+	// when:
+	receipt, err := sut.AcceptPayment(ctx, payment)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, *tx.TxID(), receipt.Txid)
+	require.Equal(t, uint64(999), receipt.Satoshis)
+	require.Equal(t, 1, broadcaster.attempts)
+
+	receipts, err := sut.ListPaymentReceipts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*engine.PaymentReceipt{receipt}, receipts)
+}
+
+func TestEngine_AcceptPayment_ShouldRejectPaymentRedeemedTwice(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, payment, _ := givenPayment(t, 500)
+	_, err := sut.AcceptPayment(ctx, payment)
+	require.NoError(t, err)
+
+	// when:
+	receipt, err := sut.AcceptPayment(ctx, payment)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrPaymentAlreadyRedeemed)
+	require.Nil(t, receipt)
+}
+
+func TestEngine_AcceptPayment_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		setup       func(sut *engine.Engine, payment *engine.Payment)
+		expectedErr error
+	}{
+		"payment storage is not configured": {
+			setup:       func(sut *engine.Engine, payment *engine.Payment) { sut.Payments = nil },
+			expectedErr: engine.ErrPaymentStorageUnavailable,
+		},
+		"payment is below the price": {
+			setup:       func(sut *engine.Engine, payment *engine.Payment) { payment.Satoshis = 1000 },
+			expectedErr: engine.ErrInsufficientPayment,
+		},
+		"payment is locked by another script": {
+			setup: func(sut *engine.Engine, payment *engine.Payment) {
+				payment.LockingScript = &script.Script{script.OpTRUE}
+			},
+			expectedErr: engine.ErrInsufficientPayment,
+		},
+		"payment transaction is malformed": {
+			setup:       func(sut *engine.Engine, payment *engine.Payment) { payment.Transaction = []byte{0x01, 0x02} },
+			expectedErr: engine.ErrInvalidPayment,
+		},
+		"payment transaction fails SPV verification": {
+			setup: func(sut *engine.Engine, payment *engine.Payment) {
+				sut.ChainTracker = fakeChainTracker{
+					isValidRootForHeight: func(root *chainhash.Hash, height uint32) (bool, error) { return false, nil },
+				}
+			},
+			expectedErr: engine.ErrInvalidPayment,
+		},
+		"payment transaction cannot be broadcast": {
+			setup: func(sut *engine.Engine, payment *engine.Payment) {
+				sut.Broadcaster = &scriptedBroadcaster{failures: []string{"double spend"}}
+			},
+			expectedErr: engine.ErrInvalidPayment,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			ctx := context.Background()
+			sut, payment, _ := givenPayment(t, 500)
+			tc.setup(sut, payment)
+
+			// when:
+			receipt, err := sut.AcceptPayment(ctx, payment)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, receipt)
+
+			if sut.Payments != nil {
+				receipts, err := sut.ListPaymentReceipts(ctx)
+				require.NoError(t, err)
+				require.Empty(t, receipts)
+			}
+		})
+	}
+}
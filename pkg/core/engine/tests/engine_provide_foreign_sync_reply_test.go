@@ -0,0 +1,57 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_ProvideForeignSyncReply_ShouldReturnUTXOsUnknownToPeer(t *testing.T) {
+	// given
+	known := &transaction.Outpoint{Txid: fakeTxID(t), Index: 0}
+	unknown := &transaction.Outpoint{Txid: fakeTxID(t), Index: 1}
+
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeManager{}},
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(ctx context.Context, topic string, since uint32, includeBEEF bool) ([]*engine.Output, error) {
+				return []*engine.Output{{Outpoint: *known}, {Outpoint: *unknown}}, nil
+			},
+		},
+	}
+
+	// when
+	reply, err := sut.ProvideForeignSyncReply(context.Background(), &core.GASPInitialResponse{UTXOList: []*transaction.Outpoint{known}}, "test-topic")
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.Outpoint{unknown}, reply.UTXOList)
+}
+
+func TestEngine_ProvideForeignSyncReply_ShouldReturnError_WhenTopicIsUnknown(t *testing.T) {
+	// given
+	sut := &engine.Engine{Managers: map[string]engine.TopicManager{}}
+
+	// when
+	reply, err := sut.ProvideForeignSyncReply(context.Background(), &core.GASPInitialResponse{}, "test-topic")
+
+	// then
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	require.Nil(t, reply)
+}
+
+func TestEngine_SubmitForeignGASPNode_ShouldReturnError_WhenTopicIsUnknown(t *testing.T) {
+	// given
+	sut := &engine.Engine{Managers: map[string]engine.TopicManager{}}
+
+	// when
+	response, err := sut.SubmitForeignGASPNode(context.Background(), &core.GASPNode{}, "test-topic")
+
+	// then
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	require.Nil(t, response)
+}
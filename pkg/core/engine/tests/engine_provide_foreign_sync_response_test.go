@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
 	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
@@ -31,12 +32,16 @@ func TestEngine_ProvideForeignSyncResponse_ShouldReturnUTXOList(t *testing.T) {
 		},
 	}
 
+	before := uint32(time.Now().Unix())
+
 	// when
 	actualResponse, actualErr := sut.ProvideForeignSyncResponse(context.Background(), &core.GASPInitialRequest{Since: 0}, "test-topic")
 
 	// then
 	require.NoError(t, actualErr)
-	require.Equal(t, expectedResponse, actualResponse)
+	require.Equal(t, expectedResponse.UTXOList, actualResponse.UTXOList)
+	require.GreaterOrEqual(t, actualResponse.Since, before)
+	require.LessOrEqual(t, actualResponse.Since, uint32(time.Now().Unix()))
 }
 
 func TestEngine_ProvideForeignSyncResponse_ShouldReturnError_WhenStorageFails(t *testing.T) {
@@ -0,0 +1,201 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+// blockHeightUpdate is a notification received by OutputBlockHeightUpdated.
+type blockHeightUpdate struct {
+	txid        chainhash.Hash
+	blockHeight uint32
+	blockIdx    uint64
+}
+
+// givenMinedParentWithChild returns an engine holding a transaction mined at the height of proofFor
+// and an unproven transaction spending it, together with the notifications of its lookup service.
+func givenMinedParentWithChild(t *testing.T, storage engine.Storage) (*engine.Engine, *transaction.Transaction, *transaction.Transaction, *[]blockHeightUpdate) {
+	t.Helper()
+
+	updates := &[]blockHeightUpdate{}
+	sut := newTokenEngine(storage, 0)
+	sut.LookupServices = map[string]engine.LookupService{
+		"test-lookup": &mockLookupService{
+			outputBlockHeightUpdatedFunc: func(ctx context.Context, txid *chainhash.Hash, blockHeight uint32, blockIdx uint64) error {
+				*updates = append(*updates, blockHeightUpdate{txid: *txid, blockHeight: blockHeight, blockIdx: blockIdx})
+				return nil
+			},
+		},
+	}
+
+	chain := givenChain(2)
+	parent, child := chain[0], chain[1]
+	parent.MerklePath = proofFor(parent)
+	submitHistorical(t, sut, chain...)
+	return sut, parent, child, updates
+}
+
+func TestEngine_HandleReorg_ShouldDowngradeTransaction_WhenProofIsOrphaned(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, parent, child, updates := givenMinedParentWithChild(t, storage)
+	sut.ChainTracker = fakeChainTracker{
+		isValidRootForHeight: func(root *chainhash.Hash, height uint32) (bool, error) {
+			return false, nil
+		},
+	}
+
+	// when:
+	result, err := sut.HandleReorg(ctx, 814435)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Checked)
+	require.Equal(t, []chainhash.Hash{*parent.TxID()}, result.Downgraded)
+	require.Equal(t, []blockHeightUpdate{{txid: *parent.TxID()}}, *updates)
+
+	downgraded, err := storage.FindOutput(ctx, &transaction.Outpoint{Txid: *parent.TxID(), Index: 0}, nil, nil, true)
+	require.NoError(t, err)
+	require.Zero(t, downgraded.BlockHeight)
+	require.Zero(t, downgraded.BlockIdx)
+	_, downgradedTx, _, err := transaction.ParseBeef(downgraded.Beef)
+	require.NoError(t, err)
+	require.Nil(t, downgradedTx.MerklePath)
+
+	spending, err := storage.FindOutput(ctx, &transaction.Outpoint{Txid: *child.TxID(), Index: 0}, nil, nil, true)
+	require.NoError(t, err)
+	_, spendingTx, _, err := transaction.ParseBeef(spending.Beef)
+	require.NoError(t, err)
+	require.NotNil(t, spendingTx.Inputs[0].SourceTransaction)
+	require.Nil(t, spendingTx.Inputs[0].SourceTransaction.MerklePath)
+}
+
+func TestEngine_HandleReorg_ShouldDowngradeTransaction_WhenLookupServiceFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, parent, _, _ := givenMinedParentWithChild(t, storage)
+	sut.LookupServices = map[string]engine.LookupService{
+		"test-lookup": &mockLookupService{
+			outputBlockHeightUpdatedFunc: func(ctx context.Context, txid *chainhash.Hash, blockHeight uint32, blockIdx uint64) error {
+				return errors.New("lookup failure")
+			},
+		},
+	}
+	sut.ChainTracker = fakeChainTracker{
+		isValidRootForHeight: func(root *chainhash.Hash, height uint32) (bool, error) {
+			return false, nil
+		},
+	}
+
+	// when:
+	result, err := sut.HandleReorg(ctx, 814435)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []chainhash.Hash{*parent.TxID()}, result.Downgraded)
+
+	downgraded, err := storage.FindOutput(ctx, &transaction.Outpoint{Txid: *parent.TxID(), Index: 0}, nil, nil, true)
+	require.NoError(t, err)
+	require.Zero(t, downgraded.BlockHeight)
+	_, downgradedTx, _, err := transaction.ParseBeef(downgraded.Beef)
+	require.NoError(t, err)
+	require.Nil(t, downgradedTx.MerklePath)
+}
+
+func TestEngine_HandleReorg_ShouldKeepProof_WhenProofIsStillValid(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, parent, _, updates := givenMinedParentWithChild(t, storage)
+
+	// when:
+	result, err := sut.HandleReorg(ctx, 814435)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Checked)
+	require.Empty(t, result.Downgraded)
+	require.Empty(t, *updates)
+
+	output, err := storage.FindOutput(ctx, &transaction.Outpoint{Txid: *parent.TxID(), Index: 0}, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, uint32(814435), output.BlockHeight)
+}
+
+func TestEngine_HandleReorg_ShouldIgnoreOutputsMinedBelowHeight(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, _, _, updates := givenMinedParentWithChild(t, storage)
+	sut.ChainTracker = fakeChainTracker{
+		isValidRootForHeight: func(root *chainhash.Hash, height uint32) (bool, error) {
+			return false, nil
+		},
+	}
+
+	// when:
+	result, err := sut.HandleReorg(ctx, 814436)
+
+	// then:
+	require.NoError(t, err)
+	require.Zero(t, result.Checked)
+	require.Empty(t, result.Downgraded)
+	require.Empty(t, *updates)
+}
+
+func TestEngine_HandleReorg_ShouldKeepAncestryCarriedBySpendingTransaction(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := newTokenEngine(storage, 0)
+	var orphanedHeight uint32
+	sut.ChainTracker = fakeChainTracker{
+		isValidRootForHeight: func(root *chainhash.Hash, height uint32) (bool, error) {
+			return height != orphanedHeight, nil
+		},
+	}
+
+	downgraded := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	downgraded.MerklePath = proofFor(downgraded)
+	mined := testabilities.GivenTX().WithInput(500).WithP2PKHOutput(499).TX()
+	mined.MerklePath = proofFor(mined)
+	mined.MerklePath.BlockHeight = 814436
+	submitHistorical(t, sut, downgraded, mined)
+	orphanedHeight = 814435
+	_, err := sut.HandleReorg(ctx, orphanedHeight)
+	require.NoError(t, err)
+
+	downgraded.MerklePath = nil
+	child := testabilities.GivenTX().
+		WithSender(testabilities.Bob).
+		WithInputFromUTXO(downgraded, 0).
+		WithInputFromUTXO(mined, 0).
+		WithP2PKHOutput(1400).
+		TX()
+	submitHistorical(t, sut, child)
+	orphanedHeight = 814436
+
+	// when:
+	result, err := sut.HandleReorg(ctx, orphanedHeight)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []chainhash.Hash{*mined.TxID()}, result.Downgraded)
+
+	spending, err := storage.FindOutput(ctx, &transaction.Outpoint{Txid: *child.TxID(), Index: 0}, nil, nil, true)
+	require.NoError(t, err)
+	beef, spendingTx, _, err := transaction.ParseBeef(spending.Beef)
+	require.NoError(t, err)
+	require.Nil(t, spendingTx.Inputs[1].SourceTransaction.MerklePath)
+	require.NotNil(t, beef.FindTransaction(downgraded.Inputs[0].SourceTXID.String()), "The ancestry carried by the spending transaction was dropped")
+}
@@ -0,0 +1,224 @@
+package engine_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// givenTopicHistory returns an engine holding a chain of three transactions of the topic, each
+// spending the output of the previous one, together with the outpoints from oldest to newest.
+func givenTopicHistory(t *testing.T, storage engine.Storage, policy engine.RetentionPolicy) (*engine.Engine, []transaction.Outpoint) {
+	t.Helper()
+
+	sut := newTokenEngine(storage, 0)
+	sut.RetentionPolicies = map[string]engine.RetentionPolicy{"test-topic": policy}
+
+	chain := givenChain(3)
+	submitHistorical(t, sut, chain...)
+	outpoints := make([]transaction.Outpoint, 0, len(chain))
+	for _, tx := range chain {
+		outpoints = append(outpoints, transaction.Outpoint{Txid: *tx.TxID(), Index: 0})
+	}
+	return sut, outpoints
+}
+
+func TestEngine_PruneTopic_ShouldRemoveOutputsNotRetainedByPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy         engine.RetentionPolicy
+		expectedPruned []int
+		expectedReason engine.PruneReason
+	}{
+		"unspent only": {
+			policy:         engine.RetentionPolicy{UnspentOnly: true},
+			expectedPruned: []int{0, 1},
+			expectedReason: engine.PruneReasonUnspentOnly,
+		},
+		"ancestry depth": {
+			policy:         engine.RetentionPolicy{MaxAncestryDepth: 1},
+			expectedPruned: []int{0},
+			expectedReason: engine.PruneReasonAncestryDepth,
+		},
+		"spent retention elapsed": {
+			policy:         engine.RetentionPolicy{SpentRetention: time.Nanosecond},
+			expectedPruned: []int{0, 1},
+			expectedReason: engine.PruneReasonSpentRetention,
+		},
+		"spent retention not elapsed": {
+			policy:         engine.RetentionPolicy{SpentRetention: time.Hour},
+			expectedPruned: []int{},
+		},
+		"policy without rules": {
+			policy:         engine.RetentionPolicy{Interval: time.Hour},
+			expectedPruned: []int{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			ctx := context.Background()
+			storage := memstorage.New()
+			sut, outpoints := givenTopicHistory(t, storage, tc.policy)
+
+			recorder := &lookupServiceRecorder{}
+			sut.LookupServices = map[string]engine.LookupService{"test-lookup": recorder.lookupService(nil)}
+
+			// when:
+			report, err := sut.PruneTopic(ctx, "test-topic", false)
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, "test-topic", report.Topic)
+			require.False(t, report.DryRun)
+
+			expected := make([]engine.PrunedOutput, 0, len(tc.expectedPruned))
+			removed := make([]transaction.Outpoint, 0, len(tc.expectedPruned))
+			for _, i := range tc.expectedPruned {
+				expected = append(expected, engine.PrunedOutput{Outpoint: outpoints[i], Reason: tc.expectedReason})
+				removed = append(removed, outpoints[i])
+			}
+			require.Equal(t, expected, report.Pruned)
+			require.ElementsMatch(t, removed, recorder.removed)
+
+			for i, outpoint := range outpoints {
+				output, err := storage.FindOutput(ctx, &outpoint, nil, nil, false)
+				require.NoError(t, err)
+				if slices.Contains(tc.expectedPruned, i) {
+					require.Nil(t, output)
+				} else {
+					require.NotNil(t, output)
+				}
+			}
+		})
+	}
+}
+
+func TestEngine_PruneTopic_ShouldOnlyReport_WhenDryRun(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, outpoints := givenTopicHistory(t, storage, engine.RetentionPolicy{MaxAncestryDepth: 1})
+
+	recorder := &lookupServiceRecorder{}
+	sut.LookupServices = map[string]engine.LookupService{"test-lookup": recorder.lookupService(nil)}
+
+	// when:
+	report, err := sut.PruneTopic(ctx, "test-topic", true)
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, []engine.PrunedOutput{{Outpoint: outpoints[0], Reason: engine.PruneReasonAncestryDepth}}, report.Pruned)
+	require.Empty(t, recorder.removed)
+
+	for _, outpoint := range outpoints {
+		output, err := storage.FindOutput(ctx, &outpoint, nil, nil, false)
+		require.NoError(t, err)
+		require.NotNil(t, output)
+	}
+}
+
+func TestEngine_PruneTopic_ShouldForgetPrunedOutputInKeptInputs(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, outpoints := givenTopicHistory(t, storage, engine.RetentionPolicy{MaxAncestryDepth: 1})
+
+	// when:
+	_, err := sut.PruneTopic(ctx, "test-topic", false)
+
+	// then:
+	require.NoError(t, err)
+
+	parent, err := storage.FindOutput(ctx, &outpoints[1], nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.Outpoint{&outpoints[2]}, parent.ConsumedBy)
+
+	history, err := sut.GetUTXOHistory(ctx, parent, func([]byte, uint32, uint32) bool { return true }, 0)
+	require.NoError(t, err)
+	require.NotNil(t, history)
+}
+
+// restoredOutputStorage reports an unspent output among the spent ones of the topic, as when a
+// conflict resolution restores an output between the search and the prune.
+type restoredOutputStorage struct {
+	*memstorage.Storage
+	restored transaction.Outpoint
+}
+
+func (s restoredOutputStorage) FindSpentOutputsForTopic(ctx context.Context, topic string, includeBEEF bool) ([]*engine.Output, error) {
+	spent, err := s.Storage.FindSpentOutputsForTopic(ctx, topic, includeBEEF)
+	if err != nil {
+		return nil, err
+	}
+	restored, err := s.Storage.FindOutput(ctx, &s.restored, &topic, nil, includeBEEF)
+	if err != nil {
+		return nil, err
+	}
+	return append(spent, restored), nil
+}
+
+func TestEngine_PruneTopic_ShouldOnlyReportPrunedOutputs_WhenOutputIsRestored(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut, outpoints := givenTopicHistory(t, storage, engine.RetentionPolicy{UnspentOnly: true})
+	sut.Storage = restoredOutputStorage{Storage: storage, restored: outpoints[2]}
+
+	recorder := &lookupServiceRecorder{}
+	sut.LookupServices = map[string]engine.LookupService{"test-lookup": recorder.lookupService(nil)}
+
+	// when:
+	report, err := sut.PruneTopic(ctx, "test-topic", false)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []engine.PrunedOutput{
+		{Outpoint: outpoints[0], Reason: engine.PruneReasonUnspentOnly},
+		{Outpoint: outpoints[1], Reason: engine.PruneReasonUnspentOnly},
+	}, report.Pruned)
+	require.ElementsMatch(t, outpoints[:2], recorder.removed)
+
+	output, err := storage.FindOutput(ctx, &outpoints[2], nil, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, output)
+}
+
+func TestEngine_PruneTopic_ShouldReturnError_WhenTopicHasNoPolicy(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, _ := givenTopicHistory(t, memstorage.New(), engine.RetentionPolicy{UnspentOnly: true})
+
+	// when:
+	report, err := sut.PruneTopic(ctx, "other-topic", false)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrNoRetentionPolicy)
+	require.Nil(t, report)
+}
+
+func TestEngine_Prune_ShouldReportEveryTopicWithPolicy(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, outpoints := givenTopicHistory(t, memstorage.New(), engine.RetentionPolicy{UnspentOnly: true})
+	sut.RetentionPolicies["other-topic"] = engine.RetentionPolicy{MaxAncestryDepth: 1}
+
+	// when:
+	reports, err := sut.Prune(ctx, true)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	require.Equal(t, "other-topic", reports[0].Topic)
+	require.Empty(t, reports[0].Pruned)
+	require.Equal(t, "test-topic", reports[1].Topic)
+	require.Len(t, reports[1].Pruned, 2)
+	require.Equal(t, outpoints[0], reports[1].Pruned[0].Outpoint)
+}
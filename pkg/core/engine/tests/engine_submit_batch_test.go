@@ -0,0 +1,92 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_SubmitBatch_ShouldSubmitParentsFirst_WhenBatchListsSpendsBeforeTheirParents(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := newTokenEngine(storage, 0)
+
+	parent := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	child := testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(900).TX()
+	grandchild := testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(child, 0).WithP2PKHOutput(800).TX()
+
+	// when:
+	results := sut.SubmitBatch(ctx, []overlay.TaggedBEEF{
+		toTaggedBEEF(t, grandchild),
+		toTaggedBEEF(t, child),
+		toTaggedBEEF(t, parent),
+	}, engine.SubmitModeHistorical)
+
+	// then:
+	require.Len(t, results, 3)
+	require.Equal(t, grandchild.TxID(), results[0].Txid)
+	require.Equal(t, child.TxID(), results[1].Txid)
+	require.Equal(t, parent.TxID(), results[2].Txid)
+	for _, result := range results {
+		require.NoError(t, result.Err)
+	}
+	require.Equal(t, overlay.Steak{"test-topic": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{0}}}, results[0].Steak)
+	require.Equal(t, overlay.Steak{"test-topic": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{0}}}, results[1].Steak)
+	require.Equal(t, overlay.Steak{"test-topic": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}, results[2].Steak)
+
+	utxos, err := storage.FindUTXOsForTopic(ctx, "test-topic", 0, false)
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	require.Equal(t, *grandchild.TxID(), utxos[0].Outpoint.Txid)
+}
+
+func TestEngine_SubmitBatch_ShouldReportErrorPerTransaction_WhenSomeSubmissionsFail(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := newTokenEngine(memstorage.New(), 0)
+
+	parent := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	spend := testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(900).TX()
+	doubleSpend := testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(800).TX()
+
+	// when:
+	results := sut.SubmitBatch(ctx, []overlay.TaggedBEEF{
+		{Topics: []string{"test-topic"}, Beef: []byte{0xbe, 0xef}},
+		toTaggedBEEF(t, spend),
+		toTaggedBEEF(t, doubleSpend),
+		toTaggedBEEF(t, parent),
+	}, engine.SubmitModeHistorical)
+
+	// then:
+	require.Len(t, results, 4)
+	require.Error(t, results[0].Err)
+	require.Nil(t, results[0].Txid)
+	require.NoError(t, results[1].Err)
+	require.ErrorIs(t, results[2].Err, engine.ErrInputSpent)
+	require.Equal(t, doubleSpend.TxID(), results[2].Txid)
+	require.Nil(t, results[2].Steak)
+	require.NoError(t, results[3].Err)
+}
+
+func TestEngine_SubmitBatch_ShouldFailRemainingTransactions_WhenContextIsDone(t *testing.T) {
+	// given:
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sut := newTokenEngine(memstorage.New(), 0)
+
+	tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+
+	// when:
+	results := sut.SubmitBatch(ctx, []overlay.TaggedBEEF{toTaggedBEEF(t, tx)}, engine.SubmitModeHistorical)
+
+	// then:
+	require.Len(t, results, 1)
+	require.ErrorIs(t, results[0].Err, context.Canceled)
+	require.Equal(t, tx.TxID(), results[0].Txid)
+}
@@ -0,0 +1,181 @@
+package engine_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenEngine returns an engine whose topic admits the first output of every transaction,
+// retaining the coins it spends. The topic manager takes the given delay to identify the outputs,
+// widening the window in which concurrent submissions race each other.
+func newTokenEngine(storage engine.Storage, delay time.Duration) *engine.Engine {
+	return engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					time.Sleep(delay)
+					admit := overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}
+					for vin := range previousCoins {
+						admit.CoinsToRetain = append(admit.CoinsToRetain, vin)
+					}
+					return admit, nil
+				},
+			},
+		},
+		Storage: storage,
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(root *chainhash.Hash, height uint32) (bool, error) {
+				return true, nil
+			},
+		},
+	})
+}
+
+func toTaggedBEEF(t *testing.T, tx *transaction.Transaction) overlay.TaggedBEEF {
+	t.Helper()
+
+	beef, err := tx.AtomicBEEF(false)
+	require.NoError(t, err)
+	return overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: beef}
+}
+
+func TestEngine_Submit_ShouldAdmitSingleSpend_WhenConflictingSubmissionsRunConcurrently(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := newTokenEngine(storage, 5*time.Millisecond)
+
+	parent := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	_, err := sut.Submit(ctx, toTaggedBEEF(t, parent), engine.SubmitModeHistorical, nil)
+	require.NoError(t, err)
+
+	const submissions = 32
+	spends := make([]overlay.TaggedBEEF, submissions)
+	for i := range spends {
+		spend := testabilities.GivenTX().
+			WithSender(testabilities.Bob).
+			WithInputFromUTXO(parent, 0).
+			WithP2PKHOutput(uint64(900 - i)).
+			TX()
+		spends[i] = toTaggedBEEF(t, spend)
+	}
+
+	// when:
+	errs := make([]error, submissions)
+	var wg sync.WaitGroup
+	for i := range spends {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = sut.Submit(ctx, spends[i], engine.SubmitModeHistorical, nil)
+		}()
+	}
+	wg.Wait()
+
+	// then:
+	admitted := 0
+	for _, err := range errs {
+		if err == nil {
+			admitted++
+			continue
+		}
+		require.ErrorIs(t, err, engine.ErrInputSpent)
+	}
+	require.Equal(t, 1, admitted)
+
+	utxos, err := storage.FindUTXOsForTopic(ctx, "test-topic", 0, false)
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	require.NotEqual(t, *parent.TxID(), utxos[0].Outpoint.Txid)
+}
+
+func TestEngine_Submit_ShouldAdmitConcurrentSubmissions_WhenSpendingDistinctOutputs(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := newTokenEngine(storage, 0)
+
+	const submissions = 16
+	spends := make([]overlay.TaggedBEEF, submissions)
+	for i := range spends {
+		parent := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(uint64(999 - i)).TX()
+		_, err := sut.Submit(ctx, toTaggedBEEF(t, parent), engine.SubmitModeHistorical, nil)
+		require.NoError(t, err)
+
+		spend := testabilities.GivenTX().
+			WithSender(testabilities.Bob).
+			WithInputFromUTXO(parent, 0).
+			WithP2PKHOutput(500).
+			TX()
+		spends[i] = toTaggedBEEF(t, spend)
+	}
+
+	// when:
+	errs := make([]error, submissions)
+	var wg sync.WaitGroup
+	for i := range spends {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = sut.Submit(ctx, spends[i], engine.SubmitModeHistorical, nil)
+		}()
+	}
+	wg.Wait()
+
+	// then:
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	utxos, err := storage.FindUTXOsForTopic(ctx, "test-topic", 0, false)
+	require.NoError(t, err)
+	require.Len(t, utxos, submissions)
+}
+
+func TestEngine_Submit_ShouldReturnContextError_WhenInputsStayLocked(t *testing.T) {
+	// given:
+	storage := memstorage.New()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	sut := newTokenEngine(storage, 0)
+	sut.Managers["test-topic"] = fakeManager{
+		identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+			close(started)
+			<-release
+			return overlay.AdmittanceInstructions{}, nil
+		},
+	}
+
+	parent := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	first := toTaggedBEEF(t, testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(900).TX())
+	second := toTaggedBEEF(t, testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(800).TX())
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := sut.Submit(context.Background(), first, engine.SubmitModeHistorical, nil)
+		done <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when:
+	_, err := sut.Submit(ctx, second, engine.SubmitModeHistorical, nil)
+
+	// then:
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	require.NoError(t, <-done)
+}
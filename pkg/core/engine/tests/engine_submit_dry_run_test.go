@@ -0,0 +1,74 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_Submit_ShouldPreviewSteakWithoutApplyingIt_WhenModeIsDryRun(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+	sut := newTokenEngine(storage, 0)
+	sut.Broadcaster = fakeBroadcasterFail{
+		broadcastFunc: func(tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+			t.Fatal("dry run should not broadcast")
+			return nil, nil
+		},
+	}
+
+	parent := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	_, err := sut.Submit(ctx, toTaggedBEEF(t, parent), engine.SubmitModeHistorical, nil)
+	require.NoError(t, err)
+
+	spend := testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(900).TX()
+
+	// when:
+	var ready *overlay.Steak
+	steak, err := sut.Submit(ctx, toTaggedBEEF(t, spend), engine.SubmitModeDryRun, func(steak *overlay.Steak) {
+		ready = steak
+	})
+
+	// then:
+	require.NoError(t, err)
+	expected := overlay.Steak{"test-topic": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{0}}}
+	require.Equal(t, expected, steak)
+	require.Equal(t, &expected, ready)
+
+	exists, err := storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{Txid: spend.TxID(), Topic: "test-topic"})
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	utxos, err := storage.FindUTXOsForTopic(ctx, "test-topic", 0, false)
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	require.Equal(t, *parent.TxID(), utxos[0].Outpoint.Txid)
+}
+
+func TestEngine_Submit_ShouldReportSpentInput_WhenModeIsDryRun(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := newTokenEngine(memstorage.New(), 0)
+
+	parent := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	spend := testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(900).TX()
+	doubleSpend := testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(800).TX()
+	for _, tx := range []*transaction.Transaction{parent, spend} {
+		_, err := sut.Submit(ctx, toTaggedBEEF(t, tx), engine.SubmitModeHistorical, nil)
+		require.NoError(t, err)
+	}
+
+	// when:
+	steak, err := sut.Submit(ctx, toTaggedBEEF(t, doubleSpend), engine.SubmitModeDryRun, nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrInputSpent)
+	require.Nil(t, steak)
+}
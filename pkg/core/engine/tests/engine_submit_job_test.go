@@ -0,0 +1,147 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+// awaitSubmitJob polls the job until it reaches the status.
+func awaitSubmitJob(t *testing.T, sut *engine.Engine, id string, status engine.SubmitJobStatus) *engine.SubmitJob {
+	t.Helper()
+
+	var job *engine.SubmitJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = sut.FindSubmitJob(context.Background(), id)
+		require.NoError(t, err)
+		return job.Status == status
+	}, 5*time.Second, time.Millisecond)
+	return job
+}
+
+func TestEngine_SubmitAsync_ShouldReturnSteakOfJob_WhenSubmissionCompletes(t *testing.T) {
+	// given:
+	ctx, cancel := context.WithCancel(context.Background())
+	sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 0))
+	tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+
+	// when:
+	job, err := sut.SubmitAsync(ctx, toTaggedBEEF(t, tx), engine.SubmitModeHistorical)
+	cancel()
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, engine.SubmitJobQueued, job.Status)
+	require.Equal(t, []string{"test-topic"}, job.Topics)
+
+	completed := awaitSubmitJob(t, sut, job.ID, engine.SubmitJobCompleted)
+	require.NoError(t, completed.Err)
+	require.Equal(t, overlay.Steak{"test-topic": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}, completed.Steak)
+	require.False(t, completed.StartedAt.Before(completed.CreatedAt))
+	require.False(t, completed.CompletedAt.Before(completed.StartedAt))
+}
+
+func TestEngine_SubmitAsync_ShouldRecordErrorOfJob_WhenSubmissionFails(t *testing.T) {
+	// given:
+	sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 0))
+
+	// when:
+	job, err := sut.SubmitAsync(context.Background(), overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: []byte{0xbe, 0xef}}, engine.SubmitModeCurrent)
+
+	// then:
+	require.NoError(t, err)
+
+	failed := awaitSubmitJob(t, sut, job.ID, engine.SubmitJobFailed)
+	require.Error(t, failed.Err)
+	require.Nil(t, failed.Steak)
+}
+
+func TestEngine_SubmitAsync_ShouldRejectSubmission_WhenQueueIsFull(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 200*time.Millisecond))
+	sut.SubmitJobs = engine.NewSubmitJobQueue(1, 1, time.Hour)
+
+	submit := func() (*engine.SubmitJob, error) {
+		tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+		return sut.SubmitAsync(ctx, toTaggedBEEF(t, tx), engine.SubmitModeHistorical)
+	}
+	processing, err := submit()
+	require.NoError(t, err)
+	awaitSubmitJob(t, sut, processing.ID, engine.SubmitJobProcessing)
+
+	waiting, err := submit()
+	require.NoError(t, err)
+
+	// when:
+	_, err = submit()
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrSubmitQueueFull)
+
+	job, err := sut.FindSubmitJob(ctx, waiting.ID)
+	require.NoError(t, err)
+	require.Equal(t, engine.SubmitJobQueued, job.Status)
+	awaitSubmitJob(t, sut, waiting.ID, engine.SubmitJobCompleted)
+}
+
+func TestEngine_FindSubmitJob_ShouldReturnNotFound_WhenJobExpired(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 0))
+	sut.SubmitJobs = engine.NewSubmitJobQueue(1, 1, 10*time.Millisecond)
+
+	tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	job, err := sut.SubmitAsync(ctx, toTaggedBEEF(t, tx), engine.SubmitModeHistorical)
+	require.NoError(t, err)
+	awaitSubmitJob(t, sut, job.ID, engine.SubmitJobCompleted)
+
+	// when:
+	time.Sleep(20 * time.Millisecond)
+	_, err = sut.FindSubmitJob(ctx, job.ID)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrNotFound)
+}
+
+func TestEngine_SubmitAsync_ShouldReturnError(t *testing.T) {
+	tests := map[string]struct {
+		topics      []string
+		submitJobs  bool
+		expectedErr error
+	}{
+		"submitting to an unknown topic": {
+			topics:      []string{"unknown-topic"},
+			submitJobs:  true,
+			expectedErr: engine.ErrUnknownTopic,
+		},
+		"submitting without a queue": {
+			topics:      []string{"test-topic"},
+			expectedErr: engine.ErrSubmitJobsUnavailable,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 0))
+			if !tc.submitJobs {
+				sut.SubmitJobs = nil
+			}
+
+			// when:
+			job, err := sut.SubmitAsync(context.Background(), overlay.TaggedBEEF{Topics: tc.topics}, engine.SubmitModeCurrent)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, job)
+		})
+	}
+}
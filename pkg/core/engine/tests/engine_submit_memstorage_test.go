@@ -0,0 +1,57 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_Submit_ShouldPersistAdmittedOutputs_WhenBackedByInMemoryStorage(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := memstorage.New()
+
+	sut := engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: storage,
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(root *chainhash.Hash, height uint32) (bool, error) {
+				return true, nil
+			},
+		},
+	})
+
+	taggedBEEF := overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   createDummyBEEF(t),
+	}
+	txid := parseBEEFToTx(t, taggedBEEF.Beef).TxID()
+
+	// when:
+	steak, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+	duplicateSteak, duplicateErr := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.Equal(t, []uint32{0}, steak["test-topic"].OutputsToAdmit)
+	require.NoError(t, duplicateErr)
+	require.Empty(t, duplicateSteak["test-topic"].OutputsToAdmit)
+
+	utxos, err := storage.FindUTXOsForTopic(ctx, "test-topic", 0, true)
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	require.Equal(t, transaction.Outpoint{Txid: *txid, Index: 0}, utxos[0].Outpoint)
+	require.Equal(t, taggedBEEF.Beef, utxos[0].Beef)
+}
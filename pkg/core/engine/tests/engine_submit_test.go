@@ -17,7 +17,7 @@ func TestEngine_Submit_Success(t *testing.T) {
 	// given:
 	ctx := context.Background()
 
-	sut := &engine.Engine{
+	sut := engine.NewEngine(engine.Engine{
 		Managers: map[string]engine.TopicManager{
 			"test-topic": fakeManager{
 				identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
@@ -55,7 +55,7 @@ func TestEngine_Submit_Success(t *testing.T) {
 				return true, nil
 			},
 		},
-	}
+	})
 
 	taggedBEEF := overlay.TaggedBEEF{
 		Topics: []string{"test-topic"},
@@ -80,7 +80,7 @@ func TestEngine_Submit_Success(t *testing.T) {
 func TestEngine_Submit_InvalidBeef_ShouldReturnError(t *testing.T) {
 	// given:
 	ctx := context.Background()
-	sut := &engine.Engine{
+	sut := engine.NewEngine(engine.Engine{
 		Managers: map[string]engine.TopicManager{
 			"test-topic": fakeManager{
 				identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
@@ -92,7 +92,7 @@ func TestEngine_Submit_InvalidBeef_ShouldReturnError(t *testing.T) {
 		},
 		Storage:      fakeStorage{},
 		ChainTracker: fakeChainTracker{},
-	}
+	})
 
 	taggedBEEF := overlay.TaggedBEEF{
 		Topics: []string{"test-topic"},
@@ -104,14 +104,14 @@ func TestEngine_Submit_InvalidBeef_ShouldReturnError(t *testing.T) {
 
 	// then:
 	require.Error(t, err)
-	require.Contains(t, err.Error(), "invalid-version") // temp fix for SPV failure Submit need to be fixed by wrapping the error to use ErrorIs
+	require.ErrorIs(t, err, engine.ErrInvalidBeef)
 	require.Nil(t, steak)
 }
 
 func TestEngine_Submit_SPVFail_ShouldReturnError(t *testing.T) {
 	// given:
 	ctx := context.Background()
-	sut := &engine.Engine{
+	sut := engine.NewEngine(engine.Engine{
 		Managers: map[string]engine.TopicManager{
 			"test-topic": fakeManager{
 				identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
@@ -140,7 +140,7 @@ func TestEngine_Submit_SPVFail_ShouldReturnError(t *testing.T) {
 			},
 		},
 		ChainTracker: fakeChainTrackerSPVFail{},
-	}
+	})
 
 	taggedBEEF := overlay.TaggedBEEF{
 		Topics: []string{"test-topic"},
@@ -152,14 +152,14 @@ func TestEngine_Submit_SPVFail_ShouldReturnError(t *testing.T) {
 
 	// then:
 	require.Error(t, err)
-	require.Equal(t, err.Error(), "input 0 has no source transaction") // temp fix for SPV failure Submit need to be fixed by wrapping the error to use ErrorIs
+	require.ErrorIs(t, err, engine.ErrInvalidTransaction)
 	require.Nil(t, steak)
 }
 
 func TestEngine_Submit_DuplicateTransaction_ShouldReturnEmptySteak(t *testing.T) {
 	// given:
 	ctx := context.Background()
-	sut := &engine.Engine{
+	sut := engine.NewEngine(engine.Engine{
 		Managers: map[string]engine.TopicManager{
 			"test-topic": fakeManager{},
 		},
@@ -173,7 +173,7 @@ func TestEngine_Submit_DuplicateTransaction_ShouldReturnEmptySteak(t *testing.T)
 				return true, nil
 			},
 		},
-	}
+	})
 	taggedBEEF := overlay.TaggedBEEF{
 		Topics: []string{"test-topic"},
 		Beef:   createDummyBEEF(t),
@@ -196,11 +196,11 @@ func TestEngine_Submit_DuplicateTransaction_ShouldReturnEmptySteak(t *testing.T)
 func TestEngine_Submit_MissingTopic_ShouldReturnError(t *testing.T) {
 	// given:
 	ctx := context.Background()
-	sut := &engine.Engine{
+	sut := engine.NewEngine(engine.Engine{
 		Managers:     map[string]engine.TopicManager{},
 		Storage:      fakeStorage{},
 		ChainTracker: fakeChainTracker{},
-	}
+	})
 	taggedBEEF := overlay.TaggedBEEF{
 		Topics: []string{"unknown-topic"},
 		Beef:   createDummyBEEF(t),
@@ -217,7 +217,7 @@ func TestEngine_Submit_MissingTopic_ShouldReturnError(t *testing.T) {
 func TestEngine_Submit_BroadcastFails_ShouldReturnError(t *testing.T) {
 	// given:
 	ctx := context.Background()
-	sut := &engine.Engine{
+	sut := engine.NewEngine(engine.Engine{
 		Managers: map[string]engine.TopicManager{
 			"test-topic": fakeManager{
 				identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
@@ -249,6 +249,7 @@ func TestEngine_Submit_BroadcastFails_ShouldReturnError(t *testing.T) {
 				return true, nil
 			},
 		},
+		ErrorOnBroadcastFailure: true,
 		Broadcaster: fakeBroadcasterFail{
 			broadcastFunc: func(tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
 				return nil, &transaction.BroadcastFailure{Description: "forced failure for testing"}
@@ -257,7 +258,7 @@ func TestEngine_Submit_BroadcastFails_ShouldReturnError(t *testing.T) {
 				return nil, &transaction.BroadcastFailure{Description: "forced failure for testing"}
 			},
 		},
-	}
+	})
 
 	taggedBEEF := overlay.TaggedBEEF{
 		Topics: []string{"test-topic"},
@@ -279,7 +280,7 @@ func TestEngine_Submit_OutputInsertFails_ShouldReturnError(t *testing.T) {
 	taggedBEEF, prevTxID := createDummyValidTaggedBEEF(t)
 	expectedErr := errors.New("insert-failed")
 
-	sut := &engine.Engine{
+	sut := engine.NewEngine(engine.Engine{
 		Managers: map[string]engine.TopicManager{
 			"test-topic": fakeManager{
 				identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
@@ -328,7 +329,7 @@ func TestEngine_Submit_OutputInsertFails_ShouldReturnError(t *testing.T) {
 			},
 		},
 		ChainTracker: fakeChainTracker{},
-	}
+	})
 
 	// when:
 	steak, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
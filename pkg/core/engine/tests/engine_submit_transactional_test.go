@@ -0,0 +1,299 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// lookupServiceRecorder records the notifications received by a fakeLookupService.
+type lookupServiceRecorder struct {
+	admitted []transaction.Outpoint
+	spent    []transaction.Outpoint
+	evicted  []transaction.Outpoint
+	removed  []transaction.Outpoint
+}
+
+func (r *lookupServiceRecorder) lookupService(admitErr error) fakeLookupService {
+	return fakeLookupService{
+		outputAdmittedByTopicFunc: func(ctx context.Context, payload *engine.OutputAdmittedByTopic) error {
+			r.admitted = append(r.admitted, *payload.Outpoint)
+			if admitErr != nil && payload.Outpoint.Index == 0 && len(r.spent) > 0 && len(r.evicted) == 0 {
+				return admitErr
+			}
+			return nil
+		},
+		outputSpentFunc: func(ctx context.Context, payload *engine.OutputSpent) error {
+			r.spent = append(r.spent, *payload.Outpoint)
+			return nil
+		},
+		outputEvictedFunc: func(ctx context.Context, outpoint *transaction.Outpoint) error {
+			r.evicted = append(r.evicted, *outpoint)
+			return nil
+		},
+		outputNoLongerRetainedInHistoryFunc: func(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+			r.removed = append(r.removed, *outpoint)
+			return nil
+		},
+	}
+}
+
+// givenSubmitWithStoredInput returns an engine backed by in-memory storage holding the output
+// spent by the submitted transaction, together with the transaction and the spent input.
+func givenSubmitWithStoredInput(t *testing.T, lookupService engine.LookupService) (*engine.Engine, *memstorage.Storage, overlay.TaggedBEEF, *engine.Output) {
+	t.Helper()
+
+	storage := memstorage.New()
+	taggedBEEF := overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   createDummyBEEF(t),
+	}
+	tx := parseBEEFToTx(t, taggedBEEF.Beef)
+
+	input := &engine.Output{
+		Outpoint: transaction.Outpoint{Txid: *tx.Inputs[0].SourceTXID, Index: tx.Inputs[0].SourceTxOutIndex},
+		Topic:    "test-topic",
+		Script:   &script.Script{script.OpTRUE},
+		Satoshis: 1000,
+		Beef:     []byte{0xbe, 0xef},
+	}
+	require.NoError(t, storage.InsertOutput(context.Background(), input))
+
+	sut := engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		LookupServices: map[string]engine.LookupService{"test-lookup": lookupService},
+		Storage:        storage,
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(root *chainhash.Hash, height uint32) (bool, error) {
+				return true, nil
+			},
+		},
+	})
+	return sut, storage, taggedBEEF, input
+}
+
+// failingAppliedStorage is an in-memory storage failing to record the applied transactions
+// as many times as it is told to, so the storage transactions applying them are rolled back.
+type failingAppliedStorage struct {
+	*memstorage.Storage
+	failures int
+}
+
+func (s *failingAppliedStorage) Begin(ctx context.Context) (engine.StorageTransaction, error) {
+	tx, err := s.Storage.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &failingAppliedTransaction{StorageTransaction: tx, storage: s}, nil
+}
+
+type failingAppliedTransaction struct {
+	engine.StorageTransaction
+	storage *failingAppliedStorage
+}
+
+func (t *failingAppliedTransaction) InsertAppliedTransaction(ctx context.Context, tx *overlay.AppliedTransaction) error {
+	if t.storage.failures > 0 {
+		t.storage.failures--
+		return errors.New("storage failure")
+	}
+	return t.StorageTransaction.InsertAppliedTransaction(ctx, tx)
+}
+
+func TestEngine_Submit_ShouldRollBackTopic_WhenStorageFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	recorder := &lookupServiceRecorder{}
+	sut, storage, taggedBEEF, input := givenSubmitWithStoredInput(t, recorder.lookupService(nil))
+	sut.Storage = &failingAppliedStorage{Storage: storage, failures: 1}
+	txid := parseBEEFToTx(t, taggedBEEF.Beef).TxID()
+	admitted := transaction.Outpoint{Txid: *txid, Index: 0}
+
+	steakReady := false
+
+	// when:
+	steak, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, func(*overlay.Steak) { steakReady = true })
+
+	// then:
+	require.Error(t, err)
+	require.Nil(t, steak)
+	require.False(t, steakReady)
+
+	restored, err := storage.FindOutput(ctx, &input.Outpoint, &input.Topic, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+	require.False(t, restored.Spent)
+
+	notAdmitted, err := storage.FindOutput(ctx, &admitted, nil, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, notAdmitted)
+
+	applied, err := storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{Txid: txid, Topic: "test-topic"})
+	require.NoError(t, err)
+	require.False(t, applied)
+
+	require.Empty(t, recorder.spent)
+	require.Empty(t, recorder.admitted)
+	require.Empty(t, recorder.evicted)
+	require.Empty(t, recorder.removed)
+}
+
+func TestEngine_Submit_ShouldAcceptResubmission_AfterRollBack(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	recorder := &lookupServiceRecorder{}
+	sut, storage, taggedBEEF, input := givenSubmitWithStoredInput(t, recorder.lookupService(nil))
+	sut.Storage = &failingAppliedStorage{Storage: storage, failures: 1}
+
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.Error(t, err)
+
+	// when:
+	steak, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, steak["test-topic"].OutputsToAdmit)
+	require.Equal(t, []uint32{0}, steak["test-topic"].CoinsRemoved)
+
+	removed, err := storage.FindOutput(ctx, &input.Outpoint, &input.Topic, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, removed)
+	require.Equal(t, []transaction.Outpoint{input.Outpoint}, recorder.removed)
+}
+
+func TestEngine_Submit_ShouldNotifyLookupServicesOnceCommitted(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	recorder := &lookupServiceRecorder{}
+	sut, storage, taggedBEEF, input := givenSubmitWithStoredInput(t, nil)
+	txid := parseBEEFToTx(t, taggedBEEF.Beef).TxID()
+	admitted := transaction.Outpoint{Txid: *txid, Index: 0}
+
+	lookupService := recorder.lookupService(errors.New("lookup service failure"))
+	admit := lookupService.outputAdmittedByTopicFunc
+	lookupService.outputAdmittedByTopicFunc = func(ctx context.Context, payload *engine.OutputAdmittedByTopic) error {
+		applied, err := storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{Txid: txid, Topic: "test-topic"})
+		require.NoError(t, err)
+		require.True(t, applied, "The lookup service was told about the output before the topic was committed")
+		return admit(ctx, payload)
+	}
+	sut.LookupServices = map[string]engine.LookupService{"test-lookup": lookupService}
+
+	// when:
+	steak, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, steak["test-topic"].OutputsToAdmit)
+
+	output, err := storage.FindOutput(ctx, &admitted, nil, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, output)
+
+	require.Equal(t, []transaction.Outpoint{input.Outpoint}, recorder.spent)
+	require.Equal(t, []transaction.Outpoint{admitted}, recorder.admitted)
+	require.Empty(t, recorder.evicted)
+}
+
+func TestEngine_Submit_ShouldNotTouchStorage_WhenBroadcastFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	recorder := &lookupServiceRecorder{}
+	sut, storage, taggedBEEF, input := givenSubmitWithStoredInput(t, recorder.lookupService(nil))
+	sut.ErrorOnBroadcastFailure = true
+	sut.Broadcaster = fakeBroadcasterFail{
+		broadcastFunc: func(tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+			return nil, &transaction.BroadcastFailure{Description: "broadcast failure"}
+		},
+	}
+
+	// when:
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.Error(t, err)
+
+	output, err := storage.FindOutput(ctx, &input.Outpoint, &input.Topic, nil, false)
+	require.NoError(t, err)
+	require.False(t, output.Spent)
+	require.Empty(t, recorder.spent)
+	require.Empty(t, recorder.admitted)
+}
+
+func TestEngine_Submit_ShouldRollBack_WhenCommitFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	expectedErr := errors.New("commit failure")
+	rolledBack := false
+
+	storage := fakeStorage{
+		doesAppliedTransactionExistFunc: func(ctx context.Context, tx *overlay.AppliedTransaction) (bool, error) {
+			return false, nil
+		},
+		findOutputsFunc: func(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spent *bool, includeBEEF bool) ([]*engine.Output, error) {
+			return make([]*engine.Output, len(outpoints)), nil
+		},
+		markUTXOsAsSpentFunc: func(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spendTxid *chainhash.Hash) error {
+			return nil
+		},
+		insertOutputFunc: func(ctx context.Context, utxo *engine.Output) error {
+			return nil
+		},
+		insertAppliedTransactionFunc: func(ctx context.Context, tx *overlay.AppliedTransaction) error {
+			return nil
+		},
+	}
+	storage.beginFunc = func(ctx context.Context) (engine.StorageTransaction, error) {
+		return fakeStorageTransaction{
+			fakeStorage: storage,
+			commitFunc:  func() error { return expectedErr },
+			rollbackFunc: func() error {
+				rolledBack = true
+				return nil
+			},
+		}, nil
+	}
+
+	sut := engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: storage,
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(root *chainhash.Hash, height uint32) (bool, error) {
+				return true, nil
+			},
+		},
+	})
+	taggedBEEF := overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   createDummyBEEF(t),
+	}
+
+	// when:
+	steak, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.ErrorIs(t, err, expectedErr)
+	require.Nil(t, steak)
+	require.False(t, rolledBack, "a failed commit must not be rolled back again")
+}
@@ -0,0 +1,162 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+const checkpointTopic = "test-topic"
+
+// peerSince is the time at which the peer returned by newSyncPeer lists its UTXOs, on its own clock.
+const peerSince = 456
+
+// newSyncPeer returns a peer answering the initial sync request with an empty UTXO list listed at
+// peerSince, or with the given status when it is not 200. The since value of the last request is
+// stored in since.
+func newSyncPeer(t *testing.T, status int, since *atomic.Int64) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Since uint32 `json:"since"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		since.Store(int64(body.Since))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"UTXOList":[],"since":456}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newCheckpointEngine(peer string, storage *memstorage.Storage) *engine.Engine {
+	return engine.NewEngine(engine.Engine{
+		Storage: storage,
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			checkpointTopic: {Type: engine.SyncConfigurationPeers, Peers: []string{peer}},
+		},
+	})
+}
+
+func TestEngine_StartGASPSync_ShouldRequestUTXOsSinceCheckpoint_WhenPeerWasSyncedBefore(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var since atomic.Int64
+	peer := newSyncPeer(t, http.StatusOK, &since)
+
+	storage := memstorage.New()
+	require.NoError(t, storage.UpsertSyncCheckpoint(ctx, &engine.SyncCheckpoint{Topic: checkpointTopic, Peer: peer.URL, Since: 123}))
+	sut := newCheckpointEngine(peer.URL, storage)
+
+	// when
+	err := sut.StartGASPSync(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, int64(123), since.Load())
+
+	checkpoints, err := sut.ListSyncCheckpoints(ctx, checkpointTopic)
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 1)
+	require.Equal(t, peer.URL, checkpoints[0].Peer)
+	require.Equal(t, uint32(peerSince), checkpoints[0].Since)
+}
+
+func TestEngine_StartGASPSync_ShouldKeepCheckpoint_WhenSyncFails(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var since atomic.Int64
+	peer := newSyncPeer(t, http.StatusInternalServerError, &since)
+
+	storage := memstorage.New()
+	expected := &engine.SyncCheckpoint{Topic: checkpointTopic, Peer: peer.URL, Since: 123, UpdatedAt: time.Unix(123, 0)}
+	require.NoError(t, storage.UpsertSyncCheckpoint(ctx, expected))
+	sut := newCheckpointEngine(peer.URL, storage)
+
+	// when
+	err := sut.StartGASPSync(ctx)
+
+	// then
+	require.NoError(t, err)
+
+	checkpoints, err := sut.ListSyncCheckpoints(ctx, checkpointTopic)
+	require.NoError(t, err)
+	require.Equal(t, []*engine.SyncCheckpoint{expected}, checkpoints)
+}
+
+func TestEngine_StartGASPSync_ShouldKeepCheckpoint_WhenGraphFails(t *testing.T) {
+	// given
+	ctx := context.Background()
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/requestSyncResponse" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"UTXOList":[{"txid":"` + chainhash.Hash{1}.String() + `","vout":0}],"since":456}`))
+	}))
+	t.Cleanup(peer.Close)
+
+	storage := memstorage.New()
+	expected := &engine.SyncCheckpoint{Topic: checkpointTopic, Peer: peer.URL, Since: 123, UpdatedAt: time.Unix(123, 0)}
+	require.NoError(t, storage.UpsertSyncCheckpoint(ctx, expected))
+	sut := newCheckpointEngine(peer.URL, storage)
+
+	// when
+	err := sut.StartGASPSync(ctx)
+
+	// then
+	require.NoError(t, err)
+
+	checkpoints, err := sut.ListSyncCheckpoints(ctx, checkpointTopic)
+	require.NoError(t, err)
+	require.Equal(t, []*engine.SyncCheckpoint{expected}, checkpoints)
+}
+
+func TestEngine_ResetSyncCheckpoints_ShouldMakeNextSyncRequestEveryUTXO(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var since atomic.Int64
+	peer := newSyncPeer(t, http.StatusOK, &since)
+
+	storage := memstorage.New()
+	require.NoError(t, storage.UpsertSyncCheckpoint(ctx, &engine.SyncCheckpoint{Topic: checkpointTopic, Peer: peer.URL, Since: 123}))
+	sut := newCheckpointEngine(peer.URL, storage)
+
+	// when
+	deleted, err := sut.ResetSyncCheckpoints(ctx, checkpointTopic, "")
+	require.NoError(t, err)
+	require.NoError(t, sut.StartGASPSync(ctx))
+
+	// then
+	require.Equal(t, 1, deleted)
+	require.Zero(t, since.Load())
+}
+
+func TestEngine_SyncCheckpoints_ShouldBeEmpty_WhenStorageDoesNotPersistThem(t *testing.T) {
+	// given
+	ctx := context.Background()
+	sut := engine.NewEngine(engine.Engine{Storage: fakeStorage{}})
+
+	// when
+	checkpoints, listErr := sut.ListSyncCheckpoints(ctx, "")
+	deleted, resetErr := sut.ResetSyncCheckpoints(ctx, "", "")
+
+	// then
+	require.NoError(t, listErr)
+	require.Empty(t, checkpoints)
+	require.NoError(t, resetErr)
+	require.Zero(t, deleted)
+}
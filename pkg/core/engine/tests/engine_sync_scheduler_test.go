@@ -0,0 +1,132 @@
+package engine_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// newCountingSyncPeer returns a peer answering the initial sync request with an empty UTXO list,
+// or with the given status when it is not 200. The number of requests is stored in requests.
+func newCountingSyncPeer(t *testing.T, status int, requests *atomic.Int32) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"UTXOList":[],"since":0}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestEngine_RunGASPSyncScheduler_ShouldSyncOnInterval_UntilContextDone(t *testing.T) {
+	// given
+	var requests atomic.Int32
+	peer := newCountingSyncPeer(t, http.StatusOK, &requests)
+
+	sut := engine.NewEngine(engine.Engine{
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"scheduled-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{peer}, Interval: 20 * time.Millisecond},
+			"manual-topic":    {Type: engine.SyncConfigurationPeers, Peers: []string{peer}},
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// when
+	go func() {
+		defer close(done)
+		sut.RunGASPSyncScheduler(ctx)
+	}()
+	require.Eventually(t, func() bool { return requests.Load() >= 3 }, 2*time.Second, 10*time.Millisecond)
+	cancel()
+
+	// then
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("scheduler did not stop after the context was cancelled")
+	}
+
+	healths := sut.ListPeerHealth()
+	require.Len(t, healths, 1)
+	require.Equal(t, "scheduled-topic", healths[0].Topic)
+	require.Zero(t, healths[0].ConsecutiveFailures)
+	require.False(t, healths[0].LastSuccess.IsZero())
+}
+
+func TestEngine_RunGASPSyncScheduler_ShouldBackOffFailingPeer(t *testing.T) {
+	// given
+	var healthyRequests, failingRequests atomic.Int32
+	healthy := newCountingSyncPeer(t, http.StatusOK, &healthyRequests)
+	failing := newCountingSyncPeer(t, http.StatusInternalServerError, &failingRequests)
+
+	sut := engine.NewEngine(engine.Engine{
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"test-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{healthy, failing}, Interval: 20 * time.Millisecond},
+		},
+		GASPSyncMaxBackoff: time.Minute,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// when
+	go func() {
+		defer close(done)
+		sut.RunGASPSyncScheduler(ctx)
+	}()
+	require.Eventually(t, func() bool { return healthyRequests.Load() >= 5 }, 2*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	// then
+	failures := int(failingRequests.Load())
+	require.Positive(t, failures)
+	require.Less(t, failures, int(healthyRequests.Load()), "the failing peer should be skipped while backing off")
+
+	healths := sut.ListPeerHealth()
+	require.Len(t, healths, 2)
+	for _, health := range healths {
+		if health.Peer != failing {
+			require.Zero(t, health.ConsecutiveFailures)
+			require.True(t, health.RetryAfter.IsZero())
+			continue
+		}
+		require.Equal(t, failures, health.ConsecutiveFailures)
+		require.NotEmpty(t, health.LastError)
+		require.Equal(t, 20*time.Millisecond<<failures, health.RetryAfter.Sub(health.LastFailure))
+	}
+}
+
+func TestEngine_StartGASPSync_ShouldSyncPeerBackingOff(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var requests atomic.Int32
+	peer := newCountingSyncPeer(t, http.StatusInternalServerError, &requests)
+
+	sut := engine.NewEngine(engine.Engine{
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"test-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{peer}, Interval: time.Hour},
+		},
+	})
+	require.NoError(t, sut.StartGASPSync(ctx))
+
+	// when
+	err := sut.StartGASPSync(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, int32(2), requests.Load())
+
+	healths := sut.ListPeerHealth()
+	require.Len(t, healths, 1)
+	require.Equal(t, 2, healths[0].ConsecutiveFailures)
+}
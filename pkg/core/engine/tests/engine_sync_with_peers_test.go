@@ -0,0 +1,150 @@
+package engine_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// syncPeerTracker counts the sync requests its peers are serving at the same time.
+type syncPeerTracker struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+// newPeer returns a peer answering the initial sync request with an empty UTXO list after a
+// short delay, or with the given status when it is not 200.
+func (tr *syncPeerTracker) newPeer(t *testing.T, status int) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := tr.inFlight.Add(1)
+		defer tr.inFlight.Add(-1)
+		for {
+			highest := tr.maxInFlight.Load()
+			if current <= highest || tr.maxInFlight.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"UTXOList":[],"since":0}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestEngine_SyncWithPeers_ShouldReportEverySession_WhenSessionsRunConcurrently(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var tracker syncPeerTracker
+	first := tracker.newPeer(t, http.StatusOK)
+	failing := tracker.newPeer(t, http.StatusInternalServerError)
+	second := tracker.newPeer(t, http.StatusOK)
+
+	sut := engine.NewEngine(engine.Engine{
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"topic-a": {Type: engine.SyncConfigurationPeers, Peers: []string{first, failing}},
+			"topic-b": {Type: engine.SyncConfigurationPeers, Peers: []string{second, first}},
+		},
+		GASPSyncConcurrency: 2,
+	})
+
+	// when
+	report, err := sut.SyncWithPeers(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, report.Results, 4)
+
+	expected := []struct{ topic, peer string }{
+		{"topic-a", first},
+		{"topic-a", failing},
+		{"topic-b", second},
+		{"topic-b", first},
+	}
+	for i, result := range report.Results {
+		require.Equal(t, expected[i].topic, result.Topic)
+		require.Equal(t, expected[i].peer, result.Peer)
+		if result.Peer == failing {
+			require.Error(t, result.Err)
+		} else {
+			require.NoError(t, result.Err)
+			require.Zero(t, result.NodesFetched)
+			require.Empty(t, result.Errors)
+		}
+	}
+
+	require.Equal(t, int32(2), tracker.maxInFlight.Load())
+}
+
+func TestEngine_SyncWithPeers_ShouldRunSessionsOneAtATime_WhenConcurrencyNotSet(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var tracker syncPeerTracker
+	peers := []string{
+		tracker.newPeer(t, http.StatusOK),
+		tracker.newPeer(t, http.StatusOK),
+		tracker.newPeer(t, http.StatusOK),
+	}
+
+	sut := engine.NewEngine(engine.Engine{
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"topic-a": {Type: engine.SyncConfigurationPeers, Peers: peers},
+		},
+	})
+
+	// when
+	report, err := sut.SyncWithPeers(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, report.Results, 3)
+	require.Equal(t, int32(1), tracker.maxInFlight.Load())
+}
+
+// recordingHTTPClient records the URLs of the requests it sends through http.DefaultClient.
+type recordingHTTPClient struct {
+	mu   sync.Mutex
+	urls []string
+}
+
+func (c *recordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.urls = append(c.urls, req.URL.String())
+	c.mu.Unlock()
+	return http.DefaultClient.Do(req)
+}
+
+func TestEngine_SyncWithPeers_ShouldSendRequestsThroughHTTPClient(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var tracker syncPeerTracker
+	peer := tracker.newPeer(t, http.StatusOK)
+	client := &recordingHTTPClient{}
+
+	sut := engine.NewEngine(engine.Engine{
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"topic-a": {Type: engine.SyncConfigurationPeers, Peers: []string{peer}},
+		},
+		HTTPClient: client,
+	})
+
+	// when
+	report, err := sut.SyncWithPeers(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	require.NoError(t, report.Results[0].Err)
+	require.Equal(t, []string{peer + "/requestSyncResponse"}, client.urls)
+}
@@ -26,9 +26,40 @@ type fakeStorage struct {
 	updateConsumedByFunc            func(ctx context.Context, outpoint *transaction.Outpoint, topic string, consumedBy []*transaction.Outpoint) error
 	deleteOutputFunc                func(ctx context.Context, outpoint *transaction.Outpoint, topic string) error
 	findUTXOsForTopicFunc           func(ctx context.Context, topic string, since uint32, includeBEEF bool) ([]*engine.Output, error)
+	findOutputsSinceBlockHeightFunc func(ctx context.Context, blockHeight uint32, includeBEEF bool) ([]*engine.Output, error)
+	findSpentOutputsForTopicFunc    func(ctx context.Context, topic string, includeBEEF bool) ([]*engine.Output, error)
 	updateTransactionBEEF           func(ctx context.Context, txid *chainhash.Hash, beef []byte) error
 	updateOutputBlockHeight         func(ctx context.Context, outpoint *transaction.Outpoint, topic string, blockHeight uint32, blockIndex uint64, ancillaryBeef []byte) error
 	findOutputsForTransaction       func(ctx context.Context, txid *chainhash.Hash, includeBEEF bool) ([]*engine.Output, error)
+	beginFunc                       func(ctx context.Context) (engine.StorageTransaction, error)
+}
+
+func (f fakeStorage) Begin(ctx context.Context) (engine.StorageTransaction, error) {
+	if f.beginFunc != nil {
+		return f.beginFunc(ctx)
+	}
+	return fakeStorageTransaction{fakeStorage: f}, nil
+}
+
+// fakeStorageTransaction runs every operation directly on the wrapped fakeStorage.
+type fakeStorageTransaction struct {
+	fakeStorage
+	commitFunc   func() error
+	rollbackFunc func() error
+}
+
+func (f fakeStorageTransaction) Commit() error {
+	if f.commitFunc != nil {
+		return f.commitFunc()
+	}
+	return nil
+}
+
+func (f fakeStorageTransaction) Rollback() error {
+	if f.rollbackFunc != nil {
+		return f.rollbackFunc()
+	}
+	return nil
 }
 
 func (f fakeStorage) FindOutput(ctx context.Context, outpoint *transaction.Outpoint, topic *string, spent *bool, includeBEEF bool) (*engine.Output, error) {
@@ -82,6 +113,20 @@ func (f fakeStorage) FindOutputsForTransaction(ctx context.Context, txid *chainh
 	panic("func not defined")
 }
 
+func (f fakeStorage) FindSpentOutputsForTopic(ctx context.Context, topic string, includeBEEF bool) ([]*engine.Output, error) {
+	if f.findSpentOutputsForTopicFunc != nil {
+		return f.findSpentOutputsForTopicFunc(ctx, topic, includeBEEF)
+	}
+	panic("func not defined")
+}
+
+func (f fakeStorage) FindOutputsSinceBlockHeight(ctx context.Context, blockHeight uint32, includeBEEF bool) ([]*engine.Output, error) {
+	if f.findOutputsSinceBlockHeightFunc != nil {
+		return f.findOutputsSinceBlockHeightFunc(ctx, blockHeight, includeBEEF)
+	}
+	panic("func not defined")
+}
+
 func (f fakeStorage) FindUTXOsForTopic(ctx context.Context, topic string, since uint32, includeBEEF bool) ([]*engine.Output, error) {
 	if f.findUTXOsForTopicFunc != nil {
 		return f.findUTXOsForTopicFunc(ctx, topic, since, includeBEEF)
@@ -225,7 +270,11 @@ func (f fakeBroadcasterFail) BroadcastCtx(ctx context.Context, tx *transaction.T
 }
 
 type fakeLookupService struct {
-	lookupFunc func(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error)
+	lookupFunc                          func(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error)
+	outputAdmittedByTopicFunc           func(ctx context.Context, payload *engine.OutputAdmittedByTopic) error
+	outputSpentFunc                     func(ctx context.Context, payload *engine.OutputSpent) error
+	outputNoLongerRetainedInHistoryFunc func(ctx context.Context, outpoint *transaction.Outpoint, topic string) error
+	outputEvictedFunc                   func(ctx context.Context, outpoint *transaction.Outpoint) error
 }
 
 func (f fakeLookupService) Lookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
@@ -236,18 +285,30 @@ func (f fakeLookupService) Lookup(ctx context.Context, question *lookup.LookupQu
 }
 
 func (f fakeLookupService) OutputAdmittedByTopic(ctx context.Context, payload *engine.OutputAdmittedByTopic) error {
+	if f.outputAdmittedByTopicFunc != nil {
+		return f.outputAdmittedByTopicFunc(ctx, payload)
+	}
 	panic("func not defined")
 }
 
 func (f fakeLookupService) OutputSpent(ctx context.Context, payload *engine.OutputSpent) error {
+	if f.outputSpentFunc != nil {
+		return f.outputSpentFunc(ctx, payload)
+	}
 	panic("func not defined")
 }
 
 func (f fakeLookupService) OutputNoLongerRetainedInHistory(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	if f.outputNoLongerRetainedInHistoryFunc != nil {
+		return f.outputNoLongerRetainedInHistoryFunc(ctx, outpoint, topic)
+	}
 	panic("func not defined")
 }
 
 func (f fakeLookupService) OutputEvicted(ctx context.Context, outpoint *transaction.Outpoint) error {
+	if f.outputEvictedFunc != nil {
+		return f.outputEvictedFunc(ctx, outpoint)
+	}
 	panic("func not defined")
 }
 
@@ -424,3 +485,29 @@ func createDummyBeefWithInputs(t *testing.T) []byte {
 
 	return beefBytes
 }
+
+// givenChain returns the given number of transactions, the first one spending a mined input and each
+// following one the output of the previous transaction.
+func givenChain(length int) []*transaction.Transaction {
+	chain := make([]*transaction.Transaction, 0, length)
+	chain = append(chain, testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX())
+	for len(chain) < length {
+		chain = append(chain, givenSpend(chain[len(chain)-1], 1000-100*uint64(len(chain))))
+	}
+	return chain
+}
+
+// givenSpend returns a transaction spending the first output of the parent into an output of the satoshis.
+func givenSpend(parent *transaction.Transaction, satoshis uint64) *transaction.Transaction {
+	return testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(satoshis).TX()
+}
+
+// submitHistorical submits the transactions in order in the historical mode.
+func submitHistorical(t *testing.T, sut *engine.Engine, txs ...*transaction.Transaction) {
+	t.Helper()
+
+	for _, tx := range txs {
+		_, err := sut.Submit(context.Background(), toTaggedBEEF(t, tx), engine.SubmitModeHistorical, nil)
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,301 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookReceiver records the requests posted to a webhook and answers them with the status code.
+type webhookReceiver struct {
+	mu         sync.Mutex
+	statusCode int
+	requests   []*http.Request
+	bodies     [][]byte
+}
+
+func (r *webhookReceiver) server(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+		w.WriteHeader(r.statusCode)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// givenWebhookEngine returns an engine delivering the events of its topic to the receiver.
+func givenWebhookEngine(t *testing.T, receiver *webhookReceiver) *engine.Engine {
+	t.Helper()
+
+	sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 0))
+	sut.ConfiguredWebhooks = []engine.Webhook{{ID: "configured", Topic: "test-topic", URL: receiver.server(t).URL, Secret: "secret"}}
+	return sut
+}
+
+func TestEngine_DeliverWebhooks_ShouldPostSignedPayloads_WhenOutputsAreAdmittedAndSpent(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	receiver := &webhookReceiver{statusCode: http.StatusNoContent}
+	sut := givenWebhookEngine(t, receiver)
+
+	parent := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	child := testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(900).TX()
+	for _, tx := range []*transaction.Transaction{parent, child} {
+		_, err := sut.Submit(ctx, toTaggedBEEF(t, tx), engine.SubmitModeHistorical, nil)
+		require.NoError(t, err)
+	}
+
+	// when:
+	attempted, err := sut.DeliverWebhooks(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 3, attempted)
+
+	type delivered struct {
+		eventType    engine.EventType
+		txid         string
+		spendingTxid string
+	}
+	var actual []delivered
+	for i, req := range receiver.requests {
+		timestamp := req.Header.Get(engine.WebhookTimestampHeader)
+		require.Equal(t, engine.SignWebhookPayload("secret", timestamp, receiver.bodies[i]), req.Header.Get(engine.WebhookSignatureHeader))
+
+		var payload engine.WebhookPayload
+		require.NoError(t, json.Unmarshal(receiver.bodies[i], &payload))
+		require.Equal(t, req.Header.Get(engine.WebhookDeliveryHeader), payload.DeliveryID)
+		require.Equal(t, "configured", payload.WebhookID)
+		require.Equal(t, "test-topic", payload.Topic)
+		actual = append(actual, delivered{eventType: payload.Type, txid: payload.Txid, spendingTxid: payload.SpendingTxid})
+	}
+	require.ElementsMatch(t, []delivered{
+		{eventType: engine.EventOutputAdmitted, txid: parent.TxID().String()},
+		{eventType: engine.EventOutputSpent, txid: parent.TxID().String(), spendingTxid: child.TxID().String()},
+		{eventType: engine.EventOutputAdmitted, txid: child.TxID().String()},
+	}, actual)
+
+	deliveries, err := sut.ListWebhookDeliveries(ctx, "configured", engine.WebhookDeliveryDelivered)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	for _, delivery := range deliveries {
+		require.Equal(t, 1, delivery.Attempts)
+		require.Equal(t, http.StatusNoContent, delivery.LastStatusCode)
+	}
+}
+
+func TestEngine_DeliverWebhooks_ShouldRetryWithBackoffAndMoveToDeadLetters_WhenWebhookFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	receiver := &webhookReceiver{statusCode: http.StatusServiceUnavailable}
+	sut := givenWebhookEngine(t, receiver)
+	sut.WebhookMaxAttempts = 2
+	sut.WebhookBackoff = 10 * time.Millisecond
+
+	tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	_, err := sut.Submit(ctx, toTaggedBEEF(t, tx), engine.SubmitModeHistorical, nil)
+	require.NoError(t, err)
+
+	// when:
+	attempted, err := sut.DeliverWebhooks(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, attempted)
+
+	pending, err := sut.ListWebhookDeliveries(ctx, "", engine.WebhookDeliveryPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, 1, pending[0].Attempts)
+	require.Equal(t, http.StatusServiceUnavailable, pending[0].LastStatusCode)
+	require.True(t, pending[0].NextAttemptAt.After(time.Now()))
+
+	attempted, err = sut.DeliverWebhooks(ctx)
+	require.NoError(t, err)
+	require.Zero(t, attempted, "the delivery is not due before its backoff elapses")
+
+	// when:
+	time.Sleep(20 * time.Millisecond)
+	attempted, err = sut.DeliverWebhooks(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, attempted)
+
+	dead, err := sut.ListWebhookDeliveries(ctx, "", engine.WebhookDeliveryDead)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.Equal(t, 2, dead[0].Attempts)
+	require.Equal(t, "webhook responded with status code 503", dead[0].LastError)
+	require.Len(t, receiver.requests, 2)
+}
+
+func TestEngine_DeliverWebhooks_ShouldDeferRemainingDeliveries_WhenWebhookFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	receiver := &webhookReceiver{statusCode: http.StatusServiceUnavailable}
+	sut := givenWebhookEngine(t, receiver)
+
+	parent := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	child := testabilities.GivenTX().WithSender(testabilities.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(900).TX()
+	for _, tx := range []*transaction.Transaction{parent, child} {
+		_, err := sut.Submit(ctx, toTaggedBEEF(t, tx), engine.SubmitModeHistorical, nil)
+		require.NoError(t, err)
+	}
+
+	// when:
+	attempted, err := sut.DeliverWebhooks(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, attempted)
+	require.Len(t, receiver.requests, 1)
+
+	pending, err := sut.ListWebhookDeliveries(ctx, "configured", engine.WebhookDeliveryPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	attempts := 0
+	for _, delivery := range pending {
+		attempts += delivery.Attempts
+		require.True(t, delivery.NextAttemptAt.After(time.Now()))
+	}
+	require.Equal(t, 1, attempts)
+
+	attempted, err = sut.DeliverWebhooks(ctx)
+	require.NoError(t, err)
+	require.Zero(t, attempted, "the deferred deliveries are not due before the retry of the failed one")
+}
+
+func TestEngine_DeliverWebhooks_ShouldDeliverToWebhooksConcurrently(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	fastReceived := make(chan struct{})
+	var once sync.Once
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		once.Do(func() { close(fastReceived) })
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(fast.Close)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-fastReceived:
+			w.WriteHeader(http.StatusOK)
+		case <-time.After(2 * time.Second):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(slow.Close)
+
+	sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 0))
+	sut.ConfiguredWebhooks = []engine.Webhook{
+		{ID: "slow", Topic: "test-topic", URL: slow.URL, Secret: "secret"},
+		{ID: "fast", Topic: "test-topic", URL: fast.URL, Secret: "secret"},
+	}
+
+	tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	_, err := sut.Submit(ctx, toTaggedBEEF(t, tx), engine.SubmitModeHistorical, nil)
+	require.NoError(t, err)
+
+	// when:
+	attempted, err := sut.DeliverWebhooks(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 2, attempted)
+
+	delivered, err := sut.ListWebhookDeliveries(ctx, "", engine.WebhookDeliveryDelivered)
+	require.NoError(t, err)
+	require.Len(t, delivered, 2, "The slow webhook held up the delivery to the fast one")
+}
+
+func TestEngine_DeliverWebhooks_ShouldMoveToDeadLetters_WhenWebhookDeleted(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	receiver := &webhookReceiver{statusCode: http.StatusOK}
+	sut := engine.NewEngine(*newTokenEngine(memstorage.New(), 0))
+
+	webhook, err := sut.RegisterWebhook(ctx, "test-topic", receiver.server(t).URL, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, webhook.Secret)
+
+	tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	_, err = sut.Submit(ctx, toTaggedBEEF(t, tx), engine.SubmitModeHistorical, nil)
+	require.NoError(t, err)
+	require.NoError(t, sut.DeleteWebhook(ctx, webhook.ID))
+
+	// when:
+	_, err = sut.DeliverWebhooks(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Empty(t, receiver.requests)
+
+	dead, err := sut.ListWebhookDeliveries(ctx, webhook.ID, engine.WebhookDeliveryDead)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.Equal(t, "webhook deleted", dead[0].LastError)
+}
+
+func TestEngine_Webhooks_ShouldReturnError(t *testing.T) {
+	tests := map[string]struct {
+		call        func(ctx context.Context, sut *engine.Engine) error
+		expectedErr error
+	}{
+		"registering a webhook of an unknown topic": {
+			call: func(ctx context.Context, sut *engine.Engine) error {
+				_, err := sut.RegisterWebhook(ctx, "unknown-topic", "https://example.com/hook", "")
+				return err
+			},
+			expectedErr: engine.ErrInvalidWebhook,
+		},
+		"registering a webhook with an invalid URL": {
+			call: func(ctx context.Context, sut *engine.Engine) error {
+				_, err := sut.RegisterWebhook(ctx, "test-topic", "ftp://example.com/hook", "")
+				return err
+			},
+			expectedErr: engine.ErrInvalidWebhook,
+		},
+		"deleting a configured webhook": {
+			call: func(ctx context.Context, sut *engine.Engine) error {
+				return sut.DeleteWebhook(ctx, "configured")
+			},
+			expectedErr: engine.ErrWebhookConfigured,
+		},
+		"deleting an unknown webhook": {
+			call: func(ctx context.Context, sut *engine.Engine) error {
+				return sut.DeleteWebhook(ctx, "unknown")
+			},
+			expectedErr: engine.ErrNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			sut := givenWebhookEngine(t, &webhookReceiver{statusCode: http.StatusOK})
+
+			// when:
+			err := tc.call(context.Background(), sut)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
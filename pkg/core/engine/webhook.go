@@ -0,0 +1,516 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWebhookMaxAttempts is how many times a delivery is attempted when WebhookMaxAttempts is not set.
+	DefaultWebhookMaxAttempts = 8
+	// DefaultWebhookBackoff is the wait before the first retry of a delivery when WebhookBackoff is not set.
+	DefaultWebhookBackoff = 10 * time.Second
+	// DefaultWebhookMaxBackoff caps the wait between the retries of a delivery when WebhookMaxBackoff is not set.
+	DefaultWebhookMaxBackoff = time.Hour
+	// DefaultWebhookPollInterval is how often the dispatcher looks for due deliveries when WebhookPollInterval is not set.
+	DefaultWebhookPollInterval = time.Second
+	// DefaultWebhookTimeout bounds a delivery attempt when WebhookClient is not set.
+	DefaultWebhookTimeout = 10 * time.Second
+	// DefaultWebhookConcurrency is how many webhooks are delivered to at once when WebhookConcurrency is not set.
+	DefaultWebhookConcurrency = 8
+	// webhookDeliveryBatchSize is the number of due deliveries attempted per poll.
+	webhookDeliveryBatchSize = 100
+)
+
+// Headers of the requests delivering the events to the webhooks.
+const (
+	// WebhookSignatureHeader carries "sha256=" followed by the hex HMAC-SHA256, keyed with the secret
+	// of the webhook, of the timestamp header value, a dot and the request body.
+	WebhookSignatureHeader = "X-Overlay-Signature"
+	// WebhookTimestampHeader carries the unix time of the attempt, so receivers can reject replays.
+	WebhookTimestampHeader = "X-Overlay-Timestamp"
+	// WebhookDeliveryHeader carries the ID of the delivery, which is the same across its retries.
+	WebhookDeliveryHeader = "X-Overlay-Delivery"
+)
+
+var (
+	// ErrWebhookStorageUnavailable is returned by the webhook operations when the engine has no WebhookStorage.
+	ErrWebhookStorageUnavailable = errors.New("webhook-storage-unavailable")
+	// ErrInvalidWebhook is returned when registering a webhook for an unknown topic or with an invalid URL.
+	ErrInvalidWebhook = errors.New("invalid-webhook")
+	// ErrWebhookConfigured is returned when deleting a webhook of the engine configuration.
+	ErrWebhookConfigured = errors.New("webhook-configured")
+)
+
+// WebhookDeliveryStatus tells where a delivery is in its lifecycle.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending deliveries are attempted once they are due.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered deliveries were acknowledged with a 2xx status code.
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDead deliveries exhausted their attempts, or their webhook was deleted.
+	// They make up the dead-letter queue.
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+// Webhook is an HTTP endpoint receiving the admitted and spent outputs of a topic.
+type Webhook struct {
+	// ID identifies the webhook.
+	ID string
+	// Topic is the topic whose events are delivered.
+	Topic string
+	// URL is where the events are posted.
+	URL string
+	// Secret keys the signature of the requests, see WebhookSignatureHeader.
+	Secret string
+	// CreatedAt is the time at which the webhook was registered, zero for configured webhooks.
+	CreatedAt time.Time
+}
+
+// WebhookDelivery is an event queued for delivery to a webhook, together with the outcome of its attempts.
+type WebhookDelivery struct {
+	// ID identifies the delivery, it is sent in WebhookDeliveryHeader.
+	ID        string
+	WebhookID string
+	Topic     string
+	EventType EventType
+	// Payload is the JSON body posted to the webhook.
+	Payload []byte
+	Status  WebhookDeliveryStatus
+	// Attempts is the number of attempts made so far.
+	Attempts int
+	// NextAttemptAt is when the delivery is attempted next while pending.
+	NextAttemptAt time.Time
+	// LastStatusCode is the status code of the last attempt, zero when no response was received.
+	LastStatusCode int
+	// LastError is why the last attempt failed, empty once delivered.
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookPayload is the JSON body delivered to the webhooks.
+type WebhookPayload struct {
+	DeliveryID   string    `json:"deliveryId"`
+	WebhookID    string    `json:"webhookId"`
+	Type         EventType `json:"type"`
+	Topic        string    `json:"topic"`
+	Txid         string    `json:"txid"`
+	OutputIndex  uint32    `json:"outputIndex"`
+	SpendingTxid string    `json:"spendingTxid,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// WebhookStorage persists the webhooks registered through RegisterWebhook and the queue of their deliveries.
+type WebhookStorage interface {
+	// Inserts the webhook
+	InsertWebhook(ctx context.Context, webhook *Webhook) error
+
+	// Deletes the webhook, returns ErrNotFound when it is not stored. Its deliveries are kept
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// Finds every stored webhook ordered by the time it was registered
+	FindWebhooks(ctx context.Context) ([]*Webhook, error)
+
+	// Inserts the deliveries
+	InsertWebhookDeliveries(ctx context.Context, deliveries []*WebhookDelivery) error
+
+	// Updates the status, attempts, next attempt, last status code, last error and update time of the delivery
+	UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error
+
+	// Finds at most limit pending deliveries whose next attempt is not after the given time, ordered by their next attempt
+	FindDueWebhookDeliveries(ctx context.Context, before time.Time, limit int) ([]*WebhookDelivery, error)
+
+	// Finds the deliveries of the webhook with the status ordered by the time they were queued,
+	// an empty webhook ID or status matches every delivery
+	FindWebhookDeliveries(ctx context.Context, webhookID string, status WebhookDeliveryStatus) ([]*WebhookDelivery, error)
+}
+
+// RegisterWebhook stores a webhook receiving the admitted and spent outputs of the topic. A secret is
+// generated when none is given. It returns ErrInvalidWebhook when the topic is unknown or the URL is
+// not an absolute http or https URL.
+func (e *Engine) RegisterWebhook(ctx context.Context, topic, rawURL, secret string) (*Webhook, error) {
+	if e.Webhooks == nil {
+		return nil, ErrWebhookStorageUnavailable
+	}
+	if _, ok := e.Managers[topic]; !ok {
+		return nil, fmt.Errorf("%w: unknown topic %s", ErrInvalidWebhook, topic)
+	}
+	if u, err := url.Parse(rawURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %s is not an http or https URL", ErrInvalidWebhook, rawURL)
+	}
+	if secret == "" {
+		secret = randomID()
+	}
+
+	webhook := &Webhook{
+		ID:        randomID(),
+		Topic:     topic,
+		URL:       rawURL,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+	if err := e.Webhooks.InsertWebhook(ctx, webhook); err != nil {
+		slog.Error("failed to insert webhook", "topic", topic, "error", err)
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a registered webhook, its pending deliveries end up in the dead-letter queue.
+// It returns ErrWebhookConfigured for the webhooks of the engine configuration, and ErrNotFound when
+// no webhook has the ID.
+func (e *Engine) DeleteWebhook(ctx context.Context, id string) error {
+	if e.Webhooks == nil {
+		return ErrWebhookStorageUnavailable
+	}
+	for _, webhook := range e.ConfiguredWebhooks {
+		if webhook.ID == id {
+			return ErrWebhookConfigured
+		}
+	}
+	return e.Webhooks.DeleteWebhook(ctx, id)
+}
+
+// ListWebhooks returns the webhooks of the engine configuration followed by the registered ones.
+func (e *Engine) ListWebhooks(ctx context.Context) ([]*Webhook, error) {
+	webhooks := make([]*Webhook, 0, len(e.ConfiguredWebhooks))
+	for _, webhook := range e.ConfiguredWebhooks {
+		webhooks = append(webhooks, &webhook)
+	}
+	if e.Webhooks == nil {
+		return webhooks, nil
+	}
+	registered, err := e.Webhooks.FindWebhooks(ctx)
+	if err != nil {
+		slog.Error("failed to find webhooks", "error", err)
+		return nil, err
+	}
+	return append(webhooks, registered...), nil
+}
+
+// ListWebhookDeliveries returns the delivery log of the webhook, or of every webhook when the ID is
+// empty, limited to the deliveries with the status when it is not empty. The dead-letter queue is
+// listed with WebhookDeliveryDead.
+func (e *Engine) ListWebhookDeliveries(ctx context.Context, webhookID string, status WebhookDeliveryStatus) ([]*WebhookDelivery, error) {
+	if e.Webhooks == nil {
+		return nil, ErrWebhookStorageUnavailable
+	}
+	return e.Webhooks.FindWebhookDeliveries(ctx, webhookID, status)
+}
+
+// enqueueWebhookDeliveries queues the admitted and spent outputs among the events for delivery
+// to the webhooks of their topics.
+func (e *Engine) enqueueWebhookDeliveries(ctx context.Context, events []Event) {
+	if e.Webhooks == nil {
+		return
+	}
+	webhooks, err := e.ListWebhooks(ctx)
+	if err != nil || len(webhooks) == 0 {
+		return
+	}
+
+	now := time.Now()
+	var deliveries []*WebhookDelivery
+	for _, event := range events {
+		if event.Type != EventOutputAdmitted && event.Type != EventOutputSpent {
+			continue
+		}
+		for _, webhook := range webhooks {
+			if webhook.Topic != event.Topic {
+				continue
+			}
+			delivery, err := newWebhookDelivery(webhook, event, now)
+			if err != nil {
+				slog.Error("failed to encode webhook payload", "webhook", webhook.ID, "outpoint", event.Outpoint.String(), "error", err)
+				continue
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	if err := e.Webhooks.InsertWebhookDeliveries(ctx, deliveries); err != nil {
+		slog.Error("failed to queue webhook deliveries", "deliveries", len(deliveries), "error", err)
+	}
+}
+
+func newWebhookDelivery(webhook *Webhook, event Event, now time.Time) (*WebhookDelivery, error) {
+	payload := WebhookPayload{
+		DeliveryID:  randomID(),
+		WebhookID:   webhook.ID,
+		Type:        event.Type,
+		Topic:       event.Topic,
+		Txid:        event.Outpoint.Txid.String(),
+		OutputIndex: event.Outpoint.Index,
+		CreatedAt:   now,
+	}
+	if event.SpendingTxid != nil {
+		payload.SpendingTxid = event.SpendingTxid.String()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookDelivery{
+		ID:            payload.DeliveryID,
+		WebhookID:     webhook.ID,
+		Topic:         event.Topic,
+		EventType:     event.Type,
+		Payload:       body,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// DeliverWebhooks attempts the pending deliveries that are due. A delivery acknowledged with a 2xx
+// status code is marked delivered, a failed one is retried with an exponential backoff until it runs
+// out of attempts and is moved to the dead-letter queue. The webhooks are delivered to concurrently,
+// up to WebhookConcurrency at once, and the deliveries of a webhook in order. Once a delivery to a
+// webhook fails, its other deliveries are not attempted and wait for the retry of the failed one.
+// It returns the number of deliveries attempted.
+func (e *Engine) DeliverWebhooks(ctx context.Context) (int, error) {
+	if e.Webhooks == nil {
+		return 0, ErrWebhookStorageUnavailable
+	}
+	due, err := e.Webhooks.FindDueWebhookDeliveries(ctx, time.Now(), webhookDeliveryBatchSize)
+	if err != nil {
+		slog.Error("failed to find due webhook deliveries", "error", err)
+		return 0, err
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+	webhooks, err := e.ListWebhooks(ctx)
+	if err != nil {
+		return 0, err
+	}
+	byID := make(map[string]*Webhook, len(webhooks))
+	for _, webhook := range webhooks {
+		byID[webhook.ID] = webhook
+	}
+
+	var webhookIDs []string
+	byWebhook := make(map[string][]*WebhookDelivery)
+	for _, delivery := range due {
+		if _, ok := byWebhook[delivery.WebhookID]; !ok {
+			webhookIDs = append(webhookIDs, delivery.WebhookID)
+		}
+		byWebhook[delivery.WebhookID] = append(byWebhook[delivery.WebhookID], delivery)
+	}
+
+	concurrency := e.WebhookConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultWebhookConcurrency
+	}
+	limiter := make(chan struct{}, concurrency)
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		attempted int
+		errs      []error
+	)
+	for _, webhookID := range webhookIDs {
+		wg.Add(1)
+		limiter <- struct{}{}
+		go func(webhook *Webhook, deliveries []*WebhookDelivery) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			n, err := e.deliverToWebhook(ctx, webhook, deliveries)
+			mu.Lock()
+			defer mu.Unlock()
+			attempted += n
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}(byID[webhookID], byWebhook[webhookID])
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return 0, err
+	}
+	return attempted, nil
+}
+
+// deliverToWebhook attempts the deliveries of the webhook in order. Once one fails, the remaining
+// deliveries are deferred to its retry, or to the first retry of a delivery when it was moved to the
+// dead-letter queue. The deliveries of a deleted webhook, given as nil, are moved to the dead-letter
+// queue. It returns the number of deliveries attempted.
+func (e *Engine) deliverToWebhook(ctx context.Context, webhook *Webhook, deliveries []*WebhookDelivery) (int, error) {
+	attempted := 0
+	var deferredUntil time.Time
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return attempted, ctx.Err()
+		}
+		switch {
+		case webhook == nil:
+			attempted++
+			delivery.Status = WebhookDeliveryDead
+			delivery.LastStatusCode = 0
+			delivery.LastError = "webhook deleted"
+		case !deferredUntil.IsZero():
+			delivery.NextAttemptAt = deferredUntil
+		default:
+			attempted++
+			e.attemptWebhookDelivery(ctx, webhook, delivery)
+			if delivery.Status == WebhookDeliveryPending {
+				deferredUntil = delivery.NextAttemptAt
+			} else if delivery.Status == WebhookDeliveryDead {
+				deferredUntil = time.Now().Add(e.webhookBackoff(1))
+			}
+		}
+		delivery.UpdatedAt = time.Now()
+		if err := e.Webhooks.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			slog.Error("failed to update webhook delivery", "delivery", delivery.ID, "error", err)
+			return attempted, err
+		}
+	}
+	return attempted, nil
+}
+
+// attemptWebhookDelivery posts the delivery to the webhook and records the outcome in the delivery.
+func (e *Engine) attemptWebhookDelivery(ctx context.Context, webhook *Webhook, delivery *WebhookDelivery) {
+	delivery.Attempts++
+	statusCode, err := e.postWebhook(ctx, webhook, delivery)
+	delivery.LastStatusCode = statusCode
+	if err == nil {
+		delivery.Status = WebhookDeliveryDelivered
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	maxAttempts := e.WebhookMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultWebhookMaxAttempts
+	}
+	if delivery.Attempts >= maxAttempts {
+		slog.Warn("webhook delivery moved to the dead-letter queue", "webhook", webhook.ID, "delivery", delivery.ID, "attempts", delivery.Attempts, "error", err)
+		delivery.Status = WebhookDeliveryDead
+		return
+	}
+	delivery.NextAttemptAt = time.Now().Add(e.webhookBackoff(delivery.Attempts))
+}
+
+// webhookBackoff returns the wait before the next attempt of a delivery which failed the given
+// number of times, doubling with every failure up to WebhookMaxBackoff.
+func (e *Engine) webhookBackoff(failures int) time.Duration {
+	backoff := e.WebhookBackoff
+	if backoff <= 0 {
+		backoff = DefaultWebhookBackoff
+	}
+	maxBackoff := e.WebhookMaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultWebhookMaxBackoff
+	}
+	return doublingBackoff(backoff, maxBackoff, failures)
+}
+
+// doublingBackoff returns the wait after the given number of failures, starting at backoff and
+// doubling with every further failure up to maxBackoff.
+func doublingBackoff(backoff, maxBackoff time.Duration, failures int) time.Duration {
+	for i := 1; i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// postWebhook posts the signed payload of the delivery and returns the status code of the response.
+func (e *Engine) postWebhook(ctx context.Context, webhook *Webhook, delivery *WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	client := e.WebhookClient
+	if client == nil {
+		client = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload returns the WebhookSignatureHeader value of the payload posted at the timestamp,
+// receivers compare it with the header to verify a request comes from the engine.
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RunWebhookDispatcher attempts the due webhook deliveries in the background, and blocks until the
+// context is done. It returns right away when the engine has no WebhookStorage.
+func (e *Engine) RunWebhookDispatcher(ctx context.Context) {
+	if e.Webhooks == nil {
+		return
+	}
+	interval := e.WebhookPollInterval
+	if interval <= 0 {
+		interval = DefaultWebhookPollInterval
+	}
+	slog.Info("dispatching webhook deliveries", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stopped dispatching webhook deliveries")
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			attempted, err := e.DeliverWebhooks(ctx)
+			if err != nil || attempted < webhookDeliveryBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// randomID returns 16 random bytes encoded as hex.
+func randomID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("failed to read random bytes: %v", err))
+	}
+	return hex.EncodeToString(b[:])
+}
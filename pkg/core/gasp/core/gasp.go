@@ -2,10 +2,12 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/transaction"
@@ -13,6 +15,10 @@ import (
 
 const MAX_CONCURRENCY = 16
 
+// ErrTooManyGraphs is returned by SubmitNode when the remote starts pushing a graph while
+// MaxGraphs graphs pushed to the instance are still incomplete.
+var ErrTooManyGraphs = errors.New("too many graphs being submitted")
+
 type GASPNodeRequest struct {
 	GraphID     *transaction.Outpoint `json:"graphID"`
 	Txid        *chainhash.Hash       `json:"txid"`
@@ -29,6 +35,11 @@ type GASPParams struct {
 	Unidirectional  bool
 	LogLevel        slog.Level
 	Concurrency     int
+	// GraphTimeout is how long a graph pushed by the remote is kept incomplete while none of its
+	// nodes is submitted, before it is discarded. Incomplete graphs do not expire when it is zero.
+	GraphTimeout time.Duration
+	// MaxGraphs caps the graphs pushed by the remote kept incomplete at once, not capped when zero.
+	MaxGraphs int
 }
 
 type GASP struct {
@@ -39,7 +50,22 @@ type GASP struct {
 	LogPrefix       string
 	Unidirectional  bool
 	LogLevel        slog.Level
-	limiter         chan struct{}
+	// limiter bounds the UTXOs synced at once. It is held for the whole unit of work of a UTXO,
+	// from requesting or hydrating its node to completing its graph.
+	limiter chan struct{}
+	// graphsMu guards graphs, the locks serializing the work on each graph by graph ID,
+	// so the nodes of a graph synced twice at once never interleave in its temporary graph.
+	graphsMu sync.Mutex
+	graphs   map[string]*graphLock
+	// awaitedMu guards awaited, the graphs the remote is submitting keyed by graph ID.
+	awaitedMu sync.Mutex
+	awaited   map[string]*awaitedGraph
+	// graphTimeout and maxGraphs bound the graphs pushed by the remote, see GASPParams.
+	graphTimeout time.Duration
+	maxGraphs    int
+	// resultMu guards result, the work done by the instance so far.
+	resultMu sync.Mutex
+	result   GASPSyncResult
 }
 
 func NewGASP(params GASPParams) *GASP {
@@ -49,6 +75,10 @@ func NewGASP(params GASPParams) *GASP {
 		LastInteraction: params.LastInteraction,
 		Unidirectional:  params.Unidirectional,
 		// Sequential:      params.Sequential,
+		awaited:      make(map[string]*awaitedGraph),
+		graphs:       make(map[string]*graphLock),
+		graphTimeout: params.GraphTimeout,
+		maxGraphs:    params.MaxGraphs,
 	}
 	if params.Concurrency > 1 {
 		gasp.limiter = make(chan struct{}, params.Concurrency)
@@ -69,6 +99,138 @@ func NewGASP(params GASPParams) *GASP {
 	return gasp
 }
 
+// Result returns a summary of the nodes fetched, the graphs finalized and discarded, and the
+// errors that did not stop the sync, accumulated over the lifetime of the instance.
+func (g *GASP) Result() GASPSyncResult {
+	g.resultMu.Lock()
+	defer g.resultMu.Unlock()
+	result := g.result
+	result.Errors = slices.Clone(g.result.Errors)
+	return result
+}
+
+func (g *GASP) track(update func(result *GASPSyncResult)) {
+	g.resultMu.Lock()
+	defer g.resultMu.Unlock()
+	update(&g.result)
+}
+
+func (g *GASP) trackError(err error) {
+	g.track(func(result *GASPSyncResult) {
+		result.Errors = append(result.Errors, err)
+	})
+}
+
+// discardGraph drops the temporary graph, so a graph that failed to sync does not linger in storage.
+func (g *GASP) discardGraph(ctx context.Context, graphID *transaction.Outpoint) error {
+	if err := g.Storage.DiscardGraph(ctx, graphID); err != nil {
+		return err
+	}
+	g.track(func(result *GASPSyncResult) {
+		result.GraphsDiscarded++
+	})
+	return nil
+}
+
+// awaitedGraph is a graph the remote is submitting, with the inputs still expected from it
+// keyed by outpoint, and the time at which one of its nodes was last submitted.
+type awaitedGraph struct {
+	graphID   *transaction.Outpoint
+	inputs    map[string]struct{}
+	touchedAt time.Time
+}
+
+// reserveGraph records that the remote is submitting a node of the graph, failing with
+// ErrTooManyGraphs when the graph is new and MaxGraphs graphs are already incomplete.
+func (g *GASP) reserveGraph(graphID *transaction.Outpoint) error {
+	g.awaitedMu.Lock()
+	defer g.awaitedMu.Unlock()
+	if g.awaited == nil {
+		g.awaited = make(map[string]*awaitedGraph)
+	}
+	key := graphID.String()
+	if graph, ok := g.awaited[key]; ok {
+		graph.touchedAt = time.Now()
+		return nil
+	}
+	if g.maxGraphs > 0 && len(g.awaited) >= g.maxGraphs {
+		return ErrTooManyGraphs
+	}
+	g.awaited[key] = &awaitedGraph{graphID: graphID, inputs: make(map[string]struct{}), touchedAt: time.Now()}
+	return nil
+}
+
+// releaseGraph forgets the graph once it is completed or discarded.
+func (g *GASP) releaseGraph(graphID *transaction.Outpoint) {
+	g.awaitedMu.Lock()
+	delete(g.awaited, graphID.String())
+	g.awaitedMu.Unlock()
+}
+
+// expireGraphs discards the graphs none of whose nodes was submitted by the remote within the
+// graph timeout, so the graphs the remote gave up on do not linger.
+func (g *GASP) expireGraphs(ctx context.Context) {
+	if g.graphTimeout <= 0 {
+		return
+	}
+	deadline := time.Now().Add(-g.graphTimeout)
+	var expired []*transaction.Outpoint
+	g.awaitedMu.Lock()
+	for key, graph := range g.awaited {
+		if graph.touchedAt.Before(deadline) {
+			delete(g.awaited, key)
+			expired = append(expired, graph.graphID)
+		}
+	}
+	g.awaitedMu.Unlock()
+
+	for _, graphID := range expired {
+		unlock := g.lockGraph(graphID)
+		g.awaitedMu.Lock()
+		_, resumed := g.awaited[graphID.String()]
+		g.awaitedMu.Unlock()
+		if !resumed {
+			slog.Warn(fmt.Sprintf("%sDiscarding expired graph %s", g.LogPrefix, graphID.String()))
+			if err := g.discardGraph(ctx, graphID); err != nil {
+				slog.Warn(fmt.Sprintf("%sError discarding graph %s: %v", g.LogPrefix, graphID.String(), err))
+			}
+		}
+		unlock()
+	}
+}
+
+// graphLock is a lock on a graph together with the number of goroutines holding or awaiting it.
+type graphLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lockGraph waits until no other goroutine works on the graph and returns the function releasing it.
+func (g *GASP) lockGraph(graphID *transaction.Outpoint) (unlock func()) {
+	key := graphID.String()
+	g.graphsMu.Lock()
+	if g.graphs == nil {
+		g.graphs = make(map[string]*graphLock)
+	}
+	lock := g.graphs[key]
+	if lock == nil {
+		lock = &graphLock{}
+		g.graphs[key] = lock
+	}
+	lock.refs++
+	g.graphsMu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+		g.graphsMu.Lock()
+		if lock.refs--; lock.refs == 0 {
+			delete(g.graphs, key)
+		}
+		g.graphsMu.Unlock()
+	}
+}
+
 func (g *GASP) Sync(ctx context.Context) error {
 	slog.Info(fmt.Sprintf("%sStarting sync process. Last interaction timestamp: %d", g.LogPrefix, g.LastInteraction))
 	initialRequest := &GASPInitialRequest{
@@ -78,7 +240,11 @@ func (g *GASP) Sync(ctx context.Context) error {
 	initialResponse, err := g.Remote.GetInitialResponse(ctx, initialRequest)
 	if err != nil {
 		return err
-	} else if len(initialResponse.UTXOList) > 0 {
+	}
+	g.track(func(result *GASPSyncResult) {
+		result.Since = initialResponse.Since
+	})
+	if len(initialResponse.UTXOList) > 0 {
 		if foreignUTXOs, err := g.Storage.FindKnownUTXOs(ctx, 0); err != nil {
 			return err
 		} else {
@@ -96,28 +262,21 @@ func (g *GASP) Sync(ctx context.Context) error {
 						<-g.limiter
 						wg.Done()
 					}()
-					slog.Info(fmt.Sprintf("%sRequesting node for UTXO: %s", g.LogPrefix, outpoint.String()))
-					var resolvedNode *GASPNode
-					var err error
-					if resolvedNode, err = g.Remote.RequestNode(ctx, outpoint, outpoint, true); err == nil {
-						slog.Debug(fmt.Sprintf("%sReceived unspent graph node from remote: %v", g.LogPrefix, resolvedNode))
-						if err = g.processIncomingNode(ctx, resolvedNode, nil, &sync.Map{}); err == nil {
-							if err = g.CompleteGraph(ctx, resolvedNode.GraphID); err == nil {
-								return
-							}
-						}
+					if err := g.syncIncomingUTXO(ctx, outpoint); err != nil {
+						slog.Warn(fmt.Sprintf("%sError with incoming UTXO %s: %v", g.LogPrefix, outpoint.String(), err))
+						g.trackError(fmt.Errorf("incoming UTXO %s: %w", outpoint.String(), err))
 					}
-					slog.Warn(fmt.Sprintf("%sError with incoming UTXO %s: %v", g.LogPrefix, outpoint.String(), err))
 				}(outpoint)
 			}
 			wg.Wait()
 		}
 	}
 	if !g.Unidirectional {
-		if initialReply, err := g.Remote.GetInitialReply(ctx, initialResponse); err != nil {
+		// The reply lists the UTXOs known locally that the remote did not list in its response,
+		// over the window of the request rather than the Since of the remote.
+		if initialReply, err := g.GetInitialReply(ctx, &GASPInitialResponse{UTXOList: initialResponse.UTXOList, Since: g.LastInteraction}); err != nil {
 			return err
 		} else {
-			slog.Info(fmt.Sprintf("%sReceived initial reply: %v", g.LogPrefix, initialReply))
 			var wg sync.WaitGroup
 			for _, outpoint := range initialReply.UTXOList {
 				wg.Add(1)
@@ -127,17 +286,18 @@ func (g *GASP) Sync(ctx context.Context) error {
 						<-g.limiter
 						wg.Done()
 					}()
-					var outgoingNode *GASPNode
 					slog.Info(fmt.Sprintf("%sHydrating GASP node for UTXO: %s", g.LogPrefix, outpoint.String()))
-					if outgoingNode, err = g.Storage.HydrateGASPNode(ctx, outpoint, outpoint, true); err == nil && outgoingNode != nil {
-						slog.Debug(fmt.Sprintf("%sSending unspent graph node for remote: %v", g.LogPrefix, outgoingNode))
-						if err = g.processOutgoingNode(ctx, outgoingNode, &sync.Map{}); err == nil {
-							return
-						}
-					} else if err != nil {
+					if outgoingNode, err := g.Storage.HydrateGASPNode(ctx, outpoint, outpoint, true); err != nil {
 						slog.Warn(fmt.Sprintf("%sError hydrating outgoing UTXO %s: %v", g.LogPrefix, outpoint, err))
-					} else {
+						g.trackError(fmt.Errorf("outgoing UTXO %s: %w", outpoint.String(), err))
+					} else if outgoingNode == nil {
 						slog.Debug(fmt.Sprintf("%sSkipping outgoing UTXO %s: not found in storage", g.LogPrefix, outpoint))
+					} else {
+						slog.Debug(fmt.Sprintf("%sSending unspent graph node for remote: %v", g.LogPrefix, outgoingNode))
+						if err := g.processOutgoingNode(ctx, outgoingNode, &sync.Map{}); err != nil {
+							slog.Warn(fmt.Sprintf("%sError with outgoing UTXO %s: %v", g.LogPrefix, outpoint, err))
+							g.trackError(fmt.Errorf("outgoing UTXO %s: %w", outpoint.String(), err))
+						}
 					}
 				}(outpoint)
 			}
@@ -148,6 +308,27 @@ func (g *GASP) Sync(ctx context.Context) error {
 	return nil
 }
 
+// syncIncomingUTXO requests the node of the UTXO from the remote together with its needed inputs,
+// then completes its graph. A graph failing to sync is discarded.
+func (g *GASP) syncIncomingUTXO(ctx context.Context, outpoint *transaction.Outpoint) error {
+	slog.Info(fmt.Sprintf("%sRequesting node for UTXO: %s", g.LogPrefix, outpoint.String()))
+	resolvedNode, err := g.requestNode(ctx, outpoint, outpoint, true)
+	if err != nil {
+		return err
+	}
+	slog.Debug(fmt.Sprintf("%sReceived unspent graph node from remote: %v", g.LogPrefix, resolvedNode))
+
+	unlock := g.lockGraph(resolvedNode.GraphID)
+	defer unlock()
+	if err := g.processIncomingNode(ctx, resolvedNode, nil, &sync.Map{}); err != nil {
+		if discardErr := g.discardGraph(ctx, resolvedNode.GraphID); discardErr != nil {
+			slog.Warn(fmt.Sprintf("%sError discarding graph %s: %v", g.LogPrefix, resolvedNode.GraphID.String(), discardErr))
+		}
+		return err
+	}
+	return g.CompleteGraph(ctx, resolvedNode.GraphID)
+}
+
 func (g *GASP) GetInitialResponse(ctx context.Context, request *GASPInitialRequest) (resp *GASPInitialResponse, err error) {
 	slog.Info(fmt.Sprintf("%sReceived initial request: %v", g.LogPrefix, request))
 	if request.Version != g.Version {
@@ -198,17 +379,61 @@ func (g *GASP) RequestNode(ctx context.Context, graphID *transaction.Outpoint, o
 	return node, nil
 }
 
+// SubmitNode appends a node pushed by the remote to its graph and returns the inputs the remote
+// has to submit next. The graph is completed once every requested input has been submitted.
 func (g *GASP) SubmitNode(ctx context.Context, node *GASPNode) (requestedInputs *GASPNodeResponse, err error) {
 	slog.Info(fmt.Sprintf("%sRemote is submitting node: %v", g.LogPrefix, node))
-	if err = g.Storage.AppendToGraph(ctx, node, nil); err != nil {
+	if node == nil || node.GraphID == nil {
+		return nil, fmt.Errorf("node or its graph ID is nil in SubmitNode")
+	}
+	txid, err := g.computeTxID(node.RawTx)
+	if err != nil {
 		return nil, err
-	} else if requestedInputs, err = g.Storage.FindNeededInputs(ctx, node); err != nil {
+	}
+	g.expireGraphs(ctx)
+	if err := g.reserveGraph(node.GraphID); err != nil {
 		return nil, err
-	} else if requestedInputs != nil {
-		slog.Debug(fmt.Sprintf("%sRequested inputs: %v", g.LogPrefix, requestedInputs))
+	}
+	graphID := node.GraphID.String()
+	unlock := g.lockGraph(node.GraphID)
+	defer unlock()
+	if err = g.Storage.AppendToGraph(ctx, node, nil); err == nil {
+		requestedInputs, err = g.Storage.FindNeededInputs(ctx, node)
+	}
+	if err != nil {
+		g.releaseGraph(node.GraphID)
+		if discardErr := g.discardGraph(ctx, node.GraphID); discardErr != nil {
+			slog.Warn(fmt.Sprintf("%sError discarding graph %s: %v", g.LogPrefix, graphID, discardErr))
+		}
+		return nil, err
+	}
+
+	g.awaitedMu.Lock()
+	awaited := g.awaited[graphID]
+	if awaited == nil {
+		// The graph was released while the node waited for it, the node starts it over.
+		awaited = &awaitedGraph{graphID: node.GraphID, inputs: make(map[string]struct{})}
+		g.awaited[graphID] = awaited
+	}
+	awaited.touchedAt = time.Now()
+	delete(awaited.inputs, (&transaction.Outpoint{Txid: *txid, Index: node.OutputIndex}).String())
+	if requestedInputs != nil {
+		for outpoint := range requestedInputs.RequestedInputs {
+			awaited.inputs[outpoint] = struct{}{}
+		}
+	}
+	complete := len(awaited.inputs) == 0
+	if complete {
+		delete(g.awaited, graphID)
+	}
+	g.awaitedMu.Unlock()
+
+	if complete {
 		if err := g.CompleteGraph(ctx, node.GraphID); err != nil {
 			return nil, err
 		}
+	} else {
+		slog.Debug(fmt.Sprintf("%sRequested inputs: %v", g.LogPrefix, requestedInputs))
 	}
 	return requestedInputs, nil
 }
@@ -217,13 +442,31 @@ func (g *GASP) CompleteGraph(ctx context.Context, graphID *transaction.Outpoint)
 	slog.Info(fmt.Sprintf("%sCompleting newly-synced graph: %s", g.LogPrefix, graphID.String()))
 	if err = g.Storage.ValidateGraphAnchor(ctx, graphID); err == nil {
 		slog.Debug(fmt.Sprintf("%sGraph validated for node: %s", g.LogPrefix, graphID.String()))
-		if err := g.Storage.FinalizeGraph(ctx, graphID); err == nil {
+		if err = g.Storage.FinalizeGraph(ctx, graphID); err == nil {
+			slog.Info(fmt.Sprintf("%sGraph finalized for node: %s", g.LogPrefix, graphID.String()))
+			g.track(func(result *GASPSyncResult) {
+				result.GraphsFinalized++
+			})
 			return nil
 		}
-		slog.Info(fmt.Sprintf("%sGraph finalized for node: %s", g.LogPrefix, graphID.String()))
 	}
 	slog.Warn(fmt.Sprintf("%sError completing graph %s: %v", g.LogPrefix, graphID.String(), err))
-	return g.Storage.DiscardGraph(ctx, graphID)
+	g.trackError(fmt.Errorf("graph %s: %w", graphID.String(), err))
+	return g.discardGraph(ctx, graphID)
+}
+
+// requestNode requests a node from the remote. It does not take the limiter, already held by
+// the UTXO the node is requested for, so nodes waiting on their inputs never starve the
+// requests for those inputs.
+func (g *GASP) requestNode(ctx context.Context, graphID *transaction.Outpoint, outpoint *transaction.Outpoint, metadata bool) (*GASPNode, error) {
+	node, err := g.Remote.RequestNode(ctx, graphID, outpoint, metadata)
+	if err != nil {
+		return nil, err
+	}
+	g.track(func(result *GASPSyncResult) {
+		result.NodesFetched++
+	})
+	return node, nil
 }
 
 func (g *GASP) processIncomingNode(ctx context.Context, node *GASPNode, spentBy *transaction.Outpoint, seenNodes *sync.Map) error {
@@ -247,19 +490,15 @@ func (g *GASP) processIncomingNode(ctx context.Context, node *GASPNode, spentBy
 		} else if neededInputs != nil {
 			slog.Debug(fmt.Sprintf("%sNeeded inputs for node %s: %v", g.LogPrefix, nodeId, neededInputs))
 			var wg sync.WaitGroup
-			errors := make(chan error)
+			errors := make(chan error, len(neededInputs.RequestedInputs))
 			for outpointStr, data := range neededInputs.RequestedInputs {
 				wg.Add(1)
-				g.limiter <- struct{}{}
 				go func(outpointStr string, data *GASPNodeResponseData) {
-					defer func() {
-						<-g.limiter
-						wg.Done()
-					}()
+					defer wg.Done()
 					slog.Info(fmt.Sprintf("%sRequesting new node for outpoint: %s, metadata: %v", g.LogPrefix, outpointStr, data.Metadata))
 					if outpoint, err := transaction.OutpointFromString(outpointStr); err != nil {
 						errors <- err
-					} else if newNode, err := g.Remote.RequestNode(ctx, node.GraphID, outpoint, data.Metadata); err != nil {
+					} else if newNode, err := g.requestNode(ctx, node.GraphID, outpoint, data.Metadata); err != nil {
 						errors <- err
 					} else {
 						slog.Debug(fmt.Sprintf("%sReceived new node: %v", g.LogPrefix, newNode))
@@ -315,12 +554,8 @@ func (g *GASP) processOutgoingNode(ctx context.Context, node *GASPNode, seenNode
 			var wg sync.WaitGroup
 			for outpointStr, data := range response.RequestedInputs {
 				wg.Add(1)
-				g.limiter <- struct{}{}
 				go func(outpointStr string, data *GASPNodeResponseData) {
-					defer func() {
-						<-g.limiter
-						wg.Done()
-					}()
+					defer wg.Done()
 					var outpoint *transaction.Outpoint
 					var err error
 					if outpoint, err = transaction.OutpointFromString(outpointStr); err == nil {
@@ -334,6 +569,7 @@ func (g *GASP) processOutgoingNode(ctx context.Context, node *GASPNode, seenNode
 						}
 					}
 					slog.Error(fmt.Sprintf("%sError hydrating node: %v", g.LogPrefix, err))
+					g.trackError(fmt.Errorf("outgoing node %s: %w", outpointStr, err))
 				}(outpointStr, data)
 			}
 			wg.Wait()
@@ -411,6 +411,18 @@ type mockStorage struct {
 	findOutputsFunc       func(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spent *bool, historical bool) ([]*engine.Output, error)
 }
 
+func (m *mockStorage) Begin(ctx context.Context) (engine.StorageTransaction, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockStorage) FindSpentOutputsForTopic(ctx context.Context, topic string, includeBEEF bool) ([]*engine.Output, error) {
+	return nil, nil
+}
+
+func (m *mockStorage) FindOutputsSinceBlockHeight(ctx context.Context, blockHeight uint32, includeBEEF bool) ([]*engine.Output, error) {
+	return nil, nil
+}
+
 func (m *mockStorage) FindUTXOsForTopic(ctx context.Context, topic string, since uint32, historical bool) ([]*engine.Output, error) {
 	if m.findUTXOsForTopicFunc != nil {
 		return m.findUTXOsForTopicFunc(ctx, topic, since, historical)
@@ -0,0 +1,159 @@
+package gasp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGASP_SubmitNode_ShouldFinalizeGraph_WhenAllRequestedInputsAreSubmitted(t *testing.T) {
+	// given
+	ctx := context.Background()
+	root := createMockUTXO("root", 0, 0)
+	input := &transaction.Outpoint{Txid: *root.Txid, Index: 1}
+
+	var finalized []*transaction.Outpoint
+	storage := newMockGASPStorage(nil)
+	storage.appendToGraphFunc = func(ctx context.Context, tx *core.GASPNode, spentBy *transaction.Outpoint) error {
+		return nil
+	}
+	storage.findNeededInputsFunc = func(ctx context.Context, tx *core.GASPNode) (*core.GASPNodeResponse, error) {
+		if tx.OutputIndex == root.OutputIndex {
+			return &core.GASPNodeResponse{RequestedInputs: map[string]*core.GASPNodeResponseData{input.String(): {Metadata: false}}}, nil
+		}
+		return nil, nil
+	}
+	storage.finalizeGraphFunc = func(ctx context.Context, graphID *transaction.Outpoint) error {
+		finalized = append(finalized, graphID)
+		return nil
+	}
+	gasp := core.NewGASP(core.GASPParams{Storage: storage})
+
+	// when
+	response, err := gasp.SubmitNode(ctx, &core.GASPNode{GraphID: root.GraphID, RawTx: root.RawTx, OutputIndex: root.OutputIndex})
+
+	// then
+	require.NoError(t, err)
+	require.Contains(t, response.RequestedInputs, input.String())
+	require.Empty(t, finalized)
+
+	// when
+	response, err = gasp.SubmitNode(ctx, &core.GASPNode{GraphID: root.GraphID, RawTx: root.RawTx, OutputIndex: input.Index})
+
+	// then
+	require.NoError(t, err)
+	require.Nil(t, response)
+	require.Equal(t, []*transaction.Outpoint{root.GraphID}, finalized)
+}
+
+func TestGASP_SubmitNode_ShouldDiscardGraph_WhenAppendToGraphFails(t *testing.T) {
+	// given
+	ctx := context.Background()
+	root := createMockUTXO("root", 0, 0)
+	appendErr := errors.New("append failed")
+
+	var discarded []*transaction.Outpoint
+	storage := newMockGASPStorage(nil)
+	storage.appendToGraphFunc = func(ctx context.Context, tx *core.GASPNode, spentBy *transaction.Outpoint) error {
+		return appendErr
+	}
+	storage.discardGraphFunc = func(ctx context.Context, graphID *transaction.Outpoint) error {
+		discarded = append(discarded, graphID)
+		return nil
+	}
+	gasp := core.NewGASP(core.GASPParams{Storage: storage})
+
+	// when
+	response, err := gasp.SubmitNode(ctx, &core.GASPNode{GraphID: root.GraphID, RawTx: root.RawTx, OutputIndex: root.OutputIndex})
+
+	// then
+	require.ErrorIs(t, err, appendErr)
+	require.Nil(t, response)
+	require.Equal(t, []*transaction.Outpoint{root.GraphID}, discarded)
+}
+
+func TestGASP_SubmitNode_ShouldReturnError_WhenGraphIDIsMissing(t *testing.T) {
+	// given
+	ctx := context.Background()
+	root := createMockUTXO("root", 0, 0)
+	gasp := core.NewGASP(core.GASPParams{Storage: newMockGASPStorage(nil)})
+
+	// when
+	response, err := gasp.SubmitNode(ctx, &core.GASPNode{RawTx: root.RawTx, OutputIndex: root.OutputIndex})
+
+	// then
+	require.Error(t, err)
+	require.Nil(t, response)
+}
+
+// newIncompleteGraphStorage returns a storage requesting an input of every node submitted as the root
+// of its graph, so the graphs stay incomplete, and recording the graphs discarded.
+func newIncompleteGraphStorage(discarded *[]*transaction.Outpoint) *mockGASPStorage {
+	storage := newMockGASPStorage(nil)
+	storage.appendToGraphFunc = func(ctx context.Context, tx *core.GASPNode, spentBy *transaction.Outpoint) error {
+		return nil
+	}
+	storage.findNeededInputsFunc = func(ctx context.Context, tx *core.GASPNode) (*core.GASPNodeResponse, error) {
+		input := &transaction.Outpoint{Txid: tx.GraphID.Txid, Index: tx.OutputIndex + 100}
+		return &core.GASPNodeResponse{RequestedInputs: map[string]*core.GASPNodeResponseData{input.String(): {Metadata: false}}}, nil
+	}
+	storage.discardGraphFunc = func(ctx context.Context, graphID *transaction.Outpoint) error {
+		*discarded = append(*discarded, graphID)
+		return nil
+	}
+	return storage
+}
+
+func TestGASP_SubmitNode_ShouldRefuseGraph_WhenMaxGraphsAreIncomplete(t *testing.T) {
+	// given
+	ctx := context.Background()
+	first := createMockUTXO("first", 0, 0)
+	second := createMockUTXO("second", 1, 0)
+	var discarded []*transaction.Outpoint
+	gasp := core.NewGASP(core.GASPParams{Storage: newIncompleteGraphStorage(&discarded), MaxGraphs: 1})
+
+	_, err := gasp.SubmitNode(ctx, &core.GASPNode{GraphID: first.GraphID, RawTx: first.RawTx, OutputIndex: first.OutputIndex})
+	require.NoError(t, err)
+
+	// when
+	response, err := gasp.SubmitNode(ctx, &core.GASPNode{GraphID: second.GraphID, RawTx: second.RawTx, OutputIndex: second.OutputIndex})
+
+	// then
+	require.ErrorIs(t, err, core.ErrTooManyGraphs)
+	require.Nil(t, response)
+	require.Empty(t, discarded)
+
+	// when
+	response, err = gasp.SubmitNode(ctx, &core.GASPNode{GraphID: first.GraphID, RawTx: first.RawTx, OutputIndex: first.OutputIndex + 100})
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, response)
+}
+
+func TestGASP_SubmitNode_ShouldDiscardGraph_WhenItExpires(t *testing.T) {
+	// given
+	ctx := context.Background()
+	first := createMockUTXO("first", 0, 0)
+	second := createMockUTXO("second", 1, 0)
+	var discarded []*transaction.Outpoint
+	gasp := core.NewGASP(core.GASPParams{Storage: newIncompleteGraphStorage(&discarded), GraphTimeout: 10 * time.Millisecond, MaxGraphs: 1})
+
+	_, err := gasp.SubmitNode(ctx, &core.GASPNode{GraphID: first.GraphID, RawTx: first.RawTx, OutputIndex: first.OutputIndex})
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+
+	// when
+	response, err := gasp.SubmitNode(ctx, &core.GASPNode{GraphID: second.GraphID, RawTx: second.RawTx, OutputIndex: second.OutputIndex})
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, []*transaction.Outpoint{first.GraphID}, discarded)
+	require.Equal(t, 1, gasp.Result().GraphsDiscarded)
+}
@@ -0,0 +1,137 @@
+package gasp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGASP_Result_ShouldCountFetchedNodesAndFinalizedGraphs(t *testing.T) {
+	// given
+	ctx := context.Background()
+	storage1 := newMockGASPStorage([]*mockUTXO{
+		createMockUTXO("mock_sender1_rawtx1", 0, 111),
+		createMockUTXO("mock_sender2_rawtx1", 1, 222),
+	})
+	storage2 := newMockGASPStorage([]*mockUTXO{})
+
+	gasp1 := core.NewGASP(core.GASPParams{Storage: storage1})
+	sut := core.NewGASP(core.GASPParams{Storage: storage2, Unidirectional: true})
+	sut.Remote = &mockGASPRemote{targetGASP: gasp1}
+
+	// when
+	err := sut.Sync(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, core.GASPSyncResult{NodesFetched: 2, GraphsFinalized: 2}, sut.Result())
+}
+
+func TestGASP_Result_ShouldCountDiscardedGraphsAndErrors(t *testing.T) {
+	// given
+	ctx := context.Background()
+	storage1 := newMockGASPStorage([]*mockUTXO{createMockUTXO("mock_sender1_rawtx1", 0, 111)})
+	storage2 := newMockGASPStorage([]*mockUTXO{})
+	expectedErr := errors.New("invalid graph anchor")
+	storage2.validateGraphAnchorFunc = func(ctx context.Context, graphID *transaction.Outpoint) error {
+		return expectedErr
+	}
+
+	gasp1 := core.NewGASP(core.GASPParams{Storage: storage1})
+	sut := core.NewGASP(core.GASPParams{Storage: storage2, Unidirectional: true})
+	sut.Remote = &mockGASPRemote{targetGASP: gasp1}
+
+	// when
+	err := sut.Sync(ctx)
+
+	// then
+	require.NoError(t, err)
+
+	result := sut.Result()
+	require.Equal(t, 1, result.NodesFetched)
+	require.Equal(t, 0, result.GraphsFinalized)
+	require.Equal(t, 1, result.GraphsDiscarded)
+	require.Len(t, result.Errors, 1)
+	require.ErrorIs(t, result.Errors[0], expectedErr)
+}
+
+func TestGASP_Result_ShouldDiscardGraph_WhenRequestingInputFails(t *testing.T) {
+	// given
+	ctx := context.Background()
+	utxo := createMockUTXO("mock_sender1_rawtx1", 0, 111)
+	storage := newMockGASPStorage([]*mockUTXO{})
+	storage.findNeededInputsFunc = func(ctx context.Context, tx *core.GASPNode) (*core.GASPNodeResponse, error) {
+		if tx.OutputIndex != utxo.OutputIndex {
+			return nil, nil
+		}
+		return &core.GASPNodeResponse{RequestedInputs: map[string]*core.GASPNodeResponseData{
+			(&transaction.Outpoint{Txid: *utxo.Txid, Index: 7}).String(): {Metadata: false},
+		}}, nil
+	}
+	discarded := false
+	storage.discardGraphFunc = func(ctx context.Context, graphID *transaction.Outpoint) error {
+		discarded = true
+		require.Equal(t, utxo.GraphID.String(), graphID.String())
+		return nil
+	}
+
+	expectedErr := errors.New("input not found")
+	sut := core.NewGASP(core.GASPParams{Storage: storage, Unidirectional: true})
+	sut.Remote = &mockGASPRemote{
+		initialResponseFunc: func(ctx context.Context, request *core.GASPInitialRequest) (*core.GASPInitialResponse, error) {
+			return &core.GASPInitialResponse{UTXOList: []*transaction.Outpoint{utxo.GraphID}}, nil
+		},
+		requestNodeFunc: func(ctx context.Context, graphID, outpoint *transaction.Outpoint, metadata bool) (*core.GASPNode, error) {
+			if !outpoint.Equal(utxo.GraphID) {
+				return nil, expectedErr
+			}
+			return &core.GASPNode{GraphID: graphID, RawTx: utxo.RawTx, OutputIndex: utxo.OutputIndex}, nil
+		},
+	}
+
+	// when
+	err := sut.Sync(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.True(t, discarded)
+
+	result := sut.Result()
+	require.Equal(t, 1, result.NodesFetched)
+	require.Equal(t, 1, result.GraphsDiscarded)
+	require.Len(t, result.Errors, 1)
+	require.ErrorIs(t, result.Errors[0], expectedErr)
+}
+
+func TestGASP_Result_ShouldReportSinceOfRemote_AndReplyOverWindowOfRequest(t *testing.T) {
+	// given
+	ctx := context.Background()
+	utxo := createMockUTXO("mock_sender1_rawtx1", 0, 200)
+	localStorage := newMockGASPStorage([]*mockUTXO{utxo})
+	remoteStorage := newMockGASPStorage([]*mockUTXO{})
+
+	remote := core.NewGASP(core.GASPParams{Storage: remoteStorage})
+	sut := core.NewGASP(core.GASPParams{Storage: localStorage, LastInteraction: 100})
+	sut.Remote = &mockGASPRemote{
+		targetGASP: remote,
+		initialResponseFunc: func(ctx context.Context, request *core.GASPInitialRequest) (*core.GASPInitialResponse, error) {
+			require.Equal(t, uint32(100), request.Since)
+			return &core.GASPInitialResponse{UTXOList: []*transaction.Outpoint{}, Since: 999}, nil
+		},
+	}
+
+	// when
+	err := sut.Sync(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, uint32(999), sut.Result().Since)
+
+	remoteUTXOs, err := remoteStorage.FindKnownUTXOs(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.Outpoint{utxo.GraphID}, remoteUTXOs)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
 	"github.com/bsv-blockchain/go-sdk/chainhash"
@@ -480,3 +482,56 @@ func TestGASP_SyncBasicScenarios(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestGASP_Sync_ShouldNotSyncMoreGraphsAtOnceThanConcurrency(t *testing.T) {
+	for _, concurrency := range []int{1, 3} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			utxos := make([]*transaction.Outpoint, 0, 8)
+			nodes := make(map[string]*mockUTXO)
+			for i := range 8 {
+				utxo := createMockUTXO("mock_sender_rawtx", uint32(i), 111)
+				utxos = append(utxos, utxo.GraphID)
+				nodes[utxo.GraphID.String()] = utxo
+			}
+
+			var mu sync.Mutex
+			inFlight, maxInFlight := 0, 0
+			storage := newMockGASPStorage([]*mockUTXO{})
+			storage.appendToGraphFunc = func(ctx context.Context, tx *core.GASPNode, spentBy *transaction.Outpoint) error {
+				mu.Lock()
+				defer mu.Unlock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				return nil
+			}
+			storage.finalizeGraphFunc = func(ctx context.Context, graphID *transaction.Outpoint) error {
+				time.Sleep(time.Millisecond)
+				mu.Lock()
+				defer mu.Unlock()
+				inFlight--
+				return nil
+			}
+
+			sut := core.NewGASP(core.GASPParams{Storage: storage, Unidirectional: true, Concurrency: concurrency})
+			sut.Remote = &mockGASPRemote{
+				initialResponseFunc: func(ctx context.Context, request *core.GASPInitialRequest) (*core.GASPInitialResponse, error) {
+					return &core.GASPInitialResponse{UTXOList: utxos}, nil
+				},
+				requestNodeFunc: func(ctx context.Context, graphID, outpoint *transaction.Outpoint, metadata bool) (*core.GASPNode, error) {
+					utxo := nodes[outpoint.String()]
+					return &core.GASPNode{GraphID: graphID, RawTx: utxo.RawTx, OutputIndex: utxo.OutputIndex}, nil
+				},
+			}
+
+			// when
+			err := sut.Sync(ctx)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, 8, sut.Result().GraphsFinalized)
+			require.LessOrEqual(t, maxInFlight, concurrency)
+		})
+	}
+}
@@ -43,6 +43,21 @@ type GASPNodeResponse struct {
 	RequestedInputs map[string]*GASPNodeResponseData `json:"requestedInputs"`
 }
 
+// GASPSyncResult summarises the work done by a GASP instance while syncing with its remote.
+type GASPSyncResult struct {
+	// NodesFetched is the number of nodes requested from and returned by the remote.
+	NodesFetched int
+	// GraphsFinalized is the number of graphs validated and admitted into storage.
+	GraphsFinalized int
+	// GraphsDiscarded is the number of graphs dropped because they failed to sync or validate.
+	GraphsDiscarded int
+	// Errors holds the failures of single UTXOs and graphs, which do not stop the sync.
+	Errors []error
+	// Since is the time, on the clock of the remote, at which the remote listed its UTXOs in its
+	// last initial response. The next sync with the remote requests the UTXOs since then.
+	Since uint32
+}
+
 type GASPVersionMismatchError struct {
 	Message        string `json:"message"`
 	Code           string `json:"code"`
@@ -0,0 +1,399 @@
+// Package mutualauth implements the client side of the BRC-103 mutual authentication over HTTP specified
+// by BRC-104, so an overlay node can send its requests to the overlay nodes requiring it.
+package mutualauth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/auth"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// The headers carrying the BRC-104 mutual authentication of the general requests and responses.
+const (
+	VersionHeader     = "x-bsv-auth-version"
+	IdentityKeyHeader = "x-bsv-auth-identity-key"
+	NonceHeader       = "x-bsv-auth-nonce"
+	YourNonceHeader   = "x-bsv-auth-your-nonce"
+	SignatureHeader   = "x-bsv-auth-signature"
+	RequestIDHeader   = "x-bsv-auth-request-id"
+)
+
+// WellKnownAuthPath is the path of the BRC-104 handshake endpoint of the overlay nodes.
+const WellKnownAuthPath = "/.well-known/auth"
+
+// originator is the originator of the wallet operations of the transport.
+const originator = "overlay-services"
+
+var (
+	// ErrUnsignedResponse is returned when a node the transport authenticated to answers without signing its response.
+	ErrUnsignedResponse = errors.New("mutual auth response is not signed")
+	// ErrInvalidResponseSignature is returned when the signature of a response does not match the identity key of the node.
+	ErrInvalidResponseSignature = errors.New("invalid mutual auth response signature")
+	// ErrInvalidHandshake is returned when a node answers the initial request with an invalid initial response.
+	ErrInvalidHandshake = errors.New("invalid mutual auth initial response")
+)
+
+// Transport is an http.RoundTripper authenticating the requests to the overlay nodes with the identity key of
+// its wallet. It performs the handshake with each node on its first request, signs the requests within the
+// established session and verifies the signature of the responses. The requests to the nodes not supporting
+// mutual authentication, answering the handshake with 404 Not Found, are sent as they are.
+//
+// It repeats the handshake once when a node no longer knows the session, for instance after a restart.
+// Requesting certificates is not supported. It is safe for concurrent use.
+type Transport struct {
+	wallet wallet.KeyOperations
+	base   http.RoundTripper
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// session is the session established by the handshake with a node, nil server key when the node
+// does not support mutual authentication.
+type session struct {
+	serverKey   *ec.PublicKey
+	serverNonce string
+	nonce       string
+}
+
+// NewTransport returns a Transport signing with the wallet and sending through the base transport, or through
+// http.DefaultTransport when it is nil. It panics if the wallet is nil.
+func NewTransport(w wallet.KeyOperations, base http.RoundTripper) *Transport {
+	if w == nil {
+		panic("mutual auth transport requires a wallet")
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{wallet: w, base: base, sessions: make(map[string]*session)}
+}
+
+// RoundTrip sends the request authenticated within the session with its node, see Transport.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the request body: %w", err)
+		}
+	}
+
+	origin := req.URL.Scheme + "://" + req.URL.Host
+	for attempt := 0; ; attempt++ {
+		s, err := t.session(req.Context(), origin)
+		if err != nil {
+			return nil, err
+		}
+		if s.serverKey == nil {
+			return t.base.RoundTrip(cloneRequest(req, body))
+		}
+
+		resp, requestID, err := t.send(req, body, s)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && resp.Header.Get(SignatureHeader) == "" {
+			// The node lost the session, or rejects it, before authenticating the request.
+			t.forget(origin, s)
+			if attempt == 0 {
+				_ = resp.Body.Close()
+				continue
+			}
+			return resp, nil
+		}
+		return t.verify(req.Context(), resp, requestID, s)
+	}
+}
+
+// session returns the session with the node of the origin, performing the handshake when there is none.
+func (t *Transport) session(ctx context.Context, origin string) (*session, error) {
+	t.mu.Lock()
+	s, ok := t.sessions[origin]
+	t.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	s, err := t.handshake(ctx, origin)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	t.sessions[origin] = s
+	t.mu.Unlock()
+	return s, nil
+}
+
+// forget drops the session with the node of the origin, unless it was already replaced.
+func (t *Transport) forget(origin string, s *session) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sessions[origin] == s {
+		delete(t.sessions, origin)
+	}
+}
+
+// handshake sends the initial request to the node of the origin and verifies its initial response.
+func (t *Transport) handshake(ctx context.Context, origin string) (*session, error) {
+	identity, err := t.wallet.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the identity key of the wallet: %w", err)
+	}
+	nonce, err := randomBase64()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(auth.AuthMessage{
+		Version:      auth.AUTH_VERSION,
+		MessageType:  auth.MessageTypeInitialRequest,
+		IdentityKey:  identity.PublicKey,
+		InitialNonce: nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, origin+WellKnownAuthPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return &session{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &util.HTTPError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("unexpected response status from %s", WellKnownAuthPath),
+		}
+	}
+
+	var message auth.AuthMessage
+	if err := json.NewDecoder(resp.Body).Decode(&message); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHandshake, err)
+	}
+	if message.MessageType != auth.MessageTypeInitialResponse || message.IdentityKey == nil || message.YourNonce != nonce {
+		return nil, ErrInvalidHandshake
+	}
+	nonceBytes, err := base64.StdEncoding.DecodeString(nonce)
+	if err != nil {
+		return nil, err
+	}
+	serverNonceBytes, err := base64.StdEncoding.DecodeString(message.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHandshake, err)
+	}
+	if !t.verifySignature(ctx, message.IdentityKey, nonce, message.Nonce, append(nonceBytes, serverNonceBytes...), message.Signature) {
+		return nil, ErrInvalidHandshake
+	}
+	return &session{serverKey: message.IdentityKey, serverNonce: message.Nonce, nonce: nonce}, nil
+}
+
+// send signs the request within the session and sends it, returning the response and the ID of the request.
+func (t *Transport) send(req *http.Request, body []byte, s *session) (*http.Response, []byte, error) {
+	ctx := req.Context()
+	identity, err := t.wallet.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, originator)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the identity key of the wallet: %w", err)
+	}
+	requestID := make([]byte, 32)
+	if _, err := rand.Read(requestID); err != nil {
+		return nil, nil, err
+	}
+	nonce, err := randomBase64()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signed := cloneRequest(req, body)
+	result, err := t.wallet.CreateSignature(ctx, wallet.CreateSignatureArgs{
+		EncryptionArgs: encryptionArgs(s.serverKey, nonce, s.serverNonce),
+		Data:           serializeRequest(signed, requestID, body),
+	}, originator)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign the request: %w", err)
+	}
+
+	signed.Header.Set(VersionHeader, auth.AUTH_VERSION)
+	signed.Header.Set(IdentityKeyHeader, identity.PublicKey.ToDERHex())
+	signed.Header.Set(NonceHeader, nonce)
+	signed.Header.Set(YourNonceHeader, s.serverNonce)
+	signed.Header.Set(SignatureHeader, hex.EncodeToString(result.Signature.Serialize()))
+	signed.Header.Set(RequestIDHeader, base64.StdEncoding.EncodeToString(requestID))
+
+	resp, err := t.base.RoundTrip(signed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, requestID, nil
+}
+
+// verify verifies the signature of the node over the response of the request, whose body is read and replaced.
+func (t *Transport) verify(ctx context.Context, resp *http.Response, requestID []byte, s *session) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if resp.Header.Get(SignatureHeader) == "" {
+		return nil, ErrUnsignedResponse
+	}
+	if resp.Header.Get(IdentityKeyHeader) != s.serverKey.ToDERHex() ||
+		resp.Header.Get(YourNonceHeader) != s.nonce ||
+		resp.Header.Get(RequestIDHeader) != base64.StdEncoding.EncodeToString(requestID) {
+		return nil, ErrInvalidResponseSignature
+	}
+	signature, err := hex.DecodeString(resp.Header.Get(SignatureHeader))
+	if err != nil {
+		return nil, ErrInvalidResponseSignature
+	}
+	if !t.verifySignature(ctx, s.serverKey, resp.Header.Get(NonceHeader), s.nonce, serializeResponse(resp, requestID, body), signature) {
+		return nil, ErrInvalidResponseSignature
+	}
+	return resp, nil
+}
+
+// verifySignature reports whether the signature of the node over the data is valid.
+func (t *Transport) verifySignature(ctx context.Context, server *ec.PublicKey, nonce, peerNonce string, data, signature []byte) bool {
+	sig, err := ec.ParseSignature(signature)
+	if err != nil {
+		return false
+	}
+	result, err := t.wallet.VerifySignature(ctx, wallet.VerifySignatureArgs{
+		EncryptionArgs: encryptionArgs(server, nonce, peerNonce),
+		Data:           data,
+		Signature:      sig,
+	}, originator)
+	return err == nil && result.Valid
+}
+
+// encryptionArgs returns the arguments deriving the signing key of a message exchanged with the node.
+func encryptionArgs(server *ec.PublicKey, nonce, peerNonce string) wallet.EncryptionArgs {
+	return wallet.EncryptionArgs{
+		ProtocolID: wallet.Protocol{
+			SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty,
+			Protocol:      auth.AUTH_PROTOCOL_ID,
+		},
+		KeyID:        nonce + " " + peerNonce,
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: server},
+	}
+}
+
+// cloneRequest returns a copy of the request sending the body, the request itself must not be modified.
+func cloneRequest(req *http.Request, body []byte) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	clone.ContentLength = int64(len(body))
+	clone.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	return clone
+}
+
+// serializeRequest serializes the signed parts of a request: its ID, method, path, query, the x-bsv-* (other
+// than x-bsv-auth-*), authorization and content-type headers, and its body.
+func serializeRequest(req *http.Request, requestID, body []byte) []byte {
+	w := util.NewWriter()
+	w.WriteBytes(requestID)
+	w.WriteString(req.Method)
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	w.WriteString(path)
+	if req.URL.RawQuery != "" {
+		w.WriteString("?" + req.URL.RawQuery)
+	} else {
+		w.WriteNegativeOne()
+	}
+
+	var headers [][2]string
+	for key, values := range req.Header {
+		key = strings.ToLower(key)
+		for _, value := range values {
+			switch {
+			case strings.HasPrefix(key, "x-bsv-auth"):
+			case strings.HasPrefix(key, "x-bsv-"), key == "authorization":
+				headers = append(headers, [2]string{key, value})
+			case key == "content-type":
+				headers = append(headers, [2]string{key, strings.TrimSpace(strings.Split(value, ";")[0])})
+			}
+		}
+	}
+	writeHeaders(w, headers)
+	writeOptionalBytes(w, body)
+	return w.Buf
+}
+
+// serializeResponse serializes the signed parts of the response of a request: the request ID, its status,
+// the x-bsv-* (other than x-bsv-auth-*) and authorization headers, and its body.
+func serializeResponse(resp *http.Response, requestID, body []byte) []byte {
+	w := util.NewWriter()
+	w.WriteBytes(requestID)
+	w.WriteVarInt(uint64(resp.StatusCode))
+
+	var headers [][2]string
+	for key, values := range resp.Header {
+		key = strings.ToLower(key)
+		if (strings.HasPrefix(key, "x-bsv-") && !strings.HasPrefix(key, "x-bsv-auth")) || key == "authorization" {
+			for _, value := range values {
+				headers = append(headers, [2]string{key, value})
+			}
+		}
+	}
+	writeHeaders(w, headers)
+	writeOptionalBytes(w, body)
+	return w.Buf
+}
+
+// writeHeaders writes the headers sorted by key, so both peers serialize them in the same order.
+func writeHeaders(w *util.Writer, headers [][2]string) {
+	sort.SliceStable(headers, func(i, j int) bool { return headers[i][0] < headers[j][0] })
+	w.WriteVarInt(uint64(len(headers)))
+	for _, header := range headers {
+		w.WriteString(header[0])
+		w.WriteString(header[1])
+	}
+}
+
+// writeOptionalBytes writes the length of the bytes followed by the bytes, or -1 when they are empty.
+func writeOptionalBytes(w *util.Writer, b []byte) {
+	if len(b) == 0 {
+		w.WriteNegativeOne()
+		return
+	}
+	w.WriteVarInt(uint64(len(b)))
+	w.WriteBytes(b)
+}
+
+// randomBase64 returns 32 random bytes encoded in base64.
+func randomBase64() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
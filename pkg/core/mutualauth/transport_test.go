@@ -0,0 +1,86 @@
+package mutualauth_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/mutualauth"
+	"github.com/bsv-blockchain/go-sdk/auth"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_ShouldSendPlainRequests_WhenNodeDoesNotSupportMutualAuth(t *testing.T) {
+	// given:
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == mutualauth.WellKnownAuthPath {
+			http.NotFound(w, r)
+			return
+		}
+		headers = r.Header.Clone()
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: mutualauth.NewTransport(newWallet(t), nil)}
+
+	// when:
+	res, err := client.Post(server.URL+"/api/v1/submit", "application/octet-stream", strings.NewReader("beef"))
+
+	// then:
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "beef", string(body))
+	require.Empty(t, headers.Get(mutualauth.SignatureHeader))
+}
+
+func TestTransport_ShouldFail_WhenInitialResponseIsNotSignedByNode(t *testing.T) {
+	// given:
+	other, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	var requested bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != mutualauth.WellKnownAuthPath {
+			requested = true
+			return
+		}
+		var message auth.AuthMessage
+		_ = json.NewDecoder(r.Body).Decode(&message)
+		_ = json.NewEncoder(w).Encode(auth.AuthMessage{
+			Version:      auth.AUTH_VERSION,
+			MessageType:  auth.MessageTypeInitialResponse,
+			IdentityKey:  other.PubKey(),
+			Nonce:        message.InitialNonce,
+			InitialNonce: message.InitialNonce,
+			YourNonce:    message.InitialNonce,
+			Signature:    []byte("invalid"),
+		})
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: mutualauth.NewTransport(newWallet(t), nil)}
+
+	// when:
+	_, err = client.Post(server.URL+"/api/v1/submit", "application/octet-stream", strings.NewReader("beef"))
+
+	// then:
+	require.ErrorIs(t, err, mutualauth.ErrInvalidHandshake)
+	require.False(t, requested, "The request was sent to a node failing the handshake")
+}
+
+func newWallet(t *testing.T) *wallet.ProtoWallet {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	w, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypePrivateKey, PrivateKey: key})
+	require.NoError(t, err)
+	return w
+}
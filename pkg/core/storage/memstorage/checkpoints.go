@@ -0,0 +1,80 @@
+package memstorage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// checkpointKey identifies the sync checkpoint of a topic with a peer.
+type checkpointKey struct {
+	topic string
+	peer  string
+}
+
+// FindSyncCheckpoint returns the checkpoint of the topic and peer.
+// It returns a nil checkpoint and a nil error when there is none.
+func (s *Storage) FindSyncCheckpoint(ctx context.Context, topic, peer string) (*engine.SyncCheckpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	checkpoint, ok := s.checkpoints[checkpointKey{topic: topic, peer: peer}]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+// FindSyncCheckpoints returns the checkpoints of the topic ordered by topic and peer.
+// An empty topic matches every topic.
+func (s *Storage) FindSyncCheckpoints(ctx context.Context, topic string) ([]*engine.SyncCheckpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sortedCheckpoints(topic), nil
+}
+
+// UpsertSyncCheckpoint stores the checkpoint, replacing the checkpoint of the same topic and peer.
+func (s *Storage) UpsertSyncCheckpoint(ctx context.Context, checkpoint *engine.SyncCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[checkpointKey{topic: checkpoint.Topic, peer: checkpoint.Peer}] = *checkpoint
+	return nil
+}
+
+// DeleteSyncCheckpoints removes the checkpoints matching the topic and peer and returns
+// how many were removed. An empty topic or peer matches every topic or peer.
+func (s *Storage) DeleteSyncCheckpoints(ctx context.Context, topic, peer string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key := range s.checkpoints {
+		if (topic == "" || key.topic == topic) && (peer == "" || key.peer == peer) {
+			delete(s.checkpoints, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// sortedCheckpoints returns copies of the checkpoints of the topic, or of every topic when the
+// topic is empty, ordered by topic and peer. The caller must hold at least the read lock.
+func (s *Storage) sortedCheckpoints(topic string) []*engine.SyncCheckpoint {
+	checkpoints := make([]*engine.SyncCheckpoint, 0, len(s.checkpoints))
+	for key, checkpoint := range s.checkpoints {
+		if topic == "" || key.topic == topic {
+			checkpoints = append(checkpoints, &checkpoint)
+		}
+	}
+	sort.Slice(checkpoints, func(i, j int) bool {
+		if checkpoints[i].Topic != checkpoints[j].Topic {
+			return checkpoints[i].Topic < checkpoints[j].Topic
+		}
+		return checkpoints[i].Peer < checkpoints[j].Peer
+	})
+	return checkpoints
+}
+
+var _ engine.SyncCheckpointStorage = (*Storage)(nil)
@@ -0,0 +1,105 @@
+package memstorage
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// conflictKey identifies the conflict of a competing transaction over an output of a topic.
+type conflictKey struct {
+	topic         string
+	outpoint      transaction.Outpoint
+	competingTxid chainhash.Hash
+}
+
+func newConflictKey(topic string, outpoint transaction.Outpoint, competingTxid chainhash.Hash) conflictKey {
+	return conflictKey{topic: topic, outpoint: outpoint, competingTxid: competingTxid}
+}
+
+// InsertConflict stores the conflict. Inserting a conflict already recorded for the same topic,
+// outpoint and competing transaction is a no-op.
+func (s *Storage) InsertConflict(ctx context.Context, conflict *engine.Conflict) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := newConflictKey(conflict.Topic, conflict.Outpoint, conflict.CompetingTxid)
+	if _, ok := s.conflicts[key]; ok {
+		return nil
+	}
+	stored := *conflict
+	stored.CompetingBeef = cloneBytes(conflict.CompetingBeef)
+	s.conflicts[key] = stored
+	return nil
+}
+
+// FindConflicts returns the conflicts of the topic ordered by topic, outpoint and competing
+// transaction. An empty topic matches every topic.
+func (s *Storage) FindConflicts(ctx context.Context, topic string) ([]*engine.Conflict, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sortedConflicts(topic), nil
+}
+
+// FindConflictsForTransaction returns the conflicts in which the transaction is either the
+// admitted or the competing one.
+func (s *Storage) FindConflictsForTransaction(ctx context.Context, txid *chainhash.Hash) ([]*engine.Conflict, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conflicts := make([]*engine.Conflict, 0)
+	for _, conflict := range s.sortedConflicts("") {
+		if conflict.AdmittedTxid.Equal(*txid) || conflict.CompetingTxid.Equal(*txid) {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts, nil
+}
+
+// DeleteConflicts removes the conflicts over the output of the topic and returns how many were removed.
+func (s *Storage) DeleteConflicts(ctx context.Context, topic string, outpoint *transaction.Outpoint) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key := range s.conflicts {
+		if key.topic == topic && key.outpoint == *outpoint {
+			delete(s.conflicts, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// sortedConflicts returns copies of the conflicts of the topic, or of every topic when the topic
+// is empty, ordered by topic, outpoint and competing transaction. The caller must hold at least
+// the read lock.
+func (s *Storage) sortedConflicts(topic string) []*engine.Conflict {
+	conflicts := make([]*engine.Conflict, 0, len(s.conflicts))
+	for key, conflict := range s.conflicts {
+		if topic == "" || key.topic == topic {
+			conflict.CompetingBeef = cloneBytes(conflict.CompetingBeef)
+			conflicts = append(conflicts, &conflict)
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		a, b := conflicts[i], conflicts[j]
+		if a.Topic != b.Topic {
+			return a.Topic < b.Topic
+		}
+		if c := strings.Compare(a.Outpoint.Txid.String(), b.Outpoint.Txid.String()); c != 0 {
+			return c < 0
+		}
+		if a.Outpoint.Index != b.Outpoint.Index {
+			return a.Outpoint.Index < b.Outpoint.Index
+		}
+		return a.CompetingTxid.String() < b.CompetingTxid.String()
+	})
+	return conflicts
+}
+
+var _ engine.ConflictStorage = (*Storage)(nil)
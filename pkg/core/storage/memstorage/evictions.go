@@ -0,0 +1,61 @@
+package memstorage
+
+import (
+	"context"
+	"slices"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// InsertEviction appends the eviction to the audit log.
+func (s *Storage) InsertEviction(ctx context.Context, eviction *engine.Eviction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictions = append(s.evictions, cloneEviction(eviction))
+	return nil
+}
+
+// InsertEviction appends the eviction to the audit log as part of the transaction, it is dropped on Rollback.
+func (t *Tx) InsertEviction(ctx context.Context, eviction *engine.Eviction) error {
+	if err := t.journal.check(); err != nil {
+		return err
+	}
+
+	recorded := len(t.s.evictions)
+	t.journal.undo = append(t.journal.undo, func() { t.s.evictions = t.s.evictions[:recorded] })
+	t.s.evictions = append(t.s.evictions, cloneEviction(eviction))
+	return nil
+}
+
+// FindEvictions returns the audit log of the evictions ordered by the time they were recorded.
+func (s *Storage) FindEvictions(ctx context.Context) ([]*engine.Eviction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sortedEvictions(), nil
+}
+
+// sortedEvictions returns copies of the evictions ordered by the time they were recorded, keeping
+// the insertion order of the evictions recorded at the same time. The caller must hold at least
+// the read lock.
+func (s *Storage) sortedEvictions() []*engine.Eviction {
+	evictions := make([]*engine.Eviction, 0, len(s.evictions))
+	for _, eviction := range s.evictions {
+		evictions = append(evictions, cloneEviction(eviction))
+	}
+	slices.SortStableFunc(evictions, func(a, b *engine.Eviction) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return evictions
+}
+
+func cloneEviction(eviction *engine.Eviction) *engine.Eviction {
+	clone := *eviction
+	clone.Evicted = slices.Clone(eviction.Evicted)
+	return &clone
+}
+
+var (
+	_ engine.EvictionAuditStorage = (*Storage)(nil)
+	_ engine.EvictionTransaction  = (*Tx)(nil)
+)
@@ -0,0 +1,250 @@
+package memstorage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ops implements the storage operations on the state of the storage. It does not lock,
+// the caller must hold the appropriate lock. When a journal is set, every mutation
+// records how to undo itself.
+type ops struct {
+	s       *Storage
+	journal *journal
+}
+
+func (o ops) InsertOutput(ctx context.Context, utxo *engine.Output) error {
+	if err := o.journal.check(); err != nil {
+		return err
+	}
+
+	key := outputKey{outpoint: utxo.Outpoint, topic: utxo.Topic}
+	if _, ok := o.s.outputs[key]; ok {
+		return nil
+	}
+	if _, ok := o.s.beefs[utxo.Outpoint.Txid]; !ok && len(utxo.Beef) > 0 {
+		o.journal.saveBeef(o.s, utxo.Outpoint.Txid)
+		o.s.beefs[utxo.Outpoint.Txid] = cloneBytes(utxo.Beef)
+	}
+
+	output := cloneOutput(utxo)
+	output.Beef = nil
+	if !output.SpentAt.IsZero() {
+		output.SpentAt = time.Unix(output.SpentAt.Unix(), 0)
+	}
+	o.journal.saveRecord(o.s, key)
+	o.s.insert(key, &record{output: *output, createdAt: o.s.now().Unix()})
+	return nil
+}
+
+func (o ops) FindOutput(ctx context.Context, outpoint *transaction.Outpoint, topic *string, spent *bool, includeBEEF bool) (*engine.Output, error) {
+	if err := o.journal.check(); err != nil {
+		return nil, err
+	}
+
+	if topic != nil {
+		rec, ok := o.s.outputs[outputKey{outpoint: *outpoint, topic: *topic}]
+		if !ok || !matchesSpent(rec, spent) {
+			return nil, nil
+		}
+		return o.s.export(rec, includeBEEF), nil
+	}
+
+	for _, key := range o.s.sortedKeys(o.s.byTxid[outpoint.Txid]) {
+		rec := o.s.outputs[key]
+		if key.outpoint.Index == outpoint.Index && matchesSpent(rec, spent) {
+			return o.s.export(rec, includeBEEF), nil
+		}
+	}
+	return nil, nil
+}
+
+func (o ops) FindOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spent *bool, includeBEEF bool) ([]*engine.Output, error) {
+	if err := o.journal.check(); err != nil {
+		return nil, err
+	}
+
+	outputs := make([]*engine.Output, len(outpoints))
+	for i, outpoint := range outpoints {
+		rec, ok := o.s.outputs[outputKey{outpoint: *outpoint, topic: topic}]
+		if ok && matchesSpent(rec, spent) {
+			outputs[i] = o.s.export(rec, includeBEEF)
+		}
+	}
+	return outputs, nil
+}
+
+func (o ops) FindOutputsForTransaction(ctx context.Context, txid *chainhash.Hash, includeBEEF bool) ([]*engine.Output, error) {
+	if err := o.journal.check(); err != nil {
+		return nil, err
+	}
+
+	var outputs []*engine.Output
+	for _, key := range o.s.sortedKeys(o.s.byTxid[*txid]) {
+		outputs = append(outputs, o.s.export(o.s.outputs[key], includeBEEF))
+	}
+	return outputs, nil
+}
+
+func (o ops) FindUTXOsForTopic(ctx context.Context, topic string, since uint32, includeBEEF bool) ([]*engine.Output, error) {
+	if err := o.journal.check(); err != nil {
+		return nil, err
+	}
+
+	var outputs []*engine.Output
+	for _, key := range o.s.sortedKeys(o.s.byTopic[topic]) {
+		rec := o.s.outputs[key]
+		if rec.output.Spent || rec.createdAt < int64(since) {
+			continue
+		}
+		outputs = append(outputs, o.s.export(rec, includeBEEF))
+	}
+	return outputs, nil
+}
+
+func (o ops) FindSpentOutputsForTopic(ctx context.Context, topic string, includeBEEF bool) ([]*engine.Output, error) {
+	if err := o.journal.check(); err != nil {
+		return nil, err
+	}
+
+	var outputs []*engine.Output
+	for _, key := range o.s.sortedKeys(o.s.byTopic[topic]) {
+		rec := o.s.outputs[key]
+		if !rec.output.Spent {
+			continue
+		}
+		outputs = append(outputs, o.s.export(rec, includeBEEF))
+	}
+	return outputs, nil
+}
+
+func (o ops) FindOutputsSinceBlockHeight(ctx context.Context, blockHeight uint32, includeBEEF bool) ([]*engine.Output, error) {
+	if err := o.journal.check(); err != nil {
+		return nil, err
+	}
+
+	var outputs []*engine.Output
+	for _, key := range o.s.sortedKeys(keySet(o.s.outputs)) {
+		rec := o.s.outputs[key]
+		if rec.output.BlockHeight == 0 || rec.output.BlockHeight < blockHeight {
+			continue
+		}
+		outputs = append(outputs, o.s.export(rec, includeBEEF))
+	}
+	sort.SliceStable(outputs, func(i, j int) bool {
+		return outputs[i].BlockHeight < outputs[j].BlockHeight
+	})
+	return outputs, nil
+}
+
+func (o ops) DeleteOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	if err := o.journal.check(); err != nil {
+		return err
+	}
+
+	key := outputKey{outpoint: *outpoint, topic: topic}
+	if _, ok := o.s.outputs[key]; !ok {
+		return nil
+	}
+
+	o.journal.saveRecord(o.s, key)
+	o.s.remove(key)
+	if _, ok := o.s.byTxid[outpoint.Txid]; !ok {
+		o.journal.saveBeef(o.s, outpoint.Txid)
+		delete(o.s.beefs, outpoint.Txid)
+	}
+	return nil
+}
+
+func (o ops) MarkUTXOsAsSpent(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spendTxid *chainhash.Hash) error {
+	if err := o.journal.check(); err != nil {
+		return err
+	}
+
+	for _, outpoint := range outpoints {
+		key := outputKey{outpoint: *outpoint, topic: topic}
+		rec, ok := o.s.outputs[key]
+		if !ok {
+			continue
+		}
+		o.journal.saveRecord(o.s, key)
+		rec.output.Spent = true
+		rec.output.SpendingTxid = cloneHash(spendTxid)
+		rec.output.SpentAt = time.Unix(o.s.now().Unix(), 0)
+	}
+	return nil
+}
+
+func (o ops) UpdateConsumedBy(ctx context.Context, outpoint *transaction.Outpoint, topic string, consumedBy []*transaction.Outpoint) error {
+	if err := o.journal.check(); err != nil {
+		return err
+	}
+
+	key := outputKey{outpoint: *outpoint, topic: topic}
+	rec, ok := o.s.outputs[key]
+	if !ok {
+		return engine.ErrNotFound
+	}
+	o.journal.saveRecord(o.s, key)
+	rec.output.ConsumedBy = cloneOutpoints(consumedBy)
+	return nil
+}
+
+func (o ops) UpdateTransactionBEEF(ctx context.Context, txid *chainhash.Hash, beef []byte) error {
+	if err := o.journal.check(); err != nil {
+		return err
+	}
+
+	if _, ok := o.s.byTxid[*txid]; !ok {
+		return engine.ErrNotFound
+	}
+	o.journal.saveBeef(o.s, *txid)
+	o.s.beefs[*txid] = cloneBytes(beef)
+	return nil
+}
+
+func (o ops) UpdateOutputBlockHeight(ctx context.Context, outpoint *transaction.Outpoint, topic string, blockHeight uint32, blockIndex uint64, ancillaryBeef []byte) error {
+	if err := o.journal.check(); err != nil {
+		return err
+	}
+
+	key := outputKey{outpoint: *outpoint, topic: topic}
+	rec, ok := o.s.outputs[key]
+	if !ok {
+		return engine.ErrNotFound
+	}
+	o.journal.saveRecord(o.s, key)
+	rec.output.BlockHeight = blockHeight
+	rec.output.BlockIdx = blockIndex
+	rec.output.AncillaryBeef = cloneBytes(ancillaryBeef)
+	return nil
+}
+
+func (o ops) InsertAppliedTransaction(ctx context.Context, tx *overlay.AppliedTransaction) error {
+	if err := o.journal.check(); err != nil {
+		return err
+	}
+
+	key := appliedKey{txid: *tx.Txid, topic: tx.Topic}
+	if _, ok := o.s.applied[key]; ok {
+		return nil
+	}
+	o.journal.saveApplied(o.s, key)
+	o.s.applied[key] = struct{}{}
+	return nil
+}
+
+func (o ops) DoesAppliedTransactionExist(ctx context.Context, tx *overlay.AppliedTransaction) (bool, error) {
+	if err := o.journal.check(); err != nil {
+		return false, err
+	}
+
+	_, ok := o.s.applied[appliedKey{txid: *tx.Txid, topic: tx.Topic}]
+	return ok, nil
+}
@@ -0,0 +1,117 @@
+package memstorage
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// InsertOutboxEntries queues the entries.
+func (s *Storage) InsertOutboxEntries(ctx context.Context, entries []*engine.OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range entries {
+		s.outbox = append(s.outbox, cloneOutboxEntry(entry))
+	}
+	return nil
+}
+
+// InsertOutboxEntries queues the entries as part of the transaction, they are dropped on Rollback.
+func (t *Tx) InsertOutboxEntries(ctx context.Context, entries []*engine.OutboxEntry) error {
+	if err := t.journal.check(); err != nil {
+		return err
+	}
+
+	queued := len(t.s.outbox)
+	t.journal.undo = append(t.journal.undo, func() { t.s.outbox = t.s.outbox[:queued] })
+	for _, entry := range entries {
+		t.s.outbox = append(t.s.outbox, cloneOutboxEntry(entry))
+	}
+	return nil
+}
+
+// UpdateOutboxEntry records the outcome of an attempt of the entry. Updating an entry
+// that is not stored is a no-op.
+func (s *Storage) UpdateOutboxEntry(ctx context.Context, entry *engine.OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.outbox {
+		if stored.ID != entry.ID {
+			continue
+		}
+		stored.Status = entry.Status
+		stored.Attempts = entry.Attempts
+		stored.NextAttemptAt = entry.NextAttemptAt
+		stored.LastError = entry.LastError
+		stored.UpdatedAt = entry.UpdatedAt
+		return nil
+	}
+	return nil
+}
+
+// FindDueOutboxEntries returns at most limit pending entries whose next attempt is not after
+// the given time, ordered by their next attempt.
+func (s *Storage) FindDueOutboxEntries(ctx context.Context, before time.Time, limit int) ([]*engine.OutboxEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	due := make([]*engine.OutboxEntry, 0)
+	for _, entry := range s.sortedOutboxEntries() {
+		if entry.Status == engine.OutboxPending && !entry.NextAttemptAt.After(before) {
+			due = append(due, entry)
+		}
+	}
+	slices.SortStableFunc(due, func(a, b *engine.OutboxEntry) int {
+		return a.NextAttemptAt.Compare(b.NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// FindOutboxEntries returns the entries of the transaction with the status ordered by the time
+// they were queued. A nil txid or an empty status matches every entry.
+func (s *Storage) FindOutboxEntries(ctx context.Context, txid *chainhash.Hash, status engine.OutboxStatus) ([]*engine.OutboxEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]*engine.OutboxEntry, 0)
+	for _, entry := range s.sortedOutboxEntries() {
+		if (txid == nil || entry.Txid == *txid) && (status == "" || entry.Status == status) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// sortedOutboxEntries returns copies of the outbox entries ordered by the time they were queued,
+// keeping the insertion order of the entries queued at the same time. The caller must hold at
+// least the read lock.
+func (s *Storage) sortedOutboxEntries() []*engine.OutboxEntry {
+	entries := make([]*engine.OutboxEntry, 0, len(s.outbox))
+	for _, entry := range s.outbox {
+		entries = append(entries, cloneOutboxEntry(entry))
+	}
+	slices.SortStableFunc(entries, func(a, b *engine.OutboxEntry) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return entries
+}
+
+func cloneOutboxEntry(entry *engine.OutboxEntry) *engine.OutboxEntry {
+	clone := *entry
+	clone.Topics = slices.Clone(entry.Topics)
+	clone.Beef = cloneBytes(entry.Beef)
+	return &clone
+}
+
+var (
+	_ engine.OutboxStorage     = (*Storage)(nil)
+	_ engine.OutboxTransaction = (*Tx)(nil)
+)
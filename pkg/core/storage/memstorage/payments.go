@@ -0,0 +1,48 @@
+package memstorage
+
+import (
+	"context"
+	"slices"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// InsertPaymentReceipt stores the receipt. It returns engine.ErrPaymentAlreadyRedeemed when
+// a receipt of the same transaction is already stored.
+func (s *Storage) InsertPaymentReceipt(ctx context.Context, receipt *engine.PaymentReceipt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.payments {
+		if stored.Txid == receipt.Txid {
+			return engine.ErrPaymentAlreadyRedeemed
+		}
+	}
+	clone := *receipt
+	s.payments = append(s.payments, &clone)
+	return nil
+}
+
+// FindPaymentReceipts returns the stored receipts ordered by the time the payments were accepted.
+func (s *Storage) FindPaymentReceipts(ctx context.Context) ([]*engine.PaymentReceipt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sortedPaymentReceipts(), nil
+}
+
+// sortedPaymentReceipts returns copies of the receipts ordered by the time the payments were accepted,
+// keeping the insertion order of the receipts recorded at the same time. The caller must hold at least
+// the read lock.
+func (s *Storage) sortedPaymentReceipts() []*engine.PaymentReceipt {
+	receipts := make([]*engine.PaymentReceipt, 0, len(s.payments))
+	for _, receipt := range s.payments {
+		clone := *receipt
+		receipts = append(receipts, &clone)
+	}
+	slices.SortStableFunc(receipts, func(a, b *engine.PaymentReceipt) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return receipts
+}
+
+var _ engine.PaymentReceiptStorage = (*Storage)(nil)
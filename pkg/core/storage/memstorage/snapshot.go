@@ -0,0 +1,374 @@
+package memstorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// snapshotVersion is the version of the snapshot format produced by Snapshot.
+const snapshotVersion = 1
+
+type snapshot struct {
+	Version      int                   `json:"version"`
+	Outputs      []snapshotOutput      `json:"outputs"`
+	Transactions []snapshotTransaction `json:"transactions"`
+	Applied      []snapshotApplied     `json:"applied"`
+	Checkpoints  []snapshotCheckpoint  `json:"checkpoints,omitempty"`
+	Conflicts    []snapshotConflict    `json:"conflicts,omitempty"`
+	Evictions    []snapshotEviction    `json:"evictions,omitempty"`
+	Webhooks     []snapshotWebhook     `json:"webhooks,omitempty"`
+	Deliveries   []snapshotDelivery    `json:"webhookDeliveries,omitempty"`
+	Outbox       []snapshotOutboxEntry `json:"outbox,omitempty"`
+	Payments     []snapshotPayment     `json:"paymentReceipts,omitempty"`
+}
+
+type snapshotOutput struct {
+	Outpoint        transaction.Outpoint    `json:"outpoint"`
+	Topic           string                  `json:"topic"`
+	Script          []byte                  `json:"script,omitempty"`
+	Satoshis        uint64                  `json:"satoshis"`
+	Spent           bool                    `json:"spent"`
+	SpendingTxid    *chainhash.Hash         `json:"spendingTxid,omitempty"`
+	SpentAt         int64                   `json:"spentAt,omitempty"`
+	OutputsConsumed []*transaction.Outpoint `json:"outputsConsumed,omitempty"`
+	ConsumedBy      []*transaction.Outpoint `json:"consumedBy,omitempty"`
+	BlockHeight     uint32                  `json:"blockHeight"`
+	BlockIdx        uint64                  `json:"blockIdx"`
+	AncillaryTxids  []*chainhash.Hash       `json:"ancillaryTxids,omitempty"`
+	AncillaryBeef   []byte                  `json:"ancillaryBeef,omitempty"`
+	CreatedAt       int64                   `json:"createdAt"`
+}
+
+type snapshotTransaction struct {
+	Txid chainhash.Hash `json:"txid"`
+	Beef []byte         `json:"beef"`
+}
+
+type snapshotApplied struct {
+	Txid  chainhash.Hash `json:"txid"`
+	Topic string         `json:"topic"`
+}
+
+type snapshotCheckpoint struct {
+	Topic     string    `json:"topic"`
+	Peer      string    `json:"peer"`
+	Since     uint32    `json:"since"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type snapshotConflict struct {
+	Topic         string               `json:"topic"`
+	Outpoint      transaction.Outpoint `json:"outpoint"`
+	AdmittedTxid  chainhash.Hash       `json:"admittedTxid"`
+	CompetingTxid chainhash.Hash       `json:"competingTxid"`
+	CompetingBeef []byte               `json:"competingBeef"`
+	CreatedAt     time.Time            `json:"createdAt"`
+}
+
+type snapshotEviction struct {
+	Outpoint       transaction.Outpoint   `json:"outpoint"`
+	IncludeHistory bool                   `json:"includeHistory"`
+	Evicted        []engine.EvictedOutput `json:"evicted"`
+	Actor          string                 `json:"actor"`
+	Reason         string                 `json:"reason"`
+	CreatedAt      time.Time              `json:"createdAt"`
+}
+
+type snapshotWebhook struct {
+	ID        string    `json:"id"`
+	Topic     string    `json:"topic"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type snapshotDelivery struct {
+	ID             string                       `json:"id"`
+	WebhookID      string                       `json:"webhookId"`
+	Topic          string                       `json:"topic"`
+	EventType      engine.EventType             `json:"eventType"`
+	Payload        []byte                       `json:"payload"`
+	Status         engine.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	NextAttemptAt  time.Time                    `json:"nextAttemptAt"`
+	LastStatusCode int                          `json:"lastStatusCode,omitempty"`
+	LastError      string                       `json:"lastError,omitempty"`
+	CreatedAt      time.Time                    `json:"createdAt"`
+	UpdatedAt      time.Time                    `json:"updatedAt"`
+}
+
+type snapshotOutboxEntry struct {
+	ID            string              `json:"id"`
+	Kind          engine.OutboxKind   `json:"kind"`
+	Txid          chainhash.Hash      `json:"txid"`
+	Topics        []string            `json:"topics,omitempty"`
+	Beef          []byte              `json:"beef"`
+	Status        engine.OutboxStatus `json:"status"`
+	Attempts      int                 `json:"attempts"`
+	NextAttemptAt time.Time           `json:"nextAttemptAt"`
+	LastError     string              `json:"lastError,omitempty"`
+	CreatedAt     time.Time           `json:"createdAt"`
+	UpdatedAt     time.Time           `json:"updatedAt"`
+}
+
+type snapshotPayment struct {
+	Txid             chainhash.Hash `json:"txid"`
+	Satoshis         uint64         `json:"satoshis"`
+	Route            string         `json:"route"`
+	Payer            string         `json:"payer"`
+	DerivationPrefix string         `json:"derivationPrefix"`
+	DerivationSuffix string         `json:"derivationSuffix"`
+	CreatedAt        time.Time      `json:"createdAt"`
+}
+
+// Snapshot writes the complete content of the storage to w as JSON.
+// The snapshot is consistent: no write is applied while it is being taken.
+func (s *Storage) Snapshot(w io.Writer) error {
+	s.mu.RLock()
+	snap := snapshot{Version: snapshotVersion}
+	for _, key := range s.sortedKeys(keySet(s.outputs)) {
+		rec := s.outputs[key]
+		out := snapshotOutput{
+			Outpoint:        rec.output.Outpoint,
+			Topic:           rec.output.Topic,
+			Satoshis:        rec.output.Satoshis,
+			Spent:           rec.output.Spent,
+			SpendingTxid:    cloneHash(rec.output.SpendingTxid),
+			OutputsConsumed: cloneOutpoints(rec.output.OutputsConsumed),
+			ConsumedBy:      cloneOutpoints(rec.output.ConsumedBy),
+			BlockHeight:     rec.output.BlockHeight,
+			BlockIdx:        rec.output.BlockIdx,
+			AncillaryTxids:  cloneHashes(rec.output.AncillaryTxids),
+			AncillaryBeef:   cloneBytes(rec.output.AncillaryBeef),
+			CreatedAt:       rec.createdAt,
+		}
+		if !rec.output.SpentAt.IsZero() {
+			out.SpentAt = rec.output.SpentAt.Unix()
+		}
+		if rec.output.Script != nil {
+			out.Script = cloneBytes(*rec.output.Script)
+		}
+		snap.Outputs = append(snap.Outputs, out)
+	}
+	for txid, beef := range s.beefs {
+		snap.Transactions = append(snap.Transactions, snapshotTransaction{Txid: txid, Beef: cloneBytes(beef)})
+	}
+	for key := range s.applied {
+		snap.Applied = append(snap.Applied, snapshotApplied{Txid: key.txid, Topic: key.topic})
+	}
+	for _, checkpoint := range s.sortedCheckpoints("") {
+		snap.Checkpoints = append(snap.Checkpoints, snapshotCheckpoint{
+			Topic:     checkpoint.Topic,
+			Peer:      checkpoint.Peer,
+			Since:     checkpoint.Since,
+			UpdatedAt: checkpoint.UpdatedAt,
+		})
+	}
+	for _, conflict := range s.sortedConflicts("") {
+		snap.Conflicts = append(snap.Conflicts, snapshotConflict{
+			Topic:         conflict.Topic,
+			Outpoint:      conflict.Outpoint,
+			AdmittedTxid:  conflict.AdmittedTxid,
+			CompetingTxid: conflict.CompetingTxid,
+			CompetingBeef: conflict.CompetingBeef,
+			CreatedAt:     conflict.CreatedAt,
+		})
+	}
+	for _, eviction := range s.sortedEvictions() {
+		snap.Evictions = append(snap.Evictions, snapshotEviction{
+			Outpoint:       eviction.Outpoint,
+			IncludeHistory: eviction.IncludeHistory,
+			Evicted:        eviction.Evicted,
+			Actor:          eviction.Actor,
+			Reason:         eviction.Reason,
+			CreatedAt:      eviction.CreatedAt,
+		})
+	}
+	for _, webhook := range s.sortedWebhooks() {
+		snap.Webhooks = append(snap.Webhooks, snapshotWebhook(*webhook))
+	}
+	for _, delivery := range s.sortedWebhookDeliveries() {
+		snap.Deliveries = append(snap.Deliveries, snapshotDelivery(*delivery))
+	}
+	for _, entry := range s.sortedOutboxEntries() {
+		snap.Outbox = append(snap.Outbox, snapshotOutboxEntry(*entry))
+	}
+	for _, receipt := range s.sortedPaymentReceipts() {
+		snap.Payments = append(snap.Payments, snapshotPayment(*receipt))
+	}
+	s.mu.RUnlock()
+
+	if err := json.NewEncoder(w).Encode(&snap); err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return nil
+}
+
+// Restore replaces the complete content of the storage with the snapshot read from r.
+// The storage is left untouched when the snapshot cannot be decoded.
+func (s *Storage) Restore(r io.Reader) error {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version: %d", snap.Version)
+	}
+
+	restored := New()
+	for _, out := range snap.Outputs {
+		output := engine.Output{
+			Outpoint:        out.Outpoint,
+			Topic:           out.Topic,
+			Satoshis:        out.Satoshis,
+			Spent:           out.Spent,
+			SpendingTxid:    out.SpendingTxid,
+			OutputsConsumed: out.OutputsConsumed,
+			ConsumedBy:      out.ConsumedBy,
+			BlockHeight:     out.BlockHeight,
+			BlockIdx:        out.BlockIdx,
+			AncillaryTxids:  out.AncillaryTxids,
+			AncillaryBeef:   out.AncillaryBeef,
+		}
+		if out.SpentAt != 0 {
+			output.SpentAt = time.Unix(out.SpentAt, 0)
+		}
+		if out.Script != nil {
+			lockingScript := script.Script(out.Script)
+			output.Script = &lockingScript
+		}
+		key := outputKey{outpoint: out.Outpoint, topic: out.Topic}
+		restored.insert(key, &record{output: output, createdAt: out.CreatedAt})
+	}
+	for _, tx := range snap.Transactions {
+		restored.beefs[tx.Txid] = tx.Beef
+	}
+	for _, applied := range snap.Applied {
+		restored.applied[appliedKey{txid: applied.Txid, topic: applied.Topic}] = struct{}{}
+	}
+	for _, checkpoint := range snap.Checkpoints {
+		restored.checkpoints[checkpointKey{topic: checkpoint.Topic, peer: checkpoint.Peer}] = engine.SyncCheckpoint{
+			Topic:     checkpoint.Topic,
+			Peer:      checkpoint.Peer,
+			Since:     checkpoint.Since,
+			UpdatedAt: checkpoint.UpdatedAt,
+		}
+	}
+	for _, conflict := range snap.Conflicts {
+		restored.conflicts[newConflictKey(conflict.Topic, conflict.Outpoint, conflict.CompetingTxid)] = engine.Conflict{
+			Topic:         conflict.Topic,
+			Outpoint:      conflict.Outpoint,
+			AdmittedTxid:  conflict.AdmittedTxid,
+			CompetingTxid: conflict.CompetingTxid,
+			CompetingBeef: conflict.CompetingBeef,
+			CreatedAt:     conflict.CreatedAt,
+		}
+	}
+	for _, eviction := range snap.Evictions {
+		restored.evictions = append(restored.evictions, &engine.Eviction{
+			Outpoint:       eviction.Outpoint,
+			IncludeHistory: eviction.IncludeHistory,
+			Evicted:        eviction.Evicted,
+			Actor:          eviction.Actor,
+			Reason:         eviction.Reason,
+			CreatedAt:      eviction.CreatedAt,
+		})
+	}
+	for _, webhook := range snap.Webhooks {
+		stored := engine.Webhook(webhook)
+		restored.webhooks = append(restored.webhooks, &stored)
+	}
+	for _, delivery := range snap.Deliveries {
+		stored := engine.WebhookDelivery(delivery)
+		restored.deliveries = append(restored.deliveries, &stored)
+	}
+	for _, entry := range snap.Outbox {
+		stored := engine.OutboxEntry(entry)
+		restored.outbox = append(restored.outbox, &stored)
+	}
+	for _, receipt := range snap.Payments {
+		stored := engine.PaymentReceipt(receipt)
+		restored.payments = append(restored.payments, &stored)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outputs = restored.outputs
+	s.byTopic = restored.byTopic
+	s.byTxid = restored.byTxid
+	s.beefs = restored.beefs
+	s.applied = restored.applied
+	s.checkpoints = restored.checkpoints
+	s.conflicts = restored.conflicts
+	s.evictions = restored.evictions
+	s.webhooks = restored.webhooks
+	s.deliveries = restored.deliveries
+	s.outbox = restored.outbox
+	s.payments = restored.payments
+	return nil
+}
+
+// SaveSnapshot writes the snapshot of the storage to the file at path. The file is
+// written to a temporary location first and renamed, so an existing snapshot is never
+// left half-written.
+func (s *Storage) SaveSnapshot(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := s.Snapshot(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save snapshot file: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot restores the storage from the snapshot file at path.
+func (s *Storage) LoadSnapshot(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return s.Restore(f)
+}
+
+// NewFromSnapshot creates a storage restored from the snapshot file at path.
+// When the file does not exist, an empty storage is returned.
+func NewFromSnapshot(path string) (*Storage, error) {
+	s := New()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return s, nil
+	}
+	if err := s.LoadSnapshot(path); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func keySet(outputs map[outputKey]*record) map[outputKey]struct{} {
+	set := make(map[outputKey]struct{}, len(outputs))
+	for key := range outputs {
+		set[key] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,316 @@
+package memstorage
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// outputKey identifies an output admitted into a single topic.
+type outputKey struct {
+	outpoint transaction.Outpoint
+	topic    string
+}
+
+// appliedKey identifies a transaction applied to a single topic.
+type appliedKey struct {
+	txid  chainhash.Hash
+	topic string
+}
+
+// record is the stored representation of an output. The transaction BEEF is kept
+// separately and shared by every output of the transaction.
+type record struct {
+	output    engine.Output
+	createdAt int64
+}
+
+// Storage is a thread-safe, in-memory engine.Storage implementation. Outputs are
+// indexed by outpoint and topic, by topic and by transaction ID. All values handed
+// in and out of the storage are copied, so callers never share memory with it.
+type Storage struct {
+	mu      sync.RWMutex
+	outputs map[outputKey]*record
+	byTopic map[string]map[outputKey]struct{}
+	byTxid  map[chainhash.Hash]map[outputKey]struct{}
+	beefs   map[chainhash.Hash][]byte
+	applied map[appliedKey]struct{}
+	// checkpoints are kept outside of the output state and are not part of transactions.
+	checkpoints map[checkpointKey]engine.SyncCheckpoint
+	// conflicts are kept outside of the output state and are not part of transactions.
+	conflicts map[conflictKey]engine.Conflict
+	// evictions are kept outside of the output state and are not part of transactions.
+	evictions []*engine.Eviction
+	// webhooks and their deliveries are kept outside of the output state and are not part of transactions.
+	webhooks   []*engine.Webhook
+	deliveries []*engine.WebhookDelivery
+	// outbox entries are kept outside of the output state and are only queued as part of transactions.
+	outbox []*engine.OutboxEntry
+	// payment receipts are kept outside of the output state and are not part of transactions.
+	payments []*engine.PaymentReceipt
+	now      func() time.Time
+}
+
+// New creates an empty in-memory storage.
+func New() *Storage {
+	return &Storage{
+		outputs:     make(map[outputKey]*record),
+		byTopic:     make(map[string]map[outputKey]struct{}),
+		byTxid:      make(map[chainhash.Hash]map[outputKey]struct{}),
+		beefs:       make(map[chainhash.Hash][]byte),
+		applied:     make(map[appliedKey]struct{}),
+		checkpoints: make(map[checkpointKey]engine.SyncCheckpoint),
+		conflicts:   make(map[conflictKey]engine.Conflict),
+		now:         time.Now,
+	}
+}
+
+// InsertOutput stores the output together with its transaction BEEF. Inserting an output
+// that already exists for the same topic is a no-op, which keeps its spent state intact.
+func (s *Storage) InsertOutput(ctx context.Context, utxo *engine.Output) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ops{s: s}.InsertOutput(ctx, utxo)
+}
+
+// FindOutput returns the output matching the outpoint and the optional topic and spent filters.
+// It returns a nil output and a nil error when no output matches.
+func (s *Storage) FindOutput(ctx context.Context, outpoint *transaction.Outpoint, topic *string, spent *bool, includeBEEF bool) (*engine.Output, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ops{s: s}.FindOutput(ctx, outpoint, topic, spent, includeBEEF)
+}
+
+// FindOutputs returns the outputs for the given outpoints within the topic.
+// The result is aligned with the outpoints slice; missing outputs are represented by nil entries.
+func (s *Storage) FindOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spent *bool, includeBEEF bool) ([]*engine.Output, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ops{s: s}.FindOutputs(ctx, outpoints, topic, spent, includeBEEF)
+}
+
+// FindOutputsForTransaction returns every output of the transaction across all topics.
+func (s *Storage) FindOutputsForTransaction(ctx context.Context, txid *chainhash.Hash, includeBEEF bool) ([]*engine.Output, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ops{s: s}.FindOutputsForTransaction(ctx, txid, includeBEEF)
+}
+
+// FindOutputsSinceBlockHeight returns every output mined in a block at or above the block height
+// across all topics, ordered by block height.
+func (s *Storage) FindOutputsSinceBlockHeight(ctx context.Context, blockHeight uint32, includeBEEF bool) ([]*engine.Output, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ops{s: s}.FindOutputsSinceBlockHeight(ctx, blockHeight, includeBEEF)
+}
+
+// FindUTXOsForTopic returns the unspent outputs of the topic admitted at or after
+// the since unix timestamp, ordered by admission time.
+func (s *Storage) FindUTXOsForTopic(ctx context.Context, topic string, since uint32, includeBEEF bool) ([]*engine.Output, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ops{s: s}.FindUTXOsForTopic(ctx, topic, since, includeBEEF)
+}
+
+// FindSpentOutputsForTopic returns the spent outputs of the topic, ordered by admission time.
+func (s *Storage) FindSpentOutputsForTopic(ctx context.Context, topic string, includeBEEF bool) ([]*engine.Output, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ops{s: s}.FindSpentOutputsForTopic(ctx, topic, includeBEEF)
+}
+
+// DeleteOutput removes the output from the topic. The transaction BEEF is removed
+// once no output references it anymore. Deleting a missing output is a no-op.
+func (s *Storage) DeleteOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ops{s: s}.DeleteOutput(ctx, outpoint, topic)
+}
+
+// MarkUTXOsAsSpent marks the outputs of the topic as spent by the given transaction.
+// Outpoints that are not stored for the topic are ignored.
+func (s *Storage) MarkUTXOsAsSpent(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spendTxid *chainhash.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ops{s: s}.MarkUTXOsAsSpent(ctx, outpoints, topic, spendTxid)
+}
+
+// UpdateConsumedBy replaces the list of outputs consuming the given output.
+// It returns engine.ErrNotFound when the output is not stored for the topic.
+func (s *Storage) UpdateConsumedBy(ctx context.Context, outpoint *transaction.Outpoint, topic string, consumedBy []*transaction.Outpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ops{s: s}.UpdateConsumedBy(ctx, outpoint, topic, consumedBy)
+}
+
+// UpdateTransactionBEEF replaces the BEEF of the transaction shared by all its outputs.
+// It returns engine.ErrNotFound when no output of the transaction is stored.
+func (s *Storage) UpdateTransactionBEEF(ctx context.Context, txid *chainhash.Hash, beef []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ops{s: s}.UpdateTransactionBEEF(ctx, txid, beef)
+}
+
+// UpdateOutputBlockHeight sets the block position and the ancillary BEEF of the output.
+// It returns engine.ErrNotFound when the output is not stored for the topic.
+func (s *Storage) UpdateOutputBlockHeight(ctx context.Context, outpoint *transaction.Outpoint, topic string, blockHeight uint32, blockIndex uint64, ancillaryBeef []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ops{s: s}.UpdateOutputBlockHeight(ctx, outpoint, topic, blockHeight, blockIndex, ancillaryBeef)
+}
+
+// InsertAppliedTransaction records that the transaction was applied to the topic.
+// Recording the same transaction twice is a no-op.
+func (s *Storage) InsertAppliedTransaction(ctx context.Context, tx *overlay.AppliedTransaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ops{s: s}.InsertAppliedTransaction(ctx, tx)
+}
+
+// DoesAppliedTransactionExist reports whether the transaction was already applied to the topic.
+func (s *Storage) DoesAppliedTransactionExist(ctx context.Context, tx *overlay.AppliedTransaction) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ops{s: s}.DoesAppliedTransactionExist(ctx, tx)
+}
+
+// Begin starts a transaction. The transaction holds the storage write lock until it is
+// committed or rolled back, so every other caller waits for it to finish.
+func (s *Storage) Begin(ctx context.Context) (engine.StorageTransaction, error) {
+	s.mu.Lock()
+	return &Tx{ops: ops{s: s, journal: &journal{}}}, nil
+}
+
+// insert adds the record to the primary map and all indexes. The caller must hold the write lock.
+func (s *Storage) insert(key outputKey, rec *record) {
+	s.outputs[key] = rec
+	addIndex(s.byTopic, key.topic, key)
+	addIndex(s.byTxid, key.outpoint.Txid, key)
+}
+
+// remove deletes the record from the primary map and all indexes. The caller must hold the write lock.
+func (s *Storage) remove(key outputKey) {
+	delete(s.outputs, key)
+	removeIndex(s.byTopic, key.topic, key)
+	removeIndex(s.byTxid, key.outpoint.Txid, key)
+}
+
+// export returns a copy of the stored output, optionally decorated with the transaction BEEF.
+// The caller must hold at least the read lock.
+func (s *Storage) export(rec *record, includeBEEF bool) *engine.Output {
+	output := cloneOutput(&rec.output)
+	if includeBEEF {
+		output.Beef = cloneBytes(s.beefs[rec.output.Outpoint.Txid])
+	}
+	return output
+}
+
+// sortedKeys returns the keys of the index ordered by admission time and outpoint,
+// which keeps query results deterministic. The caller must hold at least the read lock.
+func (s *Storage) sortedKeys(index map[outputKey]struct{}) []outputKey {
+	keys := make([]outputKey, 0, len(index))
+	for key := range index {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := s.outputs[keys[i]], s.outputs[keys[j]]
+		if a.createdAt != b.createdAt {
+			return a.createdAt < b.createdAt
+		}
+		if c := bytes.Compare(keys[i].outpoint.Txid[:], keys[j].outpoint.Txid[:]); c != 0 {
+			return c < 0
+		}
+		if keys[i].outpoint.Index != keys[j].outpoint.Index {
+			return keys[i].outpoint.Index < keys[j].outpoint.Index
+		}
+		return keys[i].topic < keys[j].topic
+	})
+	return keys
+}
+
+func matchesSpent(rec *record, spent *bool) bool {
+	return spent == nil || rec.output.Spent == *spent
+}
+
+func addIndex[K comparable](index map[K]map[outputKey]struct{}, k K, key outputKey) {
+	set, ok := index[k]
+	if !ok {
+		set = make(map[outputKey]struct{})
+		index[k] = set
+	}
+	set[key] = struct{}{}
+}
+
+func removeIndex[K comparable](index map[K]map[outputKey]struct{}, k K, key outputKey) {
+	set, ok := index[k]
+	if !ok {
+		return
+	}
+	delete(set, key)
+	if len(set) == 0 {
+		delete(index, k)
+	}
+}
+
+func cloneOutput(o *engine.Output) *engine.Output {
+	c := *o
+	if o.Script != nil {
+		s := script.Script(cloneBytes(*o.Script))
+		c.Script = &s
+	}
+	c.OutputsConsumed = cloneOutpoints(o.OutputsConsumed)
+	c.ConsumedBy = cloneOutpoints(o.ConsumedBy)
+	c.AncillaryTxids = cloneHashes(o.AncillaryTxids)
+	c.AncillaryBeef = cloneBytes(o.AncillaryBeef)
+	c.Beef = cloneBytes(o.Beef)
+	c.SpendingTxid = cloneHash(o.SpendingTxid)
+	return &c
+}
+
+func cloneOutpoints(outpoints []*transaction.Outpoint) []*transaction.Outpoint {
+	if len(outpoints) == 0 {
+		return nil
+	}
+	c := make([]*transaction.Outpoint, len(outpoints))
+	for i, outpoint := range outpoints {
+		op := *outpoint
+		c[i] = &op
+	}
+	return c
+}
+
+func cloneHashes(hashes []*chainhash.Hash) []*chainhash.Hash {
+	if len(hashes) == 0 {
+		return nil
+	}
+	c := make([]*chainhash.Hash, len(hashes))
+	for i, hash := range hashes {
+		c[i] = cloneHash(hash)
+	}
+	return c
+}
+
+func cloneHash(hash *chainhash.Hash) *chainhash.Hash {
+	if hash == nil {
+		return nil
+	}
+	c := *hash
+	return &c
+}
+
+func cloneBytes(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
+
+var _ engine.Storage = (*Storage)(nil)
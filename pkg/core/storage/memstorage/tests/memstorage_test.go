@@ -0,0 +1,247 @@
+package memstorage_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/storagetest"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_Conformance(t *testing.T) {
+	storagetest.RunStorageTests(t, func(t *testing.T) engine.Storage {
+		return memstorage.New()
+	})
+}
+
+func TestOverlayGASPStorage_Conformance(t *testing.T) {
+	storagetest.RunGASPStorageTests(t, storagetest.OverlayGASPStorageFactory(func(t *testing.T) engine.Storage {
+		return memstorage.New()
+	}))
+}
+
+func TestSyncCheckpointStorage_Conformance(t *testing.T) {
+	storagetest.RunSyncCheckpointStorageTests(t, func(t *testing.T) engine.SyncCheckpointStorage {
+		return memstorage.New()
+	})
+}
+
+func TestConflictStorage_Conformance(t *testing.T) {
+	storagetest.RunConflictStorageTests(t, func(t *testing.T) engine.ConflictStorage {
+		return memstorage.New()
+	})
+}
+
+func TestEvictionAuditStorage_Conformance(t *testing.T) {
+	storagetest.RunEvictionAuditStorageTests(t, func(t *testing.T) engine.EvictionAuditStorage {
+		return memstorage.New()
+	})
+}
+
+func TestWebhookStorage_Conformance(t *testing.T) {
+	storagetest.RunWebhookStorageTests(t, func(t *testing.T) engine.WebhookStorage {
+		return memstorage.New()
+	})
+}
+
+func TestOutboxStorage_Conformance(t *testing.T) {
+	storagetest.RunOutboxStorageTests(t, func(t *testing.T) engine.OutboxStorage {
+		return memstorage.New()
+	})
+}
+
+func TestPaymentReceiptStorage_Conformance(t *testing.T) {
+	storagetest.RunPaymentReceiptStorageTests(t, func(t *testing.T) engine.PaymentReceiptStorage {
+		return memstorage.New()
+	})
+}
+
+func TestStorage_FindOutput_ShouldReturnCopy(t *testing.T) {
+	// given
+	ctx := context.Background()
+	sut := memstorage.New()
+
+	output := storagetest.NewOutput(t, 1, 0, storagetest.Topic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+
+	found, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+
+	// when
+	found.Spent = true
+	found.Beef[0] = 0x00
+	(*found.Script)[0] = 0x00
+
+	// then
+	actual, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, output, actual)
+}
+
+func TestStorage_Snapshot_ShouldRestoreIdenticalState(t *testing.T) {
+	// given
+	ctx := context.Background()
+	source := memstorage.New()
+
+	first := storagetest.NewOutput(t, 1, 0, storagetest.Topic)
+	first.ConsumedBy = []*transaction.Outpoint{{Txid: *storagetest.NewHash(t, 5), Index: 1}}
+	second := storagetest.NewOutput(t, 2, 3, storagetest.OtherTopic)
+	applied := &overlay.AppliedTransaction{Txid: storagetest.NewHash(t, 1), Topic: storagetest.Topic}
+
+	require.NoError(t, source.InsertOutput(ctx, first))
+	require.NoError(t, source.InsertOutput(ctx, second))
+	require.NoError(t, source.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&second.Outpoint}, storagetest.OtherTopic, storagetest.NewHash(t, 6)))
+	require.NoError(t, source.InsertAppliedTransaction(ctx, applied))
+	checkpoint := storagetest.NewSyncCheckpoint(storagetest.Topic, "https://peer.example.com", 100)
+	require.NoError(t, source.UpsertSyncCheckpoint(ctx, checkpoint))
+	conflict := storagetest.NewConflict(t, storagetest.OtherTopic, 2, 6, 7)
+	require.NoError(t, source.InsertConflict(ctx, conflict))
+	eviction := storagetest.NewEviction(t, 8, 0)
+	require.NoError(t, source.InsertEviction(ctx, eviction))
+	receipt := storagetest.NewPaymentReceipt(t, 9, 0)
+	require.NoError(t, source.InsertPaymentReceipt(ctx, receipt))
+
+	var buf bytes.Buffer
+	require.NoError(t, source.Snapshot(&buf))
+
+	sut := memstorage.New()
+
+	// when
+	err := sut.Restore(&buf)
+
+	// then
+	require.NoError(t, err)
+
+	actualFirst, err := sut.FindOutput(ctx, &first.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, first, actualFirst)
+
+	actualSecond, err := sut.FindOutput(ctx, &second.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	require.True(t, actualSecond.Spent)
+	require.Equal(t, storagetest.NewHash(t, 6), actualSecond.SpendingTxid)
+	require.Equal(t, second.Beef, actualSecond.Beef)
+
+	exists, err := sut.DoesAppliedTransactionExist(ctx, applied)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	actualCheckpoint, err := sut.FindSyncCheckpoint(ctx, checkpoint.Topic, checkpoint.Peer)
+	require.NoError(t, err)
+	require.Equal(t, checkpoint.Since, actualCheckpoint.Since)
+	require.True(t, checkpoint.UpdatedAt.Equal(actualCheckpoint.UpdatedAt))
+
+	actualConflicts, err := sut.FindConflicts(ctx, conflict.Topic)
+	require.NoError(t, err)
+	require.Len(t, actualConflicts, 1)
+	require.Equal(t, conflict.CompetingTxid, actualConflicts[0].CompetingTxid)
+	require.Equal(t, conflict.CompetingBeef, actualConflicts[0].CompetingBeef)
+
+	actualEvictions, err := sut.FindEvictions(ctx)
+	require.NoError(t, err)
+	require.Len(t, actualEvictions, 1)
+	require.Equal(t, eviction.Evicted, actualEvictions[0].Evicted)
+	require.Equal(t, eviction.Reason, actualEvictions[0].Reason)
+	require.True(t, eviction.CreatedAt.Equal(actualEvictions[0].CreatedAt))
+
+	actualReceipts, err := sut.FindPaymentReceipts(ctx)
+	require.NoError(t, err)
+	require.Len(t, actualReceipts, 1)
+	require.Equal(t, receipt.Txid, actualReceipts[0].Txid)
+	require.Equal(t, receipt.Payer, actualReceipts[0].Payer)
+	require.True(t, receipt.CreatedAt.Equal(actualReceipts[0].CreatedAt))
+}
+
+func TestStorage_SaveSnapshot_ShouldBeLoadableFromDisk(t *testing.T) {
+	// given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	source := memstorage.New()
+	output := storagetest.NewOutput(t, 1, 0, storagetest.Topic)
+	require.NoError(t, source.InsertOutput(ctx, output))
+	require.NoError(t, source.SaveSnapshot(path))
+
+	// when
+	sut, err := memstorage.NewFromSnapshot(path)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, output, actual)
+}
+
+func TestStorage_NewFromSnapshot_ShouldReturnEmptyStorage_WhenFileMissing(t *testing.T) {
+	// when
+	sut, err := memstorage.NewFromSnapshot(filepath.Join(t.TempDir(), "missing.json"))
+
+	// then
+	require.NoError(t, err)
+
+	utxos, err := sut.FindUTXOsForTopic(context.Background(), storagetest.Topic, 0, false)
+	require.NoError(t, err)
+	require.Empty(t, utxos)
+}
+
+func TestStorage_Restore_ShouldKeepState_WhenSnapshotInvalid(t *testing.T) {
+	// given
+	ctx := context.Background()
+	sut := memstorage.New()
+
+	output := storagetest.NewOutput(t, 1, 0, storagetest.Topic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+
+	// when
+	err := sut.Restore(bytes.NewBufferString(`{"version": 42}`))
+
+	// then
+	require.Error(t, err)
+
+	actual, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+}
+
+func TestStorage_ShouldBeSafeForConcurrentUse(t *testing.T) {
+	// given
+	ctx := context.Background()
+	sut := memstorage.New()
+
+	const workers = 16
+
+	// when
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func(b byte) {
+			defer wg.Done()
+
+			output := storagetest.NewOutput(t, b, 0, storagetest.Topic)
+			_ = sut.InsertOutput(ctx, output)
+			_, _ = sut.FindUTXOsForTopic(ctx, storagetest.Topic, 0, true)
+			_ = sut.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&output.Outpoint}, storagetest.Topic, storagetest.NewHash(t, 0xff))
+			_ = sut.Snapshot(&bytes.Buffer{})
+		}(byte(i + 1))
+	}
+	wg.Wait()
+
+	// then
+	utxos, err := sut.FindUTXOsForTopic(ctx, storagetest.Topic, 0, false)
+	require.NoError(t, err)
+	require.Empty(t, utxos)
+
+	for i := range workers {
+		found, err := sut.FindOutput(ctx, &transaction.Outpoint{Txid: *storagetest.NewHash(t, byte(i+1))}, nil, nil, false)
+		require.NoError(t, err)
+		require.True(t, found.Spent)
+	}
+}
@@ -0,0 +1,97 @@
+package memstorage
+
+import (
+	"errors"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// ErrTxDone is returned when a transaction is used after it was committed or rolled back.
+var ErrTxDone = errors.New("transaction has already been committed or rolled back")
+
+// Tx is an engine.StorageTransaction of the in-memory storage. Writes are applied to the
+// storage right away and undone on Rollback; since the transaction holds the storage write
+// lock, no other caller can observe them before Commit. A Tx is not safe for concurrent use.
+type Tx struct {
+	ops
+}
+
+// Commit keeps every write made through the transaction and releases the storage.
+func (t *Tx) Commit() error {
+	if err := t.journal.check(); err != nil {
+		return err
+	}
+	t.journal.done = true
+	t.s.mu.Unlock()
+	return nil
+}
+
+// Rollback undoes every write made through the transaction and releases the storage.
+func (t *Tx) Rollback() error {
+	if err := t.journal.check(); err != nil {
+		return err
+	}
+	for i := len(t.journal.undo) - 1; i >= 0; i-- {
+		t.journal.undo[i]()
+	}
+	t.journal.done = true
+	t.s.mu.Unlock()
+	return nil
+}
+
+// journal records how to undo the writes of a transaction. A nil journal records nothing,
+// which is used by the non-transactional operations of the storage.
+type journal struct {
+	undo []func()
+	done bool
+}
+
+func (j *journal) check() error {
+	if j != nil && j.done {
+		return ErrTxDone
+	}
+	return nil
+}
+
+// saveRecord records the current state of the output stored under the key, or its absence.
+func (j *journal) saveRecord(s *Storage, key outputKey) {
+	if j == nil {
+		return
+	}
+	prev, ok := s.outputs[key]
+	if !ok {
+		j.undo = append(j.undo, func() { s.remove(key) })
+		return
+	}
+	saved := *prev
+	j.undo = append(j.undo, func() {
+		s.remove(key)
+		s.insert(key, &saved)
+	})
+}
+
+// saveBeef records the current BEEF of the transaction, or its absence.
+func (j *journal) saveBeef(s *Storage, txid chainhash.Hash) {
+	if j == nil {
+		return
+	}
+	prev, ok := s.beefs[txid]
+	j.undo = append(j.undo, func() {
+		if ok {
+			s.beefs[txid] = prev
+		} else {
+			delete(s.beefs, txid)
+		}
+	})
+}
+
+// saveApplied records that the applied transaction entry did not exist yet.
+func (j *journal) saveApplied(s *Storage, key appliedKey) {
+	if j == nil {
+		return
+	}
+	j.undo = append(j.undo, func() { delete(s.applied, key) })
+}
+
+var _ engine.StorageTransaction = (*Tx)(nil)
@@ -0,0 +1,145 @@
+package memstorage
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// InsertWebhook stores the webhook.
+func (s *Storage) InsertWebhook(ctx context.Context, webhook *engine.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *webhook
+	s.webhooks = append(s.webhooks, &stored)
+	return nil
+}
+
+// DeleteWebhook deletes the webhook, keeping its deliveries. It returns engine.ErrNotFound
+// when the webhook is not stored.
+func (s *Storage) DeleteWebhook(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.webhooks, func(webhook *engine.Webhook) bool { return webhook.ID == id })
+	if i < 0 {
+		return engine.ErrNotFound
+	}
+	s.webhooks = slices.Delete(s.webhooks, i, i+1)
+	return nil
+}
+
+// FindWebhooks returns the stored webhooks ordered by the time they were registered.
+func (s *Storage) FindWebhooks(ctx context.Context) ([]*engine.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sortedWebhooks(), nil
+}
+
+// InsertWebhookDeliveries queues the deliveries.
+func (s *Storage) InsertWebhookDeliveries(ctx context.Context, deliveries []*engine.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		s.deliveries = append(s.deliveries, cloneWebhookDelivery(delivery))
+	}
+	return nil
+}
+
+// UpdateWebhookDelivery records the outcome of an attempt of the delivery. Updating a delivery
+// that is not stored is a no-op.
+func (s *Storage) UpdateWebhookDelivery(ctx context.Context, delivery *engine.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.deliveries {
+		if stored.ID != delivery.ID {
+			continue
+		}
+		stored.Status = delivery.Status
+		stored.Attempts = delivery.Attempts
+		stored.NextAttemptAt = delivery.NextAttemptAt
+		stored.LastStatusCode = delivery.LastStatusCode
+		stored.LastError = delivery.LastError
+		stored.UpdatedAt = delivery.UpdatedAt
+		return nil
+	}
+	return nil
+}
+
+// FindDueWebhookDeliveries returns at most limit pending deliveries whose next attempt is not after
+// the given time, ordered by their next attempt.
+func (s *Storage) FindDueWebhookDeliveries(ctx context.Context, before time.Time, limit int) ([]*engine.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	due := make([]*engine.WebhookDelivery, 0)
+	for _, delivery := range s.sortedWebhookDeliveries() {
+		if delivery.Status == engine.WebhookDeliveryPending && !delivery.NextAttemptAt.After(before) {
+			due = append(due, delivery)
+		}
+	}
+	slices.SortStableFunc(due, func(a, b *engine.WebhookDelivery) int {
+		return a.NextAttemptAt.Compare(b.NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// FindWebhookDeliveries returns the deliveries of the webhook with the status ordered by the time
+// they were queued. An empty webhook ID or status matches every delivery.
+func (s *Storage) FindWebhookDeliveries(ctx context.Context, webhookID string, status engine.WebhookDeliveryStatus) ([]*engine.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	deliveries := make([]*engine.WebhookDelivery, 0)
+	for _, delivery := range s.sortedWebhookDeliveries() {
+		if (webhookID == "" || delivery.WebhookID == webhookID) && (status == "" || delivery.Status == status) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+// sortedWebhooks returns copies of the webhooks ordered by the time they were registered, keeping
+// the insertion order of the webhooks registered at the same time. The caller must hold at least
+// the read lock.
+func (s *Storage) sortedWebhooks() []*engine.Webhook {
+	webhooks := make([]*engine.Webhook, 0, len(s.webhooks))
+	for _, webhook := range s.webhooks {
+		clone := *webhook
+		webhooks = append(webhooks, &clone)
+	}
+	slices.SortStableFunc(webhooks, func(a, b *engine.Webhook) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return webhooks
+}
+
+// sortedWebhookDeliveries returns copies of the deliveries ordered by the time they were queued,
+// keeping the insertion order of the deliveries queued at the same time. The caller must hold at
+// least the read lock.
+func (s *Storage) sortedWebhookDeliveries() []*engine.WebhookDelivery {
+	deliveries := make([]*engine.WebhookDelivery, 0, len(s.deliveries))
+	for _, delivery := range s.deliveries {
+		deliveries = append(deliveries, cloneWebhookDelivery(delivery))
+	}
+	slices.SortStableFunc(deliveries, func(a, b *engine.WebhookDelivery) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return deliveries
+}
+
+func cloneWebhookDelivery(delivery *engine.WebhookDelivery) *engine.WebhookDelivery {
+	clone := *delivery
+	clone.Payload = cloneBytes(delivery.Payload)
+	return &clone
+}
+
+var _ engine.WebhookStorage = (*Storage)(nil)
@@ -0,0 +1,114 @@
+package sqlstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+const checkpointColumns = `topic, peer, since, updated_at`
+
+// FindSyncCheckpoint returns the checkpoint of the topic and peer.
+// It returns a nil checkpoint and a nil error when there is none.
+func (s *Storage) FindSyncCheckpoint(ctx context.Context, topic, peer string) (*engine.SyncCheckpoint, error) {
+	const query = `SELECT ` + checkpointColumns + ` FROM sync_checkpoints WHERE topic = ? AND peer = ?`
+	checkpoint, err := scanCheckpoint(s.db.QueryRowContext(ctx, s.dialect.Rebind(query), topic, peer))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sync checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// FindSyncCheckpoints returns the checkpoints of the topic ordered by topic and peer.
+// An empty topic matches every topic.
+func (s *Storage) FindSyncCheckpoints(ctx context.Context, topic string) ([]*engine.SyncCheckpoint, error) {
+	query := `SELECT ` + checkpointColumns + ` FROM sync_checkpoints`
+	var args []any
+	if topic != "" {
+		query += ` WHERE topic = ?`
+		args = append(args, topic)
+	}
+	query += ` ORDER BY topic, peer`
+
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync checkpoints: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	checkpoints := make([]*engine.SyncCheckpoint, 0)
+	for rows.Next() {
+		checkpoint, err := scanCheckpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sync checkpoint: %w", err)
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sync checkpoints: %w", err)
+	}
+	return checkpoints, nil
+}
+
+// UpsertSyncCheckpoint stores the checkpoint, replacing the checkpoint of the same topic and peer.
+func (s *Storage) UpsertSyncCheckpoint(ctx context.Context, checkpoint *engine.SyncCheckpoint) error {
+	const query = `INSERT INTO sync_checkpoints (` + checkpointColumns + `) VALUES (?, ?, ?, ?)
+		ON CONFLICT (topic, peer) DO UPDATE SET since = excluded.since, updated_at = excluded.updated_at`
+	if _, err := s.exec(ctx, query,
+		checkpoint.Topic,
+		checkpoint.Peer,
+		int64(checkpoint.Since),
+		checkpoint.UpdatedAt.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("failed to upsert sync checkpoint: %w", err)
+	}
+	return nil
+}
+
+// DeleteSyncCheckpoints removes the checkpoints matching the topic and peer and returns
+// how many were removed. An empty topic or peer matches every topic or peer.
+func (s *Storage) DeleteSyncCheckpoints(ctx context.Context, topic, peer string) (int, error) {
+	query := `DELETE FROM sync_checkpoints WHERE 1 = 1`
+	var args []any
+	if topic != "" {
+		query += ` AND topic = ?`
+		args = append(args, topic)
+	}
+	if peer != "" {
+		query += ` AND peer = ?`
+		args = append(args, peer)
+	}
+
+	result, err := s.exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sync checkpoints: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted sync checkpoints: %w", err)
+	}
+	return int(deleted), nil
+}
+
+func scanCheckpoint(row rowScanner) (*engine.SyncCheckpoint, error) {
+	var (
+		checkpoint engine.SyncCheckpoint
+		since      int64
+		updatedAt  int64
+	)
+	if err := row.Scan(&checkpoint.Topic, &checkpoint.Peer, &since, &updatedAt); err != nil {
+		return nil, err
+	}
+	checkpoint.Since = uint32(since)
+	checkpoint.UpdatedAt = time.UnixMilli(updatedAt)
+	return &checkpoint, nil
+}
+
+var _ engine.SyncCheckpointStorage = (*Storage)(nil)
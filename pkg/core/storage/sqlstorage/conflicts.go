@@ -0,0 +1,118 @@
+package sqlstorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const conflictColumns = `topic, txid, output_index, admitted_txid, competing_txid, competing_beef, created_at`
+
+// InsertConflict stores the conflict. Inserting a conflict already recorded for the same topic,
+// outpoint and competing transaction is a no-op.
+func (s *Storage) InsertConflict(ctx context.Context, conflict *engine.Conflict) error {
+	const query = `INSERT INTO conflicts (` + conflictColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (topic, txid, output_index, competing_txid) DO NOTHING`
+	if _, err := s.exec(ctx, query,
+		conflict.Topic,
+		conflict.Outpoint.Txid.String(),
+		int64(conflict.Outpoint.Index),
+		conflict.AdmittedTxid.String(),
+		conflict.CompetingTxid.String(),
+		conflict.CompetingBeef,
+		conflict.CreatedAt.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("failed to insert conflict: %w", err)
+	}
+	return nil
+}
+
+// FindConflicts returns the conflicts of the topic ordered by topic, outpoint and competing
+// transaction. An empty topic matches every topic.
+func (s *Storage) FindConflicts(ctx context.Context, topic string) ([]*engine.Conflict, error) {
+	query := `SELECT ` + conflictColumns + ` FROM conflicts`
+	var args []any
+	if topic != "" {
+		query += ` WHERE topic = ?`
+		args = append(args, topic)
+	}
+	return s.queryConflicts(ctx, query, args...)
+}
+
+// FindConflictsForTransaction returns the conflicts in which the transaction is either the
+// admitted or the competing one.
+func (s *Storage) FindConflictsForTransaction(ctx context.Context, txid *chainhash.Hash) ([]*engine.Conflict, error) {
+	const query = `SELECT ` + conflictColumns + ` FROM conflicts WHERE admitted_txid = ? OR competing_txid = ?`
+	return s.queryConflicts(ctx, query, txid.String(), txid.String())
+}
+
+// DeleteConflicts removes the conflicts over the output of the topic and returns how many were removed.
+func (s *Storage) DeleteConflicts(ctx context.Context, topic string, outpoint *transaction.Outpoint) (int, error) {
+	const query = `DELETE FROM conflicts WHERE topic = ? AND txid = ? AND output_index = ?`
+	result, err := s.exec(ctx, query, topic, outpoint.Txid.String(), int64(outpoint.Index))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete conflicts: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted conflicts: %w", err)
+	}
+	return int(deleted), nil
+}
+
+func (s *Storage) queryConflicts(ctx context.Context, query string, args ...any) ([]*engine.Conflict, error) {
+	query += ` ORDER BY topic, txid, output_index, competing_txid`
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conflicts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	conflicts := make([]*engine.Conflict, 0)
+	for rows.Next() {
+		conflict, err := scanConflict(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan conflict: %w", err)
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate conflicts: %w", err)
+	}
+	return conflicts, nil
+}
+
+func scanConflict(row rowScanner) (*engine.Conflict, error) {
+	var (
+		conflict      engine.Conflict
+		txid          string
+		outputIndex   int64
+		admittedTxid  string
+		competingTxid string
+		createdAt     int64
+	)
+	if err := row.Scan(&conflict.Topic, &txid, &outputIndex, &admittedTxid, &competingTxid, &conflict.CompetingBeef, &createdAt); err != nil {
+		return nil, err
+	}
+	hash, err := chainhash.NewHashFromHex(txid)
+	if err != nil {
+		return nil, err
+	}
+	conflict.Outpoint = transaction.Outpoint{Txid: *hash, Index: uint32(outputIndex)}
+	if hash, err = chainhash.NewHashFromHex(admittedTxid); err != nil {
+		return nil, err
+	}
+	conflict.AdmittedTxid = *hash
+	if hash, err = chainhash.NewHashFromHex(competingTxid); err != nil {
+		return nil, err
+	}
+	conflict.CompetingTxid = *hash
+	conflict.CreatedAt = time.UnixMilli(createdAt)
+	return &conflict, nil
+}
+
+var _ engine.ConflictStorage = (*Storage)(nil)
@@ -0,0 +1,55 @@
+package sqlstorage
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect abstracts the differences between the SQL engines supported by the storage.
+// Queries are written using '?' placeholders and rebound by the dialect before execution.
+type Dialect interface {
+	// Name returns the identifier of the dialect.
+	Name() string
+
+	// Rebind converts a query written with '?' placeholders into the dialect placeholder syntax.
+	Rebind(query string) string
+
+	// BlobType returns the column type used to store binary data.
+	BlobType() string
+}
+
+var (
+	// SQLite is the default dialect used by the storage.
+	SQLite Dialect = sqliteDialect{}
+
+	// Postgres is the dialect for PostgreSQL databases.
+	Postgres Dialect = postgresDialect{}
+)
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string               { return "sqlite" }
+func (sqliteDialect) Rebind(query string) string { return query }
+func (sqliteDialect) BlobType() string           { return "BLOB" }
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string     { return "postgres" }
+func (postgresDialect) BlobType() string { return "BYTEA" }
+
+func (postgresDialect) Rebind(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package sqlstorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const evictionColumns = `txid, output_index, include_history, evicted, actor, reason, created_at`
+
+// InsertEviction appends the eviction to the audit log, as part of the database transaction when
+// called on a Tx.
+func (s *store) InsertEviction(ctx context.Context, eviction *engine.Eviction) error {
+	evicted, err := json.Marshal(eviction.Evicted)
+	if err != nil {
+		return fmt.Errorf("failed to encode evicted outputs: %w", err)
+	}
+	const query = `INSERT INTO evictions (` + evictionColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	if _, err := s.exec(ctx, query,
+		eviction.Outpoint.Txid.String(),
+		int64(eviction.Outpoint.Index),
+		eviction.IncludeHistory,
+		string(evicted),
+		eviction.Actor,
+		eviction.Reason,
+		eviction.CreatedAt.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("failed to insert eviction: %w", err)
+	}
+	return nil
+}
+
+// FindEvictions returns the audit log of the evictions ordered by the time they were recorded.
+func (s *Storage) FindEvictions(ctx context.Context) ([]*engine.Eviction, error) {
+	const query = `SELECT ` + evictionColumns + ` FROM evictions ORDER BY created_at, txid, output_index`
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query evictions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	evictions := make([]*engine.Eviction, 0)
+	for rows.Next() {
+		eviction, err := scanEviction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan eviction: %w", err)
+		}
+		evictions = append(evictions, eviction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate evictions: %w", err)
+	}
+	return evictions, nil
+}
+
+func scanEviction(row rowScanner) (*engine.Eviction, error) {
+	var (
+		eviction    engine.Eviction
+		txid        string
+		outputIndex int64
+		evicted     string
+		createdAt   int64
+	)
+	if err := row.Scan(&txid, &outputIndex, &eviction.IncludeHistory, &evicted, &eviction.Actor, &eviction.Reason, &createdAt); err != nil {
+		return nil, err
+	}
+	hash, err := chainhash.NewHashFromHex(txid)
+	if err != nil {
+		return nil, err
+	}
+	eviction.Outpoint = transaction.Outpoint{Txid: *hash, Index: uint32(outputIndex)}
+	if err := json.Unmarshal([]byte(evicted), &eviction.Evicted); err != nil {
+		return nil, err
+	}
+	eviction.CreatedAt = time.UnixMilli(createdAt)
+	return &eviction, nil
+}
+
+var (
+	_ engine.EvictionAuditStorage = (*Storage)(nil)
+	_ engine.EvictionTransaction  = (*Tx)(nil)
+)
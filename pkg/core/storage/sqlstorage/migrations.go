@@ -0,0 +1,244 @@
+package sqlstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migration describes a single, versioned change of the database schema.
+// Migrations are applied in ascending version order and never modified once released.
+type migration struct {
+	version int
+	up      func(d Dialect) []string
+}
+
+var migrations = []migration{
+	{
+		version: 1,
+		up: func(d Dialect) []string {
+			return []string{
+				`CREATE TABLE IF NOT EXISTS transactions (
+					txid TEXT NOT NULL PRIMARY KEY,
+					beef ` + d.BlobType() + ` NOT NULL
+				)`,
+				`CREATE TABLE IF NOT EXISTS outputs (
+					txid TEXT NOT NULL,
+					output_index BIGINT NOT NULL,
+					topic TEXT NOT NULL,
+					script ` + d.BlobType() + `,
+					satoshis BIGINT NOT NULL,
+					spent BOOLEAN NOT NULL DEFAULT FALSE,
+					spending_txid TEXT,
+					outputs_consumed TEXT NOT NULL,
+					consumed_by TEXT NOT NULL,
+					block_height BIGINT NOT NULL DEFAULT 0,
+					block_idx BIGINT NOT NULL DEFAULT 0,
+					ancillary_txids TEXT NOT NULL,
+					ancillary_beef ` + d.BlobType() + `,
+					created_at BIGINT NOT NULL,
+					PRIMARY KEY (txid, output_index, topic)
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_outputs_topic_spent_created_at ON outputs (topic, spent, created_at)`,
+				`CREATE TABLE IF NOT EXISTS applied_transactions (
+					txid TEXT NOT NULL,
+					topic TEXT NOT NULL,
+					PRIMARY KEY (txid, topic)
+				)`,
+			}
+		},
+	},
+	{
+		version: 2,
+		up: func(d Dialect) []string {
+			return []string{
+				`CREATE TABLE IF NOT EXISTS sync_checkpoints (
+					topic TEXT NOT NULL,
+					peer TEXT NOT NULL,
+					since BIGINT NOT NULL,
+					updated_at BIGINT NOT NULL,
+					PRIMARY KEY (topic, peer)
+				)`,
+			}
+		},
+	},
+	{
+		version: 3,
+		up: func(d Dialect) []string {
+			return []string{
+				`CREATE TABLE IF NOT EXISTS conflicts (
+					topic TEXT NOT NULL,
+					txid TEXT NOT NULL,
+					output_index BIGINT NOT NULL,
+					admitted_txid TEXT NOT NULL,
+					competing_txid TEXT NOT NULL,
+					competing_beef ` + d.BlobType() + ` NOT NULL,
+					created_at BIGINT NOT NULL,
+					PRIMARY KEY (topic, txid, output_index, competing_txid)
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_conflicts_admitted_txid ON conflicts (admitted_txid)`,
+				`CREATE INDEX IF NOT EXISTS idx_conflicts_competing_txid ON conflicts (competing_txid)`,
+			}
+		},
+	},
+	{
+		version: 4,
+		up: func(d Dialect) []string {
+			return []string{
+				`CREATE INDEX IF NOT EXISTS idx_outputs_block_height ON outputs (block_height)`,
+			}
+		},
+	},
+	{
+		version: 5,
+		up: func(d Dialect) []string {
+			return []string{
+				`CREATE TABLE IF NOT EXISTS evictions (
+					txid TEXT NOT NULL,
+					output_index BIGINT NOT NULL,
+					include_history BOOLEAN NOT NULL,
+					evicted TEXT NOT NULL,
+					actor TEXT NOT NULL,
+					reason TEXT NOT NULL,
+					created_at BIGINT NOT NULL
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_evictions_created_at ON evictions (created_at)`,
+			}
+		},
+	},
+	{
+		version: 6,
+		up: func(d Dialect) []string {
+			return []string{
+				`ALTER TABLE outputs ADD COLUMN spent_at BIGINT NOT NULL DEFAULT 0`,
+			}
+		},
+	},
+	{
+		version: 7,
+		up: func(d Dialect) []string {
+			return []string{
+				`CREATE TABLE IF NOT EXISTS webhooks (
+					id TEXT NOT NULL PRIMARY KEY,
+					topic TEXT NOT NULL,
+					url TEXT NOT NULL,
+					secret TEXT NOT NULL,
+					created_at BIGINT NOT NULL
+				)`,
+				`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+					id TEXT NOT NULL PRIMARY KEY,
+					webhook_id TEXT NOT NULL,
+					topic TEXT NOT NULL,
+					event_type TEXT NOT NULL,
+					payload ` + d.BlobType() + ` NOT NULL,
+					status TEXT NOT NULL,
+					attempts BIGINT NOT NULL,
+					next_attempt_at BIGINT NOT NULL,
+					last_status_code BIGINT NOT NULL,
+					last_error TEXT,
+					created_at BIGINT NOT NULL,
+					updated_at BIGINT NOT NULL
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status_next_attempt_at ON webhook_deliveries (status, next_attempt_at)`,
+				`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id_created_at ON webhook_deliveries (webhook_id, created_at)`,
+			}
+		},
+	},
+	{
+		version: 8,
+		up: func(d Dialect) []string {
+			return []string{
+				`CREATE TABLE IF NOT EXISTS outbox (
+					id TEXT NOT NULL PRIMARY KEY,
+					kind TEXT NOT NULL,
+					txid TEXT NOT NULL,
+					topics TEXT NOT NULL,
+					beef ` + d.BlobType() + ` NOT NULL,
+					status TEXT NOT NULL,
+					attempts BIGINT NOT NULL,
+					next_attempt_at BIGINT NOT NULL,
+					last_error TEXT,
+					created_at BIGINT NOT NULL,
+					updated_at BIGINT NOT NULL
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_outbox_status_next_attempt_at ON outbox (status, next_attempt_at)`,
+				`CREATE INDEX IF NOT EXISTS idx_outbox_txid_created_at ON outbox (txid, created_at)`,
+			}
+		},
+	},
+	{
+		version: 9,
+		up: func(d Dialect) []string {
+			return []string{
+				`CREATE TABLE IF NOT EXISTS payment_receipts (
+					txid TEXT NOT NULL PRIMARY KEY,
+					satoshis BIGINT NOT NULL,
+					route TEXT NOT NULL,
+					payer TEXT NOT NULL,
+					derivation_prefix TEXT NOT NULL,
+					derivation_suffix TEXT NOT NULL,
+					created_at BIGINT NOT NULL
+				)`,
+				`CREATE INDEX IF NOT EXISTS idx_payment_receipts_created_at ON payment_receipts (created_at)`,
+			}
+		},
+	},
+}
+
+// Migrate brings the database schema up to date by applying every pending migration.
+// Each migration runs in its own database transaction together with the bookkeeping
+// entry in the schema_migrations table, so a failed migration leaves no partial state behind.
+func (s *Storage) Migrate(ctx context.Context) error {
+	const createVersionsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		applied_at BIGINT NOT NULL
+	)`
+	if _, err := s.db.ExecContext(ctx, createVersionsTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	current, err := s.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := s.applyMigration(ctx, m); err != nil {
+			return fmt.Errorf("failed to apply migration %d: %w", m.version, err)
+		}
+	}
+	return nil
+}
+
+// SchemaVersion returns the version of the most recently applied migration, or zero for an empty database.
+func (s *Storage) SchemaVersion(ctx context.Context) (int, error) {
+	var version sql.NullInt64
+	if err := s.db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+func (s *Storage) applyMigration(ctx context.Context, m migration) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, stmt := range m.up(s.dialect) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	insert := s.dialect.Rebind(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`)
+	if _, err := tx.ExecContext(ctx, insert, m.version, time.Now().Unix()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
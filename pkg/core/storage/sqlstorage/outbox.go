@@ -0,0 +1,176 @@
+package sqlstorage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+const outboxColumns = `id, kind, txid, topics, beef, status, attempts, next_attempt_at, last_error, created_at, updated_at`
+
+// InsertOutboxEntries queues the entries within one database transaction.
+func (s *Storage) InsertOutboxEntries(ctx context.Context, entries []*engine.OutboxEntry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := insertOutboxEntries(ctx, tx, s.dialect, entries); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit outbox entries: %w", err)
+	}
+	return nil
+}
+
+// InsertOutboxEntries queues the entries as part of the database transaction.
+func (t *Tx) InsertOutboxEntries(ctx context.Context, entries []*engine.OutboxEntry) error {
+	return insertOutboxEntries(ctx, t.tx, t.dialect, entries)
+}
+
+func insertOutboxEntries(ctx context.Context, q querier, dialect Dialect, entries []*engine.OutboxEntry) error {
+	query := dialect.Rebind(`INSERT INTO outbox (` + outboxColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	for _, entry := range entries {
+		topics, err := json.Marshal(entry.Topics)
+		if err != nil {
+			return fmt.Errorf("failed to encode outbox entry topics: %w", err)
+		}
+		if _, err := q.ExecContext(ctx, query,
+			entry.ID,
+			string(entry.Kind),
+			entry.Txid.String(),
+			string(topics),
+			entry.Beef,
+			string(entry.Status),
+			int64(entry.Attempts),
+			entry.NextAttemptAt.UnixMilli(),
+			entry.LastError,
+			entry.CreatedAt.UnixMilli(),
+			entry.UpdatedAt.UnixMilli(),
+		); err != nil {
+			return fmt.Errorf("failed to insert outbox entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// UpdateOutboxEntry records the outcome of an attempt of the entry. Updating an entry
+// that is not stored is a no-op.
+func (s *Storage) UpdateOutboxEntry(ctx context.Context, entry *engine.OutboxEntry) error {
+	const query = `UPDATE outbox
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, updated_at = ?
+		WHERE id = ?`
+	if _, err := s.exec(ctx, query,
+		string(entry.Status),
+		int64(entry.Attempts),
+		entry.NextAttemptAt.UnixMilli(),
+		entry.LastError,
+		entry.UpdatedAt.UnixMilli(),
+		entry.ID,
+	); err != nil {
+		return fmt.Errorf("failed to update outbox entry: %w", err)
+	}
+	return nil
+}
+
+// FindDueOutboxEntries returns at most limit pending entries whose next attempt is not after
+// the given time, ordered by their next attempt.
+func (s *Storage) FindDueOutboxEntries(ctx context.Context, before time.Time, limit int) ([]*engine.OutboxEntry, error) {
+	const query = `SELECT ` + outboxColumns + ` FROM outbox
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, created_at, id
+		LIMIT ?`
+	return s.queryOutboxEntries(ctx, query, string(engine.OutboxPending), before.UnixMilli(), limit)
+}
+
+// FindOutboxEntries returns the entries of the transaction with the status ordered by the time
+// they were queued. A nil txid or an empty status matches every entry.
+func (s *Storage) FindOutboxEntries(ctx context.Context, txid *chainhash.Hash, status engine.OutboxStatus) ([]*engine.OutboxEntry, error) {
+	const query = `SELECT ` + outboxColumns + ` FROM outbox
+		WHERE (? = '' OR txid = ?) AND (? = '' OR status = ?)
+		ORDER BY created_at, id`
+	var hex string
+	if txid != nil {
+		hex = txid.String()
+	}
+	return s.queryOutboxEntries(ctx, query, hex, hex, string(status), string(status))
+}
+
+func (s *Storage) queryOutboxEntries(ctx context.Context, query string, args ...any) ([]*engine.OutboxEntry, error) {
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox entries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := make([]*engine.OutboxEntry, 0)
+	for rows.Next() {
+		entry, err := scanOutboxEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox entries: %w", err)
+	}
+	return entries, nil
+}
+
+func scanOutboxEntry(row rowScanner) (*engine.OutboxEntry, error) {
+	var (
+		entry         engine.OutboxEntry
+		kind          string
+		txid          string
+		topics        string
+		status        string
+		attempts      int64
+		nextAttemptAt int64
+		lastError     sql.NullString
+		createdAt     int64
+		updatedAt     int64
+	)
+	if err := row.Scan(
+		&entry.ID,
+		&kind,
+		&txid,
+		&topics,
+		&entry.Beef,
+		&status,
+		&attempts,
+		&nextAttemptAt,
+		&lastError,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+	hash, err := chainhash.NewHashFromHex(txid)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(topics), &entry.Topics); err != nil {
+		return nil, err
+	}
+	entry.Kind = engine.OutboxKind(kind)
+	entry.Txid = *hash
+	entry.Status = engine.OutboxStatus(status)
+	entry.Attempts = int(attempts)
+	entry.NextAttemptAt = time.UnixMilli(nextAttemptAt)
+	entry.LastError = lastError.String
+	entry.CreatedAt = time.UnixMilli(createdAt)
+	entry.UpdatedAt = time.UnixMilli(updatedAt)
+	return &entry, nil
+}
+
+var (
+	_ engine.OutboxStorage     = (*Storage)(nil)
+	_ engine.OutboxTransaction = (*Tx)(nil)
+)
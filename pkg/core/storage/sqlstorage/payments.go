@@ -0,0 +1,76 @@
+package sqlstorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+const paymentReceiptColumns = `txid, satoshis, route, payer, derivation_prefix, derivation_suffix, created_at`
+
+// InsertPaymentReceipt stores the receipt. It returns engine.ErrPaymentAlreadyRedeemed when
+// a receipt of the same transaction is already stored.
+func (s *Storage) InsertPaymentReceipt(ctx context.Context, receipt *engine.PaymentReceipt) error {
+	const query = `INSERT INTO payment_receipts (` + paymentReceiptColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (txid) DO NOTHING`
+	res, err := s.exec(ctx, query,
+		receipt.Txid.String(),
+		int64(receipt.Satoshis),
+		receipt.Route,
+		receipt.Payer,
+		receipt.DerivationPrefix,
+		receipt.DerivationSuffix,
+		receipt.CreatedAt.UnixMilli(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert payment receipt: %w", err)
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to insert payment receipt: %w", err)
+	}
+	if inserted == 0 {
+		return engine.ErrPaymentAlreadyRedeemed
+	}
+	return nil
+}
+
+// FindPaymentReceipts returns the stored receipts ordered by the time the payments were accepted.
+func (s *Storage) FindPaymentReceipts(ctx context.Context) ([]*engine.PaymentReceipt, error) {
+	const query = `SELECT ` + paymentReceiptColumns + ` FROM payment_receipts ORDER BY created_at, txid`
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment receipts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	receipts := make([]*engine.PaymentReceipt, 0)
+	for rows.Next() {
+		var (
+			receipt   engine.PaymentReceipt
+			txid      string
+			satoshis  int64
+			createdAt int64
+		)
+		if err := rows.Scan(&txid, &satoshis, &receipt.Route, &receipt.Payer, &receipt.DerivationPrefix, &receipt.DerivationSuffix, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan payment receipt: %w", err)
+		}
+		hash, err := chainhash.NewHashFromHex(txid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment receipt: %w", err)
+		}
+		receipt.Txid = *hash
+		receipt.Satoshis = uint64(satoshis)
+		receipt.CreatedAt = time.UnixMilli(createdAt)
+		receipts = append(receipts, &receipt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payment receipts: %w", err)
+	}
+	return receipts, nil
+}
+
+var _ engine.PaymentReceiptStorage = (*Storage)(nil)
@@ -0,0 +1,34 @@
+package sqlstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// OpenSQLite opens the SQLite database described by the dsn, applies all pending
+// migrations and returns the storage ready for use. Use ":memory:" for an ephemeral database.
+func OpenSQLite(ctx context.Context, dsn string) (*Storage, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	// SQLite allows a single writer at a time, and every connection to an in-memory
+	// database would otherwise see its own, separate database.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.ExecContext(ctx, `PRAGMA foreign_keys = ON; PRAGMA busy_timeout = 5000;`); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to configure sqlite database: %w", err)
+	}
+
+	s := New(db, SQLite)
+	if err := s.Migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,494 @@
+package sqlstorage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const outputColumns = `o.txid, o.output_index, o.topic, o.script, o.satoshis, o.spent, o.spending_txid, o.spent_at, o.outputs_consumed, o.consumed_by, o.block_height, o.block_idx, o.ancillary_txids, o.ancillary_beef`
+
+// querier is the subset of database/sql shared by *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// rowScanner is the subset of database/sql shared by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// store implements the storage operations on top of a querier, which is either the
+// database handle itself or a database transaction.
+type store struct {
+	q       querier
+	dialect Dialect
+	now     func() time.Time
+}
+
+// Storage is an engine.Storage implementation backed by a relational database.
+// Transaction BEEFs are stored once per transaction and shared by every output
+// of that transaction across all topics.
+type Storage struct {
+	*store
+	db *sql.DB
+}
+
+// New creates a Storage on top of the given database handle using the given dialect.
+// When the dialect is nil, SQLite is used. The schema is not created automatically,
+// call Migrate before using the storage.
+func New(db *sql.DB, dialect Dialect) *Storage {
+	if db == nil {
+		panic("sql storage database handle is nil")
+	}
+	if dialect == nil {
+		dialect = SQLite
+	}
+	return &Storage{
+		store: &store{
+			q:       db,
+			dialect: dialect,
+			now:     time.Now,
+		},
+		db: db,
+	}
+}
+
+// DB returns the underlying database handle.
+func (s *Storage) DB() *sql.DB { return s.db }
+
+// Close closes the underlying database handle.
+func (s *Storage) Close() error { return s.db.Close() }
+
+// Begin starts a database transaction. Every operation made through the returned
+// transaction runs within it until Commit or Rollback is called.
+func (s *Storage) Begin(ctx context.Context) (engine.StorageTransaction, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return &Tx{
+		store: &store{
+			q:       tx,
+			dialect: s.dialect,
+			now:     s.now,
+		},
+		tx: tx,
+	}, nil
+}
+
+// Tx is an engine.StorageTransaction backed by a database transaction.
+type Tx struct {
+	*store
+	tx *sql.Tx
+}
+
+// Commit commits the database transaction.
+func (t *Tx) Commit() error { return t.tx.Commit() }
+
+// Rollback aborts the database transaction.
+func (t *Tx) Rollback() error { return t.tx.Rollback() }
+
+// InsertOutput stores the output together with its transaction BEEF. Inserting an output
+// that already exists for the same topic is a no-op, which keeps its spent state intact.
+func (s *store) InsertOutput(ctx context.Context, utxo *engine.Output) error {
+	txid := utxo.Outpoint.Txid.String()
+	if len(utxo.Beef) > 0 {
+		const query = `INSERT INTO transactions (txid, beef) VALUES (?, ?) ON CONFLICT (txid) DO NOTHING`
+		if _, err := s.exec(ctx, query, txid, utxo.Beef); err != nil {
+			return fmt.Errorf("failed to insert transaction beef: %w", err)
+		}
+	}
+
+	outputsConsumed, err := encodeOutpoints(utxo.OutputsConsumed)
+	if err != nil {
+		return err
+	}
+	consumedBy, err := encodeOutpoints(utxo.ConsumedBy)
+	if err != nil {
+		return err
+	}
+	ancillaryTxids, err := encodeHashes(utxo.AncillaryTxids)
+	if err != nil {
+		return err
+	}
+
+	var lockingScript []byte
+	if utxo.Script != nil {
+		lockingScript = *utxo.Script
+	}
+	var spendingTxid *string
+	if utxo.SpendingTxid != nil {
+		hex := utxo.SpendingTxid.String()
+		spendingTxid = &hex
+	}
+
+	var spentAt int64
+	if !utxo.SpentAt.IsZero() {
+		spentAt = utxo.SpentAt.Unix()
+	}
+
+	const query = `INSERT INTO outputs (txid, output_index, topic, script, satoshis, spent, spending_txid, spent_at, outputs_consumed, consumed_by, block_height, block_idx, ancillary_txids, ancillary_beef, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (txid, output_index, topic) DO NOTHING`
+	if _, err := s.exec(ctx, query,
+		txid,
+		int64(utxo.Outpoint.Index),
+		utxo.Topic,
+		lockingScript,
+		int64(utxo.Satoshis),
+		utxo.Spent,
+		spendingTxid,
+		spentAt,
+		outputsConsumed,
+		consumedBy,
+		int64(utxo.BlockHeight),
+		int64(utxo.BlockIdx),
+		ancillaryTxids,
+		utxo.AncillaryBeef,
+		s.now().Unix(),
+	); err != nil {
+		return fmt.Errorf("failed to insert output: %w", err)
+	}
+	return nil
+}
+
+// FindOutput returns the output matching the outpoint and the optional topic and spent filters.
+// It returns a nil output and a nil error when no output matches.
+func (s *store) FindOutput(ctx context.Context, outpoint *transaction.Outpoint, topic *string, spent *bool, includeBEEF bool) (*engine.Output, error) {
+	query := s.selectOutputs(includeBEEF) + ` WHERE o.txid = ? AND o.output_index = ?`
+	args := []any{outpoint.Txid.String(), int64(outpoint.Index)}
+	if topic != nil {
+		query += ` AND o.topic = ?`
+		args = append(args, *topic)
+	}
+	if spent != nil {
+		query += ` AND o.spent = ?`
+		args = append(args, *spent)
+	}
+	query += ` ORDER BY o.topic LIMIT 1`
+
+	output, err := scanOutput(s.q.QueryRowContext(ctx, s.dialect.Rebind(query), args...), includeBEEF)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find output: %w", err)
+	}
+	return output, nil
+}
+
+// FindOutputs returns the outputs for the given outpoints within the topic.
+// The result is aligned with the outpoints slice; missing outputs are represented by nil entries.
+func (s *store) FindOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spent *bool, includeBEEF bool) ([]*engine.Output, error) {
+	outputs := make([]*engine.Output, len(outpoints))
+	for i, outpoint := range outpoints {
+		output, err := s.FindOutput(ctx, outpoint, &topic, spent, includeBEEF)
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = output
+	}
+	return outputs, nil
+}
+
+// FindOutputsForTransaction returns every output of the transaction across all topics.
+func (s *store) FindOutputsForTransaction(ctx context.Context, txid *chainhash.Hash, includeBEEF bool) ([]*engine.Output, error) {
+	query := s.selectOutputs(includeBEEF) + ` WHERE o.txid = ? ORDER BY o.output_index, o.topic`
+	return s.queryOutputs(ctx, includeBEEF, query, txid.String())
+}
+
+// FindOutputsSinceBlockHeight returns every output mined in a block at or above the block height
+// across all topics, ordered by block height.
+func (s *store) FindOutputsSinceBlockHeight(ctx context.Context, blockHeight uint32, includeBEEF bool) ([]*engine.Output, error) {
+	query := s.selectOutputs(includeBEEF) + ` WHERE o.block_height > 0 AND o.block_height >= ? ORDER BY o.block_height, o.created_at, o.txid, o.output_index, o.topic`
+	return s.queryOutputs(ctx, includeBEEF, query, int64(blockHeight))
+}
+
+// FindUTXOsForTopic returns the unspent outputs of the topic admitted at or after
+// the since unix timestamp, ordered by admission time.
+func (s *store) FindUTXOsForTopic(ctx context.Context, topic string, since uint32, includeBEEF bool) ([]*engine.Output, error) {
+	query := s.selectOutputs(includeBEEF) + ` WHERE o.topic = ? AND o.spent = ? AND o.created_at >= ? ORDER BY o.created_at, o.txid, o.output_index`
+	return s.queryOutputs(ctx, includeBEEF, query, topic, false, int64(since))
+}
+
+// FindSpentOutputsForTopic returns the spent outputs of the topic, ordered by admission time.
+func (s *store) FindSpentOutputsForTopic(ctx context.Context, topic string, includeBEEF bool) ([]*engine.Output, error) {
+	query := s.selectOutputs(includeBEEF) + ` WHERE o.topic = ? AND o.spent = ? ORDER BY o.created_at, o.txid, o.output_index`
+	return s.queryOutputs(ctx, includeBEEF, query, topic, true)
+}
+
+// DeleteOutput removes the output from the topic. The transaction BEEF is removed
+// once no output references it anymore. Deleting a missing output is a no-op.
+func (s *store) DeleteOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	txid := outpoint.Txid.String()
+	if _, err := s.exec(ctx, `DELETE FROM outputs WHERE txid = ? AND output_index = ? AND topic = ?`, txid, int64(outpoint.Index), topic); err != nil {
+		return fmt.Errorf("failed to delete output: %w", err)
+	}
+	if _, err := s.exec(ctx, `DELETE FROM transactions WHERE txid = ? AND NOT EXISTS (SELECT 1 FROM outputs WHERE txid = ?)`, txid, txid); err != nil {
+		return fmt.Errorf("failed to delete transaction beef: %w", err)
+	}
+	return nil
+}
+
+// MarkUTXOsAsSpent marks the outputs of the topic as spent by the given transaction.
+// Outpoints that are not stored for the topic are ignored.
+func (s *store) MarkUTXOsAsSpent(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spendTxid *chainhash.Hash) error {
+	var spendingTxid *string
+	if spendTxid != nil {
+		str := spendTxid.String()
+		spendingTxid = &str
+	}
+	spentAt := s.now().Unix()
+	for _, outpoint := range outpoints {
+		const query = `UPDATE outputs SET spent = ?, spending_txid = ?, spent_at = ? WHERE txid = ? AND output_index = ? AND topic = ?`
+		if _, err := s.exec(ctx, query, true, spendingTxid, spentAt, outpoint.Txid.String(), int64(outpoint.Index), topic); err != nil {
+			return fmt.Errorf("failed to mark output as spent: %w", err)
+		}
+	}
+	return nil
+}
+
+// UpdateConsumedBy replaces the list of outputs consuming the given output.
+// It returns engine.ErrNotFound when the output is not stored for the topic.
+func (s *store) UpdateConsumedBy(ctx context.Context, outpoint *transaction.Outpoint, topic string, consumedBy []*transaction.Outpoint) error {
+	encoded, err := encodeOutpoints(consumedBy)
+	if err != nil {
+		return err
+	}
+	const query = `UPDATE outputs SET consumed_by = ? WHERE txid = ? AND output_index = ? AND topic = ?`
+	res, err := s.exec(ctx, query, encoded, outpoint.Txid.String(), int64(outpoint.Index), topic)
+	if err != nil {
+		return fmt.Errorf("failed to update consumed by: %w", err)
+	}
+	return requireAffected(res)
+}
+
+// UpdateTransactionBEEF replaces the BEEF of the transaction shared by all its outputs.
+// It returns engine.ErrNotFound when no output of the transaction is stored.
+func (s *store) UpdateTransactionBEEF(ctx context.Context, txid *chainhash.Hash, beef []byte) error {
+	var exists int
+	err := s.q.QueryRowContext(ctx, s.dialect.Rebind(`SELECT 1 FROM outputs WHERE txid = ? LIMIT 1`), txid.String()).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return engine.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find transaction outputs: %w", err)
+	}
+
+	const query = `INSERT INTO transactions (txid, beef) VALUES (?, ?) ON CONFLICT (txid) DO UPDATE SET beef = excluded.beef`
+	if _, err := s.exec(ctx, query, txid.String(), beef); err != nil {
+		return fmt.Errorf("failed to update transaction beef: %w", err)
+	}
+	return nil
+}
+
+// UpdateOutputBlockHeight sets the block position and the ancillary BEEF of the output.
+// It returns engine.ErrNotFound when the output is not stored for the topic.
+func (s *store) UpdateOutputBlockHeight(ctx context.Context, outpoint *transaction.Outpoint, topic string, blockHeight uint32, blockIndex uint64, ancillaryBeef []byte) error {
+	const query = `UPDATE outputs SET block_height = ?, block_idx = ?, ancillary_beef = ? WHERE txid = ? AND output_index = ? AND topic = ?`
+	res, err := s.exec(ctx, query, int64(blockHeight), int64(blockIndex), ancillaryBeef, outpoint.Txid.String(), int64(outpoint.Index), topic)
+	if err != nil {
+		return fmt.Errorf("failed to update output block height: %w", err)
+	}
+	return requireAffected(res)
+}
+
+// InsertAppliedTransaction records that the transaction was applied to the topic.
+// Recording the same transaction twice is a no-op.
+func (s *store) InsertAppliedTransaction(ctx context.Context, tx *overlay.AppliedTransaction) error {
+	const query = `INSERT INTO applied_transactions (txid, topic) VALUES (?, ?) ON CONFLICT (txid, topic) DO NOTHING`
+	if _, err := s.exec(ctx, query, tx.Txid.String(), tx.Topic); err != nil {
+		return fmt.Errorf("failed to insert applied transaction: %w", err)
+	}
+	return nil
+}
+
+// DoesAppliedTransactionExist reports whether the transaction was already applied to the topic.
+func (s *store) DoesAppliedTransactionExist(ctx context.Context, tx *overlay.AppliedTransaction) (bool, error) {
+	var exists int
+	const query = `SELECT 1 FROM applied_transactions WHERE txid = ? AND topic = ?`
+	err := s.q.QueryRowContext(ctx, s.dialect.Rebind(query), tx.Txid.String(), tx.Topic).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find applied transaction: %w", err)
+	}
+	return true, nil
+}
+
+func (s *store) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return s.q.ExecContext(ctx, s.dialect.Rebind(query), args...)
+}
+
+func (s *store) selectOutputs(includeBEEF bool) string {
+	if includeBEEF {
+		return `SELECT ` + outputColumns + `, t.beef FROM outputs o LEFT JOIN transactions t ON t.txid = o.txid`
+	}
+	return `SELECT ` + outputColumns + ` FROM outputs o`
+}
+
+func (s *store) queryOutputs(ctx context.Context, includeBEEF bool, query string, args ...any) ([]*engine.Output, error) {
+	rows, err := s.q.QueryContext(ctx, s.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outputs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var outputs []*engine.Output
+	for rows.Next() {
+		output, err := scanOutput(rows, includeBEEF)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan output: %w", err)
+		}
+		outputs = append(outputs, output)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outputs: %w", err)
+	}
+	return outputs, nil
+}
+
+func scanOutput(row rowScanner, includeBEEF bool) (*engine.Output, error) {
+	var (
+		txid            string
+		outputIndex     int64
+		topic           string
+		lockingScript   []byte
+		satoshis        int64
+		spent           bool
+		spendingTxid    sql.NullString
+		spentAt         int64
+		outputsConsumed string
+		consumedBy      string
+		blockHeight     int64
+		blockIdx        int64
+		ancillaryTxids  string
+		ancillaryBeef   []byte
+		beef            []byte
+	)
+	dest := []any{&txid, &outputIndex, &topic, &lockingScript, &satoshis, &spent, &spendingTxid, &spentAt, &outputsConsumed, &consumedBy, &blockHeight, &blockIdx, &ancillaryTxids, &ancillaryBeef}
+	if includeBEEF {
+		dest = append(dest, &beef)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	hash, err := chainhash.NewHashFromHex(txid)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &engine.Output{
+		Outpoint:      transaction.Outpoint{Txid: *hash, Index: uint32(outputIndex)},
+		Topic:         topic,
+		Satoshis:      uint64(satoshis),
+		Spent:         spent,
+		BlockHeight:   uint32(blockHeight),
+		BlockIdx:      uint64(blockIdx),
+		AncillaryBeef: nonEmpty(ancillaryBeef),
+		Beef:          nonEmpty(beef),
+	}
+	if lockingScript != nil {
+		s := script.Script(lockingScript)
+		output.Script = &s
+	}
+	if spendingTxid.Valid {
+		if output.SpendingTxid, err = chainhash.NewHashFromHex(spendingTxid.String); err != nil {
+			return nil, err
+		}
+	}
+	if spentAt != 0 {
+		output.SpentAt = time.Unix(spentAt, 0)
+	}
+	if output.OutputsConsumed, err = decodeOutpoints(outputsConsumed); err != nil {
+		return nil, err
+	}
+	if output.ConsumedBy, err = decodeOutpoints(consumedBy); err != nil {
+		return nil, err
+	}
+	if output.AncillaryTxids, err = decodeHashes(ancillaryTxids); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func requireAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	if n == 0 {
+		return engine.ErrNotFound
+	}
+	return nil
+}
+
+func encodeOutpoints(outpoints []*transaction.Outpoint) (string, error) {
+	if len(outpoints) == 0 {
+		return "[]", nil
+	}
+	b, err := json.Marshal(outpoints)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode outpoints: %w", err)
+	}
+	return string(b), nil
+}
+
+func decodeOutpoints(s string) ([]*transaction.Outpoint, error) {
+	if s == "" || s == "[]" {
+		return nil, nil
+	}
+	var outpoints []*transaction.Outpoint
+	if err := json.Unmarshal([]byte(s), &outpoints); err != nil {
+		return nil, fmt.Errorf("failed to decode outpoints: %w", err)
+	}
+	return outpoints, nil
+}
+
+func encodeHashes(hashes []*chainhash.Hash) (string, error) {
+	if len(hashes) == 0 {
+		return "[]", nil
+	}
+	b, err := json.Marshal(hashes)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode hashes: %w", err)
+	}
+	return string(b), nil
+}
+
+func decodeHashes(s string) ([]*chainhash.Hash, error) {
+	if s == "" || s == "[]" {
+		return nil, nil
+	}
+	var hashes []*chainhash.Hash
+	if err := json.Unmarshal([]byte(s), &hashes); err != nil {
+		return nil, fmt.Errorf("failed to decode hashes: %w", err)
+	}
+	return hashes, nil
+}
+
+func nonEmpty(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+var (
+	_ engine.Storage            = (*Storage)(nil)
+	_ engine.StorageTransaction = (*Tx)(nil)
+)
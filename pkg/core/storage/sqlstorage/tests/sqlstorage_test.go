@@ -0,0 +1,138 @@
+package sqlstorage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/sqlstorage"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/storagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStorage(t *testing.T) *sqlstorage.Storage {
+	t.Helper()
+
+	s, err := sqlstorage.OpenSQLite(context.Background(), ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestStorage_Conformance(t *testing.T) {
+	storagetest.RunStorageTests(t, func(t *testing.T) engine.Storage {
+		return newTestStorage(t)
+	})
+}
+
+func TestOverlayGASPStorage_Conformance(t *testing.T) {
+	storagetest.RunGASPStorageTests(t, storagetest.OverlayGASPStorageFactory(func(t *testing.T) engine.Storage {
+		return newTestStorage(t)
+	}))
+}
+
+func TestSyncCheckpointStorage_Conformance(t *testing.T) {
+	storagetest.RunSyncCheckpointStorageTests(t, func(t *testing.T) engine.SyncCheckpointStorage {
+		return newTestStorage(t)
+	})
+}
+
+func TestConflictStorage_Conformance(t *testing.T) {
+	storagetest.RunConflictStorageTests(t, func(t *testing.T) engine.ConflictStorage {
+		return newTestStorage(t)
+	})
+}
+
+func TestEvictionAuditStorage_Conformance(t *testing.T) {
+	storagetest.RunEvictionAuditStorageTests(t, func(t *testing.T) engine.EvictionAuditStorage {
+		return newTestStorage(t)
+	})
+}
+
+func TestWebhookStorage_Conformance(t *testing.T) {
+	storagetest.RunWebhookStorageTests(t, func(t *testing.T) engine.WebhookStorage {
+		return newTestStorage(t)
+	})
+}
+
+func TestOutboxStorage_Conformance(t *testing.T) {
+	storagetest.RunOutboxStorageTests(t, func(t *testing.T) engine.OutboxStorage {
+		return newTestStorage(t)
+	})
+}
+
+func TestPaymentReceiptStorage_Conformance(t *testing.T) {
+	storagetest.RunPaymentReceiptStorageTests(t, func(t *testing.T) engine.PaymentReceiptStorage {
+		return newTestStorage(t)
+	})
+}
+
+func TestStorage_DeleteOutput_ShouldRemoveBeef_WhenLastOutputDeleted(t *testing.T) {
+	// given
+	ctx := context.Background()
+	sut := newTestStorage(t)
+
+	first := storagetest.NewOutput(t, 1, 0, storagetest.Topic)
+	second := storagetest.NewOutput(t, 1, 1, storagetest.Topic)
+	require.NoError(t, sut.InsertOutput(ctx, first))
+	require.NoError(t, sut.InsertOutput(ctx, second))
+
+	// when
+	require.NoError(t, sut.DeleteOutput(ctx, &first.Outpoint, storagetest.Topic))
+	remaining, err := sut.FindOutputsForTransaction(ctx, &first.Outpoint.Txid, true)
+	require.NoError(t, err)
+	require.NoError(t, sut.DeleteOutput(ctx, &second.Outpoint, storagetest.Topic))
+
+	// then
+	require.Len(t, remaining, 1)
+	require.Equal(t, first.Beef, remaining[0].Beef)
+
+	var count int
+	require.NoError(t, sut.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM transactions`).Scan(&count))
+	require.Zero(t, count)
+}
+
+func TestStorage_Migrate_ShouldBeIdempotent(t *testing.T) {
+	// given
+	ctx := context.Background()
+	sut := newTestStorage(t)
+
+	expected, err := sut.SchemaVersion(ctx)
+	require.NoError(t, err)
+
+	// when
+	err = sut.Migrate(ctx)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.SchemaVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+	require.Positive(t, actual)
+}
+
+func TestDialect_Rebind(t *testing.T) {
+	tests := map[string]struct {
+		dialect  sqlstorage.Dialect
+		expected string
+	}{
+		"sqlite keeps question mark placeholders": {
+			dialect:  sqlstorage.SQLite,
+			expected: "SELECT * FROM outputs WHERE txid = ? AND topic = ?",
+		},
+		"postgres uses numbered placeholders": {
+			dialect:  sqlstorage.Postgres,
+			expected: "SELECT * FROM outputs WHERE txid = $1 AND topic = $2",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when
+			actual := tc.dialect.Rebind("SELECT * FROM outputs WHERE txid = ? AND topic = ?")
+
+			// then
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
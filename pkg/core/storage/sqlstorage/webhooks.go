@@ -0,0 +1,208 @@
+package sqlstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+const (
+	webhookColumns         = `id, topic, url, secret, created_at`
+	webhookDeliveryColumns = `id, webhook_id, topic, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, updated_at`
+)
+
+// InsertWebhook stores the webhook.
+func (s *Storage) InsertWebhook(ctx context.Context, webhook *engine.Webhook) error {
+	const query = `INSERT INTO webhooks (` + webhookColumns + `) VALUES (?, ?, ?, ?, ?)`
+	if _, err := s.exec(ctx, query,
+		webhook.ID,
+		webhook.Topic,
+		webhook.URL,
+		webhook.Secret,
+		webhook.CreatedAt.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("failed to insert webhook: %w", err)
+	}
+	return nil
+}
+
+// DeleteWebhook deletes the webhook, keeping its deliveries. It returns engine.ErrNotFound
+// when the webhook is not stored.
+func (s *Storage) DeleteWebhook(ctx context.Context, id string) error {
+	res, err := s.exec(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if deleted == 0 {
+		return engine.ErrNotFound
+	}
+	return nil
+}
+
+// FindWebhooks returns the stored webhooks ordered by the time they were registered.
+func (s *Storage) FindWebhooks(ctx context.Context) ([]*engine.Webhook, error) {
+	const query = `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY created_at, id`
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	webhooks := make([]*engine.Webhook, 0)
+	for rows.Next() {
+		var (
+			webhook   engine.Webhook
+			createdAt int64
+		)
+		if err := rows.Scan(&webhook.ID, &webhook.Topic, &webhook.URL, &webhook.Secret, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhook.CreatedAt = time.UnixMilli(createdAt)
+		webhooks = append(webhooks, &webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// InsertWebhookDeliveries queues the deliveries within one database transaction.
+func (s *Storage) InsertWebhookDeliveries(ctx context.Context, deliveries []*engine.WebhookDelivery) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := s.dialect.Rebind(`INSERT INTO webhook_deliveries (` + webhookDeliveryColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	for _, delivery := range deliveries {
+		if _, err := tx.ExecContext(ctx, query,
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.Topic,
+			string(delivery.EventType),
+			delivery.Payload,
+			string(delivery.Status),
+			int64(delivery.Attempts),
+			delivery.NextAttemptAt.UnixMilli(),
+			int64(delivery.LastStatusCode),
+			delivery.LastError,
+			delivery.CreatedAt.UnixMilli(),
+			delivery.UpdatedAt.UnixMilli(),
+		); err != nil {
+			return fmt.Errorf("failed to insert webhook delivery: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// UpdateWebhookDelivery records the outcome of an attempt of the delivery. Updating a delivery
+// that is not stored is a no-op.
+func (s *Storage) UpdateWebhookDelivery(ctx context.Context, delivery *engine.WebhookDelivery) error {
+	const query = `UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?, updated_at = ?
+		WHERE id = ?`
+	if _, err := s.exec(ctx, query,
+		string(delivery.Status),
+		int64(delivery.Attempts),
+		delivery.NextAttemptAt.UnixMilli(),
+		int64(delivery.LastStatusCode),
+		delivery.LastError,
+		delivery.UpdatedAt.UnixMilli(),
+		delivery.ID,
+	); err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// FindDueWebhookDeliveries returns at most limit pending deliveries whose next attempt is not after
+// the given time, ordered by their next attempt.
+func (s *Storage) FindDueWebhookDeliveries(ctx context.Context, before time.Time, limit int) ([]*engine.WebhookDelivery, error) {
+	const query = `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, created_at, id
+		LIMIT ?`
+	return s.queryWebhookDeliveries(ctx, query, string(engine.WebhookDeliveryPending), before.UnixMilli(), limit)
+}
+
+// FindWebhookDeliveries returns the deliveries of the webhook with the status ordered by the time
+// they were queued. An empty webhook ID or status matches every delivery.
+func (s *Storage) FindWebhookDeliveries(ctx context.Context, webhookID string, status engine.WebhookDeliveryStatus) ([]*engine.WebhookDelivery, error) {
+	const query = `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+		WHERE (? = '' OR webhook_id = ?) AND (? = '' OR status = ?)
+		ORDER BY created_at, id`
+	return s.queryWebhookDeliveries(ctx, query, webhookID, webhookID, string(status), string(status))
+}
+
+func (s *Storage) queryWebhookDeliveries(ctx context.Context, query string, args ...any) ([]*engine.WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	deliveries := make([]*engine.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func scanWebhookDelivery(row rowScanner) (*engine.WebhookDelivery, error) {
+	var (
+		delivery       engine.WebhookDelivery
+		eventType      string
+		status         string
+		attempts       int64
+		nextAttemptAt  int64
+		lastStatusCode int64
+		lastError      sql.NullString
+		createdAt      int64
+		updatedAt      int64
+	)
+	if err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.Topic,
+		&eventType,
+		&delivery.Payload,
+		&status,
+		&attempts,
+		&nextAttemptAt,
+		&lastStatusCode,
+		&lastError,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+	delivery.EventType = engine.EventType(eventType)
+	delivery.Status = engine.WebhookDeliveryStatus(status)
+	delivery.Attempts = int(attempts)
+	delivery.NextAttemptAt = time.UnixMilli(nextAttemptAt)
+	delivery.LastStatusCode = int(lastStatusCode)
+	delivery.LastError = lastError.String
+	delivery.CreatedAt = time.UnixMilli(createdAt)
+	delivery.UpdatedAt = time.UnixMilli(updatedAt)
+	return &delivery, nil
+}
+
+var _ engine.WebhookStorage = (*Storage)(nil)
@@ -0,0 +1,128 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// SyncCheckpointStorageFactory returns a new, empty engine.SyncCheckpointStorage.
+// It is called once per test case.
+type SyncCheckpointStorageFactory func(t *testing.T) engine.SyncCheckpointStorage
+
+// SyncCheckpointStorageTestCase is a single behavioral test of the engine.SyncCheckpointStorage suite.
+type SyncCheckpointStorageTestCase struct {
+	Name string
+	Run  func(t *testing.T, storage engine.SyncCheckpointStorage)
+}
+
+// RunSyncCheckpointStorageTests runs every case of SyncCheckpointStorageTestCases against
+// storages created by the factory.
+func RunSyncCheckpointStorageTests(t *testing.T, factory SyncCheckpointStorageFactory) {
+	t.Helper()
+
+	for _, tc := range SyncCheckpointStorageTestCases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Run(t, factory(t))
+		})
+	}
+}
+
+// SyncCheckpointStorageTestCases returns the behavioral tests of the engine.SyncCheckpointStorage suite.
+func SyncCheckpointStorageTestCases() []SyncCheckpointStorageTestCase {
+	return []SyncCheckpointStorageTestCase{
+		{Name: "FindSyncCheckpoint should return nil without error when checkpoint not found", Run: testFindSyncCheckpointNotFound},
+		{Name: "UpsertSyncCheckpoint should replace checkpoint of the same topic and peer", Run: testUpsertSyncCheckpoint},
+		{Name: "FindSyncCheckpoints should filter by topic and order by topic and peer", Run: testFindSyncCheckpoints},
+		{Name: "DeleteSyncCheckpoints should delete matching checkpoints only", Run: testDeleteSyncCheckpoints},
+	}
+}
+
+// NewSyncCheckpoint returns a checkpoint of the topic and peer recorded at a fixed time.
+func NewSyncCheckpoint(topic, peer string, since uint32) *engine.SyncCheckpoint {
+	return &engine.SyncCheckpoint{
+		Topic:     topic,
+		Peer:      peer,
+		Since:     since,
+		UpdatedAt: time.UnixMilli(1_700_000_000_000 + int64(since)),
+	}
+}
+
+func testFindSyncCheckpointNotFound(t *testing.T, sut engine.SyncCheckpointStorage) {
+	// given
+	ctx := context.Background()
+	require.NoError(t, sut.UpsertSyncCheckpoint(ctx, NewSyncCheckpoint(OtherTopic, "https://peer.example.com", 1)))
+
+	// when
+	actual, err := sut.FindSyncCheckpoint(ctx, Topic, "https://peer.example.com")
+
+	// then
+	require.NoError(t, err)
+	require.Nil(t, actual)
+}
+
+func testUpsertSyncCheckpoint(t *testing.T, sut engine.SyncCheckpointStorage) {
+	// given
+	ctx := context.Background()
+	expected := NewSyncCheckpoint(Topic, "https://peer.example.com", 200)
+	require.NoError(t, sut.UpsertSyncCheckpoint(ctx, NewSyncCheckpoint(Topic, "https://peer.example.com", 100)))
+
+	// when
+	err := sut.UpsertSyncCheckpoint(ctx, expected)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindSyncCheckpoint(ctx, Topic, "https://peer.example.com")
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func testFindSyncCheckpoints(t *testing.T, sut engine.SyncCheckpointStorage) {
+	// given
+	ctx := context.Background()
+	first := NewSyncCheckpoint(Topic, "https://a.example.com", 1)
+	second := NewSyncCheckpoint(Topic, "https://b.example.com", 2)
+	other := NewSyncCheckpoint(OtherTopic, "https://a.example.com", 3)
+	for _, checkpoint := range []*engine.SyncCheckpoint{second, other, first} {
+		require.NoError(t, sut.UpsertSyncCheckpoint(ctx, checkpoint))
+	}
+
+	// when
+	forTopic, err := sut.FindSyncCheckpoints(ctx, Topic)
+	require.NoError(t, err)
+	all, err := sut.FindSyncCheckpoints(ctx, "")
+	require.NoError(t, err)
+
+	// then
+	require.Equal(t, []*engine.SyncCheckpoint{first, second}, forTopic)
+	require.Equal(t, []*engine.SyncCheckpoint{first, second, other}, all)
+}
+
+func testDeleteSyncCheckpoints(t *testing.T, sut engine.SyncCheckpointStorage) {
+	// given
+	ctx := context.Background()
+	first := NewSyncCheckpoint(Topic, "https://a.example.com", 1)
+	second := NewSyncCheckpoint(Topic, "https://b.example.com", 2)
+	other := NewSyncCheckpoint(OtherTopic, "https://a.example.com", 3)
+	for _, checkpoint := range []*engine.SyncCheckpoint{first, second, other} {
+		require.NoError(t, sut.UpsertSyncCheckpoint(ctx, checkpoint))
+	}
+
+	// when
+	byPeer, err := sut.DeleteSyncCheckpoints(ctx, "", "https://a.example.com")
+	require.NoError(t, err)
+	byTopic, err := sut.DeleteSyncCheckpoints(ctx, Topic, "")
+	require.NoError(t, err)
+
+	// then
+	require.Equal(t, 2, byPeer)
+	require.Equal(t, 1, byTopic)
+
+	remaining, err := sut.FindSyncCheckpoints(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, remaining)
+}
@@ -0,0 +1,144 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// ConflictStorageFactory returns a new, empty engine.ConflictStorage.
+// It is called once per test case.
+type ConflictStorageFactory func(t *testing.T) engine.ConflictStorage
+
+// ConflictStorageTestCase is a single behavioral test of the engine.ConflictStorage suite.
+type ConflictStorageTestCase struct {
+	Name string
+	Run  func(t *testing.T, storage engine.ConflictStorage)
+}
+
+// RunConflictStorageTests runs every case of ConflictStorageTestCases against storages created by the factory.
+func RunConflictStorageTests(t *testing.T, factory ConflictStorageFactory) {
+	t.Helper()
+
+	for _, tc := range ConflictStorageTestCases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Run(t, factory(t))
+		})
+	}
+}
+
+// ConflictStorageTestCases returns the behavioral tests of the engine.ConflictStorage suite.
+func ConflictStorageTestCases() []ConflictStorageTestCase {
+	return []ConflictStorageTestCase{
+		{Name: "InsertConflict should keep the first record of the same topic, outpoint and competing transaction", Run: testInsertConflictDuplicate},
+		{Name: "FindConflicts should filter by topic and order by topic, outpoint and competing transaction", Run: testFindConflicts},
+		{Name: "FindConflictsForTransaction should match admitted and competing transactions", Run: testFindConflictsForTransaction},
+		{Name: "DeleteConflicts should delete the conflicts of the outpoint within the topic only", Run: testDeleteConflicts},
+	}
+}
+
+// NewConflict returns a conflict over the first output of the transaction identified by
+// outpointByte, between the admitted and competing transactions identified by their bytes (see NewHash).
+func NewConflict(t *testing.T, topic string, outpointByte, admittedByte, competingByte byte) *engine.Conflict {
+	t.Helper()
+
+	return &engine.Conflict{
+		Topic:         topic,
+		Outpoint:      transaction.Outpoint{Txid: *NewHash(t, outpointByte)},
+		AdmittedTxid:  *NewHash(t, admittedByte),
+		CompetingTxid: *NewHash(t, competingByte),
+		CompetingBeef: []byte{0xbe, 0xef, competingByte},
+		CreatedAt:     time.UnixMilli(1_700_000_000_000 + int64(competingByte)),
+	}
+}
+
+func testInsertConflictDuplicate(t *testing.T, sut engine.ConflictStorage) {
+	// given
+	ctx := context.Background()
+	expected := NewConflict(t, Topic, 1, 2, 3)
+	require.NoError(t, sut.InsertConflict(ctx, expected))
+
+	duplicate := NewConflict(t, Topic, 1, 2, 3)
+	duplicate.CompetingBeef = []byte{0x00}
+
+	// when
+	err := sut.InsertConflict(ctx, duplicate)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindConflicts(ctx, Topic)
+	require.NoError(t, err)
+	require.Equal(t, []*engine.Conflict{expected}, actual)
+}
+
+func testFindConflicts(t *testing.T, sut engine.ConflictStorage) {
+	// given
+	ctx := context.Background()
+	first := NewConflict(t, Topic, 1, 2, 3)
+	second := NewConflict(t, Topic, 1, 2, 4)
+	third := NewConflict(t, Topic, 5, 6, 7)
+	other := NewConflict(t, OtherTopic, 1, 2, 3)
+	for _, conflict := range []*engine.Conflict{third, other, second, first} {
+		require.NoError(t, sut.InsertConflict(ctx, conflict))
+	}
+
+	// when
+	forTopic, err := sut.FindConflicts(ctx, Topic)
+	require.NoError(t, err)
+	all, err := sut.FindConflicts(ctx, "")
+	require.NoError(t, err)
+
+	// then
+	require.Equal(t, []*engine.Conflict{first, second, third}, forTopic)
+	require.Equal(t, []*engine.Conflict{first, second, third, other}, all)
+}
+
+func testFindConflictsForTransaction(t *testing.T, sut engine.ConflictStorage) {
+	// given
+	ctx := context.Background()
+	byAdmitted := NewConflict(t, Topic, 1, 2, 3)
+	byCompeting := NewConflict(t, Topic, 4, 5, 2)
+	unrelated := NewConflict(t, Topic, 6, 7, 8)
+	for _, conflict := range []*engine.Conflict{byAdmitted, byCompeting, unrelated} {
+		require.NoError(t, sut.InsertConflict(ctx, conflict))
+	}
+
+	// when
+	actual, err := sut.FindConflictsForTransaction(ctx, NewHash(t, 2))
+
+	// then
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*engine.Conflict{byAdmitted, byCompeting}, actual)
+
+	none, err := sut.FindConflictsForTransaction(ctx, NewHash(t, 9))
+	require.NoError(t, err)
+	require.Empty(t, none)
+}
+
+func testDeleteConflicts(t *testing.T, sut engine.ConflictStorage) {
+	// given
+	ctx := context.Background()
+	first := NewConflict(t, Topic, 1, 2, 3)
+	second := NewConflict(t, Topic, 1, 2, 4)
+	otherOutpoint := NewConflict(t, Topic, 5, 6, 7)
+	otherTopic := NewConflict(t, OtherTopic, 1, 2, 3)
+	for _, conflict := range []*engine.Conflict{first, second, otherOutpoint, otherTopic} {
+		require.NoError(t, sut.InsertConflict(ctx, conflict))
+	}
+
+	// when
+	deleted, err := sut.DeleteConflicts(ctx, Topic, &first.Outpoint)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	remaining, err := sut.FindConflicts(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, []*engine.Conflict{otherOutpoint, otherTopic}, remaining)
+}
@@ -0,0 +1,145 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// EvictionAuditStorageFactory returns a new, empty engine.EvictionAuditStorage.
+// It is called once per test case.
+type EvictionAuditStorageFactory func(t *testing.T) engine.EvictionAuditStorage
+
+// EvictionAuditStorageTestCase is a single behavioral test of the engine.EvictionAuditStorage suite.
+type EvictionAuditStorageTestCase struct {
+	Name string
+	Run  func(t *testing.T, storage engine.EvictionAuditStorage)
+}
+
+// RunEvictionAuditStorageTests runs every case of EvictionAuditStorageTestCases against storages created by the factory.
+func RunEvictionAuditStorageTests(t *testing.T, factory EvictionAuditStorageFactory) {
+	t.Helper()
+
+	for _, tc := range EvictionAuditStorageTestCases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Run(t, factory(t))
+		})
+	}
+}
+
+// EvictionAuditStorageTestCases returns the behavioral tests of the engine.EvictionAuditStorage suite.
+func EvictionAuditStorageTestCases() []EvictionAuditStorageTestCase {
+	return []EvictionAuditStorageTestCase{
+		{Name: "FindEvictions should return nothing when no eviction was recorded", Run: testFindEvictionsEmpty},
+		{Name: "FindEvictions should order the evictions by the time they were recorded", Run: testFindEvictionsOrder},
+		{Name: "InsertEviction should keep every record of the same outpoint", Run: testInsertEvictionAppends},
+		{Name: "InsertEviction within a transaction should only record the committed evictions", Run: testInsertEvictionWithinTransaction},
+	}
+}
+
+// NewEviction returns an eviction of the first output of the transaction identified by outpointByte
+// (see NewHash) from Topic, recorded createdAt milliseconds after a fixed point in time.
+func NewEviction(t *testing.T, outpointByte byte, createdAt int64) *engine.Eviction {
+	t.Helper()
+
+	outpoint := transaction.Outpoint{Txid: *NewHash(t, outpointByte)}
+	return &engine.Eviction{
+		Outpoint:       outpoint,
+		IncludeHistory: true,
+		Evicted: []engine.EvictedOutput{
+			{Outpoint: outpoint, Topic: Topic},
+			{Outpoint: transaction.Outpoint{Txid: *NewHash(t, outpointByte+1), Index: 1}, Topic: Topic},
+		},
+		Actor:     "admin",
+		Reason:    "takedown request",
+		CreatedAt: time.UnixMilli(1_700_000_000_000 + createdAt),
+	}
+}
+
+func testFindEvictionsEmpty(t *testing.T, sut engine.EvictionAuditStorage) {
+	// when
+	actual, err := sut.FindEvictions(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	require.Empty(t, actual)
+}
+
+func testFindEvictionsOrder(t *testing.T, sut engine.EvictionAuditStorage) {
+	// given
+	ctx := context.Background()
+	first := NewEviction(t, 1, 1)
+	second := NewEviction(t, 3, 2)
+	third := NewEviction(t, 5, 3)
+	for _, eviction := range []*engine.Eviction{third, first, second} {
+		require.NoError(t, sut.InsertEviction(ctx, eviction))
+	}
+
+	// when
+	actual, err := sut.FindEvictions(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []*engine.Eviction{first, second, third}, actual)
+}
+
+func testInsertEvictionAppends(t *testing.T, sut engine.EvictionAuditStorage) {
+	// given
+	ctx := context.Background()
+	first := NewEviction(t, 1, 1)
+	second := NewEviction(t, 1, 2)
+	second.IncludeHistory = false
+	second.Evicted = second.Evicted[:1]
+	second.Reason = "second takedown request"
+	require.NoError(t, sut.InsertEviction(ctx, first))
+
+	// when
+	err := sut.InsertEviction(ctx, second)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindEvictions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*engine.Eviction{first, second}, actual)
+}
+
+func testInsertEvictionWithinTransaction(t *testing.T, sut engine.EvictionAuditStorage) {
+	// given
+	ctx := context.Background()
+	storage, ok := sut.(engine.Storage)
+	if !ok {
+		t.Skip("the eviction audit storage does not support transactions")
+	}
+	committed := NewEviction(t, 1, 1)
+	rolledBack := NewEviction(t, 3, 2)
+
+	// when
+	for _, step := range []struct {
+		eviction *engine.Eviction
+		finish   func(engine.StorageTransaction) error
+	}{
+		{eviction: committed, finish: engine.StorageTransaction.Commit},
+		{eviction: rolledBack, finish: engine.StorageTransaction.Rollback},
+	} {
+		tx, err := storage.Begin(ctx)
+		require.NoError(t, err)
+		evictionTx, ok := tx.(engine.EvictionTransaction)
+		if !ok {
+			require.NoError(t, tx.Rollback())
+			t.Skip("the storage transactions do not support evictions")
+		}
+		require.NoError(t, evictionTx.InsertEviction(ctx, step.eviction))
+		require.NoError(t, step.finish(tx))
+	}
+
+	// then
+	actual, err := sut.FindEvictions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*engine.Eviction{committed}, actual)
+}
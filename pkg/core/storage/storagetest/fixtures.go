@@ -0,0 +1,112 @@
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// Topic is the topic used by the suite for outputs that are expected to match the queries.
+const Topic = "tm_storagetest"
+
+// OtherTopic is the topic used by the suite for outputs that are expected to be filtered out.
+const OtherTopic = "tm_storagetest_other"
+
+// NewHash returns a deterministic hash with every byte set to b.
+func NewHash(t *testing.T, b byte) *chainhash.Hash {
+	t.Helper()
+
+	var raw [chainhash.HashSize]byte
+	for i := range raw {
+		raw[i] = b
+	}
+	hash, err := chainhash.NewHash(raw[:])
+	require.NoError(t, err)
+	return hash
+}
+
+// NewOutput returns a fully populated, unspent output of the transaction identified by
+// txidByte (see NewHash) admitted into the given topic.
+func NewOutput(t *testing.T, txidByte byte, index uint32, topic string) *engine.Output {
+	t.Helper()
+
+	lockingScript := script.Script{script.OpDUP, script.OpHASH160, script.OpDATA1, txidByte}
+	return &engine.Output{
+		Outpoint: transaction.Outpoint{Txid: *NewHash(t, txidByte), Index: index},
+		Topic:    topic,
+		Script:   &lockingScript,
+		Satoshis: 1000 + uint64(index),
+		Beef:     []byte{0xbe, 0xef, txidByte},
+	}
+}
+
+// gaspFixture is a pair of transactions where the child spends the first output of the parent.
+// Both transactions are unproven, so the child BEEF carries the parent transaction.
+type gaspFixture struct {
+	parent      *transaction.Transaction
+	child       *transaction.Transaction
+	parentBEEF  []byte
+	childBEEF   []byte
+	parentPoint *transaction.Outpoint
+	childPoint  *transaction.Outpoint
+}
+
+func newGASPFixture(t *testing.T) *gaspFixture {
+	t.Helper()
+
+	parent := &transaction.Transaction{
+		Outputs: []*transaction.TransactionOutput{{Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}}},
+	}
+	child := &transaction.Transaction{
+		Inputs:  []*transaction.TransactionInput{{SourceTXID: parent.TxID(), SourceTxOutIndex: 0, SourceTransaction: parent}},
+		Outputs: []*transaction.TransactionOutput{{Satoshis: 900, LockingScript: &script.Script{script.OpTRUE}}},
+	}
+
+	parentBEEF := atomicBEEF(t, parent)
+	childBEEF := atomicBEEF(t, child)
+
+	return &gaspFixture{
+		parent:      parent,
+		child:       child,
+		parentBEEF:  parentBEEF,
+		childBEEF:   childBEEF,
+		parentPoint: &transaction.Outpoint{Txid: *parent.TxID(), Index: 0},
+		childPoint:  &transaction.Outpoint{Txid: *child.TxID(), Index: 0},
+	}
+}
+
+func (f *gaspFixture) parentOutput(topic string) *engine.Output {
+	return &engine.Output{
+		Outpoint: *f.parentPoint,
+		Topic:    topic,
+		Script:   f.parent.Outputs[0].LockingScript,
+		Satoshis: f.parent.Outputs[0].Satoshis,
+		Beef:     f.parentBEEF,
+	}
+}
+
+func (f *gaspFixture) childOutput(topic string) *engine.Output {
+	return &engine.Output{
+		Outpoint:        *f.childPoint,
+		Topic:           topic,
+		Script:          f.child.Outputs[0].LockingScript,
+		Satoshis:        f.child.Outputs[0].Satoshis,
+		OutputsConsumed: []*transaction.Outpoint{f.parentPoint},
+		Beef:            f.childBEEF,
+	}
+}
+
+func atomicBEEF(t *testing.T, tx *transaction.Transaction) []byte {
+	t.Helper()
+
+	beef, err := transaction.NewBeefFromTransaction(tx)
+	require.NoError(t, err)
+
+	bytes, err := beef.AtomicBytes(tx.TxID())
+	require.NoError(t, err)
+	return bytes
+}
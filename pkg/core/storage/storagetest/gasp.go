@@ -0,0 +1,196 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// GASPStorageFactory returns a new core.GASPStorage for the topic in which the given
+// outputs are already known. It is called once per test case.
+type GASPStorageFactory func(t *testing.T, topic string, known []*engine.Output) core.GASPStorage
+
+// GASPStorageTestCase is a single behavioral test of the core.GASPStorage suite.
+type GASPStorageTestCase struct {
+	Name string
+	Run  func(t *testing.T, factory GASPStorageFactory)
+}
+
+// OverlayGASPStorageFactory returns a GASPStorageFactory creating engine.OverlayGASPStorage
+// instances on top of storages created by the given factory.
+func OverlayGASPStorageFactory(factory StorageFactory) GASPStorageFactory {
+	return func(t *testing.T, topic string, known []*engine.Output) core.GASPStorage {
+		t.Helper()
+
+		storage := factory(t)
+		for _, output := range known {
+			require.NoError(t, storage.InsertOutput(context.Background(), output))
+		}
+
+		e := engine.NewEngine(engine.Engine{
+			Storage:  storage,
+			Managers: map[string]engine.TopicManager{},
+		})
+		return engine.NewOverlayGASPStorage(topic, e, nil)
+	}
+}
+
+// RunGASPStorageTests runs every case of GASPStorageTestCases using the factory.
+func RunGASPStorageTests(t *testing.T, factory GASPStorageFactory) {
+	t.Helper()
+
+	for _, tc := range GASPStorageTestCases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Run(t, factory)
+		})
+	}
+}
+
+// GASPStorageTestCases returns the behavioral tests of the core.GASPStorage suite.
+func GASPStorageTestCases() []GASPStorageTestCase {
+	return []GASPStorageTestCase{
+		{Name: "FindKnownUTXOs should return unspent outputs of the topic", Run: testFindKnownUTXOs},
+		{Name: "HydrateGASPNode should return node for known output", Run: testHydrateGASPNode},
+		{Name: "HydrateGASPNode should fail when output unknown", Run: testHydrateGASPNodeUnknown},
+		{Name: "FindNeededInputs should request only unknown inputs of unproven node", Run: testFindNeededInputs},
+		{Name: "FindNeededInputs should return nil when every input is known", Run: testFindNeededInputsAllKnown},
+		{Name: "AppendToGraph should fail when parent node missing", Run: testAppendToGraphMissingParent},
+		{Name: "DiscardGraph should remove graph nodes", Run: testDiscardGraph},
+		{Name: "FinalizeGraph should fail when graph unknown", Run: testFinalizeGraphUnknown},
+	}
+}
+
+func testFindKnownUTXOs(t *testing.T, factory GASPStorageFactory) {
+	// given
+	ctx := context.Background()
+
+	unspent := NewOutput(t, 1, 0, Topic)
+	spent := NewOutput(t, 2, 0, Topic)
+	spent.Spent = true
+	sut := factory(t, Topic, []*engine.Output{unspent, spent, NewOutput(t, 3, 0, OtherTopic)})
+
+	// when
+	actual, err := sut.FindKnownUTXOs(ctx, 0)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.Outpoint{&unspent.Outpoint}, actual)
+}
+
+func testHydrateGASPNode(t *testing.T, factory GASPStorageFactory) {
+	// given
+	ctx := context.Background()
+	fixture := newGASPFixture(t)
+	sut := factory(t, Topic, []*engine.Output{fixture.childOutput(Topic)})
+
+	// when
+	actual, err := sut.HydrateGASPNode(ctx, fixture.childPoint, fixture.childPoint, true)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, fixture.childPoint, actual.GraphID)
+	require.Equal(t, fixture.childPoint.Index, actual.OutputIndex)
+	require.Equal(t, fixture.child.Hex(), actual.RawTx)
+	require.Nil(t, actual.Proof)
+}
+
+func testHydrateGASPNodeUnknown(t *testing.T, factory GASPStorageFactory) {
+	// given
+	ctx := context.Background()
+	fixture := newGASPFixture(t)
+	sut := factory(t, Topic, nil)
+
+	// when
+	actual, err := sut.HydrateGASPNode(ctx, fixture.childPoint, fixture.childPoint, true)
+
+	// then
+	require.Error(t, err)
+	require.Nil(t, actual)
+}
+
+func testFindNeededInputs(t *testing.T, factory GASPStorageFactory) {
+	// given
+	ctx := context.Background()
+	fixture := newGASPFixture(t)
+	sut := factory(t, Topic, []*engine.Output{fixture.parentOutput(Topic)})
+
+	unknown := &transaction.Outpoint{Txid: *NewHash(t, 5), Index: 1}
+	tx := &transaction.Transaction{
+		Inputs: []*transaction.TransactionInput{
+			{SourceTXID: &fixture.parentPoint.Txid, SourceTxOutIndex: fixture.parentPoint.Index},
+			{SourceTXID: &unknown.Txid, SourceTxOutIndex: unknown.Index},
+		},
+		Outputs: fixture.child.Outputs,
+	}
+
+	// when
+	actual, err := sut.FindNeededInputs(ctx, &core.GASPNode{RawTx: tx.Hex(), GraphID: fixture.childPoint})
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	require.Len(t, actual.RequestedInputs, 1)
+	require.Contains(t, actual.RequestedInputs, unknown.String())
+}
+
+func testFindNeededInputsAllKnown(t *testing.T, factory GASPStorageFactory) {
+	// given
+	ctx := context.Background()
+	fixture := newGASPFixture(t)
+	sut := factory(t, Topic, []*engine.Output{fixture.parentOutput(Topic)})
+
+	// when
+	actual, err := sut.FindNeededInputs(ctx, &core.GASPNode{RawTx: fixture.child.Hex(), GraphID: fixture.childPoint})
+
+	// then
+	require.NoError(t, err)
+	require.Nil(t, actual)
+}
+
+func testAppendToGraphMissingParent(t *testing.T, factory GASPStorageFactory) {
+	// given
+	ctx := context.Background()
+	fixture := newGASPFixture(t)
+	sut := factory(t, Topic, nil)
+
+	// when
+	err := sut.AppendToGraph(ctx, &core.GASPNode{RawTx: fixture.parent.Hex(), GraphID: fixture.childPoint}, fixture.childPoint)
+
+	// then
+	require.Error(t, err)
+}
+
+func testDiscardGraph(t *testing.T, factory GASPStorageFactory) {
+	// given
+	ctx := context.Background()
+	fixture := newGASPFixture(t)
+	sut := factory(t, Topic, nil)
+
+	root := &core.GASPNode{RawTx: fixture.child.Hex(), GraphID: fixture.childPoint, OutputIndex: fixture.childPoint.Index}
+	require.NoError(t, sut.AppendToGraph(ctx, root, nil))
+
+	// when
+	err := sut.DiscardGraph(ctx, fixture.childPoint)
+
+	// then
+	require.NoError(t, err)
+	require.Error(t, sut.ValidateGraphAnchor(ctx, fixture.childPoint))
+	require.Error(t, sut.AppendToGraph(ctx, &core.GASPNode{RawTx: fixture.parent.Hex(), GraphID: fixture.childPoint}, fixture.childPoint))
+}
+
+func testFinalizeGraphUnknown(t *testing.T, factory GASPStorageFactory) {
+	// given
+	ctx := context.Background()
+	fixture := newGASPFixture(t)
+	sut := factory(t, Topic, nil)
+
+	// when
+	err := sut.FinalizeGraph(ctx, fixture.childPoint)
+
+	// then
+	require.Error(t, err)
+}
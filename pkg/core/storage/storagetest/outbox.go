@@ -0,0 +1,218 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+// OutboxStorageFactory returns a new, empty engine.OutboxStorage.
+// It is called once per test case.
+type OutboxStorageFactory func(t *testing.T) engine.OutboxStorage
+
+// OutboxStorageTestCase is a single behavioral test of the engine.OutboxStorage suite.
+type OutboxStorageTestCase struct {
+	Name string
+	Run  func(t *testing.T, storage engine.OutboxStorage)
+}
+
+// RunOutboxStorageTests runs every case of OutboxStorageTestCases against storages created by the factory.
+func RunOutboxStorageTests(t *testing.T, factory OutboxStorageFactory) {
+	t.Helper()
+
+	for _, tc := range OutboxStorageTestCases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Run(t, factory(t))
+		})
+	}
+}
+
+// OutboxStorageTestCases returns the behavioral tests of the engine.OutboxStorage suite.
+func OutboxStorageTestCases() []OutboxStorageTestCase {
+	return []OutboxStorageTestCase{
+		{Name: "FindOutboxEntries should return nothing when no entry was queued", Run: testFindOutboxEntriesEmpty},
+		{Name: "InsertOutboxEntries should keep the topics and BEEF of the entries", Run: testInsertOutboxEntries},
+		{Name: "InsertOutboxEntries within a transaction should only queue the committed entries", Run: testInsertOutboxEntriesWithinTransaction},
+		{Name: "FindDueOutboxEntries should return the due pending entries by next attempt", Run: testFindDueOutboxEntries},
+		{Name: "FindDueOutboxEntries should return at most limit entries", Run: testFindDueOutboxEntriesLimit},
+		{Name: "UpdateOutboxEntry should record the outcome of the attempt", Run: testUpdateOutboxEntry},
+		{Name: "FindOutboxEntries should filter by transaction and status", Run: testFindOutboxEntriesFilters},
+	}
+}
+
+// NewOutboxEntry returns a pending broadcast of the transaction identified by seed, queued createdAt
+// milliseconds and due nextAttemptAt milliseconds after a fixed point in time.
+func NewOutboxEntry(id string, seed byte, createdAt, nextAttemptAt int64) *engine.OutboxEntry {
+	return &engine.OutboxEntry{
+		ID:            id,
+		Kind:          engine.OutboxBroadcast,
+		Txid:          chainhash.Hash{seed},
+		Beef:          []byte{seed, 0xbe, 0xef},
+		Status:        engine.OutboxPending,
+		Attempts:      1,
+		NextAttemptAt: time.UnixMilli(1_700_000_000_000 + nextAttemptAt),
+		LastError:     "broadcast failure",
+		CreatedAt:     time.UnixMilli(1_700_000_000_000 + createdAt),
+		UpdatedAt:     time.UnixMilli(1_700_000_000_000 + createdAt),
+	}
+}
+
+func testFindOutboxEntriesEmpty(t *testing.T, sut engine.OutboxStorage) {
+	// when
+	actual, err := sut.FindOutboxEntries(context.Background(), nil, "")
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	require.Empty(t, actual)
+}
+
+func testInsertOutboxEntries(t *testing.T, sut engine.OutboxStorage) {
+	// given
+	ctx := context.Background()
+	broadcast := NewOutboxEntry("e1", 1, 1, 1)
+	propagation := NewOutboxEntry("e2", 1, 2, 2)
+	propagation.Kind = engine.OutboxPropagation
+	propagation.Topics = []string{Topic, "tm_other"}
+
+	// when
+	err := sut.InsertOutboxEntries(ctx, []*engine.OutboxEntry{propagation, broadcast})
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutboxEntries(ctx, nil, "")
+	require.NoError(t, err)
+	require.Equal(t, []*engine.OutboxEntry{broadcast, propagation}, actual)
+}
+
+func testInsertOutboxEntriesWithinTransaction(t *testing.T, sut engine.OutboxStorage) {
+	// given
+	ctx := context.Background()
+	storage, ok := sut.(engine.Storage)
+	if !ok {
+		t.Skip("the outbox storage does not support transactions")
+	}
+	committed := NewOutboxEntry("e1", 1, 1, 1)
+	rolledBack := NewOutboxEntry("e2", 2, 2, 2)
+
+	// when
+	for _, step := range []struct {
+		entry  *engine.OutboxEntry
+		finish func(engine.StorageTransaction) error
+	}{
+		{entry: committed, finish: engine.StorageTransaction.Commit},
+		{entry: rolledBack, finish: engine.StorageTransaction.Rollback},
+	} {
+		tx, err := storage.Begin(ctx)
+		require.NoError(t, err)
+		outboxTx, ok := tx.(engine.OutboxTransaction)
+		if !ok {
+			require.NoError(t, tx.Rollback())
+			t.Skip("the storage transactions do not support outbox entries")
+		}
+		require.NoError(t, outboxTx.InsertOutboxEntries(ctx, []*engine.OutboxEntry{step.entry}))
+		require.NoError(t, step.finish(tx))
+	}
+
+	// then
+	actual, err := sut.FindOutboxEntries(ctx, nil, "")
+	require.NoError(t, err)
+	require.Equal(t, []*engine.OutboxEntry{committed}, actual)
+}
+
+func testFindDueOutboxEntries(t *testing.T, sut engine.OutboxStorage) {
+	// given
+	ctx := context.Background()
+	later := NewOutboxEntry("e1", 1, 1, 20)
+	sooner := NewOutboxEntry("e2", 2, 2, 10)
+	notDue := NewOutboxEntry("e3", 3, 3, 40)
+	completed := NewOutboxEntry("e4", 4, 4, 5)
+	completed.Status = engine.OutboxCompleted
+	failed := NewOutboxEntry("e5", 5, 5, 5)
+	failed.Status = engine.OutboxFailed
+	require.NoError(t, sut.InsertOutboxEntries(ctx, []*engine.OutboxEntry{later, sooner, notDue, completed, failed}))
+
+	// when
+	actual, err := sut.FindDueOutboxEntries(ctx, time.UnixMilli(1_700_000_000_030), 10)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []*engine.OutboxEntry{sooner, later}, actual)
+}
+
+func testFindDueOutboxEntriesLimit(t *testing.T, sut engine.OutboxStorage) {
+	// given
+	ctx := context.Background()
+	first := NewOutboxEntry("e1", 1, 1, 1)
+	second := NewOutboxEntry("e2", 2, 2, 2)
+	third := NewOutboxEntry("e3", 3, 3, 3)
+	require.NoError(t, sut.InsertOutboxEntries(ctx, []*engine.OutboxEntry{third, first, second}))
+
+	// when
+	actual, err := sut.FindDueOutboxEntries(ctx, time.UnixMilli(1_700_000_000_010), 2)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []*engine.OutboxEntry{first, second}, actual)
+}
+
+func testUpdateOutboxEntry(t *testing.T, sut engine.OutboxStorage) {
+	// given
+	ctx := context.Background()
+	entry := NewOutboxEntry("e1", 1, 1, 1)
+	require.NoError(t, sut.InsertOutboxEntries(ctx, []*engine.OutboxEntry{entry}))
+
+	updated := *entry
+	updated.Status = engine.OutboxCompleted
+	updated.Attempts = 2
+	updated.NextAttemptAt = time.UnixMilli(1_700_000_000_100)
+	updated.LastError = ""
+	updated.UpdatedAt = time.UnixMilli(1_700_000_000_100)
+
+	// when
+	err := sut.UpdateOutboxEntry(ctx, &updated)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutboxEntries(ctx, nil, "")
+	require.NoError(t, err)
+	require.Equal(t, []*engine.OutboxEntry{&updated}, actual)
+}
+
+func testFindOutboxEntriesFilters(t *testing.T, sut engine.OutboxStorage) {
+	// given
+	ctx := context.Background()
+	pending := NewOutboxEntry("e1", 1, 1, 1)
+	failed := NewOutboxEntry("e2", 1, 2, 2)
+	failed.Status = engine.OutboxFailed
+	other := NewOutboxEntry("e3", 2, 3, 3)
+	other.Status = engine.OutboxFailed
+	require.NoError(t, sut.InsertOutboxEntries(ctx, []*engine.OutboxEntry{other, failed, pending}))
+
+	tests := map[string]struct {
+		txid     *chainhash.Hash
+		status   engine.OutboxStatus
+		expected []*engine.OutboxEntry
+	}{
+		"every entry":                       {expected: []*engine.OutboxEntry{pending, failed, other}},
+		"entries of the transaction":        {txid: &chainhash.Hash{1}, expected: []*engine.OutboxEntry{pending, failed}},
+		"failed entries":                    {status: engine.OutboxFailed, expected: []*engine.OutboxEntry{failed, other}},
+		"failed entries of the transaction": {txid: &chainhash.Hash{2}, status: engine.OutboxFailed, expected: []*engine.OutboxEntry{other}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when
+			actual, err := sut.FindOutboxEntries(ctx, tc.txid, tc.status)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// PaymentReceiptStorageFactory returns a new, empty engine.PaymentReceiptStorage.
+// It is called once per test case.
+type PaymentReceiptStorageFactory func(t *testing.T) engine.PaymentReceiptStorage
+
+// PaymentReceiptStorageTestCase is a single behavioral test of the engine.PaymentReceiptStorage suite.
+type PaymentReceiptStorageTestCase struct {
+	Name string
+	Run  func(t *testing.T, storage engine.PaymentReceiptStorage)
+}
+
+// RunPaymentReceiptStorageTests runs every case of PaymentReceiptStorageTestCases against storages created by the factory.
+func RunPaymentReceiptStorageTests(t *testing.T, factory PaymentReceiptStorageFactory) {
+	t.Helper()
+
+	for _, tc := range PaymentReceiptStorageTestCases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Run(t, factory(t))
+		})
+	}
+}
+
+// PaymentReceiptStorageTestCases returns the behavioral tests of the engine.PaymentReceiptStorage suite.
+func PaymentReceiptStorageTestCases() []PaymentReceiptStorageTestCase {
+	return []PaymentReceiptStorageTestCase{
+		{Name: "FindPaymentReceipts should return nothing when no payment was accepted", Run: testFindPaymentReceiptsEmpty},
+		{Name: "FindPaymentReceipts should order the receipts by the time the payments were accepted", Run: testFindPaymentReceiptsOrder},
+		{Name: "InsertPaymentReceipt should reject a second receipt of the same transaction", Run: testInsertPaymentReceiptRejectsRedeemed},
+	}
+}
+
+// NewPaymentReceipt returns the receipt of the payment transaction identified by txidByte (see NewHash),
+// accepted createdAt milliseconds after a fixed point in time.
+func NewPaymentReceipt(t *testing.T, txidByte byte, createdAt int64) *engine.PaymentReceipt {
+	t.Helper()
+
+	return &engine.PaymentReceipt{
+		Txid:             *NewHash(t, txidByte),
+		Satoshis:         1000,
+		Route:            "/api/v1/submit",
+		Payer:            "02a1633cafcc01ebfb6d78e39f687a1f0995c62fc95f51ead10a02ee0be551b5dc",
+		DerivationPrefix: "prefix",
+		DerivationSuffix: "suffix",
+		CreatedAt:        time.UnixMilli(1_700_000_000_000 + createdAt),
+	}
+}
+
+func testFindPaymentReceiptsEmpty(t *testing.T, sut engine.PaymentReceiptStorage) {
+	// when
+	actual, err := sut.FindPaymentReceipts(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	require.Empty(t, actual)
+}
+
+func testFindPaymentReceiptsOrder(t *testing.T, sut engine.PaymentReceiptStorage) {
+	// given
+	ctx := context.Background()
+	first := NewPaymentReceipt(t, 1, 1)
+	second := NewPaymentReceipt(t, 2, 2)
+	third := NewPaymentReceipt(t, 3, 3)
+	for _, receipt := range []*engine.PaymentReceipt{third, first, second} {
+		require.NoError(t, sut.InsertPaymentReceipt(ctx, receipt))
+	}
+
+	// when
+	actual, err := sut.FindPaymentReceipts(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []*engine.PaymentReceipt{first, second, third}, actual)
+}
+
+func testInsertPaymentReceiptRejectsRedeemed(t *testing.T, sut engine.PaymentReceiptStorage) {
+	// given
+	ctx := context.Background()
+	first := NewPaymentReceipt(t, 1, 1)
+	second := NewPaymentReceipt(t, 1, 2)
+	second.Route = "/api/v1/lookup"
+	require.NoError(t, sut.InsertPaymentReceipt(ctx, first))
+
+	// when
+	err := sut.InsertPaymentReceipt(ctx, second)
+
+	// then
+	require.ErrorIs(t, err, engine.ErrPaymentAlreadyRedeemed)
+
+	actual, err := sut.FindPaymentReceipts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*engine.PaymentReceipt{first}, actual)
+}
@@ -0,0 +1,653 @@
+// Package storagetest provides conformance test suites for engine.Storage and
+// core.GASPStorage implementations. The suites describe the behavior the overlay
+// engine relies on, so a backend passing them can be plugged into an Engine.
+//
+// Typical usage from a backend test package:
+//
+//	func TestStorageConformance(t *testing.T) {
+//		storagetest.RunStorageTests(t, func(t *testing.T) engine.Storage {
+//			return mybackend.New(...)
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// StorageFactory returns a new, empty engine.Storage. It is called once per test case,
+// so implementations must not share state between the returned instances.
+type StorageFactory func(t *testing.T) engine.Storage
+
+// StorageTestCase is a single behavioral test of the engine.Storage suite.
+type StorageTestCase struct {
+	Name string
+	Run  func(t *testing.T, storage engine.Storage)
+}
+
+// RunStorageTests runs every case of StorageTestCases against storages created by the factory.
+func RunStorageTests(t *testing.T, factory StorageFactory) {
+	t.Helper()
+
+	for _, tc := range StorageTestCases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Run(t, factory(t))
+		})
+	}
+}
+
+// StorageTestCases returns the behavioral tests of the engine.Storage suite.
+func StorageTestCases() []StorageTestCase {
+	return []StorageTestCase{
+		{Name: "InsertOutput should round trip every output field", Run: testInsertOutputRoundTrip},
+		{Name: "InsertOutput should keep spent state when output already exists", Run: testInsertOutputDuplicate},
+		{Name: "FindOutput should return nil without error when output not found", Run: testFindOutputNotFound},
+		{Name: "FindOutput should apply topic and spent filters", Run: testFindOutputFilters},
+		{Name: "FindOutput should omit BEEF unless requested", Run: testFindOutputIncludeBEEF},
+		{Name: "FindOutputs should align result with requested outpoints", Run: testFindOutputsAligned},
+		{Name: "FindOutputsForTransaction should return outputs across topics", Run: testFindOutputsForTransaction},
+		{Name: "FindUTXOsForTopic should return unspent outputs of the topic only", Run: testFindUTXOsForTopic},
+		{Name: "FindUTXOsForTopic should honor since filter", Run: testFindUTXOsForTopicSince},
+		{Name: "FindSpentOutputsForTopic should return spent outputs of the topic only", Run: testFindSpentOutputsForTopic},
+		{Name: "FindOutputsSinceBlockHeight should return mined outputs at or above the height ordered by height", Run: testFindOutputsSinceBlockHeight},
+		{Name: "MarkUTXOsAsSpent should only affect the given topic", Run: testMarkUTXOsAsSpentPerTopic},
+		{Name: "MarkUTXOsAsSpent should ignore outpoints not stored for the topic", Run: testMarkUTXOsAsSpentMissing},
+		{Name: "DeleteOutput should remove output from every query", Run: testDeleteOutput},
+		{Name: "DeleteOutput should keep BEEF of remaining outputs", Run: testDeleteOutputKeepsSharedBEEF},
+		{Name: "DeleteOutput should be idempotent", Run: testDeleteOutputIdempotent},
+		{Name: "UpdateConsumedBy should round trip consumers", Run: testUpdateConsumedBy},
+		{Name: "UpdateTransactionBEEF should update BEEF of every output of the transaction", Run: testUpdateTransactionBEEF},
+		{Name: "UpdateOutputBlockHeight should update block position", Run: testUpdateOutputBlockHeight},
+		{Name: "Updates should return ErrNotFound when output missing", Run: testUpdatesNotFound},
+		{Name: "AppliedTransaction should be tracked per topic", Run: testAppliedTransactionPerTopic},
+		{Name: "InsertAppliedTransaction should accept duplicates", Run: testAppliedTransactionDuplicate},
+		{Name: "Transaction should see its own writes and persist them on commit", Run: testTransactionCommit},
+		{Name: "Transaction should discard every write on rollback", Run: testTransactionRollback},
+		{Name: "Transaction should fail once committed or rolled back", Run: testTransactionDone},
+	}
+}
+
+func testInsertOutputRoundTrip(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	expected := NewOutput(t, 1, 0, Topic)
+	expected.OutputsConsumed = []*transaction.Outpoint{{Txid: *NewHash(t, 9), Index: 2}}
+	expected.ConsumedBy = []*transaction.Outpoint{{Txid: *NewHash(t, 7), Index: 0}, {Txid: *NewHash(t, 7), Index: 1}}
+	expected.AncillaryTxids = []*chainhash.Hash{NewHash(t, 8)}
+	expected.AncillaryBeef = []byte{0x01, 0x02}
+	expected.BlockHeight = 100
+	expected.BlockIdx = 3
+
+	// when
+	err := sut.InsertOutput(ctx, expected)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutput(ctx, &expected.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func testInsertOutputDuplicate(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+	require.NoError(t, sut.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&output.Outpoint}, Topic, NewHash(t, 2)))
+
+	// when
+	err := sut.InsertOutput(ctx, output)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.True(t, actual.Spent)
+}
+
+func testFindOutputNotFound(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+	topic := Topic
+
+	// when
+	actual, err := sut.FindOutput(ctx, &transaction.Outpoint{Txid: *NewHash(t, 1)}, &topic, nil, true)
+
+	// then
+	require.NoError(t, err)
+	require.Nil(t, actual)
+}
+
+func testFindOutputFilters(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+
+	topic, otherTopic := Topic, OtherTopic
+	spent, unspent := true, false
+
+	// when
+	byTopic, err := sut.FindOutput(ctx, &output.Outpoint, &topic, &unspent, false)
+	require.NoError(t, err)
+	byOtherTopic, err := sut.FindOutput(ctx, &output.Outpoint, &otherTopic, nil, false)
+	require.NoError(t, err)
+	bySpent, err := sut.FindOutput(ctx, &output.Outpoint, nil, &spent, false)
+	require.NoError(t, err)
+	byOtherIndex, err := sut.FindOutput(ctx, &transaction.Outpoint{Txid: output.Outpoint.Txid, Index: 1}, nil, nil, false)
+	require.NoError(t, err)
+
+	// then
+	require.NotNil(t, byTopic)
+	require.Nil(t, byOtherTopic)
+	require.Nil(t, bySpent)
+	require.Nil(t, byOtherIndex)
+}
+
+func testFindOutputIncludeBEEF(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+
+	// when
+	withBEEF, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	withoutBEEF, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, false)
+	require.NoError(t, err)
+
+	// then
+	require.Equal(t, output.Beef, withBEEF.Beef)
+	require.Empty(t, withoutBEEF.Beef)
+}
+
+func testFindOutputsAligned(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	first := NewOutput(t, 1, 0, Topic)
+	third := NewOutput(t, 3, 1, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, first))
+	require.NoError(t, sut.InsertOutput(ctx, third))
+	require.NoError(t, sut.InsertOutput(ctx, NewOutput(t, 2, 0, OtherTopic)))
+
+	outpoints := []*transaction.Outpoint{&first.Outpoint, {Txid: *NewHash(t, 2)}, &third.Outpoint}
+
+	// when
+	actual, err := sut.FindOutputs(ctx, outpoints, Topic, nil, false)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, actual, 3)
+	require.Equal(t, first.Outpoint, actual[0].Outpoint)
+	require.Nil(t, actual[1])
+	require.Equal(t, third.Outpoint, actual[2].Outpoint)
+}
+
+func testFindOutputsForTransaction(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	require.NoError(t, sut.InsertOutput(ctx, NewOutput(t, 1, 0, Topic)))
+	require.NoError(t, sut.InsertOutput(ctx, NewOutput(t, 1, 0, OtherTopic)))
+	require.NoError(t, sut.InsertOutput(ctx, NewOutput(t, 1, 1, Topic)))
+	require.NoError(t, sut.InsertOutput(ctx, NewOutput(t, 2, 0, Topic)))
+
+	// when
+	actual, err := sut.FindOutputsForTransaction(ctx, NewHash(t, 1), true)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, actual, 3)
+	for _, output := range actual {
+		require.Equal(t, *NewHash(t, 1), output.Outpoint.Txid)
+		require.Equal(t, []byte{0xbe, 0xef, 1}, output.Beef)
+	}
+}
+
+func testFindUTXOsForTopic(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	unspent := NewOutput(t, 1, 0, Topic)
+	spent := NewOutput(t, 2, 0, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, unspent))
+	require.NoError(t, sut.InsertOutput(ctx, spent))
+	require.NoError(t, sut.InsertOutput(ctx, NewOutput(t, 3, 0, OtherTopic)))
+	require.NoError(t, sut.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&spent.Outpoint}, Topic, NewHash(t, 4)))
+
+	// when
+	actual, err := sut.FindUTXOsForTopic(ctx, Topic, 0, false)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+	require.Equal(t, unspent.Outpoint, actual[0].Outpoint)
+	require.Equal(t, Topic, actual[0].Topic)
+}
+
+func testFindUTXOsForTopicSince(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	require.NoError(t, sut.InsertOutput(ctx, NewOutput(t, 1, 0, Topic)))
+
+	// when
+	all, err := sut.FindUTXOsForTopic(ctx, Topic, 0, false)
+	require.NoError(t, err)
+	future, err := sut.FindUTXOsForTopic(ctx, Topic, ^uint32(0), false)
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, all, 1)
+	require.Empty(t, future)
+}
+
+func testFindSpentOutputsForTopic(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	unspent := NewOutput(t, 1, 0, Topic)
+	spent := NewOutput(t, 2, 0, Topic)
+	other := NewOutput(t, 2, 0, OtherTopic)
+	for _, output := range []*engine.Output{unspent, spent, other} {
+		require.NoError(t, sut.InsertOutput(ctx, output))
+	}
+	require.NoError(t, sut.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&spent.Outpoint}, Topic, NewHash(t, 4)))
+	require.NoError(t, sut.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&other.Outpoint}, OtherTopic, NewHash(t, 4)))
+
+	// when
+	actual, err := sut.FindSpentOutputsForTopic(ctx, Topic, true)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+	require.Equal(t, spent.Outpoint, actual[0].Outpoint)
+	require.Equal(t, Topic, actual[0].Topic)
+	require.Equal(t, spent.Beef, actual[0].Beef)
+	require.False(t, actual[0].SpentAt.IsZero())
+}
+
+func testFindOutputsSinceBlockHeight(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	unmined := NewOutput(t, 1, 0, Topic)
+	below := NewOutput(t, 2, 0, Topic)
+	higher := NewOutput(t, 3, 0, Topic)
+	atHeight := NewOutput(t, 4, 0, OtherTopic)
+	for _, output := range []*engine.Output{unmined, below, higher, atHeight} {
+		require.NoError(t, sut.InsertOutput(ctx, output))
+	}
+	require.NoError(t, sut.UpdateOutputBlockHeight(ctx, &below.Outpoint, below.Topic, 99, 1, nil))
+	require.NoError(t, sut.UpdateOutputBlockHeight(ctx, &higher.Outpoint, higher.Topic, 101, 2, nil))
+	require.NoError(t, sut.UpdateOutputBlockHeight(ctx, &atHeight.Outpoint, atHeight.Topic, 100, 3, nil))
+
+	// when
+	actual, err := sut.FindOutputsSinceBlockHeight(ctx, 100, true)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+	require.Equal(t, atHeight.Outpoint, actual[0].Outpoint)
+	require.Equal(t, atHeight.Beef, actual[0].Beef)
+	require.Equal(t, higher.Outpoint, actual[1].Outpoint)
+	require.Equal(t, uint32(101), actual[1].BlockHeight)
+
+	all, err := sut.FindOutputsSinceBlockHeight(ctx, 0, false)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+}
+
+func testMarkUTXOsAsSpentPerTopic(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	other := NewOutput(t, 1, 0, OtherTopic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+	require.NoError(t, sut.InsertOutput(ctx, other))
+
+	// when
+	err := sut.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&output.Outpoint}, Topic, NewHash(t, 2))
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutput(ctx, &output.Outpoint, &output.Topic, nil, false)
+	require.NoError(t, err)
+	require.True(t, actual.Spent)
+	require.Equal(t, NewHash(t, 2), actual.SpendingTxid)
+	require.False(t, actual.SpentAt.IsZero())
+
+	actual, err = sut.FindOutput(ctx, &other.Outpoint, &other.Topic, nil, false)
+	require.NoError(t, err)
+	require.False(t, actual.Spent)
+	require.Nil(t, actual.SpendingTxid)
+	require.True(t, actual.SpentAt.IsZero())
+}
+
+func testMarkUTXOsAsSpentMissing(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+
+	missing := &transaction.Outpoint{Txid: *NewHash(t, 7)}
+
+	// when
+	err := sut.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{missing, &output.Outpoint}, Topic, NewHash(t, 2))
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.True(t, actual.Spent)
+
+	found, err := sut.FindOutput(ctx, missing, nil, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
+
+func testDeleteOutput(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+
+	// when
+	err := sut.DeleteOutput(ctx, &output.Outpoint, Topic)
+
+	// then
+	require.NoError(t, err)
+
+	found, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	forTx, err := sut.FindOutputsForTransaction(ctx, &output.Outpoint.Txid, true)
+	require.NoError(t, err)
+	require.Empty(t, forTx)
+
+	utxos, err := sut.FindUTXOsForTopic(ctx, Topic, 0, false)
+	require.NoError(t, err)
+	require.Empty(t, utxos)
+}
+
+func testDeleteOutputKeepsSharedBEEF(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	deleted := NewOutput(t, 1, 0, Topic)
+	kept := NewOutput(t, 1, 0, OtherTopic)
+	require.NoError(t, sut.InsertOutput(ctx, deleted))
+	require.NoError(t, sut.InsertOutput(ctx, kept))
+
+	// when
+	err := sut.DeleteOutput(ctx, &deleted.Outpoint, Topic)
+
+	// then
+	require.NoError(t, err)
+
+	otherTopic := OtherTopic
+	actual, err := sut.FindOutput(ctx, &kept.Outpoint, &otherTopic, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, kept.Beef, actual.Beef)
+}
+
+func testDeleteOutputIdempotent(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+	require.NoError(t, sut.DeleteOutput(ctx, &output.Outpoint, Topic))
+
+	// when
+	err := sut.DeleteOutput(ctx, &output.Outpoint, Topic)
+
+	// then
+	require.NoError(t, err)
+}
+
+func testUpdateConsumedBy(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	output.ConsumedBy = []*transaction.Outpoint{{Txid: *NewHash(t, 4), Index: 0}}
+	require.NoError(t, sut.InsertOutput(ctx, output))
+
+	expected := []*transaction.Outpoint{{Txid: *NewHash(t, 2), Index: 0}, {Txid: *NewHash(t, 3), Index: 5}}
+
+	// when
+	err := sut.UpdateConsumedBy(ctx, &output.Outpoint, Topic, expected)
+	require.NoError(t, err)
+	otherTopicErr := sut.UpdateConsumedBy(ctx, &output.Outpoint, OtherTopic, nil)
+
+	// then
+	require.ErrorIs(t, otherTopicErr, engine.ErrNotFound)
+
+	actual, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual.ConsumedBy)
+}
+
+func testUpdateTransactionBEEF(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	require.NoError(t, sut.InsertOutput(ctx, NewOutput(t, 1, 0, Topic)))
+	require.NoError(t, sut.InsertOutput(ctx, NewOutput(t, 1, 1, OtherTopic)))
+
+	expected := []byte{0x0b, 0xee, 0xf0}
+
+	// when
+	err := sut.UpdateTransactionBEEF(ctx, NewHash(t, 1), expected)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutputsForTransaction(ctx, NewHash(t, 1), true)
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+	for _, output := range actual {
+		require.Equal(t, expected, output.Beef)
+	}
+}
+
+func testUpdateOutputBlockHeight(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, output))
+
+	// when
+	err := sut.UpdateOutputBlockHeight(ctx, &output.Outpoint, Topic, 800000, 12, []byte{0xaa})
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(800000), actual.BlockHeight)
+	require.Equal(t, uint64(12), actual.BlockIdx)
+	require.Equal(t, []byte{0xaa}, actual.AncillaryBeef)
+}
+
+func testUpdatesNotFound(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	outpoint := &transaction.Outpoint{Txid: *NewHash(t, 1)}
+
+	// when
+	consumedByErr := sut.UpdateConsumedBy(ctx, outpoint, Topic, nil)
+	beefErr := sut.UpdateTransactionBEEF(ctx, &outpoint.Txid, []byte{0x01})
+	blockHeightErr := sut.UpdateOutputBlockHeight(ctx, outpoint, Topic, 1, 1, nil)
+
+	// then
+	require.ErrorIs(t, consumedByErr, engine.ErrNotFound)
+	require.ErrorIs(t, beefErr, engine.ErrNotFound)
+	require.ErrorIs(t, blockHeightErr, engine.ErrNotFound)
+}
+
+func testAppliedTransactionPerTopic(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	applied := &overlay.AppliedTransaction{Txid: NewHash(t, 1), Topic: Topic}
+	other := &overlay.AppliedTransaction{Txid: NewHash(t, 1), Topic: OtherTopic}
+
+	// when
+	err := sut.InsertAppliedTransaction(ctx, applied)
+
+	// then
+	require.NoError(t, err)
+
+	exists, err := sut.DoesAppliedTransactionExist(ctx, applied)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = sut.DoesAppliedTransactionExist(ctx, other)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func testAppliedTransactionDuplicate(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	applied := &overlay.AppliedTransaction{Txid: NewHash(t, 1), Topic: Topic}
+	require.NoError(t, sut.InsertAppliedTransaction(ctx, applied))
+
+	// when
+	err := sut.InsertAppliedTransaction(ctx, applied)
+
+	// then
+	require.NoError(t, err)
+
+	exists, err := sut.DoesAppliedTransactionExist(ctx, applied)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func testTransactionCommit(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	output := NewOutput(t, 1, 0, Topic)
+	applied := &overlay.AppliedTransaction{Txid: NewHash(t, 1), Topic: Topic}
+
+	tx, err := sut.Begin(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, tx.InsertOutput(ctx, output))
+	require.NoError(t, tx.InsertAppliedTransaction(ctx, applied))
+
+	found, err := tx.FindOutput(ctx, &output.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, output, found)
+
+	// when
+	err = tx.Commit()
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindOutput(ctx, &output.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, output, actual)
+
+	exists, err := sut.DoesAppliedTransactionExist(ctx, applied)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func testTransactionRollback(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	spent := NewOutput(t, 1, 0, Topic)
+	deleted := NewOutput(t, 2, 0, Topic)
+	require.NoError(t, sut.InsertOutput(ctx, spent))
+	require.NoError(t, sut.InsertOutput(ctx, deleted))
+
+	inserted := NewOutput(t, 3, 0, Topic)
+	applied := &overlay.AppliedTransaction{Txid: NewHash(t, 3), Topic: Topic}
+
+	tx, err := sut.Begin(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, tx.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&spent.Outpoint}, Topic, NewHash(t, 3)))
+	require.NoError(t, tx.UpdateConsumedBy(ctx, &spent.Outpoint, Topic, []*transaction.Outpoint{&inserted.Outpoint}))
+	require.NoError(t, tx.DeleteOutput(ctx, &deleted.Outpoint, Topic))
+	require.NoError(t, tx.InsertOutput(ctx, inserted))
+	require.NoError(t, tx.InsertAppliedTransaction(ctx, applied))
+
+	// when
+	err = tx.Rollback()
+
+	// then
+	require.NoError(t, err)
+
+	actualSpent, err := sut.FindOutput(ctx, &spent.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, spent, actualSpent)
+
+	actualDeleted, err := sut.FindOutput(ctx, &deleted.Outpoint, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, deleted, actualDeleted)
+
+	actualInserted, err := sut.FindOutput(ctx, &inserted.Outpoint, nil, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, actualInserted)
+
+	exists, err := sut.DoesAppliedTransactionExist(ctx, applied)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func testTransactionDone(t *testing.T, sut engine.Storage) {
+	// given
+	ctx := context.Background()
+
+	committed, err := sut.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, committed.Commit())
+
+	rolledBack, err := sut.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, rolledBack.Rollback())
+
+	// when
+	commitErr := committed.Commit()
+	rollbackErr := rolledBack.Rollback()
+	insertErr := committed.InsertOutput(ctx, NewOutput(t, 1, 0, Topic))
+
+	// then
+	require.Error(t, commitErr)
+	require.Error(t, rollbackErr)
+	require.Error(t, insertErr)
+
+	actual, err := sut.FindOutput(ctx, &transaction.Outpoint{Txid: *NewHash(t, 1)}, nil, nil, false)
+	require.NoError(t, err)
+	require.Nil(t, actual)
+}
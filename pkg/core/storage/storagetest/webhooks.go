@@ -0,0 +1,228 @@
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// WebhookStorageFactory returns a new, empty engine.WebhookStorage.
+// It is called once per test case.
+type WebhookStorageFactory func(t *testing.T) engine.WebhookStorage
+
+// WebhookStorageTestCase is a single behavioral test of the engine.WebhookStorage suite.
+type WebhookStorageTestCase struct {
+	Name string
+	Run  func(t *testing.T, storage engine.WebhookStorage)
+}
+
+// RunWebhookStorageTests runs every case of WebhookStorageTestCases against storages created by the factory.
+func RunWebhookStorageTests(t *testing.T, factory WebhookStorageFactory) {
+	t.Helper()
+
+	for _, tc := range WebhookStorageTestCases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Run(t, factory(t))
+		})
+	}
+}
+
+// WebhookStorageTestCases returns the behavioral tests of the engine.WebhookStorage suite.
+func WebhookStorageTestCases() []WebhookStorageTestCase {
+	return []WebhookStorageTestCase{
+		{Name: "FindWebhooks should return nothing when no webhook was stored", Run: testFindWebhooksEmpty},
+		{Name: "FindWebhooks should order the webhooks by the time they were registered", Run: testFindWebhooksOrder},
+		{Name: "DeleteWebhook should remove the webhook", Run: testDeleteWebhook},
+		{Name: "DeleteWebhook should return ErrNotFound when the webhook is not stored", Run: testDeleteWebhookNotFound},
+		{Name: "FindDueWebhookDeliveries should return the due pending deliveries by next attempt", Run: testFindDueWebhookDeliveries},
+		{Name: "FindDueWebhookDeliveries should return at most limit deliveries", Run: testFindDueWebhookDeliveriesLimit},
+		{Name: "UpdateWebhookDelivery should record the outcome of the attempt", Run: testUpdateWebhookDelivery},
+		{Name: "FindWebhookDeliveries should filter by webhook and status", Run: testFindWebhookDeliveriesFilters},
+	}
+}
+
+// NewWebhook returns a webhook of Topic identified by id, registered createdAt milliseconds after
+// a fixed point in time.
+func NewWebhook(id string, createdAt int64) *engine.Webhook {
+	return &engine.Webhook{
+		ID:        id,
+		Topic:     Topic,
+		URL:       "https://example.com/hooks/" + id,
+		Secret:    "secret-" + id,
+		CreatedAt: time.UnixMilli(1_700_000_000_000 + createdAt),
+	}
+}
+
+// NewWebhookDelivery returns a pending delivery to the webhook identified by webhookID, queued
+// createdAt milliseconds and due nextAttemptAt milliseconds after a fixed point in time.
+func NewWebhookDelivery(id, webhookID string, createdAt, nextAttemptAt int64) *engine.WebhookDelivery {
+	return &engine.WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhookID,
+		Topic:         Topic,
+		EventType:     engine.EventOutputAdmitted,
+		Payload:       []byte(fmt.Sprintf(`{"deliveryId":%q}`, id)),
+		Status:        engine.WebhookDeliveryPending,
+		NextAttemptAt: time.UnixMilli(1_700_000_000_000 + nextAttemptAt),
+		CreatedAt:     time.UnixMilli(1_700_000_000_000 + createdAt),
+		UpdatedAt:     time.UnixMilli(1_700_000_000_000 + createdAt),
+	}
+}
+
+func testFindWebhooksEmpty(t *testing.T, sut engine.WebhookStorage) {
+	// when
+	actual, err := sut.FindWebhooks(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	require.Empty(t, actual)
+}
+
+func testFindWebhooksOrder(t *testing.T, sut engine.WebhookStorage) {
+	// given
+	ctx := context.Background()
+	first, second, third := NewWebhook("a", 1), NewWebhook("b", 2), NewWebhook("c", 3)
+	for _, webhook := range []*engine.Webhook{third, first, second} {
+		require.NoError(t, sut.InsertWebhook(ctx, webhook))
+	}
+
+	// when
+	actual, err := sut.FindWebhooks(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []*engine.Webhook{first, second, third}, actual)
+}
+
+func testDeleteWebhook(t *testing.T, sut engine.WebhookStorage) {
+	// given
+	ctx := context.Background()
+	kept, deleted := NewWebhook("a", 1), NewWebhook("b", 2)
+	require.NoError(t, sut.InsertWebhook(ctx, kept))
+	require.NoError(t, sut.InsertWebhook(ctx, deleted))
+	require.NoError(t, sut.InsertWebhookDeliveries(ctx, []*engine.WebhookDelivery{NewWebhookDelivery("d1", "b", 1, 1)}))
+
+	// when
+	err := sut.DeleteWebhook(ctx, "b")
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindWebhooks(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*engine.Webhook{kept}, actual)
+
+	deliveries, err := sut.FindWebhookDeliveries(ctx, "b", "")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+}
+
+func testDeleteWebhookNotFound(t *testing.T, sut engine.WebhookStorage) {
+	// when
+	err := sut.DeleteWebhook(context.Background(), "missing")
+
+	// then
+	require.ErrorIs(t, err, engine.ErrNotFound)
+}
+
+func testFindDueWebhookDeliveries(t *testing.T, sut engine.WebhookStorage) {
+	// given
+	ctx := context.Background()
+	later := NewWebhookDelivery("d1", "a", 1, 20)
+	sooner := NewWebhookDelivery("d2", "a", 2, 10)
+	notDue := NewWebhookDelivery("d3", "a", 3, 40)
+	delivered := NewWebhookDelivery("d4", "a", 4, 5)
+	delivered.Status = engine.WebhookDeliveryDelivered
+	dead := NewWebhookDelivery("d5", "a", 5, 5)
+	dead.Status = engine.WebhookDeliveryDead
+	require.NoError(t, sut.InsertWebhookDeliveries(ctx, []*engine.WebhookDelivery{later, sooner, notDue, delivered, dead}))
+
+	// when
+	actual, err := sut.FindDueWebhookDeliveries(ctx, time.UnixMilli(1_700_000_000_030), 10)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []*engine.WebhookDelivery{sooner, later}, actual)
+}
+
+func testFindDueWebhookDeliveriesLimit(t *testing.T, sut engine.WebhookStorage) {
+	// given
+	ctx := context.Background()
+	first := NewWebhookDelivery("d1", "a", 1, 1)
+	second := NewWebhookDelivery("d2", "a", 2, 2)
+	third := NewWebhookDelivery("d3", "a", 3, 3)
+	require.NoError(t, sut.InsertWebhookDeliveries(ctx, []*engine.WebhookDelivery{third, first, second}))
+
+	// when
+	actual, err := sut.FindDueWebhookDeliveries(ctx, time.UnixMilli(1_700_000_000_010), 2)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []*engine.WebhookDelivery{first, second}, actual)
+}
+
+func testUpdateWebhookDelivery(t *testing.T, sut engine.WebhookStorage) {
+	// given
+	ctx := context.Background()
+	delivery := NewWebhookDelivery("d1", "a", 1, 1)
+	require.NoError(t, sut.InsertWebhookDeliveries(ctx, []*engine.WebhookDelivery{delivery}))
+
+	updated := *delivery
+	updated.Status = engine.WebhookDeliveryDead
+	updated.Attempts = 3
+	updated.NextAttemptAt = time.UnixMilli(1_700_000_000_100)
+	updated.LastStatusCode = 503
+	updated.LastError = "webhook responded with status code 503"
+	updated.UpdatedAt = time.UnixMilli(1_700_000_000_100)
+
+	// when
+	err := sut.UpdateWebhookDelivery(ctx, &updated)
+
+	// then
+	require.NoError(t, err)
+
+	actual, err := sut.FindWebhookDeliveries(ctx, "", "")
+	require.NoError(t, err)
+	require.Equal(t, []*engine.WebhookDelivery{&updated}, actual)
+}
+
+func testFindWebhookDeliveriesFilters(t *testing.T, sut engine.WebhookStorage) {
+	// given
+	ctx := context.Background()
+	pending := NewWebhookDelivery("d1", "a", 1, 1)
+	dead := NewWebhookDelivery("d2", "a", 2, 2)
+	dead.Status = engine.WebhookDeliveryDead
+	other := NewWebhookDelivery("d3", "b", 3, 3)
+	other.Status = engine.WebhookDeliveryDead
+	require.NoError(t, sut.InsertWebhookDeliveries(ctx, []*engine.WebhookDelivery{other, dead, pending}))
+
+	tests := map[string]struct {
+		webhookID string
+		status    engine.WebhookDeliveryStatus
+		expected  []*engine.WebhookDelivery
+	}{
+		"every delivery":            {expected: []*engine.WebhookDelivery{pending, dead, other}},
+		"deliveries of the webhook": {webhookID: "a", expected: []*engine.WebhookDelivery{pending, dead}},
+		"dead deliveries":           {status: engine.WebhookDeliveryDead, expected: []*engine.WebhookDelivery{dead, other}},
+		"dead deliveries of the webhook": {
+			webhookID: "b",
+			status:    engine.WebhookDeliveryDead,
+			expected:  []*engine.WebhookDelivery{other},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when
+			actual, err := sut.FindWebhookDeliveries(ctx, tc.webhookID, tc.status)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -75,6 +75,97 @@ func (*NoopEngineProvider) ProvideForeignGASPNode(ctx context.Context, graphId,
 	return &core.GASPNode{}, nil
 }
 
+// ProvideForeignSyncReply is a no-op call that always returns an empty initial GASP reply with nil error.
+func (*NoopEngineProvider) ProvideForeignSyncReply(ctx context.Context, initialResponse *core.GASPInitialResponse, topic string) (*core.GASPInitialReply, error) {
+	return &core.GASPInitialReply{UTXOList: []*transaction.Outpoint{}}, nil
+}
+
+// SubmitForeignGASPNode is a no-op call that always returns a GASP node response without requested inputs and nil error.
+func (*NoopEngineProvider) SubmitForeignGASPNode(ctx context.Context, node *core.GASPNode, topic string) (*core.GASPNodeResponse, error) {
+	return &core.GASPNodeResponse{RequestedInputs: map[string]*core.GASPNodeResponseData{}}, nil
+}
+
+// ListSyncCheckpoints is a no-op call that always returns an empty list of sync checkpoints with nil error.
+func (*NoopEngineProvider) ListSyncCheckpoints(ctx context.Context, topic string) ([]*engine.SyncCheckpoint, error) {
+	return []*engine.SyncCheckpoint{}, nil
+}
+
+// ResetSyncCheckpoints is a no-op call that always returns zero deleted sync checkpoints with nil error.
+func (*NoopEngineProvider) ResetSyncCheckpoints(ctx context.Context, topic, peer string) (int, error) {
+	return 0, nil
+}
+
+// HandleReorg is a no-op call that always returns a result without checked transactions with nil error.
+func (*NoopEngineProvider) HandleReorg(ctx context.Context, fromHeight uint32) (*engine.ReorgResult, error) {
+	return &engine.ReorgResult{Downgraded: []chainhash.Hash{}}, nil
+}
+
+// EvictOutput is a no-op call that always returns an eviction without evicted outputs with nil error.
+func (*NoopEngineProvider) EvictOutput(ctx context.Context, outpoint *transaction.Outpoint, includeHistory bool, actor, reason string) (*engine.Eviction, error) {
+	return &engine.Eviction{Outpoint: *outpoint, IncludeHistory: includeHistory, Evicted: []engine.EvictedOutput{}, Actor: actor, Reason: reason}, nil
+}
+
+// Prune is a no-op call that always returns an empty list of prune reports with nil error.
+func (*NoopEngineProvider) Prune(ctx context.Context, dryRun bool) ([]*engine.PruneReport, error) {
+	return []*engine.PruneReport{}, nil
+}
+
+// PruneTopic is a no-op call that always returns a prune report without pruned outputs with nil error.
+func (*NoopEngineProvider) PruneTopic(ctx context.Context, topic string, dryRun bool) (*engine.PruneReport, error) {
+	return &engine.PruneReport{Topic: topic, DryRun: dryRun, Pruned: []engine.PrunedOutput{}}, nil
+}
+
+// SubscribeEvents is a no-op call that always returns a subscription delivering no events with nil error.
+func (*NoopEngineProvider) SubscribeEvents(topics []string, after *uint64) (*engine.EventSubscription, error) {
+	bus := engine.NewEventBus(0)
+	defer bus.Close()
+	return bus.Subscribe(topics, nil)
+}
+
+// RegisterWebhook is a no-op call that always returns a webhook of the topic with nil error.
+func (*NoopEngineProvider) RegisterWebhook(ctx context.Context, topic, url, secret string) (*engine.Webhook, error) {
+	return &engine.Webhook{Topic: topic, URL: url, Secret: secret}, nil
+}
+
+// DeleteWebhook is a no-op call that always returns nil error.
+func (*NoopEngineProvider) DeleteWebhook(ctx context.Context, id string) error {
+	return nil
+}
+
+// ListWebhooks is a no-op call that always returns an empty list of webhooks with nil error.
+func (*NoopEngineProvider) ListWebhooks(ctx context.Context) ([]*engine.Webhook, error) {
+	return []*engine.Webhook{}, nil
+}
+
+// ListWebhookDeliveries is a no-op call that always returns an empty list of deliveries with nil error.
+func (*NoopEngineProvider) ListWebhookDeliveries(ctx context.Context, webhookID string, status engine.WebhookDeliveryStatus) ([]*engine.WebhookDelivery, error) {
+	return []*engine.WebhookDelivery{}, nil
+}
+
+// ListOutboxEntries is a no-op call that always returns an empty list of outbox entries with nil error.
+func (*NoopEngineProvider) ListOutboxEntries(ctx context.Context, txid *chainhash.Hash, status engine.OutboxStatus) ([]*engine.OutboxEntry, error) {
+	return []*engine.OutboxEntry{}, nil
+}
+
+// SubmitAsync is a no-op call that always returns a queued job with nil error.
+func (*NoopEngineProvider) SubmitAsync(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode engine.SumbitMode) (*engine.SubmitJob, error) {
+	return &engine.SubmitJob{ID: "noop_engine_provider", Status: engine.SubmitJobQueued, Topics: taggedBEEF.Topics}, nil
+}
+
+// FindSubmitJob is a no-op call that always returns a completed job with an empty STEAK and nil error.
+func (*NoopEngineProvider) FindSubmitJob(ctx context.Context, id string) (*engine.SubmitJob, error) {
+	return &engine.SubmitJob{ID: id, Status: engine.SubmitJobCompleted, Topics: []string{}, Steak: overlay.Steak{}}, nil
+}
+
+// SubmitBatch is a no-op call that always returns an empty STEAK for every transaction of the batch.
+func (*NoopEngineProvider) SubmitBatch(ctx context.Context, taggedBEEFs []overlay.TaggedBEEF, mode engine.SumbitMode) []*engine.SubmitBatchResult {
+	results := make([]*engine.SubmitBatchResult, len(taggedBEEFs))
+	for i := range taggedBEEFs {
+		results[i] = &engine.SubmitBatchResult{Steak: overlay.Steak{}}
+	}
+	return results
+}
+
 // ListTopicManagers is a no-op call that always returns an empty topic managers map with nil error.
 func (*NoopEngineProvider) ListTopicManagers() map[string]*overlay.MetaData {
 	return map[string]*overlay.MetaData{}
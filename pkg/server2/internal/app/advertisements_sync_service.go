@@ -41,9 +41,8 @@ func NewAdvertisementsSyncService(provider SyncAdvertisementsProvider) *Advertis
 // in the provider while processing a sync advertisements request.
 // Typically used when the overlay engine encounters an issue.
 func NewSyncAdvertisementsProviderError(err error) Error {
-	return Error{
-		errorType: ErrorTypeProviderFailure,
-		err:       err.Error(),
-		slug:      "Unable to process sync advertisements request due to issues with the overlay engine.",
-	}
+	return newProviderError(
+		err,
+		"Unable to process sync advertisements request due to issues with the overlay engine.",
+	)
 }
@@ -82,8 +82,8 @@ func NewInvalidTxIDFormatError(err error) Error {
 // NewArcIngestProviderError returns an error indicating that the underlying ARCIngestProvider
 // failed to process the Merkle proof. This is typically a system-level failure.
 func NewArcIngestProviderError(err error) Error {
-	return NewProviderFailureError(
-		err.Error(),
+	return newProviderError(
+		err,
 		"Unable to process Merkle proof due to an internal error. Please try again later or contact the support team.",
 	)
 }
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// DeleteWebhookProvider defines the interface for components that can delete
+// the registered webhooks of the overlay engine.
+type DeleteWebhookProvider interface {
+	DeleteWebhook(ctx context.Context, id string) error
+}
+
+// DeleteWebhookService coordinates the deletion of the registered webhooks.
+type DeleteWebhookService struct {
+	provider DeleteWebhookProvider
+}
+
+// DeleteWebhook deletes the registered webhook identified by the ID. The webhooks of the
+// engine configuration cannot be deleted.
+func (s *DeleteWebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	if id == "" {
+		return NewIncorrectInputWithFieldError("id")
+	}
+
+	err := s.provider.DeleteWebhook(ctx, id)
+	switch {
+	case errors.Is(err, engine.ErrNotFound):
+		return NewWebhookNotFoundError(id)
+	case errors.Is(err, engine.ErrWebhookConfigured):
+		return NewConfiguredWebhookError(id)
+	case err != nil:
+		return NewDeleteWebhookProviderError(err)
+	}
+	return nil
+}
+
+// NewDeleteWebhookService creates a new DeleteWebhookService with the given provider.
+// Panics if the provider is nil.
+func NewDeleteWebhookService(provider DeleteWebhookProvider) *DeleteWebhookService {
+	if provider == nil {
+		panic("delete webhook provider is nil")
+	}
+	return &DeleteWebhookService{provider: provider}
+}
+
+// NewWebhookNotFoundError returns an Error indicating that no webhook has the ID requested to be deleted.
+func NewWebhookNotFoundError(id string) Error {
+	msg := "Unable to delete the webhook " + id + " as it is not registered."
+	return NewNotFoundError(msg, msg)
+}
+
+// NewConfiguredWebhookError returns an Error indicating that the webhook requested to be deleted
+// comes from the engine configuration.
+func NewConfiguredWebhookError(id string) Error {
+	msg := "Unable to delete the webhook " + id + " as it comes from the engine configuration."
+	return NewConflictError(msg, msg).WithCode(ErrorCodeWebhookConfigured)
+}
+
+// NewDeleteWebhookProviderError returns an Error indicating that the configured provider
+// failed to delete the webhook.
+func NewDeleteWebhookProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to delete the webhook due to an internal error. Please try again later or contact the support team.",
+	)
+}
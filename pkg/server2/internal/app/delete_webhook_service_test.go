@@ -0,0 +1,85 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteWebhookService_InvalidCases(t *testing.T) {
+	providerError := errors.New("internal delete webhook service test error")
+	tests := map[string]struct {
+		id           string
+		expectations testabilities.DeleteWebhookProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"Delete webhook service fails to delete a webhook without ID": {
+			expectedErr: app.NewIncorrectInputWithFieldError("id"),
+		},
+		"Delete webhook service fails to delete an unknown webhook": {
+			id: "unknown",
+			expectations: testabilities.DeleteWebhookProviderMockExpectations{
+				DeleteWebhookCall: true,
+				ID:                "unknown",
+				Error:             engine.ErrNotFound,
+			},
+			expectedErr: app.NewWebhookNotFoundError("unknown"),
+		},
+		"Delete webhook service fails to delete a configured webhook": {
+			id: "configured",
+			expectations: testabilities.DeleteWebhookProviderMockExpectations{
+				DeleteWebhookCall: true,
+				ID:                "configured",
+				Error:             engine.ErrWebhookConfigured,
+			},
+			expectedErr: app.NewConfiguredWebhookError("configured"),
+		},
+		"Delete webhook service fails to delete the webhook": {
+			id: "id",
+			expectations: testabilities.DeleteWebhookProviderMockExpectations{
+				DeleteWebhookCall: true,
+				ID:                "id",
+				Error:             providerError,
+			},
+			expectedErr: app.NewDeleteWebhookProviderError(providerError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewDeleteWebhookProviderMock(t, tc.expectations)
+			service := app.NewDeleteWebhookService(mock)
+
+			// when:
+			err := service.DeleteWebhook(context.Background(), tc.id)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestDeleteWebhookService_ValidCase(t *testing.T) {
+	// given:
+	mock := testabilities.NewDeleteWebhookProviderMock(t, testabilities.DeleteWebhookProviderMockExpectations{
+		DeleteWebhookCall: true,
+		ID:                "id",
+	})
+	service := app.NewDeleteWebhookService(mock)
+
+	// when:
+	err := service.DeleteWebhook(context.Background(), "id")
+
+	// then:
+	require.NoError(t, err)
+	mock.AssertCalled()
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+)
+
+// Stable machine-readable codes of the errors reported to the requester. The codes of the errors
+// not listed here are the names of their ErrorType.
+const (
+	ErrorCodeUnknownTopic         = "unknown-topic"
+	ErrorCodeInvalidBEEF          = "invalid-beef"
+	ErrorCodeInvalidTransaction   = "invalid-transaction"
+	ErrorCodeMissingInput         = "missing-input"
+	ErrorCodeInputSpent           = "input-spent"
+	ErrorCodeNotFound             = "not-found"
+	ErrorCodeGASPVersionMismatch  = "gasp-version-mismatch"
+	ErrorCodeSubmitQueueFull      = "submit-queue-full"
+	ErrorCodeGASPTooManyGraphs    = "gasp-too-many-graphs"
+	ErrorCodeInvalidWebhook       = "invalid-webhook"
+	ErrorCodeWebhookConfigured    = "webhook-configured"
+	ErrorCodeNoRetentionPolicy    = "no-retention-policy"
+	ErrorCodeEventCursorExpired   = "event-cursor-expired"
+	ErrorCodeServiceNotConfigured = "service-not-configured"
+	ErrorCodeInvalidPayment       = "invalid-payment"
+	ErrorCodeInsufficientPayment  = "insufficient-payment"
+	ErrorCodePaymentRedeemed      = "payment-already-redeemed"
+)
+
+// catalogEntry describes how an engine error is reported to the requester.
+type catalogEntry struct {
+	match     func(err error) bool
+	code      string
+	errorType ErrorType
+	slug      string
+}
+
+// is returns a matcher of the errors wrapping the target.
+func is(target error) func(err error) bool {
+	return func(err error) bool { return errors.Is(err, target) }
+}
+
+// errorCatalog lists the engine errors reported to the requester by their own code and type
+// instead of as a provider failure, since they tell what is wrong with the request.
+var errorCatalog = []catalogEntry{
+	{
+		match:     is(engine.ErrUnknownTopic),
+		code:      ErrorCodeUnknownTopic,
+		errorType: ErrorTypeNotFound,
+		slug:      "The requested topic or lookup service is not hosted by the overlay.",
+	},
+	{
+		match:     is(engine.ErrInvalidBeef),
+		code:      ErrorCodeInvalidBEEF,
+		errorType: ErrorTypeIncorrectInput,
+		slug:      "The submitted BEEF does not contain the transaction.",
+	},
+	{
+		match:     is(engine.ErrInvalidTransaction),
+		code:      ErrorCodeInvalidTransaction,
+		errorType: ErrorTypeUnprocessable,
+		slug:      "The submitted transaction failed SPV verification.",
+	},
+	{
+		match:     is(engine.ErrMissingInput),
+		code:      ErrorCodeMissingInput,
+		errorType: ErrorTypeUnprocessable,
+		slug:      "The submitted transaction spends an input unknown to the overlay.",
+	},
+	{
+		match:     is(engine.ErrInputSpent),
+		code:      ErrorCodeInputSpent,
+		errorType: ErrorTypeConflict,
+		slug:      "The submitted transaction spends an input already spent by another transaction.",
+	},
+	{
+		match:     is(engine.ErrNotFound),
+		code:      ErrorCodeNotFound,
+		errorType: ErrorTypeNotFound,
+		slug:      "The requested resource was not found.",
+	},
+	{
+		match: func(err error) bool {
+			var mismatch *core.GASPVersionMismatchError
+			return errors.As(err, &mismatch)
+		},
+		code:      ErrorCodeGASPVersionMismatch,
+		errorType: ErrorTypeConflict,
+		slug:      "The GASP version of the request is not supported by the overlay. Please upgrade the peer and try again.",
+	},
+	{
+		match:     is(engine.ErrSubmitQueueFull),
+		code:      ErrorCodeSubmitQueueFull,
+		errorType: ErrorTypeServiceUnavailable,
+		slug:      "Unable to queue the submitted transaction as too many transactions are being processed. Please try again later.",
+	},
+	{
+		match:     is(core.ErrTooManyGraphs),
+		code:      ErrorCodeGASPTooManyGraphs,
+		errorType: ErrorTypeServiceUnavailable,
+		slug:      "Unable to accept the GASP node as too many graphs are being synced. Please try again later.",
+	},
+	{
+		match:     is(engine.ErrInvalidWebhook),
+		code:      ErrorCodeInvalidWebhook,
+		errorType: ErrorTypeIncorrectInput,
+		slug:      "The webhook is invalid. Please verify its topic, URL and secret and try again.",
+	},
+	{
+		match:     is(engine.ErrWebhookConfigured),
+		code:      ErrorCodeWebhookConfigured,
+		errorType: ErrorTypeConflict,
+		slug:      "The webhook comes from the engine configuration and cannot be changed.",
+	},
+	{
+		match:     is(engine.ErrNoRetentionPolicy),
+		code:      ErrorCodeNoRetentionPolicy,
+		errorType: ErrorTypeNotFound,
+		slug:      "The topic has no retention policy.",
+	},
+	{
+		match:     is(engine.ErrEventCursorExpired),
+		code:      ErrorCodeEventCursorExpired,
+		errorType: ErrorTypeIncorrectInput,
+		slug:      "The events after the requested cursor are no longer available. Please resume from the latest event.",
+	},
+	{
+		match:     is(engine.ErrInvalidPayment),
+		code:      ErrorCodeInvalidPayment,
+		errorType: ErrorTypeIncorrectInput,
+		slug:      "The attached payment transaction is invalid. Please verify the payment and try again.",
+	},
+	{
+		match:     is(engine.ErrInsufficientPayment),
+		code:      ErrorCodeInsufficientPayment,
+		errorType: ErrorTypePaymentRequired,
+		slug:      "The attached payment does not cover the price of the request.",
+	},
+	{
+		match:     is(engine.ErrPaymentAlreadyRedeemed),
+		code:      ErrorCodePaymentRedeemed,
+		errorType: ErrorTypeConflict,
+		slug:      "The attached payment transaction has already paid for another request.",
+	},
+	{
+		match: func(err error) bool {
+			return errors.Is(err, engine.ErrSubmitJobsUnavailable) ||
+				errors.Is(err, engine.ErrWebhookStorageUnavailable) ||
+				errors.Is(err, engine.ErrOutboxStorageUnavailable) ||
+				errors.Is(err, engine.ErrEvictionAuditUnavailable) ||
+				errors.Is(err, engine.ErrPaymentStorageUnavailable)
+		},
+		code:      ErrorCodeServiceNotConfigured,
+		errorType: ErrorTypeUnsupportedOperation,
+		slug:      "The requested operation is not enabled on this overlay.",
+	},
+}
+
+// lookupErrorCatalog returns the catalog entry of the error, false when the error is not catalogued.
+func lookupErrorCatalog(err error) (catalogEntry, bool) {
+	for _, entry := range errorCatalog {
+		if entry.match(err) {
+			return entry, true
+		}
+	}
+	return catalogEntry{}, false
+}
+
+// NewCatalogError returns the Error reporting a catalogued engine error by its own code, type and slug,
+// false when the error is not catalogued.
+func NewCatalogError(err error) (Error, bool) {
+	entry, ok := lookupErrorCatalog(err)
+	if !ok {
+		return Error{}, false
+	}
+	return Error{
+		err:       err.Error(),
+		slug:      entry.slug,
+		errorType: entry.errorType,
+		code:      entry.code,
+	}, true
+}
+
+// newProviderError returns the Error reporting a failure of a provider, by its catalog entry when
+// the failure is a catalogued engine error, or as a provider failure with the given slug otherwise.
+func newProviderError(err error, slug string) Error {
+	if catalogued, ok := NewCatalogError(err); ok {
+		return catalogued
+	}
+	return NewProviderFailureError(err.Error(), slug)
+}
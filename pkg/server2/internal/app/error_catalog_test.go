@@ -0,0 +1,162 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/discovery"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/spv"
+	testvectors "github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCatalogError_ValidCases(t *testing.T) {
+	tests := map[string]struct {
+		err               error
+		expectedCode      string
+		expectedErrorType app.ErrorType
+	}{
+		"Unknown topic": {
+			err:               engine.ErrUnknownTopic,
+			expectedCode:      app.ErrorCodeUnknownTopic,
+			expectedErrorType: app.ErrorTypeNotFound,
+		},
+		"Invalid BEEF": {
+			err:               engine.ErrInvalidBeef,
+			expectedCode:      app.ErrorCodeInvalidBEEF,
+			expectedErrorType: app.ErrorTypeIncorrectInput,
+		},
+		"Missing input": {
+			err:               engine.ErrMissingInput,
+			expectedCode:      app.ErrorCodeMissingInput,
+			expectedErrorType: app.ErrorTypeUnprocessable,
+		},
+		"Spent input": {
+			err:               engine.ErrInputSpent,
+			expectedCode:      app.ErrorCodeInputSpent,
+			expectedErrorType: app.ErrorTypeConflict,
+		},
+		"Not found": {
+			err:               engine.ErrNotFound,
+			expectedCode:      app.ErrorCodeNotFound,
+			expectedErrorType: app.ErrorTypeNotFound,
+		},
+		"GASP version mismatch": {
+			err:               core.NewGASPVersionMismatchError(2, 1),
+			expectedCode:      app.ErrorCodeGASPVersionMismatch,
+			expectedErrorType: app.ErrorTypeConflict,
+		},
+		"Submit queue full": {
+			err:               engine.ErrSubmitQueueFull,
+			expectedCode:      app.ErrorCodeSubmitQueueFull,
+			expectedErrorType: app.ErrorTypeServiceUnavailable,
+		},
+		"Too many GASP graphs": {
+			err:               core.ErrTooManyGraphs,
+			expectedCode:      app.ErrorCodeGASPTooManyGraphs,
+			expectedErrorType: app.ErrorTypeServiceUnavailable,
+		},
+		"Webhook storage not configured": {
+			err:               engine.ErrWebhookStorageUnavailable,
+			expectedCode:      app.ErrorCodeServiceNotConfigured,
+			expectedErrorType: app.ErrorTypeUnsupportedOperation,
+		},
+		"Insufficient payment wrapped by the engine": {
+			err:               fmt.Errorf("%w: 10 satoshis paid out of 100", engine.ErrInsufficientPayment),
+			expectedCode:      app.ErrorCodeInsufficientPayment,
+			expectedErrorType: app.ErrorTypePaymentRequired,
+		},
+		"Payment already redeemed": {
+			err:               engine.ErrPaymentAlreadyRedeemed,
+			expectedCode:      app.ErrorCodePaymentRedeemed,
+			expectedErrorType: app.ErrorTypeConflict,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			actualErr, ok := app.NewCatalogError(tc.err)
+
+			// then:
+			require.True(t, ok)
+			require.Equal(t, tc.expectedCode, actualErr.Code())
+			require.Equal(t, tc.expectedErrorType, actualErr.ErrorType())
+			require.Equal(t, tc.err.Error(), actualErr.Error())
+			require.NotEmpty(t, actualErr.Slug())
+		})
+	}
+}
+
+func TestNewCatalogError_ValidCases_EngineSubmit(t *testing.T) {
+	unsigned, err := testvectors.GivenTX().WithInput(1000).WithoutSigning().WithP2PKHOutput(999).TX().AtomicBEEF(false)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		beef              []byte
+		expectedErr       error
+		expectedCode      string
+		expectedErrorType app.ErrorType
+	}{
+		"Malformed BEEF": {
+			beef:              []byte{0x01, 0x02, 0x03},
+			expectedErr:       engine.ErrInvalidBeef,
+			expectedCode:      app.ErrorCodeInvalidBEEF,
+			expectedErrorType: app.ErrorTypeIncorrectInput,
+		},
+		"Transaction failing script verification": {
+			beef:              unsigned,
+			expectedErr:       engine.ErrInvalidTransaction,
+			expectedCode:      app.ErrorCodeInvalidTransaction,
+			expectedErrorType: app.ErrorTypeUnprocessable,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			sut := engine.NewEngine(engine.Engine{
+				Managers:     map[string]engine.TopicManager{"tm_ship": discovery.NewSHIPTopicManager()},
+				Storage:      memstorage.New(),
+				ChainTracker: &spv.GullibleHeadersClient{},
+			})
+			taggedBEEF := overlay.TaggedBEEF{Beef: tc.beef, Topics: []string{"tm_ship"}}
+
+			// when:
+			_, submitErr := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+			actualErr, ok := app.NewCatalogError(submitErr)
+
+			// then:
+			require.ErrorIs(t, submitErr, tc.expectedErr)
+			require.True(t, ok)
+			require.Equal(t, tc.expectedCode, actualErr.Code())
+			require.Equal(t, tc.expectedErrorType, actualErr.ErrorType())
+			require.Equal(t, submitErr.Error(), actualErr.Error())
+		})
+	}
+}
+
+func TestNewCatalogError_InvalidCase_UncataloguedError(t *testing.T) {
+	// when:
+	actualErr, ok := app.NewCatalogError(errors.New("internal error"))
+
+	// then:
+	require.False(t, ok)
+	require.True(t, actualErr.IsZero())
+}
+
+func TestErrorCode_DefaultsToErrorType(t *testing.T) {
+	// when:
+	actualErr := app.NewLookupQuestionProviderError(errors.New("internal error"))
+
+	// then:
+	require.Equal(t, app.ErrorTypeProviderFailure, actualErr.ErrorType())
+	require.Equal(t, app.ErrorTypeProviderFailure.String(), actualErr.Code())
+}
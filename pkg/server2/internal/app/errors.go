@@ -17,8 +17,16 @@ var (
 	ErrorTypeOperationTimeout     = ErrorType{"operation-timeout"}
 	ErrorTypeRawDataProcessing    = ErrorType{"raw-data-processing"}
 	ErrorTypeUnsupportedOperation = ErrorType{"unsupported-operation"}
+	ErrorTypeServiceUnavailable   = ErrorType{"service-unavailable"}
+	ErrorTypeNotFound             = ErrorType{"not-found"}
+	ErrorTypeConflict             = ErrorType{"conflict"}
+	ErrorTypeUnprocessable        = ErrorType{"unprocessable"}
+	ErrorTypePaymentRequired      = ErrorType{"payment-required"}
 )
 
+// String returns the name of the error type, which is also the default code of its errors.
+func (t ErrorType) String() string { return t.s }
+
 // Error defines a generic application-layer error that should be translated
 // into a specific response format for the requester.
 //
@@ -31,10 +39,15 @@ var (
 // to include it in the final response to avoid exposing sensitive information.
 // Instead, it is highly recommended to use the slug string, which is intended
 // for the response, ensuring no sensitive data is leaked to the requester.
+//
+// The code is a stable machine-readable identifier of the failure returned to the
+// requester along with the slug, see the ErrorCode constants. Errors without a code
+// of their own are identified by the name of their type.
 type Error struct {
 	err       string
 	slug      string
 	errorType ErrorType
+	code      string
 }
 
 func (e Error) Slug() string         { return e.slug }
@@ -42,6 +55,20 @@ func (e Error) IsZero() bool         { return e == Error{} }
 func (e Error) Error() string        { return e.err }
 func (e Error) ErrorType() ErrorType { return e.errorType }
 
+// Code returns the machine-readable code of the error, the name of its type when it has no code of its own.
+func (e Error) Code() string {
+	if e.code != "" {
+		return e.code
+	}
+	return e.errorType.String()
+}
+
+// WithCode returns a copy of the error identified by the given code.
+func (e Error) WithCode(code string) Error {
+	e.code = code
+	return e
+}
+
 func NewUnsupportedOperationError(err, slug string) Error {
 	return Error{
 		slug:      slug,
@@ -113,6 +140,46 @@ func NewRawDataProcessingWithFieldError(err error, field string) Error {
 	)
 }
 
+// NewServiceUnavailableError returns an error that handles requests the service is temporarily
+// unable to take on, such as when it is at capacity. The requester may retry later.
+func NewServiceUnavailableError(err, slug string) Error {
+	return Error{
+		slug:      slug,
+		errorType: ErrorTypeServiceUnavailable,
+		err:       err,
+	}
+}
+
+// NewNotFoundError returns an error that handles requests for resources that do not exist,
+// such as unknown topics, outputs or jobs.
+func NewNotFoundError(err, slug string) Error {
+	return Error{
+		slug:      slug,
+		errorType: ErrorTypeNotFound,
+		err:       err,
+	}
+}
+
+// NewConflictError returns an error that handles requests conflicting with the current state
+// of a resource, such as spending an output already spent by another transaction.
+func NewConflictError(err, slug string) Error {
+	return Error{
+		slug:      slug,
+		errorType: ErrorTypeConflict,
+		err:       err,
+	}
+}
+
+// NewPaymentRequiredError returns an error that handles requests which cannot be served
+// until they are paid for, such as requests without a payment or with an insufficient one.
+func NewPaymentRequiredError(err, slug string) Error {
+	return Error{
+		slug:      slug,
+		errorType: ErrorTypePaymentRequired,
+		err:       err,
+	}
+}
+
 // NewUnknownError returns an error that represents an unexpected or unclassified
 // issue that doesn't fall into predefined error categories. Useful as a fallback
 // when the exact nature of the error is unclear.
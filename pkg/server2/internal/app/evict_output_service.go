@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// EvictOutputDTO represents the data transfer object used to request the eviction of an output.
+type EvictOutputDTO struct {
+	TxID           string // TxID is the hexadecimal ID of the transaction holding the output.
+	OutputIndex    uint32 // OutputIndex is the index of the output within the transaction.
+	IncludeHistory bool   // IncludeHistory tells whether the outputs the output was built from are evicted too.
+	Actor          string // Actor identifies who requested the eviction.
+	Reason         string // Reason explains why the output is evicted.
+}
+
+// EvictedOutputDTO is a transport-friendly representation of an output of a topic removed by an eviction.
+type EvictedOutputDTO struct {
+	TxID        string // TxID is the hexadecimal ID of the transaction holding the output.
+	OutputIndex uint32 // OutputIndex is the index of the output within the transaction.
+	Topic       string // Topic is the topic the output was removed from.
+}
+
+// EvictionDTO is a transport-friendly representation of a recorded eviction.
+type EvictionDTO struct {
+	Evicted   []EvictedOutputDTO // Evicted lists every output removed from storage, per topic.
+	CreatedAt time.Time          // CreatedAt is the time at which the eviction was recorded.
+}
+
+// EvictOutputProvider defines the interface for components that can evict
+// an output from the overlay engine and record the eviction in the audit log.
+type EvictOutputProvider interface {
+	EvictOutput(ctx context.Context, outpoint *transaction.Outpoint, includeHistory bool, actor, reason string) (*engine.Eviction, error)
+}
+
+// EvictOutputService coordinates the legal eviction of outputs, which purges them
+// from storage and from every lookup service.
+type EvictOutputService struct {
+	provider EvictOutputProvider
+}
+
+// EvictOutput validates the request and evicts the output from every topic. Both the actor
+// and the reason are required, as they are recorded in the audit log of the eviction.
+func (s *EvictOutputService) EvictOutput(ctx context.Context, dto EvictOutputDTO) (EvictionDTO, error) {
+	txID, err := chainhash.NewHashFromHex(dto.TxID)
+	if err != nil {
+		return EvictionDTO{}, NewIncorrectInputWithFieldError("txid")
+	}
+	if dto.Actor == "" {
+		return EvictionDTO{}, NewIncorrectInputWithFieldError("actor")
+	}
+	if dto.Reason == "" {
+		return EvictionDTO{}, NewIncorrectInputWithFieldError("reason")
+	}
+
+	outpoint := &transaction.Outpoint{Txid: *txID, Index: dto.OutputIndex}
+	eviction, err := s.provider.EvictOutput(ctx, outpoint, dto.IncludeHistory, dto.Actor, dto.Reason)
+	if errors.Is(err, engine.ErrNotFound) {
+		return EvictionDTO{}, NewEvictedOutputNotFoundError(outpoint)
+	}
+	if err != nil {
+		return EvictionDTO{}, NewEvictOutputProviderError(err)
+	}
+
+	evicted := make([]EvictedOutputDTO, 0, len(eviction.Evicted))
+	for _, output := range eviction.Evicted {
+		evicted = append(evicted, EvictedOutputDTO{
+			TxID:        output.Outpoint.Txid.String(),
+			OutputIndex: output.Outpoint.Index,
+			Topic:       output.Topic,
+		})
+	}
+	return EvictionDTO{Evicted: evicted, CreatedAt: eviction.CreatedAt}, nil
+}
+
+// NewEvictOutputService creates a new EvictOutputService with the given provider.
+// Panics if the provider is nil.
+func NewEvictOutputService(provider EvictOutputProvider) *EvictOutputService {
+	if provider == nil {
+		panic("evict output provider is nil")
+	}
+	return &EvictOutputService{provider: provider}
+}
+
+// NewEvictedOutputNotFoundError returns an Error indicating that the output requested to be
+// evicted is not stored in any topic.
+func NewEvictedOutputNotFoundError(outpoint *transaction.Outpoint) Error {
+	msg := "Unable to evict the output " + outpoint.String() + " as it is not stored in any topic."
+	return NewNotFoundError(msg, msg)
+}
+
+// NewEvictOutputProviderError returns an Error indicating that the configured provider
+// failed to evict the output.
+func NewEvictOutputProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to evict the output due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,121 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvictOutputService_InvalidCases(t *testing.T) {
+	txid := chainhash.DoubleHashH([]byte("evicted"))
+	outpoint := &transaction.Outpoint{Txid: txid, Index: 1}
+	providerError := errors.New("internal evict output service test error")
+
+	tests := map[string]struct {
+		dto          app.EvictOutputDTO
+		expectations testabilities.EvictOutputProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"Evict output service fails to parse the txid": {
+			dto:          app.EvictOutputDTO{TxID: "invalid", Actor: "admin", Reason: "takedown request"},
+			expectations: testabilities.EvictOutputProviderMockExpectations{EvictOutputCall: false},
+			expectedErr:  app.NewIncorrectInputWithFieldError("txid"),
+		},
+		"Evict output service requires the actor": {
+			dto:          app.EvictOutputDTO{TxID: txid.String(), Reason: "takedown request"},
+			expectations: testabilities.EvictOutputProviderMockExpectations{EvictOutputCall: false},
+			expectedErr:  app.NewIncorrectInputWithFieldError("actor"),
+		},
+		"Evict output service requires the reason": {
+			dto:          app.EvictOutputDTO{TxID: txid.String(), Actor: "admin"},
+			expectations: testabilities.EvictOutputProviderMockExpectations{EvictOutputCall: false},
+			expectedErr:  app.NewIncorrectInputWithFieldError("reason"),
+		},
+		"Evict output service fails when the output is not stored": {
+			dto: app.EvictOutputDTO{TxID: txid.String(), OutputIndex: 1, Actor: "admin", Reason: "takedown request"},
+			expectations: testabilities.EvictOutputProviderMockExpectations{
+				EvictOutputCall: true,
+				Outpoint:        outpoint,
+				Actor:           "admin",
+				Reason:          "takedown request",
+				Error:           engine.ErrNotFound,
+			},
+			expectedErr: app.NewEvictedOutputNotFoundError(outpoint),
+		},
+		"Evict output service fails to evict the output": {
+			dto: app.EvictOutputDTO{TxID: txid.String(), OutputIndex: 1, Actor: "admin", Reason: "takedown request"},
+			expectations: testabilities.EvictOutputProviderMockExpectations{
+				EvictOutputCall: true,
+				Outpoint:        outpoint,
+				Actor:           "admin",
+				Reason:          "takedown request",
+				Error:           providerError,
+			},
+			expectedErr: app.NewEvictOutputProviderError(providerError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewEvictOutputProviderMock(t, tc.expectations)
+			service := app.NewEvictOutputService(mock)
+
+			// when:
+			eviction, err := service.EvictOutput(context.Background(), tc.dto)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Zero(t, eviction)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestEvictOutputService_ValidCase(t *testing.T) {
+	// given:
+	txid := chainhash.DoubleHashH([]byte("evicted"))
+	outpoint := &transaction.Outpoint{Txid: txid, Index: 1}
+	createdAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	expectations := testabilities.EvictOutputProviderMockExpectations{
+		EvictOutputCall: true,
+		Outpoint:        outpoint,
+		IncludeHistory:  true,
+		Actor:           "admin",
+		Reason:          "takedown request",
+		Eviction: &engine.Eviction{
+			Outpoint:  *outpoint,
+			Evicted:   []engine.EvictedOutput{{Outpoint: *outpoint, Topic: "tm_test"}},
+			CreatedAt: createdAt,
+		},
+	}
+	mock := testabilities.NewEvictOutputProviderMock(t, expectations)
+	service := app.NewEvictOutputService(mock)
+
+	// when:
+	eviction, err := service.EvictOutput(context.Background(), app.EvictOutputDTO{
+		TxID:           txid.String(),
+		OutputIndex:    1,
+		IncludeHistory: true,
+		Actor:          "admin",
+		Reason:         "takedown request",
+	})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, app.EvictionDTO{
+		Evicted:   []app.EvictedOutputDTO{{TxID: txid.String(), OutputIndex: 1, Topic: "tm_test"}},
+		CreatedAt: createdAt,
+	}, eviction)
+	mock.AssertCalled()
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+// SubmitJobFailedCode is the code of the failed submissions that are not reported by their own code.
+const SubmitJobFailedCode = "submit-failed"
+
+// SubmitJobDTO is a transport-friendly representation of an asynchronous transaction submission.
+type SubmitJobDTO struct {
+	ID           string         // ID identifies the job.
+	Status       string         // Status is one of queued, processing, completed or failed.
+	Topics       []string       // Topics are the topics the transaction was submitted to.
+	Steak        *overlay.Steak // Steak is what the topics admitted, set once completed.
+	ErrorCode    string         // ErrorCode is the machine-readable reason of the failure, set once failed.
+	ErrorMessage string         // ErrorMessage is the human-readable reason of the failure, set once failed.
+	CreatedAt    time.Time      // CreatedAt is when the job was queued.
+	StartedAt    time.Time      // StartedAt is when the job started being processed, zero while queued.
+	CompletedAt  time.Time      // CompletedAt is when the job finished, zero until completed or failed.
+}
+
+// NewSubmitJobDTO converts the engine job into a SubmitJobDTO. The error of a failed job is reported
+// by its code when it is a catalogued engine error, and as SubmitJobFailedCode without its details otherwise.
+func NewSubmitJobDTO(job *engine.SubmitJob) SubmitJobDTO {
+	dto := SubmitJobDTO{
+		ID:          job.ID,
+		Status:      string(job.Status),
+		Topics:      job.Topics,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		CompletedAt: job.CompletedAt,
+	}
+	switch job.Status {
+	case engine.SubmitJobCompleted:
+		dto.Steak = &job.Steak
+	case engine.SubmitJobFailed:
+		dto.ErrorCode, dto.ErrorMessage = submitErrorCode(job.Err)
+	}
+	return dto
+}
+
+// submitErrorCode returns the code and the message reporting the failed submission to the requester,
+// hiding the details of the errors that are not catalogued engine errors.
+func submitErrorCode(err error) (string, string) {
+	if entry, ok := lookupErrorCatalog(err); ok {
+		return entry.code, entry.slug
+	}
+	return SubmitJobFailedCode, "Unable to process submitted transaction octet-stream. Please verify the content and try again later or contact the support team."
+}
+
+// SubmitJobStatusProvider defines the interface for components that can look up
+// the asynchronous transaction submissions of the overlay engine.
+type SubmitJobStatusProvider interface {
+	FindSubmitJob(ctx context.Context, id string) (*engine.SubmitJob, error)
+}
+
+// SubmitJobStatusService coordinates the retrieval of the status of the asynchronous transaction submissions.
+type SubmitJobStatusService struct {
+	provider SubmitJobStatusProvider
+}
+
+// GetSubmitJobStatus validates the job ID and returns the job, with its STEAK once completed
+// or its error once failed.
+func (s *SubmitJobStatusService) GetSubmitJobStatus(ctx context.Context, jobID string) (SubmitJobDTO, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return SubmitJobDTO{}, NewIncorrectInputWithFieldError("jobId")
+	}
+
+	job, err := s.provider.FindSubmitJob(ctx, jobID)
+	switch {
+	case errors.Is(err, engine.ErrNotFound):
+		return SubmitJobDTO{}, NewSubmitJobNotFoundError(jobID)
+	case err != nil:
+		return SubmitJobDTO{}, NewSubmitJobStatusProviderError(err)
+	}
+	return NewSubmitJobDTO(job), nil
+}
+
+// NewSubmitJobStatusService creates a new SubmitJobStatusService with the given provider.
+// Panics if the provider is nil.
+func NewSubmitJobStatusService(provider SubmitJobStatusProvider) *SubmitJobStatusService {
+	if provider == nil {
+		panic("submit job status provider is nil")
+	}
+	return &SubmitJobStatusService{provider: provider}
+}
+
+// NewSubmitJobNotFoundError returns an Error indicating that the job is unknown or its outcome is no longer kept.
+func NewSubmitJobNotFoundError(jobID string) Error {
+	msg := fmt.Sprintf("The submission job %q was not found, it may have expired.", jobID)
+	return NewNotFoundError(msg, msg)
+}
+
+// NewSubmitJobStatusProviderError returns an Error indicating that the configured provider
+// failed to look up the submission job.
+func NewSubmitJobStatusProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to retrieve the submission job status due to an internal error. Please try again later or contact the support team.",
+	)
+}
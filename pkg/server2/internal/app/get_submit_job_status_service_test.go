@@ -0,0 +1,157 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitJobStatusService_InvalidCases(t *testing.T) {
+	providerError := errors.New("internal submit job status service test error")
+	tests := map[string]struct {
+		jobID        string
+		expectations testabilities.SubmitJobStatusProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"Submit job status service fails to find a job without ID": {
+			jobID:       " ",
+			expectedErr: app.NewIncorrectInputWithFieldError("jobId"),
+		},
+		"Submit job status service fails to find an unknown job": {
+			jobID: "unknown",
+			expectations: testabilities.SubmitJobStatusProviderMockExpectations{
+				FindSubmitJobCall: true,
+				JobID:             "unknown",
+				Error:             engine.ErrNotFound,
+			},
+			expectedErr: app.NewSubmitJobNotFoundError("unknown"),
+		},
+		"Submit job status service fails to find a job": {
+			jobID: "job",
+			expectations: testabilities.SubmitJobStatusProviderMockExpectations{
+				FindSubmitJobCall: true,
+				JobID:             "job",
+				Error:             providerError,
+			},
+			expectedErr: app.NewSubmitJobStatusProviderError(providerError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitJobStatusProviderMock(t, tc.expectations)
+			service := app.NewSubmitJobStatusService(mock)
+
+			// when:
+			job, err := service.GetSubmitJobStatus(context.Background(), tc.jobID)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Zero(t, job)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestSubmitJobStatusService_ValidCases(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	steak := overlay.Steak{"tm_test": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}
+	tests := map[string]struct {
+		job      *engine.SubmitJob
+		expected app.SubmitJobDTO
+	}{
+		"Submit job status service returns the STEAK of a completed job": {
+			job: &engine.SubmitJob{
+				ID:          "job",
+				Status:      engine.SubmitJobCompleted,
+				Topics:      []string{"tm_test"},
+				Steak:       steak,
+				CreatedAt:   createdAt,
+				StartedAt:   createdAt.Add(time.Second),
+				CompletedAt: createdAt.Add(2 * time.Second),
+			},
+			expected: app.SubmitJobDTO{
+				ID:          "job",
+				Status:      "completed",
+				Topics:      []string{"tm_test"},
+				Steak:       &steak,
+				CreatedAt:   createdAt,
+				StartedAt:   createdAt.Add(time.Second),
+				CompletedAt: createdAt.Add(2 * time.Second),
+			},
+		},
+		"Submit job status service returns the code of a job failed with a known error": {
+			job: &engine.SubmitJob{
+				ID:          "job",
+				Status:      engine.SubmitJobFailed,
+				Topics:      []string{"tm_test"},
+				Err:         fmt.Errorf("failed to apply the transaction: %w", engine.ErrInputSpent),
+				CreatedAt:   createdAt,
+				StartedAt:   createdAt,
+				CompletedAt: createdAt,
+			},
+			expected: app.SubmitJobDTO{
+				ID:           "job",
+				Status:       "failed",
+				Topics:       []string{"tm_test"},
+				ErrorCode:    "input-spent",
+				ErrorMessage: "The submitted transaction spends an input already spent by another transaction.",
+				CreatedAt:    createdAt,
+				StartedAt:    createdAt,
+				CompletedAt:  createdAt,
+			},
+		},
+		"Submit job status service hides the details of a job failed with an unknown error": {
+			job: &engine.SubmitJob{
+				ID:          "job",
+				Status:      engine.SubmitJobFailed,
+				Topics:      []string{"tm_test"},
+				Err:         errors.New("database is locked"),
+				CreatedAt:   createdAt,
+				StartedAt:   createdAt,
+				CompletedAt: createdAt,
+			},
+			expected: app.SubmitJobDTO{
+				ID:           "job",
+				Status:       "failed",
+				Topics:       []string{"tm_test"},
+				ErrorCode:    app.SubmitJobFailedCode,
+				ErrorMessage: "Unable to process submitted transaction octet-stream. Please verify the content and try again later or contact the support team.",
+				CreatedAt:    createdAt,
+				StartedAt:    createdAt,
+				CompletedAt:  createdAt,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitJobStatusProviderMock(t, testabilities.SubmitJobStatusProviderMockExpectations{
+				FindSubmitJobCall: true,
+				JobID:             "job",
+				Job:               tc.job,
+			})
+			service := app.NewSubmitJobStatusService(mock)
+
+			// when:
+			job, err := service.GetSubmitJobStatus(context.Background(), "job")
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, job)
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// HandleReorgProvider defines the interface for components that can re-verify
+// the stored merkle proofs of the overlay engine after a chain reorganization.
+type HandleReorgProvider interface {
+	HandleReorg(ctx context.Context, fromHeight uint32) (*engine.ReorgResult, error)
+}
+
+// ReorgResultDTO is the outcome of re-verifying the stored merkle proofs after a reorg.
+type ReorgResultDTO struct {
+	Checked    int
+	Downgraded []string
+}
+
+// HandleReorgService coordinates the re-verification of the stored merkle proofs,
+// which downgrades to unproven the transactions whose blocks were orphaned.
+type HandleReorgService struct {
+	provider HandleReorgProvider
+}
+
+// HandleReorg re-verifies the merkle proofs of the transactions mined at or above the block height.
+// Returns the number of verified transactions and the IDs of the downgraded ones.
+func (s *HandleReorgService) HandleReorg(ctx context.Context, fromHeight uint32) (ReorgResultDTO, error) {
+	result, err := s.provider.HandleReorg(ctx, fromHeight)
+	if err != nil {
+		return ReorgResultDTO{}, NewHandleReorgProviderError(err)
+	}
+
+	downgraded := make([]string, 0, len(result.Downgraded))
+	for _, txid := range result.Downgraded {
+		downgraded = append(downgraded, txid.String())
+	}
+	return ReorgResultDTO{Checked: result.Checked, Downgraded: downgraded}, nil
+}
+
+// NewHandleReorgService creates a new HandleReorgService with the given provider.
+// Panics if the provider is nil.
+func NewHandleReorgService(provider HandleReorgProvider) *HandleReorgService {
+	if provider == nil {
+		panic("handle reorg provider is nil")
+	}
+	return &HandleReorgService{provider: provider}
+}
+
+// NewHandleReorgProviderError returns an Error indicating that the configured provider
+// failed to re-verify the stored merkle proofs.
+func NewHandleReorgProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to re-verify merkle proofs due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,56 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleReorgService_InvalidCase(t *testing.T) {
+	// given:
+	providerError := errors.New("internal handle reorg service test error")
+	expectations := testabilities.HandleReorgProviderMockExpectations{
+		HandleReorgCall: true,
+		FromHeight:      814435,
+		Error:           providerError,
+	}
+	expectedErr := app.NewHandleReorgProviderError(providerError)
+	mock := testabilities.NewHandleReorgProviderMock(t, expectations)
+	service := app.NewHandleReorgService(mock)
+
+	// when:
+	result, err := service.HandleReorg(context.Background(), 814435)
+
+	// then:
+	var actualErr app.Error
+	require.ErrorAs(t, err, &actualErr)
+	require.Equal(t, expectedErr, actualErr)
+	require.Zero(t, result)
+	mock.AssertCalled()
+}
+
+func TestHandleReorgService_ValidCase(t *testing.T) {
+	// given:
+	txid := chainhash.DoubleHashH([]byte("orphaned"))
+	expectations := testabilities.HandleReorgProviderMockExpectations{
+		HandleReorgCall: true,
+		FromHeight:      814435,
+		Result:          &engine.ReorgResult{Checked: 2, Downgraded: []chainhash.Hash{txid}},
+	}
+	mock := testabilities.NewHandleReorgProviderMock(t, expectations)
+	service := app.NewHandleReorgService(mock)
+
+	// when:
+	result, err := service.HandleReorg(context.Background(), 814435)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, app.ReorgResultDTO{Checked: 2, Downgraded: []string{txid.String()}}, result)
+	mock.AssertCalled()
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// ImportTransactionsService coordinates the import of historical transactions using the configured
+// SubmitBatchProvider. Imported transactions are admitted like submitted ones, but neither broadcast
+// nor propagated to peers, which allows seeding a node with legacy data.
+type ImportTransactionsService struct {
+	provider SubmitBatchProvider
+}
+
+// ImportTransactions validates the batch, resolves the BEEF of every transaction and submits them to the
+// provider in historical mode, returning the outcome of every transaction in the order of the batch.
+// The batch is rejected as a whole when any of its transactions is malformed.
+func (s *ImportTransactionsService) ImportTransactions(ctx context.Context, dto SubmitBatchDTO) ([]SubmitBatchResultDTO, error) {
+	taggedBEEFs, err := dto.taggedBEEFs()
+	if err != nil {
+		return nil, err
+	}
+
+	results := s.provider.SubmitBatch(ctx, taggedBEEFs, engine.SubmitModeHistorical)
+	if len(results) != len(taggedBEEFs) {
+		return nil, NewImportTransactionsProviderError(fmt.Errorf("expected %d import results, got %d", len(taggedBEEFs), len(results)))
+	}
+	return newSubmitBatchResultDTOs(results), nil
+}
+
+// NewImportTransactionsService creates a new ImportTransactionsService with the given provider.
+// Panics if the provider is nil.
+func NewImportTransactionsService(provider SubmitBatchProvider) *ImportTransactionsService {
+	if provider == nil {
+		panic("import transactions provider is nil")
+	}
+	return &ImportTransactionsService{provider: provider}
+}
+
+// NewImportTransactionsProviderError returns an Error indicating that the configured provider
+// failed to process imported transactions.
+func NewImportTransactionsProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to import the transactions due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,103 @@
+package app_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportTransactionsService_InvalidCases(t *testing.T) {
+	beef := hex.EncodeToString(testabilities.DummyTxBEEF(t))
+	tests := map[string]struct {
+		dto          app.SubmitBatchDTO
+		expectations testabilities.SubmitBatchProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"Import transactions service fails to import an empty batch": {
+			dto:         app.SubmitBatchDTO{},
+			expectedErr: app.NewIncorrectInputWithFieldError("transactions"),
+		},
+		"Import transactions service fails to import a transaction without topics": {
+			dto: app.SubmitBatchDTO{
+				Transactions: []app.SubmitBatchTransactionDTO{{BEEF: beef}},
+			},
+			expectedErr: app.NewIncorrectInputWithFieldError("transactions[0].topics"),
+		},
+		"Import transactions service fails when the provider does not return a result per transaction": {
+			dto: app.SubmitBatchDTO{
+				Transactions: []app.SubmitBatchTransactionDTO{{BEEF: beef, Topics: []string{"tm_test"}}},
+			},
+			expectations: testabilities.SubmitBatchProviderMockExpectations{
+				SubmitBatchCall: true,
+				SubmitMode:      engine.SubmitModeHistorical,
+				TaggedBEEFs:     []overlay.TaggedBEEF{{Beef: testabilities.DummyTxBEEF(t), Topics: []string{"tm_test"}}},
+			},
+			expectedErr: app.NewImportTransactionsProviderError(errors.New("expected 1 import results, got 0")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitBatchProviderMock(t, tc.expectations)
+			service := app.NewImportTransactionsService(mock)
+
+			// when:
+			results, err := service.ImportTransactions(context.Background(), tc.dto)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Nil(t, results)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestImportTransactionsService_ValidCase(t *testing.T) {
+	// given:
+	beef := testabilities.DummyTxBEEF(t)
+	txid := testabilities.DummyTxHash(t, "03895fb984362a4196bc9931629318fcbb2aeba7c6293638119ea653fa31d119")
+	steak := overlay.Steak{"tm_test": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}
+	mock := testabilities.NewSubmitBatchProviderMock(t, testabilities.SubmitBatchProviderMockExpectations{
+		SubmitBatchCall: true,
+		SubmitMode:      engine.SubmitModeHistorical,
+		TaggedBEEFs: []overlay.TaggedBEEF{
+			{Beef: beef, Topics: []string{"tm_test"}},
+			{Beef: beef, Topics: []string{"tm_other"}},
+		},
+		Results: []*engine.SubmitBatchResult{
+			{Txid: txid, Steak: steak},
+			{Txid: txid, Err: engine.ErrUnknownTopic},
+		},
+	})
+	service := app.NewImportTransactionsService(mock)
+
+	// when:
+	results, err := service.ImportTransactions(context.Background(), app.SubmitBatchDTO{
+		Transactions: []app.SubmitBatchTransactionDTO{
+			{BEEF: hex.EncodeToString(beef), Topics: []string{"tm_test"}},
+			{BEEF: hex.EncodeToString(beef), Topics: []string{"tm_other"}},
+		},
+	})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []app.SubmitBatchResultDTO{
+		{TxID: txid.String(), Steak: &steak},
+		{
+			TxID:         txid.String(),
+			ErrorCode:    "unknown-topic",
+			ErrorMessage: "The requested topic or lookup service is not hosted by the overlay.",
+		},
+	}, results)
+	mock.AssertCalled()
+}
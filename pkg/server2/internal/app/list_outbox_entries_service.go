@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// ListOutboxEntriesDTO represents the data transfer object used to request the broadcasts and
+// propagations of the submitted transactions.
+type ListOutboxEntriesDTO struct {
+	TxID   string // TxID is the hexadecimal ID of the transaction to list the entries of, every transaction when empty.
+	Status string // Status is the status of the entries to list, every status when empty.
+}
+
+// OutboxEntryDTO is a transport-friendly representation of a broadcast or a propagation of a submitted transaction.
+type OutboxEntryDTO struct {
+	ID            string    // ID identifies the entry.
+	Kind          string    // Kind is either broadcast or propagation.
+	TxID          string    // TxID is the hexadecimal ID of the transaction.
+	Topics        []string  // Topics are the topics the transaction is propagated for, empty for broadcasts.
+	Status        string    // Status is one of pending, completed or failed.
+	Attempts      int       // Attempts is the number of attempts made so far.
+	NextAttemptAt time.Time // NextAttemptAt is when the entry is attempted next while pending.
+	LastError     string    // LastError is why the last attempt failed, empty once completed.
+	CreatedAt     time.Time // CreatedAt is when the entry was queued.
+	UpdatedAt     time.Time // UpdatedAt is when the last attempt was made.
+}
+
+// ListOutboxEntriesProvider defines the interface for components that can list
+// the broadcasts and propagations of the overlay engine.
+type ListOutboxEntriesProvider interface {
+	ListOutboxEntries(ctx context.Context, txid *chainhash.Hash, status engine.OutboxStatus) ([]*engine.OutboxEntry, error)
+}
+
+// ListOutboxEntriesService coordinates the retrieval of the status of the broadcasts and
+// propagations of the submitted transactions.
+type ListOutboxEntriesService struct {
+	provider ListOutboxEntriesProvider
+}
+
+// ListOutboxEntries validates the requested transaction ID and status and returns the matching
+// entries, ordered by the time they were queued.
+func (s *ListOutboxEntriesService) ListOutboxEntries(ctx context.Context, dto ListOutboxEntriesDTO) ([]OutboxEntryDTO, error) {
+	var txid *chainhash.Hash
+	if dto.TxID != "" {
+		hash, err := chainhash.NewHashFromHex(dto.TxID)
+		if err != nil {
+			return nil, NewIncorrectInputWithFieldError("txid")
+		}
+		txid = hash
+	}
+	status := engine.OutboxStatus(dto.Status)
+	switch status {
+	case "", engine.OutboxPending, engine.OutboxCompleted, engine.OutboxFailed:
+	default:
+		return nil, NewIncorrectInputWithFieldError("status")
+	}
+
+	entries, err := s.provider.ListOutboxEntries(ctx, txid, status)
+	if err != nil {
+		return nil, NewListOutboxEntriesProviderError(err)
+	}
+
+	dtos := make([]OutboxEntryDTO, 0, len(entries))
+	for _, entry := range entries {
+		dtos = append(dtos, OutboxEntryDTO{
+			ID:            entry.ID,
+			Kind:          string(entry.Kind),
+			TxID:          entry.Txid.String(),
+			Topics:        entry.Topics,
+			Status:        string(entry.Status),
+			Attempts:      entry.Attempts,
+			NextAttemptAt: entry.NextAttemptAt,
+			LastError:     entry.LastError,
+			CreatedAt:     entry.CreatedAt,
+			UpdatedAt:     entry.UpdatedAt,
+		})
+	}
+	return dtos, nil
+}
+
+// NewListOutboxEntriesService creates a new ListOutboxEntriesService with the given provider.
+// Panics if the provider is nil.
+func NewListOutboxEntriesService(provider ListOutboxEntriesProvider) *ListOutboxEntriesService {
+	if provider == nil {
+		panic("list outbox entries provider is nil")
+	}
+	return &ListOutboxEntriesService{provider: provider}
+}
+
+// NewListOutboxEntriesProviderError returns an Error indicating that the configured provider
+// failed to list the outbox entries.
+func NewListOutboxEntriesProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to list the outbox entries due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,104 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOutboxEntriesService_InvalidCases(t *testing.T) {
+	providerError := errors.New("internal list outbox entries service test error")
+	txid := chainhash.DoubleHashH([]byte("outbox"))
+	tests := map[string]struct {
+		dto          app.ListOutboxEntriesDTO
+		expectations testabilities.ListOutboxEntriesProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"List outbox entries service fails to list the entries of an invalid txid": {
+			dto:         app.ListOutboxEntriesDTO{TxID: "invalid"},
+			expectedErr: app.NewIncorrectInputWithFieldError("txid"),
+		},
+		"List outbox entries service fails to list the entries of an unknown status": {
+			dto:         app.ListOutboxEntriesDTO{Status: "lost"},
+			expectedErr: app.NewIncorrectInputWithFieldError("status"),
+		},
+		"List outbox entries service fails to list the entries": {
+			dto: app.ListOutboxEntriesDTO{TxID: txid.String(), Status: "failed"},
+			expectations: testabilities.ListOutboxEntriesProviderMockExpectations{
+				ListOutboxEntriesCall: true,
+				TxID:                  &txid,
+				Status:                engine.OutboxFailed,
+				Error:                 providerError,
+			},
+			expectedErr: app.NewListOutboxEntriesProviderError(providerError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewListOutboxEntriesProviderMock(t, tc.expectations)
+			service := app.NewListOutboxEntriesService(mock)
+
+			// when:
+			entries, err := service.ListOutboxEntries(context.Background(), tc.dto)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Nil(t, entries)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestListOutboxEntriesService_ValidCase(t *testing.T) {
+	// given:
+	txid := chainhash.DoubleHashH([]byte("outbox"))
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock := testabilities.NewListOutboxEntriesProviderMock(t, testabilities.ListOutboxEntriesProviderMockExpectations{
+		ListOutboxEntriesCall: true,
+		Status:                engine.OutboxPending,
+		Entries: []*engine.OutboxEntry{{
+			ID:            "entry",
+			Kind:          engine.OutboxPropagation,
+			Txid:          txid,
+			Topics:        []string{"tm_test"},
+			Beef:          []byte{0xbe, 0xef},
+			Status:        engine.OutboxPending,
+			Attempts:      2,
+			NextAttemptAt: createdAt.Add(time.Minute),
+			LastError:     "no hosts",
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt.Add(time.Second),
+		}},
+	})
+	service := app.NewListOutboxEntriesService(mock)
+
+	// when:
+	entries, err := service.ListOutboxEntries(context.Background(), app.ListOutboxEntriesDTO{Status: "pending"})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []app.OutboxEntryDTO{{
+		ID:            "entry",
+		Kind:          "propagation",
+		TxID:          txid.String(),
+		Topics:        []string{"tm_test"},
+		Status:        "pending",
+		Attempts:      2,
+		NextAttemptAt: createdAt.Add(time.Minute),
+		LastError:     "no hosts",
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt.Add(time.Second),
+	}}, entries)
+	mock.AssertCalled()
+}
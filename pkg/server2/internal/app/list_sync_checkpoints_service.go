@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// SyncCheckpointDTO is a transport-friendly representation of the last successful
+// GASP sync of a topic with a peer.
+type SyncCheckpointDTO struct {
+	Topic     string    // Topic is the synced topic.
+	Peer      string    // Peer is the endpoint of the peer the topic was synced with.
+	Since     uint32    // Since is the unix timestamp at which the last successful sync started.
+	UpdatedAt time.Time // UpdatedAt is the time at which the checkpoint was recorded.
+}
+
+// ListSyncCheckpointsProvider defines the interface for components that can
+// list the GASP sync checkpoints of the overlay engine.
+type ListSyncCheckpointsProvider interface {
+	ListSyncCheckpoints(ctx context.Context, topic string) ([]*engine.SyncCheckpoint, error)
+}
+
+// ListSyncCheckpointsService coordinates the retrieval of the GASP sync checkpoints.
+type ListSyncCheckpointsService struct {
+	provider ListSyncCheckpointsProvider
+}
+
+// ListSyncCheckpoints returns the sync checkpoints of the topic, or of every topic when
+// the topic is empty. The checkpoints are transformed into DTOs suitable for external use.
+func (s *ListSyncCheckpointsService) ListSyncCheckpoints(ctx context.Context, topic string) ([]SyncCheckpointDTO, error) {
+	checkpoints, err := s.provider.ListSyncCheckpoints(ctx, topic)
+	if err != nil {
+		return nil, NewListSyncCheckpointsProviderError(err)
+	}
+
+	dtos := make([]SyncCheckpointDTO, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		dtos = append(dtos, SyncCheckpointDTO{
+			Topic:     checkpoint.Topic,
+			Peer:      checkpoint.Peer,
+			Since:     checkpoint.Since,
+			UpdatedAt: checkpoint.UpdatedAt,
+		})
+	}
+	return dtos, nil
+}
+
+// NewListSyncCheckpointsService creates a new ListSyncCheckpointsService with the given provider.
+// Panics if the provider is nil.
+func NewListSyncCheckpointsService(provider ListSyncCheckpointsProvider) *ListSyncCheckpointsService {
+	if provider == nil {
+		panic("list sync checkpoints provider is nil")
+	}
+	return &ListSyncCheckpointsService{provider: provider}
+}
+
+// NewListSyncCheckpointsProviderError returns an Error indicating that the configured provider
+// failed to list the sync checkpoints.
+func NewListSyncCheckpointsProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to list GASP sync checkpoints due to an internal error. Please try again later or contact the support team.",
+	)
+}
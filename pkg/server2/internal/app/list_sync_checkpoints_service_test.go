@@ -0,0 +1,60 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSyncCheckpointsService_InvalidCase(t *testing.T) {
+	// given:
+	providerError := errors.New("internal list sync checkpoints service test error")
+	expectations := testabilities.ListSyncCheckpointsProviderMockExpectations{
+		ListSyncCheckpointsCall: true,
+		Topic:                   testabilities.DefaultSyncCheckpoint.Topic,
+		Error:                   providerError,
+	}
+	expectedErr := app.NewListSyncCheckpointsProviderError(providerError)
+	mock := testabilities.NewListSyncCheckpointsProviderMock(t, expectations)
+	service := app.NewListSyncCheckpointsService(mock)
+
+	// when:
+	checkpoints, err := service.ListSyncCheckpoints(context.Background(), testabilities.DefaultSyncCheckpoint.Topic)
+
+	// then:
+	var actualErr app.Error
+	require.ErrorAs(t, err, &actualErr)
+	require.Equal(t, expectedErr, actualErr)
+	require.Nil(t, checkpoints)
+	mock.AssertCalled()
+}
+
+func TestListSyncCheckpointsService_ValidCase(t *testing.T) {
+	// given:
+	checkpoint := testabilities.DefaultSyncCheckpoint
+	expectations := testabilities.ListSyncCheckpointsProviderMockExpectations{
+		ListSyncCheckpointsCall: true,
+		Checkpoints:             []*engine.SyncCheckpoint{checkpoint},
+	}
+	expectedDTOs := []app.SyncCheckpointDTO{{
+		Topic:     checkpoint.Topic,
+		Peer:      checkpoint.Peer,
+		Since:     checkpoint.Since,
+		UpdatedAt: checkpoint.UpdatedAt,
+	}}
+	mock := testabilities.NewListSyncCheckpointsProviderMock(t, expectations)
+	service := app.NewListSyncCheckpointsService(mock)
+
+	// when:
+	checkpoints, err := service.ListSyncCheckpoints(context.Background(), "")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, expectedDTOs, checkpoints)
+	mock.AssertCalled()
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// ListWebhookDeliveriesDTO represents the data transfer object used to request the delivery log of the webhooks.
+type ListWebhookDeliveriesDTO struct {
+	WebhookID string // WebhookID is the webhook to list the deliveries of, every webhook when empty.
+	Status    string // Status is the status of the deliveries to list, every status when empty.
+}
+
+// WebhookDeliveryDTO is a transport-friendly representation of an event queued for delivery to a webhook.
+type WebhookDeliveryDTO struct {
+	ID             string    // ID identifies the delivery across its attempts.
+	WebhookID      string    // WebhookID is the webhook the event is delivered to.
+	Topic          string    // Topic is the topic of the event.
+	EventType      string    // EventType is either output-admitted or output-spent.
+	Payload        string    // Payload is the JSON body posted to the webhook.
+	Status         string    // Status is one of pending, delivered or dead.
+	Attempts       int       // Attempts is the number of attempts made so far.
+	NextAttemptAt  time.Time // NextAttemptAt is when the delivery is attempted next while pending.
+	LastStatusCode int       // LastStatusCode is the status code of the last attempt, zero when no response was received.
+	LastError      string    // LastError is why the last attempt failed, empty once delivered.
+	CreatedAt      time.Time // CreatedAt is when the delivery was queued.
+	UpdatedAt      time.Time // UpdatedAt is when the last attempt was made.
+}
+
+// ListWebhookDeliveriesProvider defines the interface for components that can list
+// the delivery log of the webhooks of the overlay engine.
+type ListWebhookDeliveriesProvider interface {
+	ListWebhookDeliveries(ctx context.Context, webhookID string, status engine.WebhookDeliveryStatus) ([]*engine.WebhookDelivery, error)
+}
+
+// ListWebhookDeliveriesService coordinates the retrieval of the delivery log of the webhooks,
+// including the dead letters.
+type ListWebhookDeliveriesService struct {
+	provider ListWebhookDeliveriesProvider
+}
+
+// ListWebhookDeliveries validates the requested status and returns the matching deliveries,
+// ordered by the time they were queued.
+func (s *ListWebhookDeliveriesService) ListWebhookDeliveries(ctx context.Context, dto ListWebhookDeliveriesDTO) ([]WebhookDeliveryDTO, error) {
+	status := engine.WebhookDeliveryStatus(dto.Status)
+	switch status {
+	case "", engine.WebhookDeliveryPending, engine.WebhookDeliveryDelivered, engine.WebhookDeliveryDead:
+	default:
+		return nil, NewIncorrectInputWithFieldError("status")
+	}
+
+	deliveries, err := s.provider.ListWebhookDeliveries(ctx, dto.WebhookID, status)
+	if err != nil {
+		return nil, NewListWebhookDeliveriesProviderError(err)
+	}
+
+	dtos := make([]WebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		dtos = append(dtos, WebhookDeliveryDTO{
+			ID:             delivery.ID,
+			WebhookID:      delivery.WebhookID,
+			Topic:          delivery.Topic,
+			EventType:      string(delivery.EventType),
+			Payload:        string(delivery.Payload),
+			Status:         string(delivery.Status),
+			Attempts:       delivery.Attempts,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			LastStatusCode: delivery.LastStatusCode,
+			LastError:      delivery.LastError,
+			CreatedAt:      delivery.CreatedAt,
+			UpdatedAt:      delivery.UpdatedAt,
+		})
+	}
+	return dtos, nil
+}
+
+// NewListWebhookDeliveriesService creates a new ListWebhookDeliveriesService with the given provider.
+// Panics if the provider is nil.
+func NewListWebhookDeliveriesService(provider ListWebhookDeliveriesProvider) *ListWebhookDeliveriesService {
+	if provider == nil {
+		panic("list webhook deliveries provider is nil")
+	}
+	return &ListWebhookDeliveriesService{provider: provider}
+}
+
+// NewListWebhookDeliveriesProviderError returns an Error indicating that the configured provider
+// failed to list the webhook deliveries.
+func NewListWebhookDeliveriesProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to list the webhook deliveries due to an internal error. Please try again later or contact the support team.",
+	)
+}
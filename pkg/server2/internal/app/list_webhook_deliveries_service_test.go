@@ -0,0 +1,100 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWebhookDeliveriesService_InvalidCases(t *testing.T) {
+	providerError := errors.New("internal list webhook deliveries service test error")
+	tests := map[string]struct {
+		dto          app.ListWebhookDeliveriesDTO
+		expectations testabilities.ListWebhookDeliveriesProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"List webhook deliveries service fails to list the deliveries of an unknown status": {
+			dto:         app.ListWebhookDeliveriesDTO{Status: "lost"},
+			expectedErr: app.NewIncorrectInputWithFieldError("status"),
+		},
+		"List webhook deliveries service fails to list the deliveries": {
+			dto: app.ListWebhookDeliveriesDTO{WebhookID: "id", Status: "dead"},
+			expectations: testabilities.ListWebhookDeliveriesProviderMockExpectations{
+				ListWebhookDeliveriesCall: true,
+				WebhookID:                 "id",
+				Status:                    engine.WebhookDeliveryDead,
+				Error:                     providerError,
+			},
+			expectedErr: app.NewListWebhookDeliveriesProviderError(providerError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewListWebhookDeliveriesProviderMock(t, tc.expectations)
+			service := app.NewListWebhookDeliveriesService(mock)
+
+			// when:
+			deliveries, err := service.ListWebhookDeliveries(context.Background(), tc.dto)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Nil(t, deliveries)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestListWebhookDeliveriesService_ValidCase(t *testing.T) {
+	// given:
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock := testabilities.NewListWebhookDeliveriesProviderMock(t, testabilities.ListWebhookDeliveriesProviderMockExpectations{
+		ListWebhookDeliveriesCall: true,
+		Status:                    engine.WebhookDeliveryDead,
+		Deliveries: []*engine.WebhookDelivery{{
+			ID:             "delivery",
+			WebhookID:      "id",
+			Topic:          "tm_test",
+			EventType:      engine.EventOutputAdmitted,
+			Payload:        []byte(`{"type":"output-admitted"}`),
+			Status:         engine.WebhookDeliveryDead,
+			Attempts:       8,
+			NextAttemptAt:  createdAt,
+			LastStatusCode: 500,
+			LastError:      "webhook responded with status code 500",
+			CreatedAt:      createdAt,
+			UpdatedAt:      createdAt.Add(time.Hour),
+		}},
+	})
+	service := app.NewListWebhookDeliveriesService(mock)
+
+	// when:
+	deliveries, err := service.ListWebhookDeliveries(context.Background(), app.ListWebhookDeliveriesDTO{Status: "dead"})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []app.WebhookDeliveryDTO{{
+		ID:             "delivery",
+		WebhookID:      "id",
+		Topic:          "tm_test",
+		EventType:      "output-admitted",
+		Payload:        `{"type":"output-admitted"}`,
+		Status:         "dead",
+		Attempts:       8,
+		NextAttemptAt:  createdAt,
+		LastStatusCode: 500,
+		LastError:      "webhook responded with status code 500",
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt.Add(time.Hour),
+	}}, deliveries)
+	mock.AssertCalled()
+}
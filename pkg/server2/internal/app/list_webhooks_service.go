@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// WebhookDTO is a transport-friendly representation of a webhook receiving the admitted
+// and spent outputs of a topic.
+type WebhookDTO struct {
+	ID        string    // ID identifies the webhook.
+	Topic     string    // Topic is the topic whose events are delivered.
+	URL       string    // URL is where the events are posted.
+	Secret    string    // Secret keys the signature of the deliveries, only exposed on registration.
+	CreatedAt time.Time // CreatedAt is when the webhook was registered, zero for configured webhooks.
+}
+
+// NewWebhookDTO returns the WebhookDTO of the webhook.
+func NewWebhookDTO(webhook *engine.Webhook) WebhookDTO {
+	return WebhookDTO{
+		ID:        webhook.ID,
+		Topic:     webhook.Topic,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+// ListWebhooksProvider defines the interface for components that can list
+// the webhooks of the overlay engine.
+type ListWebhooksProvider interface {
+	ListWebhooks(ctx context.Context) ([]*engine.Webhook, error)
+}
+
+// ListWebhooksService coordinates the retrieval of the webhooks.
+type ListWebhooksService struct {
+	provider ListWebhooksProvider
+}
+
+// ListWebhooks returns the webhooks of the engine configuration followed by the registered ones.
+func (s *ListWebhooksService) ListWebhooks(ctx context.Context) ([]WebhookDTO, error) {
+	webhooks, err := s.provider.ListWebhooks(ctx)
+	if err != nil {
+		return nil, NewListWebhooksProviderError(err)
+	}
+
+	dtos := make([]WebhookDTO, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		dtos = append(dtos, NewWebhookDTO(webhook))
+	}
+	return dtos, nil
+}
+
+// NewListWebhooksService creates a new ListWebhooksService with the given provider.
+// Panics if the provider is nil.
+func NewListWebhooksService(provider ListWebhooksProvider) *ListWebhooksService {
+	if provider == nil {
+		panic("list webhooks provider is nil")
+	}
+	return &ListWebhooksService{provider: provider}
+}
+
+// NewListWebhooksProviderError returns an Error indicating that the configured provider
+// failed to list the webhooks.
+func NewListWebhooksProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to list the webhooks due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,57 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWebhooksService_InvalidCase(t *testing.T) {
+	// given:
+	providerError := errors.New("internal list webhooks service test error")
+	mock := testabilities.NewListWebhooksProviderMock(t, testabilities.ListWebhooksProviderMockExpectations{
+		ListWebhooksCall: true,
+		Error:            providerError,
+	})
+	service := app.NewListWebhooksService(mock)
+
+	// when:
+	webhooks, err := service.ListWebhooks(context.Background())
+
+	// then:
+	var actualErr app.Error
+	require.ErrorAs(t, err, &actualErr)
+	require.Equal(t, app.NewListWebhooksProviderError(providerError), actualErr)
+	require.Nil(t, webhooks)
+	mock.AssertCalled()
+}
+
+func TestListWebhooksService_ValidCase(t *testing.T) {
+	// given:
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock := testabilities.NewListWebhooksProviderMock(t, testabilities.ListWebhooksProviderMockExpectations{
+		ListWebhooksCall: true,
+		Webhooks: []*engine.Webhook{
+			{ID: "configured", Topic: "tm_test", URL: "https://example.com/configured"},
+			{ID: "registered", Topic: "tm_test", URL: "https://example.com/registered", CreatedAt: createdAt},
+		},
+	})
+	service := app.NewListWebhooksService(mock)
+
+	// when:
+	webhooks, err := service.ListWebhooks(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []app.WebhookDTO{
+		{ID: "configured", Topic: "tm_test", URL: "https://example.com/configured"},
+		{ID: "registered", Topic: "tm_test", URL: "https://example.com/registered", CreatedAt: createdAt},
+	}, webhooks)
+	mock.AssertCalled()
+}
@@ -119,8 +119,8 @@ func NewLookupQuestionParserError(err error) Error {
 // Produces a standardized user-facing error message while retaining the original error internally
 // for logging or diagnostics.
 func NewLookupQuestionProviderError(err error) Error {
-	return NewProviderFailureError(
-		err.Error(),
+	return newProviderError(
+		err,
 		"Unable to process lookup question due to an internal error. Please try again later or contact the support team.",
 	)
 }
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// PruneHistoryDTO represents the data transfer object used to request the enforcement of the retention policies.
+type PruneHistoryDTO struct {
+	Topic  string // Topic is the topic to prune, every topic with a retention policy when empty.
+	DryRun bool   // DryRun tells whether the outputs are only reported, without removing them.
+}
+
+// PrunedOutputDTO is a transport-friendly representation of a spent output pruned from the history of a topic.
+type PrunedOutputDTO struct {
+	TxID        string // TxID is the hexadecimal ID of the transaction holding the output.
+	OutputIndex uint32 // OutputIndex is the index of the output within the transaction.
+	Reason      string // Reason is the rule of the retention policy the output is not retained by.
+}
+
+// PruneReportDTO is a transport-friendly representation of the outputs pruned from the history of a topic.
+type PruneReportDTO struct {
+	Topic  string            // Topic is the pruned topic.
+	DryRun bool              // DryRun tells whether the outputs were only reported.
+	Pruned []PrunedOutputDTO // Pruned lists the spent outputs removed from the history of the topic.
+}
+
+// PruneHistoryProvider defines the interface for components that can enforce
+// the retention policies of the topics of the overlay engine.
+type PruneHistoryProvider interface {
+	Prune(ctx context.Context, dryRun bool) ([]*engine.PruneReport, error)
+	PruneTopic(ctx context.Context, topic string, dryRun bool) (*engine.PruneReport, error)
+}
+
+// PruneHistoryService coordinates the enforcement of the retention policies, which removes
+// from storage the spent outputs the topics no longer retain as history.
+type PruneHistoryService struct {
+	provider PruneHistoryProvider
+}
+
+// PruneHistory enforces the retention policy of the requested topic, or of every topic with
+// a retention policy when no topic is requested. In dry-run mode nothing is removed.
+func (s *PruneHistoryService) PruneHistory(ctx context.Context, dto PruneHistoryDTO) ([]PruneReportDTO, error) {
+	var reports []*engine.PruneReport
+	if dto.Topic == "" {
+		all, err := s.provider.Prune(ctx, dto.DryRun)
+		if err != nil {
+			return nil, NewPruneHistoryProviderError(err)
+		}
+		reports = all
+	} else {
+		report, err := s.provider.PruneTopic(ctx, dto.Topic, dto.DryRun)
+		if errors.Is(err, engine.ErrNoRetentionPolicy) {
+			return nil, NewRetentionPolicyNotFoundError(dto.Topic)
+		}
+		if err != nil {
+			return nil, NewPruneHistoryProviderError(err)
+		}
+		reports = []*engine.PruneReport{report}
+	}
+
+	dtos := make([]PruneReportDTO, 0, len(reports))
+	for _, report := range reports {
+		pruned := make([]PrunedOutputDTO, 0, len(report.Pruned))
+		for _, output := range report.Pruned {
+			pruned = append(pruned, PrunedOutputDTO{
+				TxID:        output.Outpoint.Txid.String(),
+				OutputIndex: output.Outpoint.Index,
+				Reason:      string(output.Reason),
+			})
+		}
+		dtos = append(dtos, PruneReportDTO{Topic: report.Topic, DryRun: report.DryRun, Pruned: pruned})
+	}
+	return dtos, nil
+}
+
+// NewPruneHistoryService creates a new PruneHistoryService with the given provider.
+// Panics if the provider is nil.
+func NewPruneHistoryService(provider PruneHistoryProvider) *PruneHistoryService {
+	if provider == nil {
+		panic("prune history provider is nil")
+	}
+	return &PruneHistoryService{provider: provider}
+}
+
+// NewRetentionPolicyNotFoundError returns an Error indicating that the topic requested to be
+// pruned has no retention policy.
+func NewRetentionPolicyNotFoundError(topic string) Error {
+	msg := "Unable to prune the history of the topic " + topic + " as it has no retention policy."
+	return NewNotFoundError(msg, msg).WithCode(ErrorCodeNoRetentionPolicy)
+}
+
+// NewPruneHistoryProviderError returns an Error indicating that the configured provider
+// failed to enforce the retention policies.
+func NewPruneHistoryProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to prune the history due to an internal error. Please try again later or contact the support team.",
+	)
+}
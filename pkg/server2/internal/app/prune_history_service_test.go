@@ -0,0 +1,98 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneHistoryService_InvalidCases(t *testing.T) {
+	providerError := errors.New("internal prune history service test error")
+	tests := map[string]struct {
+		dto          app.PruneHistoryDTO
+		expectations testabilities.PruneHistoryProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"Prune history service fails to prune every topic": {
+			dto: app.PruneHistoryDTO{DryRun: true},
+			expectations: testabilities.PruneHistoryProviderMockExpectations{
+				PruneHistoryCall: true,
+				DryRun:           true,
+				Error:            providerError,
+			},
+			expectedErr: app.NewPruneHistoryProviderError(providerError),
+		},
+		"Prune history service fails to prune the topic": {
+			dto: app.PruneHistoryDTO{Topic: "tm_test"},
+			expectations: testabilities.PruneHistoryProviderMockExpectations{
+				PruneHistoryCall: true,
+				Topic:            "tm_test",
+				Error:            providerError,
+			},
+			expectedErr: app.NewPruneHistoryProviderError(providerError),
+		},
+		"Prune history service fails to prune a topic without retention policy": {
+			dto: app.PruneHistoryDTO{Topic: "tm_test"},
+			expectations: testabilities.PruneHistoryProviderMockExpectations{
+				PruneHistoryCall: true,
+				Topic:            "tm_test",
+				Error:            engine.ErrNoRetentionPolicy,
+			},
+			expectedErr: app.NewRetentionPolicyNotFoundError("tm_test"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewPruneHistoryProviderMock(t, tc.expectations)
+			service := app.NewPruneHistoryService(mock)
+
+			// when:
+			reports, err := service.PruneHistory(context.Background(), tc.dto)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Nil(t, reports)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestPruneHistoryService_ValidCase(t *testing.T) {
+	// given:
+	txid := chainhash.DoubleHashH([]byte("pruned"))
+	expectations := testabilities.PruneHistoryProviderMockExpectations{
+		PruneHistoryCall: true,
+		Topic:            "tm_test",
+		DryRun:           true,
+		Reports: []*engine.PruneReport{{
+			Topic:  "tm_test",
+			DryRun: true,
+			Pruned: []engine.PrunedOutput{{Outpoint: transaction.Outpoint{Txid: txid, Index: 1}, Reason: engine.PruneReasonAncestryDepth}},
+		}},
+	}
+	mock := testabilities.NewPruneHistoryProviderMock(t, expectations)
+	service := app.NewPruneHistoryService(mock)
+
+	// when:
+	reports, err := service.PruneHistory(context.Background(), app.PruneHistoryDTO{Topic: "tm_test", DryRun: true})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []app.PruneReportDTO{{
+		Topic:  "tm_test",
+		DryRun: true,
+		Pruned: []app.PrunedOutputDTO{{TxID: txid.String(), OutputIndex: 1, Reason: "ancestry-depth"}},
+	}}, reports)
+	mock.AssertCalled()
+}
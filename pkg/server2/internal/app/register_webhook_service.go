@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// RegisterWebhookDTO represents the data transfer object used to request the registration of a webhook.
+type RegisterWebhookDTO struct {
+	Topic  string // Topic is the topic whose admitted and spent outputs are delivered.
+	URL    string // URL is where the signed payloads are posted.
+	Secret string // Secret keys the signature of the payloads, generated when empty.
+}
+
+// RegisterWebhookProvider defines the interface for components that can register
+// webhooks in the overlay engine.
+type RegisterWebhookProvider interface {
+	RegisterWebhook(ctx context.Context, topic, url, secret string) (*engine.Webhook, error)
+}
+
+// RegisterWebhookService coordinates the registration of the webhooks.
+type RegisterWebhookService struct {
+	provider RegisterWebhookProvider
+}
+
+// RegisterWebhook validates the request and registers the webhook. The returned webhook
+// carries its secret, which is never listed afterwards.
+func (s *RegisterWebhookService) RegisterWebhook(ctx context.Context, dto RegisterWebhookDTO) (WebhookDTO, error) {
+	if dto.Topic == "" {
+		return WebhookDTO{}, NewIncorrectInputWithFieldError("topic")
+	}
+	if dto.URL == "" {
+		return WebhookDTO{}, NewIncorrectInputWithFieldError("url")
+	}
+
+	webhook, err := s.provider.RegisterWebhook(ctx, dto.Topic, dto.URL, dto.Secret)
+	if errors.Is(err, engine.ErrInvalidWebhook) {
+		return WebhookDTO{}, NewInvalidWebhookError(err)
+	}
+	if err != nil {
+		return WebhookDTO{}, NewRegisterWebhookProviderError(err)
+	}
+	return NewWebhookDTO(webhook), nil
+}
+
+// NewRegisterWebhookService creates a new RegisterWebhookService with the given provider.
+// Panics if the provider is nil.
+func NewRegisterWebhookService(provider RegisterWebhookProvider) *RegisterWebhookService {
+	if provider == nil {
+		panic("register webhook provider is nil")
+	}
+	return &RegisterWebhookService{provider: provider}
+}
+
+// NewInvalidWebhookError returns an Error indicating that the webhook requested to be registered
+// has an unknown topic or an invalid URL.
+func NewInvalidWebhookError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"Unable to register the webhook as its topic is unknown or its URL is not an http or https URL.",
+	).WithCode(ErrorCodeInvalidWebhook)
+}
+
+// NewRegisterWebhookProviderError returns an Error indicating that the configured provider
+// failed to register the webhook.
+func NewRegisterWebhookProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to register the webhook due to an internal error. Please try again later or contact the support team.",
+	)
+}
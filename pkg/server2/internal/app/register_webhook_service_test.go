@@ -0,0 +1,96 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterWebhookService_InvalidCases(t *testing.T) {
+	providerError := errors.New("internal register webhook service test error")
+	invalidWebhookError := fmt.Errorf("%w: unknown topic tm_unknown", engine.ErrInvalidWebhook)
+	tests := map[string]struct {
+		dto          app.RegisterWebhookDTO
+		expectations testabilities.RegisterWebhookProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"Register webhook service fails to register a webhook without topic": {
+			dto:         app.RegisterWebhookDTO{URL: "https://example.com/hook"},
+			expectedErr: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Register webhook service fails to register a webhook without URL": {
+			dto:         app.RegisterWebhookDTO{Topic: "tm_test"},
+			expectedErr: app.NewIncorrectInputWithFieldError("url"),
+		},
+		"Register webhook service fails to register an invalid webhook": {
+			dto: app.RegisterWebhookDTO{Topic: "tm_unknown", URL: "https://example.com/hook"},
+			expectations: testabilities.RegisterWebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				Topic:               "tm_unknown",
+				URL:                 "https://example.com/hook",
+				Error:               invalidWebhookError,
+			},
+			expectedErr: app.NewInvalidWebhookError(invalidWebhookError),
+		},
+		"Register webhook service fails to register the webhook": {
+			dto: app.RegisterWebhookDTO{Topic: "tm_test", URL: "https://example.com/hook"},
+			expectations: testabilities.RegisterWebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				Topic:               "tm_test",
+				URL:                 "https://example.com/hook",
+				Error:               providerError,
+			},
+			expectedErr: app.NewRegisterWebhookProviderError(providerError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewRegisterWebhookProviderMock(t, tc.expectations)
+			service := app.NewRegisterWebhookService(mock)
+
+			// when:
+			webhook, err := service.RegisterWebhook(context.Background(), tc.dto)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Zero(t, webhook)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestRegisterWebhookService_ValidCase(t *testing.T) {
+	// given:
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock := testabilities.NewRegisterWebhookProviderMock(t, testabilities.RegisterWebhookProviderMockExpectations{
+		RegisterWebhookCall: true,
+		Topic:               "tm_test",
+		URL:                 "https://example.com/hook",
+		Secret:              "secret",
+		Webhook:             &engine.Webhook{ID: "id", Topic: "tm_test", URL: "https://example.com/hook", Secret: "secret", CreatedAt: createdAt},
+	})
+	service := app.NewRegisterWebhookService(mock)
+
+	// when:
+	webhook, err := service.RegisterWebhook(context.Background(), app.RegisterWebhookDTO{
+		Topic:  "tm_test",
+		URL:    "https://example.com/hook",
+		Secret: "secret",
+	})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, app.WebhookDTO{ID: "id", Topic: "tm_test", URL: "https://example.com/hook", Secret: "secret", CreatedAt: createdAt}, webhook)
+	mock.AssertCalled()
+}
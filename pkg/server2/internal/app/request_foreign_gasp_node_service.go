@@ -69,8 +69,8 @@ func NewRequestForeignGASPNodeService(provider RequestForeignGASPNodeProvider) *
 // NewForeignGASPNodeProviderError wraps a lower-level provider error in a user-facing error with guidance.
 // Used when the provider fails to supply the requested foreign GASP node.
 func NewForeignGASPNodeProviderError(err error) Error {
-	return NewProviderFailureError(
-		err.Error(),
+	return newProviderError(
+		err,
 		"Unable to process foreign gasp node request due to an internal error. Please try again later or contact the support team.",
 	)
 }
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// RequestSyncReplyDTO is a transport-friendly structure that encapsulates
+// the reply to an initial sync response: the UTXO outpoints known to the
+// overlay engine that the requester did not list.
+type RequestSyncReplyDTO struct {
+	UTXOList []OutpointDTO
+}
+
+// RequestSyncReplyProvider defines the interface for components that can
+// reply to the initial sync response of a foreign peer.
+type RequestSyncReplyProvider interface {
+	ProvideForeignSyncReply(ctx context.Context, initialResponse *core.GASPInitialResponse, topic string) (*core.GASPInitialReply, error)
+}
+
+// RequestSyncReplyService coordinates the sync reply operation within the
+// application layer. It validates inputs, delegates the core logic to a provider,
+// and adapts the reply into a client-facing DTO.
+type RequestSyncReplyService struct {
+	provider RequestSyncReplyProvider
+}
+
+// RequestSyncReply replies to the initial sync response of a foreign peer for a given topic.
+// It validates the topic and the listed outpoints, constructs the initial response payload,
+// and delegates the operation to the provider. The reply is transformed into a DTO
+// suitable for external use.
+func (s *RequestSyncReplyService) RequestSyncReply(ctx context.Context, topic Topic, utxos []OutpointDTO, since Since) (*RequestSyncReplyDTO, error) {
+	if topic.IsEmpty() {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+
+	outpoints := make([]*transaction.Outpoint, 0, len(utxos))
+	for _, utxo := range utxos {
+		txID, err := chainhash.NewHashFromHex(utxo.TxID)
+		if err != nil {
+			return nil, NewRawDataProcessingWithFieldError(err, "UTXOList")
+		}
+		outpoints = append(outpoints, &transaction.Outpoint{Txid: *txID, Index: utxo.OutputIndex})
+	}
+
+	reply, err := s.provider.ProvideForeignSyncReply(ctx, &core.GASPInitialResponse{UTXOList: outpoints, Since: since.Unit32()}, topic.String())
+	if err != nil {
+		return nil, NewRequestSyncReplyProviderError(err)
+	}
+	return NewRequestSyncReplyDTO(reply), nil
+}
+
+// NewRequestSyncReplyDTO transforms the core GASP initial reply into a
+// client-friendly DTO, preserving only the UTXO data.
+func NewRequestSyncReplyDTO(reply *core.GASPInitialReply) *RequestSyncReplyDTO {
+	outpoints := make([]OutpointDTO, 0, len(reply.UTXOList))
+	for _, utxo := range reply.UTXOList {
+		outpoints = append(outpoints, OutpointDTO{
+			TxID:        utxo.Txid.String(),
+			OutputIndex: utxo.Index,
+		})
+	}
+	return &RequestSyncReplyDTO{UTXOList: outpoints}
+}
+
+// NewRequestSyncReplyService constructs a new RequestSyncReplyService with the
+// provided provider. It panics if the provider is nil to enforce safe initialization.
+func NewRequestSyncReplyService(provider RequestSyncReplyProvider) *RequestSyncReplyService {
+	if provider == nil {
+		panic("request sync reply provider is nil")
+	}
+	return &RequestSyncReplyService{provider: provider}
+}
+
+// NewRequestSyncReplyProviderError wraps a low-level provider error that occurred
+// during a sync reply request. The resulting error is classified as a provider failure
+// and returns a generic slug message suitable for client-facing usage.
+func NewRequestSyncReplyProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to process sync reply request due to an error in the overlay engine.",
+	)
+}
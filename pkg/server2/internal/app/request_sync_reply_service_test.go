@@ -0,0 +1,103 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestSyncReplyService_ValidCase(t *testing.T) {
+	// given:
+	outpoint := &transaction.Outpoint{
+		Txid:  *testabilities.DummyTxHash(t, "03895fb984362a4196bc9931629318fcbb2aeba7c6293638119ea653fa31d119"),
+		Index: 1,
+	}
+	expectations := testabilities.RequestSyncReplyProviderMockExpectations{
+		ProvideForeignSyncReplyCall: true,
+		InitialResponse: &core.GASPInitialResponse{
+			UTXOList: []*transaction.Outpoint{outpoint},
+			Since:    testabilities.DefaultSince,
+		},
+		Topic: testabilities.DefaultTopic,
+		Reply: &core.GASPInitialReply{
+			UTXOList: []*transaction.Outpoint{
+				{
+					Txid:  *testabilities.DummyTxHash(t, "27c8f37851aabc468d3dbb6bf0789dc398a602dcb897ca04e7815d939d621595"),
+					Index: 0,
+				},
+			},
+		},
+	}
+	provider := testabilities.NewRequestSyncReplyProviderMock(t, expectations)
+	service := app.NewRequestSyncReplyService(provider)
+
+	// when:
+	actualDTO, err := service.RequestSyncReply(
+		t.Context(),
+		testabilities.DefaultTopic,
+		[]app.OutpointDTO{{TxID: outpoint.Txid.String(), OutputIndex: outpoint.Index}},
+		testabilities.DefaultSince,
+	)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, app.NewRequestSyncReplyDTO(expectations.Reply), actualDTO)
+	provider.AssertCalled()
+}
+
+func TestRequestSyncReplyService_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		topic             app.Topic
+		utxos             []app.OutpointDTO
+		expectations      testabilities.RequestSyncReplyProviderMockExpectations
+		expectedErrorType app.ErrorType
+	}{
+		"Request sync reply service fails due to an empty topic": {
+			topic:             "",
+			expectations:      testabilities.RequestSyncReplyProviderMockExpectations{ProvideForeignSyncReplyCall: false},
+			expectedErrorType: app.ErrorTypeIncorrectInput,
+		},
+		"Request sync reply service fails due to an invalid transaction ID format": {
+			topic:             testabilities.DefaultTopic,
+			utxos:             []app.OutpointDTO{{TxID: testabilities.DefaultInvalidTxID}},
+			expectations:      testabilities.RequestSyncReplyProviderMockExpectations{ProvideForeignSyncReplyCall: false},
+			expectedErrorType: app.ErrorTypeRawDataProcessing,
+		},
+		"Request sync reply service fails due to an internal provider failure": {
+			topic: testabilities.DefaultTopic,
+			expectations: testabilities.RequestSyncReplyProviderMockExpectations{
+				ProvideForeignSyncReplyCall: true,
+				Error:                       testabilities.ErrTestNoopOpFailure,
+				InitialResponse: &core.GASPInitialResponse{
+					UTXOList: []*transaction.Outpoint{},
+					Since:    testabilities.DefaultSince,
+				},
+				Topic: testabilities.DefaultTopic,
+			},
+			expectedErrorType: app.ErrorTypeProviderFailure,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			provider := testabilities.NewRequestSyncReplyProviderMock(t, tc.expectations)
+			service := app.NewRequestSyncReplyService(provider)
+
+			// when:
+			dto, err := service.RequestSyncReply(t.Context(), tc.topic, tc.utxos, testabilities.DefaultSince)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErrorType, actualErr.ErrorType())
+
+			require.Nil(t, dto)
+			provider.AssertCalled()
+		})
+	}
+}
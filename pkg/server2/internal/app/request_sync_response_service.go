@@ -117,9 +117,8 @@ func NewRequestSyncResponseService(provider RequestSyncResponseProvider) *Reques
 // during a sync response request. The resulting error is classified as a provider failure
 // and returns a generic slug message suitable for client-facing usage.
 func NewRequestSyncResponseProviderError(err error) Error {
-	return Error{
-		errorType: ErrorTypeProviderFailure,
-		err:       err.Error(),
-		slug:      "Unable to process sync response request due to an error in the overlay engine.",
-	}
+	return newProviderError(
+		err,
+		"Unable to process sync response request due to an error in the overlay engine.",
+	)
 }
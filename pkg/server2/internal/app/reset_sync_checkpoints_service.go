@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+)
+
+// ResetSyncCheckpointsProvider defines the interface for components that can
+// reset the GASP sync checkpoints of the overlay engine.
+type ResetSyncCheckpointsProvider interface {
+	ResetSyncCheckpoints(ctx context.Context, topic, peer string) (int, error)
+}
+
+// ResetSyncCheckpointsService coordinates the reset of the GASP sync checkpoints,
+// which makes the next sync with the affected peers request their whole UTXO set.
+type ResetSyncCheckpointsService struct {
+	provider ResetSyncCheckpointsProvider
+}
+
+// ResetSyncCheckpoints deletes the sync checkpoints matching the topic and peer, where an
+// empty topic or peer matches every topic or peer. Returns how many checkpoints were deleted.
+func (s *ResetSyncCheckpointsService) ResetSyncCheckpoints(ctx context.Context, topic, peer string) (int, error) {
+	deleted, err := s.provider.ResetSyncCheckpoints(ctx, topic, peer)
+	if err != nil {
+		return 0, NewResetSyncCheckpointsProviderError(err)
+	}
+	return deleted, nil
+}
+
+// NewResetSyncCheckpointsService creates a new ResetSyncCheckpointsService with the given provider.
+// Panics if the provider is nil.
+func NewResetSyncCheckpointsService(provider ResetSyncCheckpointsProvider) *ResetSyncCheckpointsService {
+	if provider == nil {
+		panic("reset sync checkpoints provider is nil")
+	}
+	return &ResetSyncCheckpointsService{provider: provider}
+}
+
+// NewResetSyncCheckpointsProviderError returns an Error indicating that the configured provider
+// failed to reset the sync checkpoints.
+func NewResetSyncCheckpointsProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to reset GASP sync checkpoints due to an internal error. Please try again later or contact the support team.",
+	)
+}
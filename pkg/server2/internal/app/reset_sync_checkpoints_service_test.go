@@ -0,0 +1,54 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetSyncCheckpointsService_InvalidCase(t *testing.T) {
+	// given:
+	providerError := errors.New("internal reset sync checkpoints service test error")
+	expectations := testabilities.ResetSyncCheckpointsProviderMockExpectations{
+		ResetSyncCheckpointsCall: true,
+		Topic:                    testabilities.DefaultSyncCheckpoint.Topic,
+		Error:                    providerError,
+	}
+	expectedErr := app.NewResetSyncCheckpointsProviderError(providerError)
+	mock := testabilities.NewResetSyncCheckpointsProviderMock(t, expectations)
+	service := app.NewResetSyncCheckpointsService(mock)
+
+	// when:
+	deleted, err := service.ResetSyncCheckpoints(context.Background(), testabilities.DefaultSyncCheckpoint.Topic, "")
+
+	// then:
+	var actualErr app.Error
+	require.ErrorAs(t, err, &actualErr)
+	require.Equal(t, expectedErr, actualErr)
+	require.Zero(t, deleted)
+	mock.AssertCalled()
+}
+
+func TestResetSyncCheckpointsService_ValidCase(t *testing.T) {
+	// given:
+	expectations := testabilities.ResetSyncCheckpointsProviderMockExpectations{
+		ResetSyncCheckpointsCall: true,
+		Topic:                    testabilities.DefaultSyncCheckpoint.Topic,
+		Peer:                     testabilities.DefaultSyncCheckpoint.Peer,
+		Deleted:                  1,
+	}
+	mock := testabilities.NewResetSyncCheckpointsProviderMock(t, expectations)
+	service := app.NewResetSyncCheckpointsService(mock)
+
+	// when:
+	deleted, err := service.ResetSyncCheckpoints(context.Background(), testabilities.DefaultSyncCheckpoint.Topic, testabilities.DefaultSyncCheckpoint.Peer)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+	mock.AssertCalled()
+}
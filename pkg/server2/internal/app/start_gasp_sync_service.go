@@ -36,9 +36,8 @@ func NewStartGASPSyncService(provider StartGASPSyncProvider) *StartGASPSyncServi
 // NewStartGASPSyncProviderError returns an Error indicating that the configured provider
 // failed to process a GASP sync request.
 func NewStartGASPSyncProviderError(err error) Error {
-	return Error{
-		errorType: ErrorTypeProviderFailure,
-		err:       err.Error(),
-		slug:      "Unable to synchronize GASP due to an internal error. Please try again later or contact the support team.",
-	}
+	return newProviderError(
+		err,
+		"Unable to synchronize GASP due to an internal error. Please try again later or contact the support team.",
+	)
 }
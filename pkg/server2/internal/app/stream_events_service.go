@@ -0,0 +1,102 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+)
+
+// StreamEventsDTO represents the data transfer object used to subscribe to the events of the overlay engine.
+type StreamEventsDTO struct {
+	Topics []string // Topics are the topics to stream the events of, every topic when empty.
+	Cursor *uint64  // Cursor is the cursor of the last event received, nil to stream only new events.
+}
+
+// EventDTO is a transport-friendly representation of an event published by the overlay engine.
+type EventDTO struct {
+	Cursor       uint64    // Cursor identifies the event, the cursors of successive events are increasing.
+	Type         string    // Type tells what happened to the outputs of the topic.
+	Topic        string    // Topic is the topic the event is about.
+	TxID         string    // TxID is the hexadecimal ID of the transaction of the output, empty for sync-finished events.
+	OutputIndex  uint32    // OutputIndex is the index of the output within the transaction.
+	SpendingTxID string    // SpendingTxID is the hexadecimal ID of the spending transaction, set for output-spent events.
+	BlockHeight  uint32    // BlockHeight is the block height of the output, set for block-height-updated events.
+	BlockIdx     uint64    // BlockIdx is the index of the transaction within its block, set for block-height-updated events.
+	Peer         string    // Peer is the peer the topic was synced with, set for sync-finished events.
+	Error        string    // Error is why the sync failed, set for failed sync-finished events.
+	CreatedAt    time.Time // CreatedAt is when the event was published.
+}
+
+// NewEventDTO returns the EventDTO of the event published by the overlay engine.
+func NewEventDTO(event engine.Event) EventDTO {
+	dto := EventDTO{
+		Cursor:      event.Cursor,
+		Type:        string(event.Type),
+		Topic:       event.Topic,
+		BlockHeight: event.BlockHeight,
+		BlockIdx:    event.BlockIdx,
+		Peer:        event.Peer,
+		Error:       event.Error,
+		CreatedAt:   event.CreatedAt,
+	}
+	if event.Type != engine.EventSyncFinished {
+		dto.TxID = event.Outpoint.Txid.String()
+		dto.OutputIndex = event.Outpoint.Index
+	}
+	if event.SpendingTxid != nil {
+		dto.SpendingTxID = event.SpendingTxid.String()
+	}
+	return dto
+}
+
+// StreamEventsProvider defines the interface for components that can subscribe
+// to the events published by the overlay engine.
+type StreamEventsProvider interface {
+	SubscribeEvents(topics []string, after *uint64) (*engine.EventSubscription, error)
+}
+
+// StreamEventsService coordinates the subscriptions to the events published by the overlay engine.
+type StreamEventsService struct {
+	provider StreamEventsProvider
+}
+
+// Subscribe returns a subscription to the events of the requested topics. When a cursor is
+// requested, the retained events following it are delivered first. The caller must close the
+// subscription once done with it.
+func (s *StreamEventsService) Subscribe(dto StreamEventsDTO) (*engine.EventSubscription, error) {
+	sub, err := s.provider.SubscribeEvents(dto.Topics, dto.Cursor)
+	if errors.Is(err, engine.ErrEventCursorExpired) && dto.Cursor != nil {
+		return nil, NewEventCursorExpiredError(*dto.Cursor)
+	}
+	if err != nil {
+		return nil, NewStreamEventsProviderError(err)
+	}
+	return sub, nil
+}
+
+// NewStreamEventsService creates a new StreamEventsService with the given provider.
+// Panics if the provider is nil.
+func NewStreamEventsService(provider StreamEventsProvider) *StreamEventsService {
+	if provider == nil {
+		panic("stream events provider is nil")
+	}
+	return &StreamEventsService{provider: provider}
+}
+
+// NewEventCursorExpiredError returns an Error indicating that the events following the
+// requested cursor are no longer retained, or that the cursor was never published.
+func NewEventCursorExpiredError(cursor uint64) Error {
+	msg := fmt.Sprintf("Unable to resume the events from the cursor %d as the events following it are no longer available. Please subscribe without a cursor.", cursor)
+	return NewIncorrectInputError(msg, msg).WithCode(ErrorCodeEventCursorExpired)
+}
+
+// NewStreamEventsProviderError returns an Error indicating that the configured provider
+// failed to subscribe to the events.
+func NewStreamEventsProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to stream the events due to an internal error. Please try again later or contact the support team.",
+	)
+}
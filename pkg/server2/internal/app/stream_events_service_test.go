@@ -0,0 +1,97 @@
+package app_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamEventsService_InvalidCases(t *testing.T) {
+	providerError := errors.New("internal stream events service test error")
+	cursor := uint64(7)
+	tests := map[string]struct {
+		dto          app.StreamEventsDTO
+		expectations testabilities.StreamEventsProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"Stream events service fails to subscribe to the events": {
+			dto: app.StreamEventsDTO{Topics: []string{"tm_test"}},
+			expectations: testabilities.StreamEventsProviderMockExpectations{
+				SubscribeEventsCall: true,
+				Topics:              []string{"tm_test"},
+				Error:               providerError,
+			},
+			expectedErr: app.NewStreamEventsProviderError(providerError),
+		},
+		"Stream events service fails to resume from an expired cursor": {
+			dto: app.StreamEventsDTO{Cursor: &cursor},
+			expectations: testabilities.StreamEventsProviderMockExpectations{
+				SubscribeEventsCall: true,
+				Cursor:              &cursor,
+				Error:               engine.ErrEventCursorExpired,
+			},
+			expectedErr: app.NewEventCursorExpiredError(cursor),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewStreamEventsProviderMock(t, tc.expectations)
+			service := app.NewStreamEventsService(mock)
+
+			// when:
+			sub, err := service.Subscribe(tc.dto)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Nil(t, sub)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestStreamEventsService_ValidCase(t *testing.T) {
+	// given:
+	txid := chainhash.DoubleHashH([]byte("admitted"))
+	cursor := uint64(0)
+	expectations := testabilities.StreamEventsProviderMockExpectations{
+		SubscribeEventsCall: true,
+		Topics:              []string{"tm_test"},
+		Cursor:              &cursor,
+		Events: []engine.Event{
+			{Type: engine.EventOutputAdmitted, Topic: "tm_test", Outpoint: transaction.Outpoint{Txid: txid, Index: 1}},
+			{Type: engine.EventOutputAdmitted, Topic: "tm_other", Outpoint: transaction.Outpoint{Txid: txid, Index: 2}},
+		},
+	}
+	mock := testabilities.NewStreamEventsProviderMock(t, expectations)
+	service := app.NewStreamEventsService(mock)
+
+	// when:
+	sub, err := service.Subscribe(app.StreamEventsDTO{Topics: []string{"tm_test"}, Cursor: &cursor})
+
+	// then:
+	require.NoError(t, err)
+	var events []app.EventDTO
+	for event := range sub.Events() {
+		events = append(events, app.NewEventDTO(event))
+	}
+	require.Len(t, events, 1)
+	require.Equal(t, app.EventDTO{
+		Cursor:      1,
+		Type:        "output-admitted",
+		Topic:       "tm_test",
+		TxID:        txid.String(),
+		OutputIndex: 1,
+		CreatedAt:   events[0].CreatedAt,
+	}, events[0])
+	mock.AssertCalled()
+}
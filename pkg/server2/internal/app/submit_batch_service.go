@@ -0,0 +1,175 @@
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// MaxSubmitBatchSize is the maximum number of transactions accepted in a single batch.
+const MaxSubmitBatchSize = 1000
+
+// SubmitBatchTransactionDTO represents a transaction of a batch, coming either with its own BEEF
+// or selected by its ID from the BEEF shared by the batch.
+type SubmitBatchTransactionDTO struct {
+	BEEF   string   // BEEF is the hexadecimal BEEF of the transaction, empty when selected from the shared BEEF.
+	TxID   string   // TxID is the hexadecimal ID of the transaction in the shared BEEF, empty when it comes with its own BEEF.
+	Topics []string // Topics are the topics to submit the transaction to.
+}
+
+// SubmitBatchDTO represents the data transfer object used to submit a batch of transactions.
+type SubmitBatchDTO struct {
+	BEEF         string                      // BEEF is the hexadecimal BEEF shared by the transactions selected by their ID.
+	Transactions []SubmitBatchTransactionDTO // Transactions are the transactions to submit.
+}
+
+// SubmitBatchResultDTO is a transport-friendly representation of the outcome of a transaction of a batch.
+type SubmitBatchResultDTO struct {
+	TxID         string         // TxID is the hexadecimal ID of the transaction, empty when its BEEF cannot be parsed.
+	Steak        *overlay.Steak // Steak is what the topics admitted, nil when the submission failed.
+	ErrorCode    string         // ErrorCode is the machine-readable reason of the failure, empty when admitted.
+	ErrorMessage string         // ErrorMessage is the human-readable reason of the failure, empty when admitted.
+}
+
+// SubmitBatchProvider defines the interface for submitting a batch of tagged transactions
+// to the overlay engine for processing.
+type SubmitBatchProvider interface {
+	SubmitBatch(ctx context.Context, taggedBEEFs []overlay.TaggedBEEF, mode engine.SumbitMode) []*engine.SubmitBatchResult
+}
+
+// SubmitBatchService coordinates the submission of batches of transactions using the configured SubmitBatchProvider.
+type SubmitBatchService struct {
+	provider SubmitBatchProvider
+}
+
+// SubmitBatch validates the batch, resolves the BEEF of every transaction and submits them to the
+// provider, returning the outcome of every transaction in the order of the batch. The batch is
+// rejected as a whole when any of its transactions is malformed.
+func (s *SubmitBatchService) SubmitBatch(ctx context.Context, dto SubmitBatchDTO) ([]SubmitBatchResultDTO, error) {
+	taggedBEEFs, err := dto.taggedBEEFs()
+	if err != nil {
+		return nil, err
+	}
+
+	results := s.provider.SubmitBatch(ctx, taggedBEEFs, engine.SubmitModeCurrent)
+	if len(results) != len(taggedBEEFs) {
+		return nil, NewSubmitBatchProviderError(fmt.Errorf("expected %d batch results, got %d", len(taggedBEEFs), len(results)))
+	}
+	return newSubmitBatchResultDTOs(results), nil
+}
+
+// taggedBEEFs validates the batch and resolves the tagged BEEF of every transaction, in the order of the batch.
+func (dto SubmitBatchDTO) taggedBEEFs() ([]overlay.TaggedBEEF, error) {
+	switch {
+	case len(dto.Transactions) == 0:
+		return nil, NewIncorrectInputWithFieldError("transactions")
+	case len(dto.Transactions) > MaxSubmitBatchSize:
+		return nil, NewSubmitBatchTooLargeError(len(dto.Transactions))
+	}
+
+	var shared *transaction.Beef
+	if dto.BEEF != "" {
+		beef, err := hex.DecodeString(dto.BEEF)
+		if err != nil {
+			return nil, NewIncorrectInputWithFieldError("beef")
+		}
+		shared, _, _, err = transaction.ParseBeef(beef)
+		if err != nil {
+			return nil, NewIncorrectInputWithFieldError("beef")
+		}
+	}
+
+	taggedBEEFs := make([]overlay.TaggedBEEF, 0, len(dto.Transactions))
+	for i, tx := range dto.Transactions {
+		field := fmt.Sprintf("transactions[%d]", i)
+		if err := TransactionTopics(tx.Topics).Verify(); err != nil {
+			return nil, NewIncorrectInputWithFieldError(field + ".topics")
+		}
+
+		var beef []byte
+		switch {
+		case tx.BEEF != "" && tx.TxID == "":
+			decoded, err := hex.DecodeString(tx.BEEF)
+			if err != nil {
+				return nil, NewIncorrectInputWithFieldError(field + ".beef")
+			}
+			beef = decoded
+		case tx.TxID != "" && tx.BEEF == "":
+			atomic, err := atomicBEEFFromShared(shared, tx.TxID)
+			if err != nil {
+				return nil, NewIncorrectInputWithFieldError(field + ".txid")
+			}
+			beef = atomic
+		default:
+			return nil, NewIncorrectInputWithFieldError(field)
+		}
+		taggedBEEFs = append(taggedBEEFs, overlay.TaggedBEEF{Beef: beef, Topics: tx.Topics})
+	}
+	return taggedBEEFs, nil
+}
+
+// newSubmitBatchResultDTOs converts the outcome of the transactions of a batch into their transport-friendly representation.
+func newSubmitBatchResultDTOs(results []*engine.SubmitBatchResult) []SubmitBatchResultDTO {
+	dtos := make([]SubmitBatchResultDTO, 0, len(results))
+	for _, result := range results {
+		var dto SubmitBatchResultDTO
+		if result.Txid != nil {
+			dto.TxID = result.Txid.String()
+		}
+		if result.Err != nil {
+			dto.ErrorCode, dto.ErrorMessage = submitErrorCode(result.Err)
+		} else {
+			steak := result.Steak
+			dto.Steak = &steak
+		}
+		dtos = append(dtos, dto)
+	}
+	return dtos
+}
+
+// atomicBEEFFromShared returns the atomic BEEF of the transaction of the shared BEEF, holding only its ancestry.
+func atomicBEEFFromShared(shared *transaction.Beef, txid string) ([]byte, error) {
+	if shared == nil {
+		return nil, fmt.Errorf("no shared BEEF to select transaction %s from", txid)
+	}
+	hash, err := chainhash.NewHashFromHex(txid)
+	if err != nil {
+		return nil, err
+	}
+	tx := shared.FindAtomicTransaction(hash.String())
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %s not found in the shared BEEF", txid)
+	}
+	return tx.AtomicBEEF(false)
+}
+
+// NewSubmitBatchService creates a new SubmitBatchService with the given provider.
+// Panics if the provider is nil.
+func NewSubmitBatchService(provider SubmitBatchProvider) *SubmitBatchService {
+	if provider == nil {
+		panic("submit batch provider is nil")
+	}
+	return &SubmitBatchService{provider: provider}
+}
+
+// NewSubmitBatchTooLargeError returns an Error indicating that the batch holds more than MaxSubmitBatchSize transactions.
+func NewSubmitBatchTooLargeError(size int) Error {
+	return NewIncorrectInputError(
+		fmt.Sprintf("The batch holds %d transactions, more than the %d accepted.", size, MaxSubmitBatchSize),
+		fmt.Sprintf("A batch cannot hold more than %d transactions. Please split the batch and try again.", MaxSubmitBatchSize),
+	)
+}
+
+// NewSubmitBatchProviderError returns an Error indicating that the configured provider
+// failed to process a submitted batch.
+func NewSubmitBatchProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to process the submitted batch due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,158 @@
+package app_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	testvectors "github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitBatchService_InvalidCases(t *testing.T) {
+	beef := hex.EncodeToString(testabilities.DummyTxBEEF(t))
+	tests := map[string]struct {
+		dto          app.SubmitBatchDTO
+		expectations testabilities.SubmitBatchProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"Submit batch service fails to submit an empty batch": {
+			dto:         app.SubmitBatchDTO{},
+			expectedErr: app.NewIncorrectInputWithFieldError("transactions"),
+		},
+		"Submit batch service fails to submit a batch holding too many transactions": {
+			dto:         app.SubmitBatchDTO{Transactions: make([]app.SubmitBatchTransactionDTO, app.MaxSubmitBatchSize+1)},
+			expectedErr: app.NewSubmitBatchTooLargeError(app.MaxSubmitBatchSize + 1),
+		},
+		"Submit batch service fails to submit a batch with an invalid shared BEEF": {
+			dto: app.SubmitBatchDTO{
+				BEEF:         "beef",
+				Transactions: []app.SubmitBatchTransactionDTO{{BEEF: beef, Topics: []string{"tm_test"}}},
+			},
+			expectedErr: app.NewIncorrectInputWithFieldError("beef"),
+		},
+		"Submit batch service fails to submit a transaction without topics": {
+			dto: app.SubmitBatchDTO{
+				Transactions: []app.SubmitBatchTransactionDTO{{BEEF: beef, Topics: []string{"tm_test"}}, {BEEF: beef}},
+			},
+			expectedErr: app.NewIncorrectInputWithFieldError("transactions[1].topics"),
+		},
+		"Submit batch service fails to submit a transaction with an invalid BEEF": {
+			dto: app.SubmitBatchDTO{
+				Transactions: []app.SubmitBatchTransactionDTO{{BEEF: "not hex", Topics: []string{"tm_test"}}},
+			},
+			expectedErr: app.NewIncorrectInputWithFieldError("transactions[0].beef"),
+		},
+		"Submit batch service fails to submit a transaction with both a BEEF and a txid": {
+			dto: app.SubmitBatchDTO{
+				Transactions: []app.SubmitBatchTransactionDTO{{BEEF: beef, TxID: "txid", Topics: []string{"tm_test"}}},
+			},
+			expectedErr: app.NewIncorrectInputWithFieldError("transactions[0]"),
+		},
+		"Submit batch service fails to submit a transaction selected without a shared BEEF": {
+			dto: app.SubmitBatchDTO{
+				Transactions: []app.SubmitBatchTransactionDTO{{TxID: "03895fb984362a4196bc9931629318fcbb2aeba7c6293638119ea653fa31d119", Topics: []string{"tm_test"}}},
+			},
+			expectedErr: app.NewIncorrectInputWithFieldError("transactions[0].txid"),
+		},
+		"Submit batch service fails to submit a transaction missing from the shared BEEF": {
+			dto: app.SubmitBatchDTO{
+				BEEF:         beef,
+				Transactions: []app.SubmitBatchTransactionDTO{{TxID: "a3c0b9f2e1d4c5b6a7980f1e2d3c4b5a69788f9e0d1c2b3a4f5e6d7c8b9a0f1e", Topics: []string{"tm_test"}}},
+			},
+			expectedErr: app.NewIncorrectInputWithFieldError("transactions[0].txid"),
+		},
+		"Submit batch service fails when the provider does not return a result per transaction": {
+			dto: app.SubmitBatchDTO{
+				Transactions: []app.SubmitBatchTransactionDTO{{BEEF: beef, Topics: []string{"tm_test"}}},
+			},
+			expectations: testabilities.SubmitBatchProviderMockExpectations{
+				SubmitBatchCall: true,
+				TaggedBEEFs:     []overlay.TaggedBEEF{{Beef: testabilities.DummyTxBEEF(t), Topics: []string{"tm_test"}}},
+			},
+			expectedErr: app.NewSubmitBatchProviderError(errors.New("expected 1 batch results, got 0")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitBatchProviderMock(t, tc.expectations)
+			service := app.NewSubmitBatchService(mock)
+
+			// when:
+			results, err := service.SubmitBatch(context.Background(), tc.dto)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Nil(t, results)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestSubmitBatchService_ValidCase(t *testing.T) {
+	// given:
+	parent := testvectors.GivenTX().WithInput(1000).WithP2PKHOutput(999).TX()
+	child := testvectors.GivenTX().WithSender(testvectors.Bob).WithInputFromUTXO(parent, 0).WithP2PKHOutput(900).TX()
+	other := testvectors.GivenTX().WithInput(500).WithP2PKHOutput(499).TX()
+
+	shared, err := transaction.NewBeefFromTransaction(child)
+	require.NoError(t, err)
+	sharedBytes, err := shared.Bytes()
+	require.NoError(t, err)
+
+	otherBEEF, err := other.BEEF()
+	require.NoError(t, err)
+	parentBEEF, err := parent.AtomicBEEF(false)
+	require.NoError(t, err)
+	childBEEF, err := child.AtomicBEEF(false)
+	require.NoError(t, err)
+
+	steak := overlay.Steak{"tm_test": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}
+	mock := testabilities.NewSubmitBatchProviderMock(t, testabilities.SubmitBatchProviderMockExpectations{
+		SubmitBatchCall: true,
+		TaggedBEEFs: []overlay.TaggedBEEF{
+			{Beef: childBEEF, Topics: []string{"tm_test"}},
+			{Beef: parentBEEF, Topics: []string{"tm_test"}},
+			{Beef: otherBEEF, Topics: []string{"tm_other"}},
+		},
+		Results: []*engine.SubmitBatchResult{
+			{Txid: child.TxID(), Steak: steak},
+			{Txid: parent.TxID(), Steak: steak},
+			{Txid: other.TxID(), Err: engine.ErrInputSpent},
+		},
+	})
+	service := app.NewSubmitBatchService(mock)
+
+	// when:
+	results, err := service.SubmitBatch(context.Background(), app.SubmitBatchDTO{
+		BEEF: hex.EncodeToString(sharedBytes),
+		Transactions: []app.SubmitBatchTransactionDTO{
+			{TxID: child.TxID().String(), Topics: []string{"tm_test"}},
+			{TxID: parent.TxID().String(), Topics: []string{"tm_test"}},
+			{BEEF: hex.EncodeToString(otherBEEF), Topics: []string{"tm_other"}},
+		},
+	})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []app.SubmitBatchResultDTO{
+		{TxID: child.TxID().String(), Steak: &steak},
+		{TxID: parent.TxID().String(), Steak: &steak},
+		{
+			TxID:         other.TxID().String(),
+			ErrorCode:    "input-spent",
+			ErrorMessage: "The submitted transaction spends an input already spent by another transaction.",
+		},
+	}, results)
+	mock.AssertCalled()
+}
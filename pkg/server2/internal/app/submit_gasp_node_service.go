@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// SubmitGASPNodeDTO represents the data transfer object of a GASP node pushed by a foreign peer.
+type SubmitGASPNodeDTO struct {
+	GraphID        string            // GraphID is a string representation of the graph's outpoint.
+	RawTx          string            // RawTx is the hexadecimal raw transaction of the node.
+	OutputIndex    uint32            // OutputIndex specifies the index of the node output within the transaction.
+	Proof          string            // Proof is the optional hexadecimal merkle proof of the transaction.
+	TxMetadata     string            // TxMetadata is the optional metadata of the transaction.
+	OutputMetadata string            // OutputMetadata is the optional metadata of the output.
+	Inputs         map[string]string // Inputs maps the input identifiers of the node to their hashes.
+	AncillaryBeef  []byte            // AncillaryBeef is the optional BEEF needed to validate the transaction.
+	Topic          string            // Topic is the topic of the graph the node belongs to.
+}
+
+// SubmitGASPNodeProvider defines the interface that must be implemented to accept
+// GASP nodes pushed by foreign peers.
+type SubmitGASPNodeProvider interface {
+	// SubmitForeignGASPNode appends the node to its graph and returns the inputs of
+	// the node the peer has to submit next.
+	SubmitForeignGASPNode(ctx context.Context, node *core.GASPNode, topic string) (*core.GASPNodeResponse, error)
+}
+
+// SubmitGASPNodeService coordinates and orchestrates the submission of GASP nodes pushed by foreign peers.
+// It uses the injected provider to append the node to its graph based on validated input.
+type SubmitGASPNodeService struct {
+	provider SubmitGASPNodeProvider
+}
+
+// SubmitGASPNode validates and converts input DTO fields and delegates the submission to the provider.
+// It parses the graph ID into an outpoint and checks that the raw transaction can be decoded.
+// Returns the inputs the peer has to submit next, or a detailed error if processing fails.
+func (s *SubmitGASPNodeService) SubmitGASPNode(ctx context.Context, dto SubmitGASPNodeDTO) (*core.GASPNodeResponse, error) {
+	if dto.Topic == "" {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+
+	graphID, err := transaction.OutpointFromString(dto.GraphID)
+	if err != nil {
+		return nil, NewRawDataProcessingWithFieldError(err, "GraphID")
+	}
+
+	if _, err := transaction.NewTransactionFromHex(dto.RawTx); err != nil {
+		return nil, NewRawDataProcessingWithFieldError(err, "RawTx")
+	}
+
+	node := &core.GASPNode{
+		GraphID:        graphID,
+		RawTx:          dto.RawTx,
+		OutputIndex:    dto.OutputIndex,
+		TxMetadata:     dto.TxMetadata,
+		OutputMetadata: dto.OutputMetadata,
+		AncillaryBeef:  dto.AncillaryBeef,
+	}
+	if dto.Proof != "" {
+		node.Proof = &dto.Proof
+	}
+	if len(dto.Inputs) > 0 {
+		node.Inputs = make(map[string]*core.GASPInput, len(dto.Inputs))
+		for id, hash := range dto.Inputs {
+			node.Inputs[id] = &core.GASPInput{Hash: hash}
+		}
+	}
+
+	response, err := s.provider.SubmitForeignGASPNode(ctx, node, dto.Topic)
+	if err != nil {
+		return nil, NewSubmitGASPNodeProviderError(err)
+	}
+	return response, nil
+}
+
+// NewSubmitGASPNodeService constructs and returns a new instance of SubmitGASPNodeService.
+// Panics if the given provider is nil, as a valid provider is required for service operation.
+func NewSubmitGASPNodeService(provider SubmitGASPNodeProvider) *SubmitGASPNodeService {
+	if provider == nil {
+		panic("submit GASP node service provider is nil")
+	}
+
+	return &SubmitGASPNodeService{provider: provider}
+}
+
+// NewSubmitGASPNodeProviderError wraps a lower-level provider error in a user-facing error with guidance.
+// Used when the provider fails to accept the submitted GASP node.
+func NewSubmitGASPNodeProviderError(err error) Error {
+	return newProviderError(
+		err,
+		"Unable to process submitted GASP node due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,105 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitGASPNodeService_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		dto               app.SubmitGASPNodeDTO
+		expectations      testabilities.SubmitGASPNodeProviderMockExpectations
+		expectedErrorType app.ErrorType
+	}{
+		"Submit GASP node service fails due to an empty topic": {
+			dto: app.SubmitGASPNodeDTO{
+				GraphID: testabilities.DefaultValidGraphID,
+				RawTx:   testabilities.DefaultValidRawTx,
+				Topic:   testabilities.DefaultEmptyTopic,
+			},
+			expectations:      testabilities.SubmitGASPNodeProviderMockExpectations{SubmitForeignGASPNodeCall: false},
+			expectedErrorType: app.ErrorTypeIncorrectInput,
+		},
+		"Submit GASP node service fails due to an invalid graph ID format": {
+			dto: app.SubmitGASPNodeDTO{
+				GraphID: testabilities.DefaultInvalidGraphID,
+				RawTx:   testabilities.DefaultValidRawTx,
+				Topic:   testabilities.DefaultValidTopic,
+			},
+			expectations:      testabilities.SubmitGASPNodeProviderMockExpectations{SubmitForeignGASPNodeCall: false},
+			expectedErrorType: app.ErrorTypeRawDataProcessing,
+		},
+		"Submit GASP node service fails due to an invalid raw transaction": {
+			dto: app.SubmitGASPNodeDTO{
+				GraphID: testabilities.DefaultValidGraphID,
+				RawTx:   "invalid-rawtx",
+				Topic:   testabilities.DefaultValidTopic,
+			},
+			expectations:      testabilities.SubmitGASPNodeProviderMockExpectations{SubmitForeignGASPNodeCall: false},
+			expectedErrorType: app.ErrorTypeRawDataProcessing,
+		},
+		"Submit GASP node service fails due to an internal provider failure": {
+			dto: testabilities.SubmitGASPNodeDefaultDTO,
+			expectations: testabilities.SubmitGASPNodeProviderMockExpectations{
+				SubmitForeignGASPNodeCall: true,
+				Error:                     testabilities.ErrTestNoopOpFailure,
+			},
+			expectedErrorType: app.ErrorTypeProviderFailure,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitGASPNodeProviderMock(t, tc.expectations)
+			service := app.NewSubmitGASPNodeService(mock)
+
+			// when:
+			response, err := service.SubmitGASPNode(t.Context(), tc.dto)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErrorType, actualErr.ErrorType())
+
+			require.Nil(t, response)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestSubmitGASPNodeService_ValidCase(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitGASPNodeProviderMockExpectations{
+		SubmitForeignGASPNodeCall: true,
+		Response: &core.GASPNodeResponse{
+			RequestedInputs: map[string]*core.GASPNodeResponseData{
+				testabilities.DefaultValidGraphID: {Metadata: true},
+			},
+		},
+	}
+	mock := testabilities.NewSubmitGASPNodeProviderMock(t, expectations)
+	service := app.NewSubmitGASPNodeService(mock)
+
+	dto := testabilities.SubmitGASPNodeDefaultDTO
+	dto.Proof = "proof"
+	dto.Inputs = map[string]string{"input": "hash"}
+
+	// when:
+	response, err := service.SubmitGASPNode(t.Context(), dto)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, expectations.Response, response)
+	mock.AssertCalled()
+
+	node := mock.Node()
+	require.Equal(t, testabilities.DefaultValidGraphID, node.GraphID.String())
+	require.Equal(t, testabilities.DefaultValidRawTx, node.RawTx)
+	require.Equal(t, "proof", *node.Proof)
+	require.Equal(t, map[string]*core.GASPInput{"input": {Hash: "hash"}}, node.Inputs)
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+// SubmitTransactionAsyncProvider defines the interface for queueing a tagged transaction
+// in the overlay engine for asynchronous processing.
+type SubmitTransactionAsyncProvider interface {
+	SubmitAsync(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode engine.SumbitMode) (*engine.SubmitJob, error)
+}
+
+// SubmitTransactionAsyncService coordinates the asynchronous transaction submission process
+// using the configured SubmitTransactionAsyncProvider.
+type SubmitTransactionAsyncService struct {
+	provider SubmitTransactionAsyncProvider
+}
+
+// SubmitTransactionAsync validates the provided topics and queues the transaction, returning its job
+// without waiting for the STEAK. The outcome is then retrieved with the SubmitJobStatusService.
+func (s *SubmitTransactionAsyncService) SubmitTransactionAsync(ctx context.Context, topics TransactionTopics, txBytes ...byte) (SubmitJobDTO, error) {
+	err := topics.Verify()
+	if err != nil {
+		return SubmitJobDTO{}, err
+	}
+
+	job, err := s.provider.SubmitAsync(ctx, overlay.TaggedBEEF{Beef: txBytes, Topics: topics}, engine.SubmitModeCurrent)
+	if err != nil {
+		return SubmitJobDTO{}, NewSubmitTransactionProviderError(err)
+	}
+	return NewSubmitJobDTO(job), nil
+}
+
+// NewSubmitTransactionAsyncService creates a new SubmitTransactionAsyncService with the given provider.
+// Panics if the provider is nil.
+func NewSubmitTransactionAsyncService(provider SubmitTransactionAsyncProvider) *SubmitTransactionAsyncService {
+	if provider == nil {
+		panic("submit transaction async provider is nil")
+	}
+	return &SubmitTransactionAsyncService{provider: provider}
+}
+
+// NewSubmitQueueFullError returns an Error indicating that the overlay engine queues
+// as many transactions as it accepts.
+func NewSubmitQueueFullError(err error) Error {
+	return NewServiceUnavailableError(
+		err.Error(),
+		"Unable to queue the submitted transaction as too many transactions are being processed. Please try again later.",
+	).WithCode(ErrorCodeSubmitQueueFull)
+}
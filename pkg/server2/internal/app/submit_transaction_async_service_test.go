@@ -0,0 +1,96 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitTransactionAsyncService_InvalidCases(t *testing.T) {
+	providerError := errors.New("internal submit transaction async service test error")
+	tests := map[string]struct {
+		topics       app.TransactionTopics
+		expectations testabilities.SubmitTransactionAsyncProviderMockExpectations
+		expectedErr  app.Error
+	}{
+		"Submit transaction async service fails to queue a transaction without topics": {
+			topics:      app.TransactionTopics{},
+			expectedErr: app.NewEmptyTransactionTopicsError(),
+		},
+		"Submit transaction async service fails to queue a transaction with an invalid topic": {
+			topics:      app.TransactionTopics{"tm_test", " "},
+			expectedErr: app.NewErrInvalidTopicFormatError(1),
+		},
+		"Submit transaction async service fails to queue a transaction when the queue is full": {
+			topics: app.TransactionTopics{"tm_test"},
+			expectations: testabilities.SubmitTransactionAsyncProviderMockExpectations{
+				SubmitAsyncCall: true,
+				Topics:          []string{"tm_test"},
+				Error:           engine.ErrSubmitQueueFull,
+			},
+			expectedErr: app.NewSubmitQueueFullError(engine.ErrSubmitQueueFull),
+		},
+		"Submit transaction async service fails to queue a transaction": {
+			topics: app.TransactionTopics{"tm_test"},
+			expectations: testabilities.SubmitTransactionAsyncProviderMockExpectations{
+				SubmitAsyncCall: true,
+				Topics:          []string{"tm_test"},
+				Error:           providerError,
+			},
+			expectedErr: app.NewSubmitTransactionProviderError(providerError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitTransactionAsyncProviderMock(t, tc.expectations)
+			service := app.NewSubmitTransactionAsyncService(mock)
+
+			// when:
+			job, err := service.SubmitTransactionAsync(context.Background(), tc.topics, 0xbe, 0xef)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErr, actualErr)
+			require.Zero(t, job)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestSubmitTransactionAsyncService_ValidCase(t *testing.T) {
+	// given:
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock := testabilities.NewSubmitTransactionAsyncProviderMock(t, testabilities.SubmitTransactionAsyncProviderMockExpectations{
+		SubmitAsyncCall: true,
+		Topics:          []string{"tm_test"},
+		Job: &engine.SubmitJob{
+			ID:        "job",
+			Status:    engine.SubmitJobQueued,
+			Topics:    []string{"tm_test"},
+			CreatedAt: createdAt,
+		},
+	})
+	service := app.NewSubmitTransactionAsyncService(mock)
+
+	// when:
+	job, err := service.SubmitTransactionAsync(context.Background(), app.TransactionTopics{"tm_test"}, 0xbe, 0xef)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, app.SubmitJobDTO{
+		ID:        "job",
+		Status:    "queued",
+		Topics:    []string{"tm_test"},
+		CreatedAt: createdAt,
+	}, job)
+	mock.AssertCalled()
+}
@@ -25,13 +25,25 @@ type SubmitTransactionService struct {
 // Returns a non-nil *overlay.Steak on success, or an error if topics are missing, invalid,
 // the provider fails, or a timeout occurs.
 func (s *SubmitTransactionService) SubmitTransaction(ctx context.Context, topics TransactionTopics, txBytes ...byte) (*overlay.Steak, error) {
+	return s.submit(ctx, engine.SubmitModeCurrent, topics, txBytes)
+}
+
+// DryRunTransaction previews the submission of a transaction to the configured provider.
+// It validates the provided topics and returns the STEAK the topic managers would produce,
+// while the provider stores, broadcasts and propagates nothing.
+func (s *SubmitTransactionService) DryRunTransaction(ctx context.Context, topics TransactionTopics, txBytes ...byte) (*overlay.Steak, error) {
+	return s.submit(ctx, engine.SubmitModeDryRun, topics, txBytes)
+}
+
+// submit sends the transaction to the provider in the given mode and waits for its STEAK.
+func (s *SubmitTransactionService) submit(ctx context.Context, mode engine.SumbitMode, topics TransactionTopics, txBytes []byte) (*overlay.Steak, error) {
 	err := topics.Verify()
 	if err != nil {
 		return nil, err
 	}
 
 	ch := make(chan *overlay.Steak, 1)
-	_, err = s.provider.Submit(ctx, overlay.TaggedBEEF{Beef: txBytes, Topics: topics}, engine.SubmitModeCurrent, func(steak *overlay.Steak) {
+	_, err = s.provider.Submit(ctx, overlay.TaggedBEEF{Beef: txBytes, Topics: topics}, mode, func(steak *overlay.Steak) {
 		ch <- steak
 	})
 	if err != nil {
@@ -99,9 +111,8 @@ func NewErrInvalidTopicFormatError(i int) Error {
 // NewSubmitTransactionProviderError returns an Error indicating that the configured provider
 // failed to process a submitted transaction octet-stream.
 func NewSubmitTransactionProviderError(err error) Error {
-	return Error{
-		errorType: ErrorTypeProviderFailure,
-		err:       err.Error(),
-		slug:      "Unable to process submitted transaction octet-stream due to an internal error. Please try again later or contact the support team.",
-	}
+	return newProviderError(
+		err,
+		"Unable to process submitted transaction octet-stream due to an internal error. Please try again later or contact the support team.",
+	)
 }
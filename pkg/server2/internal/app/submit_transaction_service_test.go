@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
 	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
 	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
 	"github.com/bsv-blockchain/go-sdk/overlay"
@@ -109,6 +110,7 @@ func TestSubmitTransactionService_ValidCase(t *testing.T) {
 		},
 		Error:      nil,
 		SubmitCall: true,
+		SubmitMode: engine.SubmitModeCurrent,
 	}
 
 	topics := app.TransactionTopics{"topic1", "topic2"}
@@ -123,3 +125,28 @@ func TestSubmitTransactionService_ValidCase(t *testing.T) {
 	require.Equal(t, expectations.STEAK, actualSTEAK)
 	mock.AssertCalled()
 }
+
+func TestSubmitTransactionService_DryRun_ValidCase(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitTransactionProviderMockExpectations{
+		STEAK: &overlay.Steak{
+			"test_response": &overlay.AdmittanceInstructions{
+				OutputsToAdmit: []uint32{1},
+			},
+		},
+		SubmitCall: true,
+		SubmitMode: engine.SubmitModeDryRun,
+	}
+
+	topics := app.TransactionTopics{"topic1", "topic2"}
+	mock := testabilities.NewSubmitTransactionProviderMock(t, expectations)
+	service := app.NewSubmitTransactionService(mock)
+
+	// when:
+	actualSTEAK, err := service.DryRunTransaction(context.Background(), topics)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, expectations.STEAK, actualSTEAK)
+	mock.AssertCalled()
+}
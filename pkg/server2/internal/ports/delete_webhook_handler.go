@@ -0,0 +1,39 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeleteWebhookHandler is a Fiber-compatible HTTP handler that deletes the registered webhooks
+// of the overlay engine. It acts as the adapter between HTTP requests and the
+// application-layer DeleteWebhookService.
+type DeleteWebhookHandler struct {
+	service *app.DeleteWebhookService
+}
+
+// Handle processes an HTTP DELETE request deleting the registered webhook identified by
+// the id query parameter. Its pending deliveries end up in the dead letters.
+//
+// On success, returns 200 OK with the ID of the deleted webhook.
+// On failure, returns an application error.
+func (h *DeleteWebhookHandler) Handle(c *fiber.Ctx, params openapi.DeleteWebhookParams) error {
+	if err := h.service.DeleteWebhook(c.UserContext(), params.Id); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewDeleteWebhookSuccessResponse(params.Id))
+}
+
+// NewDeleteWebhookHandler creates a new DeleteWebhookHandler with the given provider.
+// If the provider is nil, it panics.
+func NewDeleteWebhookHandler(provider app.DeleteWebhookProvider) *DeleteWebhookHandler {
+	return &DeleteWebhookHandler{service: app.NewDeleteWebhookService(provider)}
+}
+
+// NewDeleteWebhookSuccessResponse returns a DeleteWebhookResponse
+// carrying the ID of the deleted webhook.
+func NewDeleteWebhookSuccessResponse(id string) openapi.DeleteWebhookResponse {
+	return openapi.DeleteWebhookResponse{Id: id}
+}
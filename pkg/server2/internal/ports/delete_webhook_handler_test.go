@@ -0,0 +1,103 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteWebhookHandler_InvalidCases(t *testing.T) {
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	tests := map[string]struct {
+		id               string
+		expectations     testabilities.DeleteWebhookProviderMockExpectations
+		expectedStatus   int
+		expectedResponse openapi.Error
+	}{
+		"Delete webhook service rejects an unknown webhook": {
+			id: "unknown",
+			expectations: testabilities.DeleteWebhookProviderMockExpectations{
+				DeleteWebhookCall: true,
+				ID:                "unknown",
+				Error:             engine.ErrNotFound,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewWebhookNotFoundError("unknown")),
+		},
+		"Delete webhook service rejects a configured webhook": {
+			id: "configured",
+			expectations: testabilities.DeleteWebhookProviderMockExpectations{
+				DeleteWebhookCall: true,
+				ID:                "configured",
+				Error:             engine.ErrWebhookConfigured,
+			},
+			expectedStatus:   fiber.StatusConflict,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewConfiguredWebhookError("configured")),
+		},
+		"Delete webhook service fails to handle the request": {
+			id: "id",
+			expectations: testabilities.DeleteWebhookProviderMockExpectations{
+				DeleteWebhookCall: true,
+				ID:                "id",
+				Error:             testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewDeleteWebhookProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithDeleteWebhookProvider(testabilities.NewDeleteWebhookProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParam("id", tc.id).
+				SetError(&actualResponse).
+				Delete("/api/v1/admin/webhooks")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestDeleteWebhookHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	expectations := testabilities.DeleteWebhookProviderMockExpectations{
+		DeleteWebhookCall: true,
+		ID:                "id",
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithDeleteWebhookProvider(testabilities.NewDeleteWebhookProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.DeleteWebhook
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("id", "id").
+		SetResult(&actualResponse).
+		Delete("/api/v1/admin/webhooks")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewDeleteWebhookSuccessResponse("id"), actualResponse)
+	stub.AssertProvidersState()
+}
@@ -10,18 +10,23 @@ import (
 
 // ErrorHandler returns a Fiber error handler that translates application-level errors
 // into appropriate HTTP status codes and JSON responses. The handler maps specific
-// error types to corresponding HTTP status codes and includes a user-friendly message
-// (the slug) in the response body. If an error is unrecognized or zero, the handler
-// returns a generic internal server error response.
+// error types to corresponding HTTP status codes and includes a machine-readable code
+// and a user-friendly message (the slug) in the response body. If an error is unrecognized
+// or zero, the handler returns a generic internal server error response.
 func ErrorHandler() fiber.ErrorHandler {
 	codes := map[app.ErrorType]int{
 		app.ErrorTypeAuthorization:        fiber.StatusUnauthorized,
 		app.ErrorTypeAccessForbidden:      fiber.StatusForbidden,
 		app.ErrorTypeIncorrectInput:       fiber.StatusBadRequest,
+		app.ErrorTypeNotFound:             fiber.StatusNotFound,
+		app.ErrorTypeConflict:             fiber.StatusConflict,
+		app.ErrorTypeUnprocessable:        fiber.StatusUnprocessableEntity,
 		app.ErrorTypeOperationTimeout:     fiber.StatusRequestTimeout,
 		app.ErrorTypeProviderFailure:      fiber.StatusInternalServerError,
 		app.ErrorTypeRawDataProcessing:    fiber.StatusInternalServerError,
 		app.ErrorTypeUnsupportedOperation: fiber.StatusNotFound,
+		app.ErrorTypeServiceUnavailable:   fiber.StatusServiceUnavailable,
+		app.ErrorTypePaymentRequired:      fiber.StatusPaymentRequired,
 	}
 
 	return func(c *fiber.Ctx, err error) error {
@@ -31,7 +36,7 @@ func ErrorHandler() fiber.ErrorHandler {
 
 		var fiberErr *fiber.Error
 		if errors.As(err, &fiberErr) {
-			return c.Status(fiberErr.Code).JSON(openapi.Error{Message: fiberErr.Message}) // TODO: Add more descriptive responses.
+			return c.Status(fiberErr.Code).JSON(openapi.Error{Code: fiberErrorCode(fiberErr.Code), Message: fiberErr.Message}) // TODO: Add more descriptive responses.
 		}
 
 		var appErr app.Error
@@ -40,7 +45,34 @@ func ErrorHandler() fiber.ErrorHandler {
 		}
 
 		code := codes[appErr.ErrorType()]
-		return c.Status(code).JSON(openapi.Error{Message: appErr.Slug()})
+		return c.Status(code).JSON(openapi.Error{Code: appErr.Code(), Message: appErr.Slug()})
+	}
+}
+
+// fiberErrorCode returns the machine-readable code of a Fiber error by its HTTP status code,
+// matching the code of the application error type reported with the same status.
+func fiberErrorCode(status int) string {
+	switch status {
+	case fiber.StatusBadRequest:
+		return app.ErrorTypeIncorrectInput.String()
+	case fiber.StatusUnauthorized:
+		return app.ErrorTypeAuthorization.String()
+	case fiber.StatusPaymentRequired:
+		return app.ErrorTypePaymentRequired.String()
+	case fiber.StatusForbidden:
+		return app.ErrorTypeAccessForbidden.String()
+	case fiber.StatusNotFound:
+		return app.ErrorTypeNotFound.String()
+	case fiber.StatusRequestTimeout:
+		return app.ErrorTypeOperationTimeout.String()
+	case fiber.StatusConflict:
+		return app.ErrorTypeConflict.String()
+	case fiber.StatusUnprocessableEntity:
+		return app.ErrorTypeUnprocessable.String()
+	case fiber.StatusServiceUnavailable:
+		return app.ErrorTypeServiceUnavailable.String()
+	default:
+		return app.ErrorTypeUnknown.String()
 	}
 }
 
@@ -49,6 +81,7 @@ func ErrorHandler() fiber.ErrorHandler {
 // It represents a generic internal server error to avoid exposing internal details to the client.
 func NewUnhandledErrorTypeResponse() openapi.Error {
 	return openapi.Error{
+		Code:    app.ErrorTypeUnknown.String(),
 		Message: "An internal error occurred during processing the request. Please try again later or contact the support team.",
 	}
 }
@@ -0,0 +1,66 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EvictOutputHandler is a Fiber-compatible HTTP handler that evicts an output from
+// the overlay engine. It acts as the adapter between HTTP requests and the
+// application-layer EvictOutputService.
+type EvictOutputHandler struct {
+	service *app.EvictOutputService
+}
+
+// Handle processes an HTTP POST request evicting the output identified in the JSON body,
+// conforming to the EvictOutputJSONBody OpenAPI definition, from every topic.
+//
+// On success, returns 200 OK with the evicted outputs and the time the eviction was recorded.
+// On failure, returns a request parsing or service-level error.
+func (h *EvictOutputHandler) Handle(c *fiber.Ctx) error {
+	var body openapi.EvictOutputJSONBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	dto := app.EvictOutputDTO{
+		TxID:        body.Txid,
+		OutputIndex: body.OutputIndex,
+		Actor:       body.Actor,
+		Reason:      body.Reason,
+	}
+	if body.IncludeHistory != nil {
+		dto.IncludeHistory = *body.IncludeHistory
+	}
+
+	eviction, err := h.service.EvictOutput(c.UserContext(), dto)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewEvictOutputSuccessResponse(eviction))
+}
+
+// NewEvictOutputHandler creates a new EvictOutputHandler with the given provider.
+// If the provider is nil, it panics.
+func NewEvictOutputHandler(provider app.EvictOutputProvider) *EvictOutputHandler {
+	return &EvictOutputHandler{service: app.NewEvictOutputService(provider)}
+}
+
+// NewEvictOutputSuccessResponse converts the eviction DTO into an
+// EvictOutputResponse object compatible with the OpenAPI specification.
+func NewEvictOutputSuccessResponse(eviction app.EvictionDTO) openapi.EvictOutputResponse {
+	response := openapi.EvictOutputResponse{
+		Evicted:   make([]openapi.EvictedOutput, 0, len(eviction.Evicted)),
+		CreatedAt: eviction.CreatedAt,
+	}
+	for _, output := range eviction.Evicted {
+		response.Evicted = append(response.Evicted, openapi.EvictedOutput{
+			Txid:        output.TxID,
+			OutputIndex: output.OutputIndex,
+			Topic:       output.Topic,
+		})
+	}
+	return response
+}
@@ -0,0 +1,129 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvictOutputHandler_InvalidCases(t *testing.T) {
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	txid := chainhash.DoubleHashH([]byte("evicted"))
+	outpoint := &transaction.Outpoint{Txid: txid, Index: 1}
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.EvictOutputProviderMockExpectations
+		expectedStatus   int
+		expectedResponse openapi.Error
+	}{
+		"Evict output service rejects the request without a reason": {
+			body:             map[string]any{"txid": txid.String(), "outputIndex": 1, "actor": "admin"},
+			expectations:     testabilities.EvictOutputProviderMockExpectations{EvictOutputCall: false},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("reason")),
+		},
+		"Evict output service rejects the request for an output not stored": {
+			body: map[string]any{"txid": txid.String(), "outputIndex": 1, "actor": "admin", "reason": "takedown request"},
+			expectations: testabilities.EvictOutputProviderMockExpectations{
+				EvictOutputCall: true,
+				Outpoint:        outpoint,
+				Actor:           "admin",
+				Reason:          "takedown request",
+				Error:           engine.ErrNotFound,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewEvictedOutputNotFoundError(outpoint)),
+		},
+		"Evict output service fails to handle the request": {
+			body: map[string]any{"txid": txid.String(), "outputIndex": 1, "actor": "admin", "reason": "takedown request"},
+			expectations: testabilities.EvictOutputProviderMockExpectations{
+				EvictOutputCall: true,
+				Outpoint:        outpoint,
+				Actor:           "admin",
+				Reason:          "takedown request",
+				Error:           testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewEvictOutputProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithEvictOutputProvider(testabilities.NewEvictOutputProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetError(&actualResponse).
+				Post("/api/v1/admin/evictOutput")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestEvictOutputHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	txid := chainhash.DoubleHashH([]byte("evicted"))
+	outpoint := &transaction.Outpoint{Txid: txid, Index: 1}
+	createdAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	expectations := testabilities.EvictOutputProviderMockExpectations{
+		EvictOutputCall: true,
+		Outpoint:        outpoint,
+		IncludeHistory:  true,
+		Actor:           "admin",
+		Reason:          "takedown request",
+		Eviction: &engine.Eviction{
+			Outpoint:  *outpoint,
+			Evicted:   []engine.EvictedOutput{{Outpoint: *outpoint, Topic: "tm_test"}},
+			CreatedAt: createdAt,
+		},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithEvictOutputProvider(testabilities.NewEvictOutputProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.EvictOutput
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetBody(map[string]any{
+			"txid":           txid.String(),
+			"outputIndex":    1,
+			"includeHistory": true,
+			"actor":          "admin",
+			"reason":         "takedown request",
+		}).
+		SetResult(&actualResponse).
+		Post("/api/v1/admin/evictOutput")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewEvictOutputSuccessResponse(app.EvictionDTO{
+		Evicted:   []app.EvictedOutputDTO{{TxID: txid.String(), OutputIndex: 1, Topic: "tm_test"}},
+		CreatedAt: createdAt,
+	}), actualResponse)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,57 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetSubmitJobStatusHandler handles requests for the status of the asynchronous transaction submissions.
+type GetSubmitJobStatusHandler struct {
+	service *app.SubmitJobStatusService
+}
+
+// Handle processes an HTTP request for the status of a submission job.
+// On success, it returns HTTP 200 OK with the job (openapi.SubmitJobStatusResponse), including
+// its STEAK once completed or its structured error once failed.
+func (h *GetSubmitJobStatusHandler) Handle(c *fiber.Ctx, params openapi.GetSubmitJobStatusParams) error {
+	job, err := h.service.GetSubmitJobStatus(c.UserContext(), params.JobId)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewSubmitJobResponse(job))
+}
+
+// NewGetSubmitJobStatusHandler creates a new GetSubmitJobStatusHandler with the given provider.
+// It panics if the provider is nil.
+func NewGetSubmitJobStatusHandler(provider app.SubmitJobStatusProvider) *GetSubmitJobStatusHandler {
+	return &GetSubmitJobStatusHandler{service: app.NewSubmitJobStatusService(provider)}
+}
+
+// NewSubmitJobResponse converts the submission job into an OpenAPI-compatible SubmitJob.
+// The start and completion times are omitted until they are known.
+func NewSubmitJobResponse(job app.SubmitJobDTO) openapi.SubmitJob {
+	topics := job.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	response := openapi.SubmitJob{
+		JobId:     job.ID,
+		Status:    job.Status,
+		Topics:    topics,
+		CreatedAt: job.CreatedAt,
+	}
+	if !job.StartedAt.IsZero() {
+		response.StartedAt = &job.StartedAt
+	}
+	if !job.CompletedAt.IsZero() {
+		response.CompletedAt = &job.CompletedAt
+	}
+	if job.Steak != nil {
+		response.STEAK = &NewSubmitTransactionSuccessResponse(job.Steak).STEAK
+	}
+	if job.ErrorCode != "" {
+		response.Error = &openapi.SubmitJobError{Code: job.ErrorCode, Message: job.ErrorMessage}
+	}
+	return response
+}
@@ -0,0 +1,143 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSubmitJobStatusHandler_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		queryParams      map[string]string
+		expectations     testabilities.SubmitJobStatusProviderMockExpectations
+		expectedStatus   int
+		expectedResponse openapi.Error
+	}{
+		"Submit job status service rejects an unknown job": {
+			queryParams: map[string]string{"jobId": "unknown"},
+			expectations: testabilities.SubmitJobStatusProviderMockExpectations{
+				FindSubmitJobCall: true,
+				JobID:             "unknown",
+				Error:             engine.ErrNotFound,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitJobNotFoundError("unknown")),
+		},
+		"Submit job status service fails to handle the request": {
+			queryParams: map[string]string{"jobId": "job"},
+			expectations: testabilities.SubmitJobStatusProviderMockExpectations{
+				FindSubmitJobCall: true,
+				JobID:             "job",
+				Error:             testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitJobStatusProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitJobStatusProvider(testabilities.NewSubmitJobStatusProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetQueryParams(tc.queryParams).
+				SetError(&actualResponse).
+				Get("/api/v1/submit/status")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestGetSubmitJobStatusHandler_ValidCases(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	steak := overlay.Steak{"tm_test": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{1}}}
+	tests := map[string]struct {
+		job      *engine.SubmitJob
+		expected app.SubmitJobDTO
+	}{
+		"Submit job status service returns a completed job with its STEAK": {
+			job: &engine.SubmitJob{
+				ID:          "job",
+				Status:      engine.SubmitJobCompleted,
+				Topics:      []string{"tm_test"},
+				Steak:       steak,
+				CreatedAt:   createdAt,
+				StartedAt:   createdAt.Add(time.Second),
+				CompletedAt: createdAt.Add(2 * time.Second),
+			},
+			expected: app.SubmitJobDTO{
+				ID:          "job",
+				Status:      "completed",
+				Topics:      []string{"tm_test"},
+				Steak:       &steak,
+				CreatedAt:   createdAt,
+				StartedAt:   createdAt.Add(time.Second),
+				CompletedAt: createdAt.Add(2 * time.Second),
+			},
+		},
+		"Submit job status service returns a failed job with its error": {
+			job: &engine.SubmitJob{
+				ID:          "job",
+				Status:      engine.SubmitJobFailed,
+				Topics:      []string{"tm_test"},
+				Err:         engine.ErrInvalidBeef,
+				CreatedAt:   createdAt,
+				StartedAt:   createdAt,
+				CompletedAt: createdAt,
+			},
+			expected: app.SubmitJobDTO{
+				ID:           "job",
+				Status:       "failed",
+				Topics:       []string{"tm_test"},
+				ErrorCode:    "invalid-beef",
+				ErrorMessage: "The submitted BEEF does not contain the transaction.",
+				CreatedAt:    createdAt,
+				StartedAt:    createdAt,
+				CompletedAt:  createdAt,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitJobStatusProvider(testabilities.NewSubmitJobStatusProviderMock(t, testabilities.SubmitJobStatusProviderMockExpectations{
+				FindSubmitJobCall: true,
+				JobID:             "job",
+				Job:               tc.job,
+			})))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+			// when:
+			var actualResponse openapi.SubmitJob
+			res, _ := fixture.Client().
+				R().
+				SetQueryParam("jobId", "job").
+				SetResult(&actualResponse).
+				Get("/api/v1/submit/status")
+
+			// then:
+			require.Equal(t, fiber.StatusOK, res.StatusCode())
+			require.Equal(t, ports.NewSubmitJobResponse(tc.expected), actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
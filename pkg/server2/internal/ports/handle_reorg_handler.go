@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// HandleReorgHandler is a Fiber-compatible HTTP handler that re-verifies the stored
+// merkle proofs after a chain reorganization. It acts as the adapter between
+// HTTP requests and the application-layer HandleReorgService.
+type HandleReorgHandler struct {
+	service *app.HandleReorgService
+}
+
+// Handle processes an HTTP POST request re-verifying the merkle proofs of the transactions
+// mined at or above the block height given by the fromHeight query parameter.
+//
+// On success, returns 200 OK with the number of verified and the list of downgraded transactions.
+// On failure, returns an application error.
+func (h *HandleReorgHandler) Handle(c *fiber.Ctx, params openapi.HandleReorgParams) error {
+	result, err := h.service.HandleReorg(c.UserContext(), params.FromHeight)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewHandleReorgSuccessResponse(result))
+}
+
+// NewHandleReorgHandler creates a new HandleReorgHandler with the given provider.
+// If the provider is nil, it panics.
+func NewHandleReorgHandler(provider app.HandleReorgProvider) *HandleReorgHandler {
+	return &HandleReorgHandler{service: app.NewHandleReorgService(provider)}
+}
+
+// NewHandleReorgSuccessResponse returns a HandleReorgResponse
+// built from the outcome of the re-verification.
+func NewHandleReorgSuccessResponse(result app.ReorgResultDTO) openapi.HandleReorgResponse {
+	return openapi.HandleReorgResponse{Checked: result.Checked, Downgraded: result.Downgraded}
+}
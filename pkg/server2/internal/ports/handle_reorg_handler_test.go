@@ -0,0 +1,103 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleReorgHandler_InvalidCases(t *testing.T) {
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	tests := map[string]struct {
+		queryParams      map[string]string
+		expectations     testabilities.HandleReorgProviderMockExpectations
+		expectedStatus   int
+		expectedResponse openapi.Error
+	}{
+		"Handle reorg service fails to handle the request": {
+			queryParams: map[string]string{"fromHeight": "814435"},
+			expectations: testabilities.HandleReorgProviderMockExpectations{
+				HandleReorgCall: true,
+				FromHeight:      814435,
+				Error:           testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewHandleReorgProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithHandleReorgProvider(testabilities.NewHandleReorgProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParams(tc.queryParams).
+				SetError(&actualResponse).
+				Post("/api/v1/admin/reorg")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestHandleReorgHandler_ShouldRejectRequest_WhenFromHeightIsMissing(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	stub := testabilities.NewTestOverlayEngineStub(t)
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		Post("/api/v1/admin/reorg")
+
+	// then:
+	require.Equal(t, fiber.StatusBadRequest, res.StatusCode())
+	stub.AssertProvidersState()
+}
+
+func TestHandleReorgHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	txid := chainhash.DoubleHashH([]byte("orphaned"))
+	expectations := testabilities.HandleReorgProviderMockExpectations{
+		HandleReorgCall: true,
+		FromHeight:      814435,
+		Result:          &engine.ReorgResult{Checked: 2, Downgraded: []chainhash.Hash{txid}},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithHandleReorgProvider(testabilities.NewHandleReorgProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.HandleReorg
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("fromHeight", "814435").
+		SetResult(&actualResponse).
+		Post("/api/v1/admin/reorg")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewHandleReorgSuccessResponse(app.ReorgResultDTO{Checked: 2, Downgraded: []string{txid.String()}}), actualResponse)
+	stub.AssertProvidersState()
+}
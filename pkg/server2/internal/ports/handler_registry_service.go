@@ -15,9 +15,25 @@ type HandlerRegistryService struct {
 	startGASPSync             *StartGASPSyncHandler
 	topicManagerDocumentation *TopicManagerDocumentationHandler
 	submitTransaction         *SubmitTransactionHandler
+	getSubmitJobStatus        *GetSubmitJobStatusHandler
+	submitBatch               *SubmitBatchHandler
+	importTransactions        *ImportTransactionsHandler
 	syncAdvertisements        *SyncAdvertisementsHandler
 	requestForeignGASPNode    *RequestForeignGASPNodeHandler
 	requestSyncResponse       *RequestSyncResponseHandler
+	requestSyncReply          *RequestSyncReplyHandler
+	submitGASPNode            *SubmitGASPNodeHandler
+	listSyncCheckpoints       *ListSyncCheckpointsHandler
+	resetSyncCheckpoints      *ResetSyncCheckpointsHandler
+	handleReorg               *HandleReorgHandler
+	evictOutput               *EvictOutputHandler
+	pruneHistory              *PruneHistoryHandler
+	streamEvents              *StreamEventsHandler
+	listWebhooks              *ListWebhooksHandler
+	registerWebhook           *RegisterWebhookHandler
+	deleteWebhook             *DeleteWebhookHandler
+	listWebhookDeliveries     *ListWebhookDeliveriesHandler
+	listOutboxEntries         *ListOutboxEntriesHandler
 	metadataHandler           *MetadataHandler
 	lookupQuestion            *LookupQuestionHandler
 	arcIngest                 decorators.Handler
@@ -78,6 +94,86 @@ func (h *HandlerRegistryService) RequestSyncResponse(c *fiber.Ctx, params openap
 	return h.requestSyncResponse.Handle(c, params)
 }
 
+// RequestSyncReply method delegates the request to the configured request sync reply handler.
+func (h *HandlerRegistryService) RequestSyncReply(c *fiber.Ctx, params openapi.RequestSyncReplyParams) error {
+	return h.requestSyncReply.Handle(c, params)
+}
+
+// SubmitGASPNode method delegates the request to the configured submit GASP node handler.
+func (h *HandlerRegistryService) SubmitGASPNode(c *fiber.Ctx, params openapi.SubmitGASPNodeParams) error {
+	return h.submitGASPNode.Handle(c, params)
+}
+
+// ListSyncCheckpoints method delegates the request to the configured list sync checkpoints handler.
+func (h *HandlerRegistryService) ListSyncCheckpoints(c *fiber.Ctx, params openapi.ListSyncCheckpointsParams) error {
+	return h.listSyncCheckpoints.Handle(c, params)
+}
+
+// ResetSyncCheckpoints method delegates the request to the configured reset sync checkpoints handler.
+func (h *HandlerRegistryService) ResetSyncCheckpoints(c *fiber.Ctx, params openapi.ResetSyncCheckpointsParams) error {
+	return h.resetSyncCheckpoints.Handle(c, params)
+}
+
+// HandleReorg method delegates the request to the configured reorg handler.
+func (h *HandlerRegistryService) HandleReorg(c *fiber.Ctx, params openapi.HandleReorgParams) error {
+	return h.handleReorg.Handle(c, params)
+}
+
+// EvictOutput method delegates the request to the configured evict output handler.
+func (h *HandlerRegistryService) EvictOutput(c *fiber.Ctx) error {
+	return h.evictOutput.Handle(c)
+}
+
+// PruneHistory method delegates the request to the configured prune history handler.
+func (h *HandlerRegistryService) PruneHistory(c *fiber.Ctx, params openapi.PruneHistoryParams) error {
+	return h.pruneHistory.Handle(c, params)
+}
+
+// StreamEvents method delegates the request to the configured stream events handler.
+func (h *HandlerRegistryService) StreamEvents(c *fiber.Ctx, params openapi.StreamEventsParams) error {
+	return h.streamEvents.Handle(c, params)
+}
+
+// ListWebhooks method delegates the request to the configured list webhooks handler.
+func (h *HandlerRegistryService) ListWebhooks(c *fiber.Ctx) error {
+	return h.listWebhooks.Handle(c)
+}
+
+// RegisterWebhook method delegates the request to the configured register webhook handler.
+func (h *HandlerRegistryService) RegisterWebhook(c *fiber.Ctx) error {
+	return h.registerWebhook.Handle(c)
+}
+
+// DeleteWebhook method delegates the request to the configured delete webhook handler.
+func (h *HandlerRegistryService) DeleteWebhook(c *fiber.Ctx, params openapi.DeleteWebhookParams) error {
+	return h.deleteWebhook.Handle(c, params)
+}
+
+// ListWebhookDeliveries method delegates the request to the configured list webhook deliveries handler.
+func (h *HandlerRegistryService) ListWebhookDeliveries(c *fiber.Ctx, params openapi.ListWebhookDeliveriesParams) error {
+	return h.listWebhookDeliveries.Handle(c, params)
+}
+
+// ListOutboxEntries method delegates the request to the configured list outbox entries handler.
+func (h *HandlerRegistryService) ListOutboxEntries(c *fiber.Ctx, params openapi.ListOutboxEntriesParams) error {
+	return h.listOutboxEntries.Handle(c, params)
+}
+
+// GetSubmitJobStatus method delegates the request to the configured submit job status handler.
+func (h *HandlerRegistryService) GetSubmitJobStatus(c *fiber.Ctx, params openapi.GetSubmitJobStatusParams) error {
+	return h.getSubmitJobStatus.Handle(c, params)
+}
+
+// SubmitBatch method delegates the request to the configured submit batch handler.
+func (h *HandlerRegistryService) SubmitBatch(c *fiber.Ctx) error {
+	return h.submitBatch.Handle(c)
+}
+
+// ImportTransactions method delegates the request to the configured import transactions handler.
+func (h *HandlerRegistryService) ImportTransactions(c *fiber.Ctx) error {
+	return h.importTransactions.Handle(c)
+}
+
 // NewHandlerRegistryService creates and returns a new HandlerRegistryService instance.
 // It initializes all handler implementations with their required dependencies.
 func NewHandlerRegistryService(provider engine.OverlayEngineProvider, cfg *decorators.ARCAuthorizationDecoratorConfig) *HandlerRegistryService {
@@ -92,9 +188,25 @@ func NewHandlerRegistryService(provider engine.OverlayEngineProvider, cfg *decor
 			)),
 		lookupQuestion:            NewLookupQuestionHandler(provider),
 		topicManagerDocumentation: NewTopicManagerDocumentationHandler(provider),
-		submitTransaction:         NewSubmitTransactionHandler(provider),
+		submitTransaction:         NewSubmitTransactionHandler(provider, provider),
+		getSubmitJobStatus:        NewGetSubmitJobStatusHandler(provider),
+		submitBatch:               NewSubmitBatchHandler(provider),
+		importTransactions:        NewImportTransactionsHandler(provider),
 		syncAdvertisements:        NewSyncAdvertisementsHandler(provider),
 		requestForeignGASPNode:    NewRequestForeignGASPNodeHandler(provider),
 		requestSyncResponse:       NewRequestSyncResponseHandler(provider),
+		requestSyncReply:          NewRequestSyncReplyHandler(provider),
+		submitGASPNode:            NewSubmitGASPNodeHandler(provider),
+		listSyncCheckpoints:       NewListSyncCheckpointsHandler(provider),
+		resetSyncCheckpoints:      NewResetSyncCheckpointsHandler(provider),
+		handleReorg:               NewHandleReorgHandler(provider),
+		evictOutput:               NewEvictOutputHandler(provider),
+		pruneHistory:              NewPruneHistoryHandler(provider),
+		streamEvents:              NewStreamEventsHandler(provider),
+		listWebhooks:              NewListWebhooksHandler(provider),
+		registerWebhook:           NewRegisterWebhookHandler(provider),
+		deleteWebhook:             NewDeleteWebhookHandler(provider),
+		listWebhookDeliveries:     NewListWebhookDeliveriesHandler(provider),
+		listOutboxEntries:         NewListOutboxEntriesHandler(provider),
 	}
 }
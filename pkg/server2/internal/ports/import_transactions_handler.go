@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImportTransactionsHandler is a Fiber-compatible HTTP handler that imports historical transactions
+// into the overlay engine without broadcasting or propagating them. It acts as the interface adapter
+// between HTTP input and application-layer logic provided by ImportTransactionsService.
+type ImportTransactionsHandler struct {
+	service *app.ImportTransactionsService
+}
+
+// Handle processes an HTTP POST request importing a batch of historical transactions.
+// It expects a JSON body conforming to the ImportTransactionsJSONBody OpenAPI definition.
+//
+// On success, returns a 200 OK response with the outcome of every transaction, even when some of
+// them were rejected. On failure, returns a request parsing or service-level error.
+func (h *ImportTransactionsHandler) Handle(c *fiber.Ctx) error {
+	var body openapi.ImportTransactionsJSONBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	results, err := h.service.ImportTransactions(c.UserContext(), NewSubmitBatchDTO(openapi.SubmitBatchJSONBody(body)))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewSubmitBatchSuccessResponse(results))
+}
+
+// NewImportTransactionsHandler creates a new ImportTransactionsHandler with the given provider.
+// It panics if the provider is nil.
+func NewImportTransactionsHandler(provider app.SubmitBatchProvider) *ImportTransactionsHandler {
+	return &ImportTransactionsHandler{service: app.NewImportTransactionsService(provider)}
+}
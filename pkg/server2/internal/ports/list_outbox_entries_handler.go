@@ -0,0 +1,70 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListOutboxEntriesHandler is a Fiber-compatible HTTP handler that lists the broadcasts and
+// propagations of the submitted transactions. It acts as the adapter between HTTP requests and the
+// application-layer ListOutboxEntriesService.
+type ListOutboxEntriesHandler struct {
+	service *app.ListOutboxEntriesService
+}
+
+// Handle processes an HTTP GET request listing the outbox entries matching the optional txid
+// and status query parameters.
+//
+// On success, returns 200 OK with the entries ordered by the time they were queued.
+// On failure, returns an application error.
+func (h *ListOutboxEntriesHandler) Handle(c *fiber.Ctx, params openapi.ListOutboxEntriesParams) error {
+	var dto app.ListOutboxEntriesDTO
+	if params.Txid != nil {
+		dto.TxID = *params.Txid
+	}
+	if params.Status != nil {
+		dto.Status = string(*params.Status)
+	}
+
+	entries, err := h.service.ListOutboxEntries(c.UserContext(), dto)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewListOutboxEntriesSuccessResponse(entries))
+}
+
+// NewListOutboxEntriesHandler creates a new ListOutboxEntriesHandler with the given provider.
+// If the provider is nil, it panics.
+func NewListOutboxEntriesHandler(provider app.ListOutboxEntriesProvider) *ListOutboxEntriesHandler {
+	return &ListOutboxEntriesHandler{service: app.NewListOutboxEntriesService(provider)}
+}
+
+// NewListOutboxEntriesSuccessResponse converts the outbox entry DTOs into a
+// ListOutboxEntriesResponse object compatible with the OpenAPI specification.
+func NewListOutboxEntriesSuccessResponse(entries []app.OutboxEntryDTO) openapi.ListOutboxEntriesResponse {
+	response := openapi.ListOutboxEntriesResponse{Entries: make([]openapi.OutboxEntry, 0, len(entries))}
+	for _, entry := range entries {
+		item := openapi.OutboxEntry{
+			Id:            entry.ID,
+			Kind:          entry.Kind,
+			Txid:          entry.TxID,
+			Topics:        entry.Topics,
+			Status:        entry.Status,
+			Attempts:      entry.Attempts,
+			NextAttemptAt: entry.NextAttemptAt,
+			CreatedAt:     entry.CreatedAt,
+			UpdatedAt:     entry.UpdatedAt,
+		}
+		if item.Topics == nil {
+			item.Topics = []string{}
+		}
+		if entry.LastError != "" {
+			lastError := entry.LastError
+			item.LastError = &lastError
+		}
+		response.Entries = append(response.Entries, item)
+	}
+	return response
+}
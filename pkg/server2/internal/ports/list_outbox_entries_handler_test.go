@@ -0,0 +1,137 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOutboxEntriesHandler_InvalidCases(t *testing.T) {
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	tests := map[string]struct {
+		queryParams      map[string]string
+		expectations     testabilities.ListOutboxEntriesProviderMockExpectations
+		expectedStatus   int
+		expectedResponse openapi.Error
+	}{
+		"List outbox entries service rejects an invalid txid": {
+			queryParams:      map[string]string{"txid": "invalid"},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("txid")),
+		},
+		"List outbox entries service fails to handle the request": {
+			expectations: testabilities.ListOutboxEntriesProviderMockExpectations{
+				ListOutboxEntriesCall: true,
+				Error:                 testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewListOutboxEntriesProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithListOutboxEntriesProvider(testabilities.NewListOutboxEntriesProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParams(tc.queryParams).
+				SetError(&actualResponse).
+				Get("/api/v1/admin/outbox")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestListOutboxEntriesHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	txid := chainhash.DoubleHashH([]byte("outbox"))
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	expectations := testabilities.ListOutboxEntriesProviderMockExpectations{
+		ListOutboxEntriesCall: true,
+		TxID:                  &txid,
+		Entries: []*engine.OutboxEntry{
+			{
+				ID:            "broadcast",
+				Kind:          engine.OutboxBroadcast,
+				Txid:          txid,
+				Status:        engine.OutboxCompleted,
+				Attempts:      1,
+				NextAttemptAt: createdAt,
+				CreatedAt:     createdAt,
+				UpdatedAt:     createdAt,
+			},
+			{
+				ID:            "propagation",
+				Kind:          engine.OutboxPropagation,
+				Txid:          txid,
+				Topics:        []string{"tm_test"},
+				Status:        engine.OutboxPending,
+				Attempts:      1,
+				NextAttemptAt: createdAt.Add(5 * time.Second),
+				LastError:     "no hosts",
+				CreatedAt:     createdAt,
+				UpdatedAt:     createdAt,
+			},
+		},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithListOutboxEntriesProvider(testabilities.NewListOutboxEntriesProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.ListOutboxEntries
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("txid", txid.String()).
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/outbox")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewListOutboxEntriesSuccessResponse([]app.OutboxEntryDTO{
+		{
+			ID:            "broadcast",
+			Kind:          "broadcast",
+			TxID:          txid.String(),
+			Status:        "completed",
+			Attempts:      1,
+			NextAttemptAt: createdAt,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		},
+		{
+			ID:            "propagation",
+			Kind:          "propagation",
+			TxID:          txid.String(),
+			Topics:        []string{"tm_test"},
+			Status:        "pending",
+			Attempts:      1,
+			NextAttemptAt: createdAt.Add(5 * time.Second),
+			LastError:     "no hosts",
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		},
+	}), actualResponse)
+	stub.AssertProvidersState()
+}
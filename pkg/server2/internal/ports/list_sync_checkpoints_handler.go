@@ -0,0 +1,54 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListSyncCheckpointsHandler is a Fiber-compatible HTTP handler that returns
+// the GASP sync checkpoints of the overlay engine. It acts as the adapter between
+// HTTP requests and the application-layer ListSyncCheckpointsService.
+type ListSyncCheckpointsHandler struct {
+	service *app.ListSyncCheckpointsService
+}
+
+// Handle processes an HTTP GET request listing the sync checkpoints, optionally
+// filtered by the topic query parameter.
+//
+// On success, returns 200 OK with the list of checkpoints.
+// On failure, returns an application error.
+func (h *ListSyncCheckpointsHandler) Handle(c *fiber.Ctx, params openapi.ListSyncCheckpointsParams) error {
+	var topic string
+	if params.Topic != nil {
+		topic = *params.Topic
+	}
+
+	checkpoints, err := h.service.ListSyncCheckpoints(c.UserContext(), topic)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewListSyncCheckpointsSuccessResponse(checkpoints))
+}
+
+// NewListSyncCheckpointsHandler creates a new ListSyncCheckpointsHandler with the given provider.
+// If the provider is nil, it panics.
+func NewListSyncCheckpointsHandler(provider app.ListSyncCheckpointsProvider) *ListSyncCheckpointsHandler {
+	return &ListSyncCheckpointsHandler{service: app.NewListSyncCheckpointsService(provider)}
+}
+
+// NewListSyncCheckpointsSuccessResponse converts the sync checkpoint DTOs into a
+// ListSyncCheckpointsResponse object compatible with the OpenAPI specification.
+func NewListSyncCheckpointsSuccessResponse(checkpoints []app.SyncCheckpointDTO) openapi.ListSyncCheckpointsResponse {
+	response := openapi.ListSyncCheckpointsResponse{Checkpoints: make([]openapi.SyncCheckpoint, 0, len(checkpoints))}
+	for _, checkpoint := range checkpoints {
+		response.Checkpoints = append(response.Checkpoints, openapi.SyncCheckpoint{
+			Topic:     checkpoint.Topic,
+			Peer:      checkpoint.Peer,
+			Since:     checkpoint.Since,
+			UpdatedAt: checkpoint.UpdatedAt,
+		})
+	}
+	return response
+}
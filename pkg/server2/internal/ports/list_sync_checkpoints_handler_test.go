@@ -0,0 +1,73 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSyncCheckpointsHandler_InvalidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	expectations := testabilities.ListSyncCheckpointsProviderMockExpectations{
+		ListSyncCheckpointsCall: true,
+		Error:                   testabilities.ErrTestNoopOpFailure,
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithListSyncCheckpointsProvider(testabilities.NewListSyncCheckpointsProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+	expectedResponse := testabilities.NewTestOpenapiErrorResponse(t, app.NewListSyncCheckpointsProviderError(testabilities.ErrTestNoopOpFailure))
+
+	// when:
+	var actualResponse openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetError(&actualResponse).
+		Get("/api/v1/admin/syncCheckpoints")
+
+	// then:
+	require.Equal(t, fiber.StatusInternalServerError, res.StatusCode())
+	require.Equal(t, expectedResponse, actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestListSyncCheckpointsHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	checkpoint := testabilities.DefaultSyncCheckpoint
+	expectations := testabilities.ListSyncCheckpointsProviderMockExpectations{
+		ListSyncCheckpointsCall: true,
+		Topic:                   checkpoint.Topic,
+		Checkpoints:             []*engine.SyncCheckpoint{checkpoint},
+	}
+	expectedResponse := openapi.ListSyncCheckpoints{Checkpoints: []openapi.SyncCheckpoint{{
+		Topic:     checkpoint.Topic,
+		Peer:      checkpoint.Peer,
+		Since:     checkpoint.Since,
+		UpdatedAt: checkpoint.UpdatedAt,
+	}}}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithListSyncCheckpointsProvider(testabilities.NewListSyncCheckpointsProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.ListSyncCheckpoints
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("topic", checkpoint.Topic).
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/syncCheckpoints")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, expectedResponse, actualResponse)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,72 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListWebhookDeliveriesHandler is a Fiber-compatible HTTP handler that lists the delivery log
+// of the webhooks of the overlay engine. It acts as the adapter between HTTP requests and the
+// application-layer ListWebhookDeliveriesService.
+type ListWebhookDeliveriesHandler struct {
+	service *app.ListWebhookDeliveriesService
+}
+
+// Handle processes an HTTP GET request listing the deliveries matching the optional webhookId
+// and status query parameters. The dead letters are listed with the dead status.
+//
+// On success, returns 200 OK with the deliveries ordered by the time they were queued.
+// On failure, returns an application error.
+func (h *ListWebhookDeliveriesHandler) Handle(c *fiber.Ctx, params openapi.ListWebhookDeliveriesParams) error {
+	var dto app.ListWebhookDeliveriesDTO
+	if params.WebhookId != nil {
+		dto.WebhookID = *params.WebhookId
+	}
+	if params.Status != nil {
+		dto.Status = string(*params.Status)
+	}
+
+	deliveries, err := h.service.ListWebhookDeliveries(c.UserContext(), dto)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewListWebhookDeliveriesSuccessResponse(deliveries))
+}
+
+// NewListWebhookDeliveriesHandler creates a new ListWebhookDeliveriesHandler with the given provider.
+// If the provider is nil, it panics.
+func NewListWebhookDeliveriesHandler(provider app.ListWebhookDeliveriesProvider) *ListWebhookDeliveriesHandler {
+	return &ListWebhookDeliveriesHandler{service: app.NewListWebhookDeliveriesService(provider)}
+}
+
+// NewListWebhookDeliveriesSuccessResponse converts the delivery DTOs into a
+// ListWebhookDeliveriesResponse object compatible with the OpenAPI specification.
+func NewListWebhookDeliveriesSuccessResponse(deliveries []app.WebhookDeliveryDTO) openapi.ListWebhookDeliveriesResponse {
+	response := openapi.ListWebhookDeliveriesResponse{Deliveries: make([]openapi.WebhookDelivery, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		item := openapi.WebhookDelivery{
+			Id:            delivery.ID,
+			WebhookId:     delivery.WebhookID,
+			Topic:         delivery.Topic,
+			EventType:     delivery.EventType,
+			Payload:       delivery.Payload,
+			Status:        delivery.Status,
+			Attempts:      delivery.Attempts,
+			NextAttemptAt: delivery.NextAttemptAt,
+			CreatedAt:     delivery.CreatedAt,
+			UpdatedAt:     delivery.UpdatedAt,
+		}
+		if delivery.LastStatusCode != 0 {
+			lastStatusCode := delivery.LastStatusCode
+			item.LastStatusCode = &lastStatusCode
+		}
+		if delivery.LastError != "" {
+			lastError := delivery.LastError
+			item.LastError = &lastError
+		}
+		response.Deliveries = append(response.Deliveries, item)
+	}
+	return response
+}
@@ -0,0 +1,96 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWebhookDeliveriesHandler_InvalidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	expectations := testabilities.ListWebhookDeliveriesProviderMockExpectations{
+		ListWebhookDeliveriesCall: true,
+		WebhookID:                 "id",
+		Error:                     testabilities.ErrTestNoopOpFailure,
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithListWebhookDeliveriesProvider(testabilities.NewListWebhookDeliveriesProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("webhookId", "id").
+		SetError(&actualResponse).
+		Get("/api/v1/admin/webhooks/deliveries")
+
+	// then:
+	require.Equal(t, fiber.StatusInternalServerError, res.StatusCode())
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, app.NewListWebhookDeliveriesProviderError(testabilities.ErrTestNoopOpFailure)), actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestListWebhookDeliveriesHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	expectations := testabilities.ListWebhookDeliveriesProviderMockExpectations{
+		ListWebhookDeliveriesCall: true,
+		Status:                    engine.WebhookDeliveryDead,
+		Deliveries: []*engine.WebhookDelivery{{
+			ID:             "delivery",
+			WebhookID:      "id",
+			Topic:          "tm_test",
+			EventType:      engine.EventOutputSpent,
+			Payload:        []byte(`{"type":"output-spent"}`),
+			Status:         engine.WebhookDeliveryDead,
+			Attempts:       8,
+			NextAttemptAt:  createdAt,
+			LastStatusCode: 503,
+			LastError:      "webhook responded with status code 503",
+			CreatedAt:      createdAt,
+			UpdatedAt:      createdAt.Add(time.Hour),
+		}},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithListWebhookDeliveriesProvider(testabilities.NewListWebhookDeliveriesProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.ListWebhookDeliveries
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("status", "dead").
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/webhooks/deliveries")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewListWebhookDeliveriesSuccessResponse([]app.WebhookDeliveryDTO{{
+		ID:             "delivery",
+		WebhookID:      "id",
+		Topic:          "tm_test",
+		EventType:      "output-spent",
+		Payload:        `{"type":"output-spent"}`,
+		Status:         "dead",
+		Attempts:       8,
+		NextAttemptAt:  createdAt,
+		LastStatusCode: 503,
+		LastError:      "webhook responded with status code 503",
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt.Add(time.Hour),
+	}}), actualResponse)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListWebhooksHandler is a Fiber-compatible HTTP handler that lists the webhooks of
+// the overlay engine. It acts as the adapter between HTTP requests and the
+// application-layer ListWebhooksService.
+type ListWebhooksHandler struct {
+	service *app.ListWebhooksService
+}
+
+// Handle processes an HTTP GET request listing the webhooks of the engine configuration
+// followed by the registered ones. Their secrets are never listed.
+//
+// On success, returns 200 OK with the webhooks.
+// On failure, returns an application error.
+func (h *ListWebhooksHandler) Handle(c *fiber.Ctx) error {
+	webhooks, err := h.service.ListWebhooks(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewListWebhooksSuccessResponse(webhooks))
+}
+
+// NewListWebhooksHandler creates a new ListWebhooksHandler with the given provider.
+// If the provider is nil, it panics.
+func NewListWebhooksHandler(provider app.ListWebhooksProvider) *ListWebhooksHandler {
+	return &ListWebhooksHandler{service: app.NewListWebhooksService(provider)}
+}
+
+// NewListWebhooksSuccessResponse converts the webhook DTOs into a
+// ListWebhooksResponse object compatible with the OpenAPI specification.
+func NewListWebhooksSuccessResponse(webhooks []app.WebhookDTO) openapi.ListWebhooksResponse {
+	response := openapi.ListWebhooksResponse{Webhooks: make([]openapi.Webhook, 0, len(webhooks))}
+	for _, webhook := range webhooks {
+		item := openapi.Webhook{
+			Id:    webhook.ID,
+			Topic: webhook.Topic,
+			Url:   webhook.URL,
+		}
+		if !webhook.CreatedAt.IsZero() {
+			createdAt := webhook.CreatedAt
+			item.CreatedAt = &createdAt
+		}
+		response.Webhooks = append(response.Webhooks, item)
+	}
+	return response
+}
@@ -0,0 +1,73 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWebhooksHandler_InvalidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	expectations := testabilities.ListWebhooksProviderMockExpectations{
+		ListWebhooksCall: true,
+		Error:            testabilities.ErrTestNoopOpFailure,
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithListWebhooksProvider(testabilities.NewListWebhooksProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetError(&actualResponse).
+		Get("/api/v1/admin/webhooks")
+
+	// then:
+	require.Equal(t, fiber.StatusInternalServerError, res.StatusCode())
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, app.NewListWebhooksProviderError(testabilities.ErrTestNoopOpFailure)), actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestListWebhooksHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	expectations := testabilities.ListWebhooksProviderMockExpectations{
+		ListWebhooksCall: true,
+		Webhooks: []*engine.Webhook{
+			{ID: "configured", Topic: "tm_test", URL: "https://example.com/configured", Secret: "secret"},
+			{ID: "registered", Topic: "tm_test", URL: "https://example.com/registered", Secret: "secret", CreatedAt: createdAt},
+		},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithListWebhooksProvider(testabilities.NewListWebhooksProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.ListWebhooks
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/webhooks")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewListWebhooksSuccessResponse([]app.WebhookDTO{
+		{ID: "configured", Topic: "tm_test", URL: "https://example.com/configured"},
+		{ID: "registered", Topic: "tm_test", URL: "https://example.com/registered", CreatedAt: createdAt},
+	}), actualResponse)
+	require.NotContains(t, string(res.Body()), "secret")
+	stub.AssertProvidersState()
+}
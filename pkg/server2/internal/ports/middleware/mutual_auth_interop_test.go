@@ -0,0 +1,195 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/mutualauth"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/middleware"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/auth"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/go-resty/resty/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// The go-sdk authhttp client cannot be used as is against any BRC-104 server in v1.2.1: its transport hands
+// the initial response to the peer within the call sending the initial request, on which the handshake of the
+// peer deadlocks. The interop test drives the go-sdk auth.Peer it is built on over a transport doing BRC-104.
+func TestMutualAuthMiddleware_GoSDKPeer(t *testing.T) {
+	// given:
+	peer := newMutualAuthPeer(t)
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		LookupQuestionCall: true,
+		Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "answer"},
+		IdentityKey:        peer.key.PubKey(),
+	})))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)))
+	peer.handshake(fixture.Client())
+
+	sessions := auth.NewSessionManager()
+	require.NoError(t, sessions.AddSession(&auth.PeerSession{
+		IsAuthenticated: true,
+		SessionNonce:    peer.initialNonce,
+		PeerNonce:       peer.sessionNonce,
+		PeerIdentityKey: peer.serverIdentityKey,
+	}))
+	sdkPeer := auth.NewPeer(&auth.PeerOptions{
+		Wallet:         sdkPeerWallet{ProtoWallet: peer.wallet},
+		Transport:      &sdkPeerTransport{t: t, client: fixture.Client()},
+		SessionManager: sessions,
+	})
+	var response []byte
+	sdkPeer.ListenForGeneralMessages(func(_ *ec.PublicKey, payload []byte) error {
+		response = payload
+		return nil
+	})
+
+	requestID := make([]byte, 32)
+	_, err := rand.Read(requestID)
+	require.NoError(t, err)
+	request := util.NewWriter()
+	request.WriteBytes(requestID)
+	request.WriteString(fiber.MethodPost)
+	request.WriteString("/api/v1/lookup")
+	request.WriteNegativeOne()
+	request.WriteVarInt(1)
+	request.WriteString("content-type")
+	request.WriteString(fiber.MIMEApplicationJSON)
+	request.WriteVarInt(uint64(len(lookupBody)))
+	request.WriteBytes([]byte(lookupBody))
+
+	// when:
+	err = sdkPeer.ToPeer(context.Background(), request.Buf, peer.serverIdentityKey, 5000)
+
+	// then:
+	require.NoError(t, err)
+	require.NotNil(t, response, "The go-sdk peer did not accept the signed response")
+	reader := util.NewReader(response)
+	responseID, err := reader.ReadBytes(32)
+	require.NoError(t, err)
+	require.Equal(t, requestID, responseID)
+	status, err := reader.ReadVarInt()
+	require.NoError(t, err)
+	require.Equal(t, uint64(fiber.StatusOK), status)
+	stub.AssertProvidersState()
+}
+
+func TestMutualAuthMiddleware_MutualAuthTransport(t *testing.T) {
+	// given:
+	peer := newMutualAuthPeer(t)
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		LookupQuestionCall: true,
+		Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "answer"},
+		IdentityKey:        peer.key.PubKey(),
+	})))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)))
+	client := &http.Client{Transport: mutualauth.NewTransport(peer.wallet, fixture.RoundTripper())}
+
+	// when:
+	res, err := client.Post("http://overlay.test/api/v1/lookup", fiber.MIMEApplicationJSON, strings.NewReader(lookupBody))
+
+	// then:
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+	require.Equal(t, fiber.StatusOK, res.StatusCode)
+	require.NotEmpty(t, res.Header.Get(middleware.AuthSignatureHeader))
+	stub.AssertProvidersState()
+}
+
+// sdkPeerWallet completes the wallet of the test peer into the wallet.Interface required by the go-sdk peer,
+// which only uses its key operations.
+type sdkPeerWallet struct {
+	*wallet.ProtoWallet
+	unsupportedWallet
+}
+
+type unsupportedWallet struct{ wallet.Interface }
+
+// sdkPeerTransport carries the general messages of a go-sdk peer to the overlay node as BRC-104 requests,
+// the request being serialized in their payload, and hands the signed responses back to the peer.
+type sdkPeerTransport struct {
+	t      *testing.T
+	client *resty.Client
+	onData func(context.Context, *auth.AuthMessage) error
+}
+
+func (tr *sdkPeerTransport) OnData(callback func(context.Context, *auth.AuthMessage) error) error {
+	tr.onData = callback
+	return nil
+}
+
+func (tr *sdkPeerTransport) GetRegisteredOnData() (func(context.Context, *auth.AuthMessage) error, error) {
+	return tr.onData, nil
+}
+
+func (tr *sdkPeerTransport) Send(ctx context.Context, message *auth.AuthMessage) error {
+	tr.t.Helper()
+	require.Equal(tr.t, auth.MessageTypeGeneral, message.MessageType, "Unexpected message of the go-sdk peer")
+
+	payload := util.NewReader(message.Payload)
+	requestID, err := payload.ReadBytes(32)
+	require.NoError(tr.t, err)
+	method, err := payload.ReadString()
+	require.NoError(tr.t, err)
+	path, err := payload.ReadString()
+	require.NoError(tr.t, err)
+	_, err = payload.ReadString()
+	require.NoError(tr.t, err)
+	count, err := payload.ReadVarInt()
+	require.NoError(tr.t, err)
+	req := tr.client.R()
+	for range count {
+		key, err := payload.ReadString()
+		require.NoError(tr.t, err)
+		value, err := payload.ReadString()
+		require.NoError(tr.t, err)
+		req.SetHeader(key, value)
+	}
+	body, err := payload.ReadOptionalBytes()
+	require.NoError(tr.t, err)
+
+	res, err := req.
+		SetHeaders(map[string]string{
+			middleware.AuthVersionHeader:     message.Version,
+			middleware.AuthIdentityKeyHeader: message.IdentityKey.ToDERHex(),
+			middleware.AuthNonceHeader:       message.Nonce,
+			middleware.AuthYourNonceHeader:   message.YourNonce,
+			middleware.AuthSignatureHeader:   hex.EncodeToString(message.Signature),
+			middleware.AuthRequestIDHeader:   base64.StdEncoding.EncodeToString(requestID),
+		}).
+		SetBody(body).
+		Execute(method, path)
+	require.NoError(tr.t, err)
+
+	identityKey, err := ec.PublicKeyFromString(res.Header().Get(middleware.AuthIdentityKeyHeader))
+	require.NoError(tr.t, err)
+	signature, err := hex.DecodeString(res.Header().Get(middleware.AuthSignatureHeader))
+	require.NoError(tr.t, err)
+	response := util.NewWriter()
+	response.WriteBytes(requestID)
+	response.WriteVarInt(uint64(res.StatusCode()))
+	response.WriteVarInt(0)
+	response.WriteVarInt(uint64(len(res.Body())))
+	response.WriteBytes(res.Body())
+
+	return tr.onData(ctx, &auth.AuthMessage{
+		Version:     res.Header().Get(middleware.AuthVersionHeader),
+		MessageType: auth.MessageTypeGeneral,
+		IdentityKey: identityKey,
+		Nonce:       res.Header().Get(middleware.AuthNonceHeader),
+		YourNonce:   res.Header().Get(middleware.AuthYourNonceHeader),
+		Payload:     response.Buf,
+		Signature:   signature,
+	})
+}
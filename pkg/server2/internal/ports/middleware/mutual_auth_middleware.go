@@ -0,0 +1,601 @@
+package middleware
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/bsv-blockchain/go-sdk/auth"
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The headers carrying the BRC-104 mutual authentication of the general requests and responses.
+const (
+	AuthVersionHeader     = "x-bsv-auth-version"
+	AuthIdentityKeyHeader = "x-bsv-auth-identity-key"
+	AuthNonceHeader       = "x-bsv-auth-nonce"
+	AuthYourNonceHeader   = "x-bsv-auth-your-nonce"
+	AuthSignatureHeader   = "x-bsv-auth-signature"
+	AuthRequestIDHeader   = "x-bsv-auth-request-id"
+)
+
+// WellKnownAuthPath is the path of the BRC-104 handshake endpoint, receiving the initial requests of the peers.
+const WellKnownAuthPath = "/.well-known/auth"
+
+// DefaultMutualAuthSessionTimeout is the idle time after which a peer must repeat the handshake.
+const DefaultMutualAuthSessionTimeout = time.Hour
+
+// DefaultMutualAuthMaxSessions is the number of sessions kept at most, the least recently used session
+// is dropped to make room for a new one.
+const DefaultMutualAuthMaxSessions = 10_000
+
+// DefaultMutualAuthMaxSessionsPerIdentity is the number of sessions kept at most per identity key of the
+// peers, the least recently used session of the identity is dropped to make room for a new one.
+const DefaultMutualAuthMaxSessionsPerIdentity = 16
+
+// DefaultMutualAuthMaxPendingSessions is the number of sessions awaiting their first signed request kept at
+// most, the oldest pending session is dropped to make room for a new one.
+const DefaultMutualAuthMaxPendingSessions = 1_000
+
+// mutualAuthMaxRequestsPerSession is the number of requests a session serves at most, as the request IDs and
+// nonces it used are kept to reject replayed requests. The peer repeats the handshake afterwards.
+const mutualAuthMaxRequestsPerSession = 10_000
+
+// mutualAuthOriginator is the originator of the wallet operations of the middleware.
+const mutualAuthOriginator = "overlay-services"
+
+// mutualAuthRequestIDLength is the length of the request ID prefixing the signed request and response payloads.
+const mutualAuthRequestIDLength = 32
+
+// MutualAuthMiddlewareConfig defines configuration options of the mutual authentication middleware.
+type MutualAuthMiddlewareConfig struct {
+	Wallet                 wallet.KeyOperations    // Wallet holding the identity key of the overlay node.
+	AllowUnauthenticated   bool                    // Let the requests without authentication headers through anonymously.
+	SessionTimeout         time.Duration           // Idle time after which a session expires, DefaultMutualAuthSessionTimeout when zero.
+	MaxSessions            int                     // Sessions kept at most, DefaultMutualAuthMaxSessions when zero.
+	MaxSessionsPerIdentity int                     // Sessions kept at most per identity, DefaultMutualAuthMaxSessionsPerIdentity when zero.
+	MaxPendingSessions     int                     // Sessions awaiting their first signed request kept at most, DefaultMutualAuthMaxPendingSessions when zero.
+	Next                   func(c *fiber.Ctx) bool // Skips the middleware for the requests it returns true for.
+}
+
+// MutualAuthMiddleware returns a fiber.Handler implementing the BRC-103 peer-to-peer mutual authentication
+// over HTTP as specified by BRC-104.
+//
+// Peers establish a session by sending their initial request to WellKnownAuthPath, answered with the
+// signed initial response of the overlay node. The session stays pending, apart from the limits of the
+// established sessions, until its first signed request is verified. The general requests of the session carry the identity key
+// of the peer and a signature over the method, path, query, signed headers and body of the request.
+// Once verified, the identity key of the peer is carried by the user context of the request, see
+// engine.IdentityKeyFromContext, and the response is signed by the overlay node in return. Streamed
+// responses are not signed. Requests reusing a request ID or a nonce already used in the session are
+// rejected as replayed.
+//
+// Requests without authentication headers are rejected, unless AllowUnauthenticated is set in which case
+// they are handled anonymously. Requesting certificates is not supported.
+// It panics if the wallet is nil or unable to provide its identity key.
+func MutualAuthMiddleware(cfg MutualAuthMiddlewareConfig) fiber.Handler {
+	if cfg.Wallet == nil {
+		panic("mutual auth middleware requires a wallet")
+	}
+	if cfg.SessionTimeout <= 0 {
+		cfg.SessionTimeout = DefaultMutualAuthSessionTimeout
+	}
+	if cfg.MaxSessions <= 0 {
+		cfg.MaxSessions = DefaultMutualAuthMaxSessions
+	}
+	if cfg.MaxSessionsPerIdentity <= 0 {
+		cfg.MaxSessionsPerIdentity = DefaultMutualAuthMaxSessionsPerIdentity
+	}
+	if cfg.MaxPendingSessions <= 0 {
+		cfg.MaxPendingSessions = DefaultMutualAuthMaxPendingSessions
+	}
+
+	identity, err := cfg.Wallet.GetPublicKey(context.Background(), wallet.GetPublicKeyArgs{IdentityKey: true}, mutualAuthOriginator)
+	if err != nil {
+		panic(fmt.Sprintf("mutual auth middleware failed to get the identity key of the wallet: %v", err))
+	}
+
+	m := &mutualAuth{
+		cfg:         cfg,
+		identityKey: identity.PublicKey,
+		sessions:    newMutualAuthSessions(cfg.SessionTimeout, cfg.MaxSessions, cfg.MaxSessionsPerIdentity, cfg.MaxPendingSessions),
+	}
+
+	return func(c *fiber.Ctx) error {
+		if c.Path() == WellKnownAuthPath && c.Method() == fiber.MethodPost {
+			return m.handshake(c)
+		}
+		if cfg.Next != nil && cfg.Next(c) {
+			return c.Next()
+		}
+		if c.Get(AuthIdentityKeyHeader) == "" {
+			if cfg.AllowUnauthenticated {
+				return c.Next()
+			}
+			return NewMissingMutualAuthHeadersError()
+		}
+
+		request, err := m.authenticate(c)
+		if err != nil {
+			return err
+		}
+		c.SetUserContext(engine.WithIdentityKey(c.UserContext(), request.session.identityKey))
+
+		// The error of the handler is turned into its response here, like the logger middleware does,
+		// so the error responses are signed as well.
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				return err
+			}
+		}
+		if c.Response().IsBodyStream() {
+			return nil
+		}
+		return m.signResponse(c, request)
+	}
+}
+
+// mutualAuth holds the identity key and the sessions of the mutual authentication middleware.
+type mutualAuth struct {
+	cfg         MutualAuthMiddlewareConfig
+	identityKey *ec.PublicKey
+	sessions    *mutualAuthSessions
+}
+
+// mutualAuthRequest is a general request authenticated within a session.
+type mutualAuthRequest struct {
+	id      []byte
+	session mutualAuthSession
+}
+
+// handshake answers the initial request of a peer with the signed initial response of the overlay node
+// and keeps the session of the peer pending until its first signed request.
+func (m *mutualAuth) handshake(c *fiber.Ctx) error {
+	var message auth.AuthMessage
+	if err := json.Unmarshal(c.Body(), &message); err != nil {
+		return NewInvalidMutualAuthHandshakeError(err)
+	}
+	if message.Version != auth.AUTH_VERSION {
+		return NewInvalidMutualAuthHandshakeError(fmt.Errorf("unsupported auth version %q", message.Version))
+	}
+	if message.MessageType != auth.MessageTypeInitialRequest {
+		return NewInvalidMutualAuthHandshakeError(fmt.Errorf("unsupported message type %q", message.MessageType))
+	}
+	if message.IdentityKey == nil || message.InitialNonce == "" {
+		return NewInvalidMutualAuthHandshakeError(errors.New("identity key and initial nonce are required"))
+	}
+	initialNonce, err := base64.StdEncoding.DecodeString(message.InitialNonce)
+	if err != nil {
+		return NewInvalidMutualAuthHandshakeError(fmt.Errorf("initial nonce is not base64: %w", err))
+	}
+
+	ctx := c.UserContext()
+	sessionNonce, err := utils.CreateNonce(ctx, m.cfg.Wallet, wallet.Counterparty{Type: wallet.CounterpartyTypeSelf})
+	if err != nil {
+		return NewMutualAuthSigningError(err)
+	}
+	sessionNonceBytes, err := base64.StdEncoding.DecodeString(sessionNonce)
+	if err != nil {
+		return NewMutualAuthSigningError(err)
+	}
+
+	signature, err := m.sign(ctx, message.IdentityKey, message.InitialNonce, sessionNonce, append(initialNonce, sessionNonceBytes...))
+	if err != nil {
+		return NewMutualAuthSigningError(err)
+	}
+
+	m.sessions.addPending(sessionNonce, mutualAuthSession{identityKey: message.IdentityKey, peerNonce: message.InitialNonce})
+
+	return c.Status(fiber.StatusOK).JSON(auth.AuthMessage{
+		Version:      auth.AUTH_VERSION,
+		MessageType:  auth.MessageTypeInitialResponse,
+		IdentityKey:  m.identityKey,
+		Nonce:        sessionNonce,
+		InitialNonce: message.InitialNonce,
+		YourNonce:    message.InitialNonce,
+		Signature:    signature,
+	})
+}
+
+// authenticate verifies the signature of a general request against the session it belongs to, and records
+// its request ID and nonce in the session.
+func (m *mutualAuth) authenticate(c *fiber.Ctx) (mutualAuthRequest, error) {
+	if version := c.Get(AuthVersionHeader); version != auth.AUTH_VERSION {
+		return mutualAuthRequest{}, NewInvalidMutualAuthRequestError(fmt.Errorf("unsupported auth version %q", version))
+	}
+	identityKey, err := ec.PublicKeyFromString(c.Get(AuthIdentityKeyHeader))
+	if err != nil {
+		return mutualAuthRequest{}, NewInvalidMutualAuthRequestError(fmt.Errorf("invalid identity key: %w", err))
+	}
+	requestID, err := base64.StdEncoding.DecodeString(c.Get(AuthRequestIDHeader))
+	if err != nil || len(requestID) != mutualAuthRequestIDLength {
+		return mutualAuthRequest{}, NewInvalidMutualAuthRequestError(errors.New("invalid request ID"))
+	}
+	if c.Get(AuthNonceHeader) == "" {
+		return mutualAuthRequest{}, NewInvalidMutualAuthRequestError(errors.New("nonce is required"))
+	}
+	signatureBytes, err := hex.DecodeString(c.Get(AuthSignatureHeader))
+	if err != nil {
+		return mutualAuthRequest{}, NewInvalidMutualAuthRequestError(fmt.Errorf("invalid signature: %w", err))
+	}
+	signature, err := ec.ParseSignature(signatureBytes)
+	if err != nil {
+		return mutualAuthRequest{}, NewInvalidMutualAuthRequestError(fmt.Errorf("invalid signature: %w", err))
+	}
+
+	session, ok := m.sessions.get(c.Get(AuthYourNonceHeader))
+	if !ok || !session.identityKey.IsEqual(identityKey) {
+		return mutualAuthRequest{}, NewMutualAuthSessionNotFoundError()
+	}
+
+	result, err := m.cfg.Wallet.VerifySignature(c.UserContext(), wallet.VerifySignatureArgs{
+		EncryptionArgs: m.encryptionArgs(identityKey, c.Get(AuthNonceHeader), c.Get(AuthYourNonceHeader)),
+		Data:           serializeMutualAuthRequest(c, requestID),
+		Signature:      signature,
+	}, mutualAuthOriginator)
+	if err != nil || !result.Valid {
+		return mutualAuthRequest{}, NewInvalidMutualAuthSignatureError()
+	}
+	if err := m.sessions.use(c.Get(AuthYourNonceHeader), c.Get(AuthRequestIDHeader), c.Get(AuthNonceHeader)); err != nil {
+		return mutualAuthRequest{}, err
+	}
+	return mutualAuthRequest{id: requestID, session: session}, nil
+}
+
+// signResponse signs the response of a general request and attaches the signature to its headers.
+func (m *mutualAuth) signResponse(c *fiber.Ctx, request mutualAuthRequest) error {
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return NewMutualAuthSigningError(err)
+	}
+	responseNonce := base64.StdEncoding.EncodeToString(nonce)
+
+	signature, err := m.sign(c.UserContext(), request.session.identityKey, responseNonce, request.session.peerNonce, serializeMutualAuthResponse(c, request.id))
+	if err != nil {
+		return NewMutualAuthSigningError(err)
+	}
+
+	c.Set(AuthVersionHeader, auth.AUTH_VERSION)
+	c.Set(AuthIdentityKeyHeader, m.identityKey.ToDERHex())
+	c.Set(AuthNonceHeader, responseNonce)
+	c.Set(AuthYourNonceHeader, request.session.peerNonce)
+	c.Set(AuthSignatureHeader, hex.EncodeToString(signature))
+	c.Set(AuthRequestIDHeader, base64.StdEncoding.EncodeToString(request.id))
+	return nil
+}
+
+// sign signs the data for the peer with the key derived from the given nonces.
+func (m *mutualAuth) sign(ctx context.Context, peer *ec.PublicKey, nonce, peerNonce string, data []byte) ([]byte, error) {
+	result, err := m.cfg.Wallet.CreateSignature(ctx, wallet.CreateSignatureArgs{
+		EncryptionArgs: m.encryptionArgs(peer, nonce, peerNonce),
+		Data:           data,
+	}, mutualAuthOriginator)
+	if err != nil {
+		return nil, err
+	}
+	return result.Signature.Serialize(), nil
+}
+
+// encryptionArgs returns the arguments deriving the signing key of a message exchanged with the peer.
+func (m *mutualAuth) encryptionArgs(peer *ec.PublicKey, nonce, peerNonce string) wallet.EncryptionArgs {
+	return wallet.EncryptionArgs{
+		ProtocolID: wallet.Protocol{
+			SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty,
+			Protocol:      auth.AUTH_PROTOCOL_ID,
+		},
+		KeyID:        nonce + " " + peerNonce,
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: peer},
+	}
+}
+
+// serializeMutualAuthRequest serializes the signed parts of a general request: its ID, method, path, query,
+// the x-bsv-* (other than x-bsv-auth-*), authorization and content-type headers, and its body.
+func serializeMutualAuthRequest(c *fiber.Ctx, requestID []byte) []byte {
+	w := util.NewWriter()
+	w.WriteBytes(requestID)
+	w.WriteString(c.Method())
+	writeOptionalString(w, string(c.Request().URI().PathOriginal()))
+	if query := string(c.Request().URI().QueryString()); query != "" {
+		w.WriteString("?" + query)
+	} else {
+		w.WriteNegativeOne()
+	}
+
+	var headers [][2]string
+	c.Request().Header.VisitAll(func(k, v []byte) {
+		key := strings.ToLower(string(k))
+		switch {
+		case strings.HasPrefix(key, "x-bsv-auth"):
+		case strings.HasPrefix(key, "x-bsv-"), key == "authorization":
+			headers = append(headers, [2]string{key, string(v)})
+		case key == "content-type":
+			headers = append(headers, [2]string{key, strings.TrimSpace(strings.Split(string(v), ";")[0])})
+		}
+	})
+	writeHeaders(w, headers)
+	writeOptionalBytes(w, c.Request().Body())
+	return w.Buf
+}
+
+// serializeMutualAuthResponse serializes the signed parts of the response of a general request: the request ID,
+// its status, the x-bsv-* (other than x-bsv-auth-*) and authorization headers, and its body.
+func serializeMutualAuthResponse(c *fiber.Ctx, requestID []byte) []byte {
+	w := util.NewWriter()
+	w.WriteBytes(requestID)
+	w.WriteVarInt(uint64(c.Response().StatusCode()))
+
+	var headers [][2]string
+	c.Response().Header.VisitAll(func(k, v []byte) {
+		key := strings.ToLower(string(k))
+		if (strings.HasPrefix(key, "x-bsv-") && !strings.HasPrefix(key, "x-bsv-auth")) || key == "authorization" {
+			headers = append(headers, [2]string{key, string(v)})
+		}
+	})
+	writeHeaders(w, headers)
+	writeOptionalBytes(w, c.Response().Body())
+	return w.Buf
+}
+
+// writeHeaders writes the headers sorted by key, so both peers serialize them in the same order.
+func writeHeaders(w *util.Writer, headers [][2]string) {
+	sort.Slice(headers, func(i, j int) bool { return headers[i][0] < headers[j][0] })
+	w.WriteVarInt(uint64(len(headers)))
+	for _, header := range headers {
+		w.WriteString(header[0])
+		w.WriteString(header[1])
+	}
+}
+
+// writeOptionalString writes the string, or -1 when it is empty.
+func writeOptionalString(w *util.Writer, s string) {
+	if s == "" {
+		w.WriteNegativeOne()
+		return
+	}
+	w.WriteString(s)
+}
+
+// writeOptionalBytes writes the length of the bytes followed by the bytes, or -1 when they are empty.
+func writeOptionalBytes(w *util.Writer, b []byte) {
+	if len(b) == 0 {
+		w.WriteNegativeOne()
+		return
+	}
+	w.WriteVarInt(uint64(len(b)))
+	w.WriteBytes(b)
+}
+
+// mutualAuthSession is the session established by the handshake of a peer.
+type mutualAuthSession struct {
+	identityKey *ec.PublicKey
+	peerNonce   string
+	lastUsed    time.Time
+}
+
+// mutualAuthSessions holds the sessions of the peers by the session nonce of the overlay node. The sessions
+// awaiting their first signed request are pending, from the newest to the oldest, and the oldest is dropped
+// once there are too many of them. The established sessions are held from the most to the least recently
+// used, and the least recently used are dropped once there are too many of them in total or for an
+// identity key. The sessions idle for longer than the timeout expire.
+type mutualAuthSessions struct {
+	mu          sync.Mutex
+	timeout     time.Duration
+	max         int
+	maxIdentity int
+	maxPending  int
+	lru         *list.List
+	pending     *list.List
+	sessions    map[string]*list.Element
+	identities  map[string]int
+}
+
+// mutualAuthSessionEntry is a session held by mutualAuthSessions, with the request IDs and nonces used in it.
+type mutualAuthSessionEntry struct {
+	nonce      string
+	identity   string
+	session    mutualAuthSession
+	pending    bool
+	requestIDs map[string]struct{}
+	nonces     map[string]struct{}
+}
+
+func newMutualAuthSessions(timeout time.Duration, maxSessions, maxSessionsPerIdentity, maxPendingSessions int) *mutualAuthSessions {
+	return &mutualAuthSessions{
+		timeout:     timeout,
+		max:         maxSessions,
+		maxIdentity: maxSessionsPerIdentity,
+		maxPending:  maxPendingSessions,
+		lru:         list.New(),
+		pending:     list.New(),
+		sessions:    make(map[string]*list.Element),
+		identities:  make(map[string]int),
+	}
+}
+
+// addPending stores the session as pending, after dropping the expired sessions and the oldest pending
+// session when there are too many of them.
+func (s *mutualAuthSessions) addPending(nonce string, session mutualAuthSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.removeExpired(s.lru, now)
+	s.removeExpired(s.pending, now)
+
+	if s.pending.Len() >= s.maxPending {
+		s.remove(s.pending.Back())
+	}
+	if e, ok := s.sessions[nonce]; ok {
+		s.remove(e)
+	}
+
+	session.lastUsed = now
+	s.sessions[nonce] = s.pending.PushFront(&mutualAuthSessionEntry{
+		nonce:      nonce,
+		identity:   session.identityKey.ToDERHex(),
+		session:    session,
+		pending:    true,
+		requestIDs: make(map[string]struct{}),
+		nonces:     make(map[string]struct{}),
+	})
+}
+
+// get returns the session of the nonce and refreshes its last use unless it is pending, false when it is
+// unknown or expired.
+func (s *mutualAuthSessions) get(nonce string) (mutualAuthSession, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.sessions[nonce]
+	if !ok {
+		return mutualAuthSession{}, false
+	}
+	entry := e.Value.(*mutualAuthSessionEntry)
+	now := time.Now()
+	if now.Sub(entry.session.lastUsed) > s.timeout {
+		s.remove(e)
+		return mutualAuthSession{}, false
+	}
+	if !entry.pending {
+		entry.session.lastUsed = now
+		s.lru.MoveToFront(e)
+	}
+	return entry.session, true
+}
+
+// use records the request ID and nonce of a verified request of the session, and establishes the session
+// when it is pending, after dropping the sessions exceeding the limits. It returns an error when the session
+// is gone or when the request ID or the nonce was already used in it.
+func (s *mutualAuthSessions) use(nonce, requestID, requestNonce string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.sessions[nonce]
+	if !ok {
+		return NewMutualAuthSessionNotFoundError()
+	}
+	entry := e.Value.(*mutualAuthSessionEntry)
+	if _, ok := entry.requestIDs[requestID]; ok {
+		return NewReplayedMutualAuthRequestError()
+	}
+	if _, ok := entry.nonces[requestNonce]; ok {
+		return NewReplayedMutualAuthRequestError()
+	}
+	if len(entry.requestIDs) >= mutualAuthMaxRequestsPerSession {
+		s.remove(e)
+		return NewMutualAuthSessionNotFoundError()
+	}
+	entry.requestIDs[requestID] = struct{}{}
+	entry.nonces[requestNonce] = struct{}{}
+
+	if entry.pending {
+		s.establish(e)
+	}
+	return nil
+}
+
+// establish moves the pending session of the element to the established sessions, after dropping the
+// sessions exceeding the limits.
+func (s *mutualAuthSessions) establish(e *list.Element) {
+	entry := s.pending.Remove(e).(*mutualAuthSessionEntry)
+	delete(s.sessions, entry.nonce)
+
+	s.removeExpired(s.lru, time.Now())
+	if s.identities[entry.identity] >= s.maxIdentity {
+		for e := s.lru.Back(); e != nil; e = e.Prev() {
+			if e.Value.(*mutualAuthSessionEntry).identity == entry.identity {
+				s.remove(e)
+				break
+			}
+		}
+	}
+	if s.lru.Len() >= s.max {
+		s.remove(s.lru.Back())
+	}
+
+	entry.pending = false
+	s.sessions[entry.nonce] = s.lru.PushFront(entry)
+	s.identities[entry.identity]++
+}
+
+// removeExpired drops the sessions of the list idle for longer than the timeout.
+func (s *mutualAuthSessions) removeExpired(sessions *list.List, now time.Time) {
+	for back := sessions.Back(); back != nil && now.Sub(back.Value.(*mutualAuthSessionEntry).session.lastUsed) > s.timeout; back = sessions.Back() {
+		s.remove(back)
+	}
+}
+
+// remove drops the session of the element.
+func (s *mutualAuthSessions) remove(e *list.Element) {
+	if e.Value.(*mutualAuthSessionEntry).pending {
+		delete(s.sessions, s.pending.Remove(e).(*mutualAuthSessionEntry).nonce)
+		return
+	}
+	entry := s.lru.Remove(e).(*mutualAuthSessionEntry)
+	delete(s.sessions, entry.nonce)
+	s.identities[entry.identity]--
+	if s.identities[entry.identity] == 0 {
+		delete(s.identities, entry.identity)
+	}
+}
+
+// NewMissingMutualAuthHeadersError returns an app.Error indicating that the request
+// does not carry the mutual authentication headers.
+func NewMissingMutualAuthHeadersError() app.Error {
+	const str = "Unauthorized access: Missing mutual authentication headers in the request"
+	return app.NewAuthorizationError(str, str)
+}
+
+// NewInvalidMutualAuthRequestError returns an app.Error indicating that the mutual
+// authentication headers of the request are malformed.
+func NewInvalidMutualAuthRequestError(err error) app.Error {
+	return app.NewAuthorizationError(err.Error(), "Unauthorized access: Invalid mutual authentication headers in the request")
+}
+
+// NewMutualAuthSessionNotFoundError returns an app.Error indicating that the request does not
+// belong to an established session, the peer has to repeat the handshake.
+func NewMutualAuthSessionNotFoundError() app.Error {
+	const str = "Unauthorized access: Mutual authentication session not found, please repeat the handshake"
+	return app.NewAuthorizationError(str, str)
+}
+
+// NewInvalidMutualAuthSignatureError returns an app.Error indicating that the signature
+// of the request does not match the identity key of the peer.
+func NewInvalidMutualAuthSignatureError() app.Error {
+	const str = "Unauthorized access: Invalid mutual authentication signature"
+	return app.NewAuthorizationError(str, str)
+}
+
+// NewReplayedMutualAuthRequestError returns an app.Error indicating that the request reuses
+// a request ID or a nonce already used in its session.
+func NewReplayedMutualAuthRequestError() app.Error {
+	const str = "Unauthorized access: Mutual authentication request already used in the session"
+	return app.NewAuthorizationError(str, str)
+}
+
+// NewInvalidMutualAuthHandshakeError returns an app.Error indicating that the initial
+// request of the handshake is malformed.
+func NewInvalidMutualAuthHandshakeError(err error) app.Error {
+	return app.NewIncorrectInputError(err.Error(), "Unable to process the mutual authentication handshake. Please verify the initial request and try again.")
+}
+
+// NewMutualAuthSigningError returns an app.Error indicating that the overlay node failed
+// to sign a message of the mutual authentication.
+func NewMutualAuthSigningError(err error) app.Error {
+	return app.NewProviderFailureError(err.Error(), "Unable to sign the mutual authentication message. Please try again later.")
+}
@@ -0,0 +1,471 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/middleware"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/auth"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/go-resty/resty/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const lookupBody = `{"service":"test-service","query":{"test":"query"}}`
+
+func TestMutualAuthMiddleware_ValidCase(t *testing.T) {
+	// given:
+	peer := newMutualAuthPeer(t)
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		LookupQuestionCall: true,
+		Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "answer"},
+		IdentityKey:        peer.key.PubKey(),
+	})))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)))
+	peer.handshake(fixture.Client())
+
+	// when:
+	headers, requestID := peer.sign(fiber.MethodPost, "/api/v1/lookup", lookupBody)
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(headers).
+		SetBody(lookupBody).
+		Post("/api/v1/lookup")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	peer.verifyResponse(res, requestID)
+	stub.AssertProvidersState()
+}
+
+func TestMutualAuthMiddleware_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		headers        func(peer *mutualAuthPeer) map[string]string
+		expectedStatus int
+	}{
+		"Request without mutual authentication headers": {
+			headers: func(*mutualAuthPeer) map[string]string {
+				return map[string]string{fiber.HeaderContentType: fiber.MIMEApplicationJSON}
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		"Request signed over a different body": {
+			headers: func(peer *mutualAuthPeer) map[string]string {
+				headers, _ := peer.sign(fiber.MethodPost, "/api/v1/lookup", `{"service":"other-service"}`)
+				return headers
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		"Request signed over a different path": {
+			headers: func(peer *mutualAuthPeer) map[string]string {
+				headers, _ := peer.sign(fiber.MethodPost, "/api/v1/submit", lookupBody)
+				return headers
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		"Request of an unknown session": {
+			headers: func(peer *mutualAuthPeer) map[string]string {
+				headers, _ := peer.sign(fiber.MethodPost, "/api/v1/lookup", lookupBody)
+				headers[middleware.AuthYourNonceHeader] = base64.StdEncoding.EncodeToString(make([]byte, 48))
+				return headers
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		"Request with a malformed identity key": {
+			headers: func(peer *mutualAuthPeer) map[string]string {
+				headers, _ := peer.sign(fiber.MethodPost, "/api/v1/lookup", lookupBody)
+				headers[middleware.AuthIdentityKeyHeader] = "invalid"
+				return headers
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			peer := newMutualAuthPeer(t)
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+				LookupQuestionCall: false,
+			})))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)))
+			peer.handshake(fixture.Client())
+
+			// when:
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(tc.headers(peer)).
+				SetBody(lookupBody).
+				Post("/api/v1/lookup")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestMutualAuthMiddleware_AllowUnauthenticated(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		LookupQuestionCall: true,
+		Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "answer"},
+	})))
+	fixture := server2.NewServerTestFixture(t,
+		server2.WithEngine(stub),
+		server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)),
+		server2.WithMutualAuthAllowUnauthenticated(true),
+	)
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+		SetBody(lookupBody).
+		Post("/api/v1/lookup")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Empty(t, res.Header().Get(middleware.AuthSignatureHeader))
+	stub.AssertProvidersState()
+}
+
+func TestMutualAuthMiddleware_AdminEndpointsAreExempt(t *testing.T) {
+	// given:
+	const bearerToken = "valid_admin_token"
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSyncAdvertisementsProvider(testabilities.NewSyncAdvertisementsProviderMock(t, testabilities.SyncAdvertisementsProviderMockExpectations{
+		SyncAdvertisementsCall: true,
+	})))
+	fixture := server2.NewServerTestFixture(t,
+		server2.WithEngine(stub),
+		server2.WithAdminBearerToken(bearerToken),
+		server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)),
+	)
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+bearerToken).
+		Post("/api/v1/admin/syncAdvertisements")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	stub.AssertProvidersState()
+}
+
+func TestMutualAuthMiddleware_InvalidHandshake(t *testing.T) {
+	// given:
+	fixture := server2.NewServerTestFixture(t, server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)))
+	peer := newMutualAuthPeer(t)
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+		SetBody(auth.AuthMessage{
+			Version:     auth.AUTH_VERSION,
+			MessageType: auth.MessageTypeGeneral,
+			IdentityKey: peer.key.PubKey(),
+		}).
+		Post(middleware.WellKnownAuthPath)
+
+	// then:
+	require.Equal(t, fiber.StatusBadRequest, res.StatusCode())
+}
+
+func TestMutualAuthMiddleware_SessionLimits(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		LookupQuestionCall: true,
+		Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "answer"},
+	})))
+	fixture := server2.NewServerTestFixture(t,
+		server2.WithEngine(stub),
+		server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)),
+		server2.WithMutualAuthMaxSessions(3, 2),
+	)
+	lookupStatus := func(peer *mutualAuthPeer) int {
+		headers, _ := peer.sign(fiber.MethodPost, "/api/v1/lookup", lookupBody)
+		res, _ := fixture.Client().R().SetHeaders(headers).SetBody(lookupBody).Post("/api/v1/lookup")
+		return res.StatusCode()
+	}
+
+	first := newMutualAuthPeer(t)
+	first.handshake(fixture.Client())
+	firstOld := *first
+	first.handshake(fixture.Client())
+	firstDropped := *first
+	second := newMutualAuthPeer(t)
+	second.handshake(fixture.Client())
+	first.handshake(fixture.Client())
+	require.Equal(t, fiber.StatusOK, lookupStatus(&firstDropped))
+	require.Equal(t, fiber.StatusOK, lookupStatus(second))
+	require.Equal(t, fiber.StatusOK, lookupStatus(&firstOld))
+
+	// when:
+	firstNew := *first
+	require.Equal(t, fiber.StatusOK, lookupStatus(&firstNew))
+	third := newMutualAuthPeer(t)
+	third.handshake(fixture.Client())
+	require.Equal(t, fiber.StatusOK, lookupStatus(third))
+
+	// then:
+	require.Equal(t, fiber.StatusOK, lookupStatus(&firstOld), "The recently used session of the identity was dropped")
+	require.Equal(t, fiber.StatusOK, lookupStatus(&firstNew))
+	require.Equal(t, fiber.StatusUnauthorized, lookupStatus(&firstDropped), "The least recently used session of the identity was kept over the limit")
+	require.Equal(t, fiber.StatusUnauthorized, lookupStatus(second), "The least recently used session was kept over the limit")
+	stub.AssertProvidersState()
+}
+
+func TestMutualAuthMiddleware_PendingSessionLimits(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		LookupQuestionCall: true,
+		Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "answer"},
+	})))
+	fixture := server2.NewServerTestFixture(t,
+		server2.WithEngine(stub),
+		server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)),
+		server2.WithMutualAuthMaxSessions(3, 1),
+		server2.WithMutualAuthMaxPendingSessions(2),
+	)
+	lookupStatus := func(peer *mutualAuthPeer) int {
+		headers, _ := peer.sign(fiber.MethodPost, "/api/v1/lookup", lookupBody)
+		res, _ := fixture.Client().R().SetHeaders(headers).SetBody(lookupBody).Post("/api/v1/lookup")
+		return res.StatusCode()
+	}
+
+	peer := newMutualAuthPeer(t)
+	peer.handshake(fixture.Client())
+	established := *peer
+	require.Equal(t, fiber.StatusOK, lookupStatus(&established))
+
+	// when:
+	peer.handshake(fixture.Client())
+	pendingDropped := *peer
+	peer.handshake(fixture.Client())
+	pendingKept := *peer
+	peer.handshake(fixture.Client())
+
+	// then:
+	require.Equal(t, fiber.StatusOK, lookupStatus(&established), "The pending sessions of the identity counted toward its limit")
+	require.Equal(t, fiber.StatusUnauthorized, lookupStatus(&pendingDropped), "The oldest pending session was kept over the limit")
+	require.Equal(t, fiber.StatusOK, lookupStatus(&pendingKept))
+	require.Equal(t, fiber.StatusUnauthorized, lookupStatus(&established), "The established session was kept over the limit of the identity")
+	stub.AssertProvidersState()
+}
+
+func TestMutualAuthMiddleware_ReplayedRequests(t *testing.T) {
+	tests := map[string]struct {
+		replay func(peer *mutualAuthPeer, headers map[string]string) map[string]string
+	}{
+		"Request reusing the headers of a previous request": {
+			replay: func(_ *mutualAuthPeer, headers map[string]string) map[string]string {
+				return headers
+			},
+		},
+		"Request reusing the nonce of a previous request": {
+			replay: func(peer *mutualAuthPeer, headers map[string]string) map[string]string {
+				replayed, _ := peer.signWithNonce(fiber.MethodPost, "/api/v1/lookup", lookupBody, headers[middleware.AuthNonceHeader])
+				return replayed
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			peer := newMutualAuthPeer(t)
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+				LookupQuestionCall: true,
+				Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "answer"},
+			})))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithMutualAuthPrivateKey(newPrivateKeyHex(t)))
+			peer.handshake(fixture.Client())
+
+			headers, _ := peer.sign(fiber.MethodPost, "/api/v1/lookup", lookupBody)
+			res, _ := fixture.Client().R().SetHeaders(headers).SetBody(lookupBody).Post("/api/v1/lookup")
+			require.Equal(t, fiber.StatusOK, res.StatusCode())
+
+			// when:
+			res, _ = fixture.Client().
+				R().
+				SetHeaders(tc.replay(peer, headers)).
+				SetBody(lookupBody).
+				Post("/api/v1/lookup")
+
+			// then:
+			require.Equal(t, fiber.StatusUnauthorized, res.StatusCode())
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+// mutualAuthPeer is a test peer performing the BRC-104 handshake with the overlay node
+// and signing its requests within the established session.
+type mutualAuthPeer struct {
+	t                 *testing.T
+	key               *ec.PrivateKey
+	wallet            *wallet.ProtoWallet
+	initialNonce      string
+	sessionNonce      string
+	serverIdentityKey *ec.PublicKey
+}
+
+func newMutualAuthPeer(t *testing.T) *mutualAuthPeer {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	w, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypePrivateKey, PrivateKey: key})
+	require.NoError(t, err)
+	return &mutualAuthPeer{t: t, key: key, wallet: w}
+}
+
+// handshake establishes the session of the peer and verifies the signature of the initial response.
+func (p *mutualAuthPeer) handshake(client *resty.Client) {
+	p.t.Helper()
+	p.initialNonce = randomBase64(p.t)
+
+	var response auth.AuthMessage
+	res, _ := client.R().
+		SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+		SetBody(auth.AuthMessage{
+			Version:      auth.AUTH_VERSION,
+			MessageType:  auth.MessageTypeInitialRequest,
+			IdentityKey:  p.key.PubKey(),
+			InitialNonce: p.initialNonce,
+		}).
+		Post(middleware.WellKnownAuthPath)
+	require.Equal(p.t, fiber.StatusOK, res.StatusCode())
+	require.NoError(p.t, json.Unmarshal(res.Body(), &response))
+	require.Equal(p.t, auth.MessageTypeInitialResponse, response.MessageType)
+	require.Equal(p.t, p.initialNonce, response.YourNonce)
+
+	initialNonce, err := base64.StdEncoding.DecodeString(p.initialNonce)
+	require.NoError(p.t, err)
+	sessionNonce, err := base64.StdEncoding.DecodeString(response.Nonce)
+	require.NoError(p.t, err)
+	p.verify(response.IdentityKey, p.initialNonce, response.Nonce, append(initialNonce, sessionNonce...), response.Signature)
+
+	p.sessionNonce = response.Nonce
+	p.serverIdentityKey = response.IdentityKey
+}
+
+// sign returns the mutual authentication headers of a JSON request and its request ID.
+func (p *mutualAuthPeer) sign(method, path, body string) (map[string]string, []byte) {
+	p.t.Helper()
+	return p.signWithNonce(method, path, body, randomBase64(p.t))
+}
+
+// signWithNonce returns the mutual authentication headers of a JSON request signed with the nonce, and its request ID.
+func (p *mutualAuthPeer) signWithNonce(method, path, body, nonce string) (map[string]string, []byte) {
+	p.t.Helper()
+	requestID := make([]byte, 32)
+	_, err := rand.Read(requestID)
+	require.NoError(p.t, err)
+
+	w := util.NewWriter()
+	w.WriteBytes(requestID)
+	w.WriteString(method)
+	w.WriteString(path)
+	w.WriteNegativeOne()
+	w.WriteVarInt(1)
+	w.WriteString("content-type")
+	w.WriteString(fiber.MIMEApplicationJSON)
+	w.WriteVarInt(uint64(len(body)))
+	w.WriteBytes([]byte(body))
+
+	result, err := p.wallet.CreateSignature(context.Background(), wallet.CreateSignatureArgs{
+		EncryptionArgs: p.encryptionArgs(p.serverIdentityKey, nonce, p.sessionNonce),
+		Data:           w.Buf,
+	}, "test")
+	require.NoError(p.t, err)
+
+	return map[string]string{
+		fiber.HeaderContentType:          fiber.MIMEApplicationJSON,
+		middleware.AuthVersionHeader:     auth.AUTH_VERSION,
+		middleware.AuthIdentityKeyHeader: p.key.PubKey().ToDERHex(),
+		middleware.AuthNonceHeader:       nonce,
+		middleware.AuthYourNonceHeader:   p.sessionNonce,
+		middleware.AuthSignatureHeader:   hex.EncodeToString(result.Signature.Serialize()),
+		middleware.AuthRequestIDHeader:   base64.StdEncoding.EncodeToString(requestID),
+	}, requestID
+}
+
+// verifyResponse verifies the signature of the overlay node over the response of a request.
+func (p *mutualAuthPeer) verifyResponse(res *resty.Response, requestID []byte) {
+	p.t.Helper()
+	require.Equal(p.t, p.serverIdentityKey.ToDERHex(), res.Header().Get(middleware.AuthIdentityKeyHeader))
+	require.Equal(p.t, p.initialNonce, res.Header().Get(middleware.AuthYourNonceHeader))
+	require.Equal(p.t, base64.StdEncoding.EncodeToString(requestID), res.Header().Get(middleware.AuthRequestIDHeader))
+
+	w := util.NewWriter()
+	w.WriteBytes(requestID)
+	w.WriteVarInt(uint64(res.StatusCode()))
+	var headers []string
+	for key := range res.Header() {
+		key = strings.ToLower(key)
+		if strings.HasPrefix(key, "x-bsv-") && !strings.HasPrefix(key, "x-bsv-auth") {
+			headers = append(headers, key)
+		}
+	}
+	require.Empty(p.t, headers, "Unexpected signed response headers")
+	w.WriteVarInt(0)
+	w.WriteVarInt(uint64(len(res.Body())))
+	w.WriteBytes(res.Body())
+
+	signature, err := hex.DecodeString(res.Header().Get(middleware.AuthSignatureHeader))
+	require.NoError(p.t, err)
+	p.verify(p.serverIdentityKey, res.Header().Get(middleware.AuthNonceHeader), p.initialNonce, w.Buf, signature)
+}
+
+// verify verifies the signature of the overlay node over the data.
+func (p *mutualAuthPeer) verify(server *ec.PublicKey, nonce, peerNonce string, data, signature []byte) {
+	p.t.Helper()
+	sig, err := ec.ParseSignature(signature)
+	require.NoError(p.t, err)
+	result, err := p.wallet.VerifySignature(context.Background(), wallet.VerifySignatureArgs{
+		EncryptionArgs: p.encryptionArgs(server, nonce, peerNonce),
+		Data:           data,
+		Signature:      sig,
+	}, "test")
+	require.NoError(p.t, err)
+	require.True(p.t, result.Valid, "Invalid signature of the overlay node")
+}
+
+func (p *mutualAuthPeer) encryptionArgs(counterparty *ec.PublicKey, nonce, peerNonce string) wallet.EncryptionArgs {
+	return wallet.EncryptionArgs{
+		ProtocolID: wallet.Protocol{
+			SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty,
+			Protocol:      auth.AUTH_PROTOCOL_ID,
+		},
+		KeyID:        nonce + " " + peerNonce,
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: counterparty},
+	}
+}
+
+func randomBase64(t *testing.T) string {
+	t.Helper()
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func newPrivateKeyHex(t *testing.T) string {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	return hex.EncodeToString(key.Serialize())
+}
@@ -0,0 +1,315 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/gofiber/fiber/v2"
+)
+
+// The headers carrying the BRC-105 payment challenges and the payments attached to the requests.
+const (
+	PaymentVersionHeader          = "x-bsv-payment-version"
+	PaymentSatoshisRequiredHeader = "x-bsv-payment-satoshis-required"
+	PaymentDerivationPrefixHeader = "x-bsv-payment-derivation-prefix"
+	PaymentSatoshisPaidHeader     = "x-bsv-payment-satoshis-paid"
+	PaymentHeader                 = "x-bsv-payment"
+)
+
+// PaymentVersion is the version of the BRC-105 payment flow implemented by the payment middleware.
+const PaymentVersion = "1.0"
+
+// paymentOriginator is the originator of the wallet operations of the payment middleware.
+const paymentOriginator = "overlay-services"
+
+// paymentProtocol is the BRC-29 protocol deriving the keys the payments are locked by.
+var paymentProtocol = wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: "3241645161d8"}
+
+// The paths of the requests priced by the payment middleware.
+const (
+	paidSubmitPath      = "/api/v1/submit"
+	paidSubmitBatchPath = "/api/v1/submitBatch"
+	paidLookupPath      = "/api/v1/lookup"
+)
+
+// PaymentPrices defines the prices, in satoshis, of the requests to the overlay. Requests priced at zero are free.
+type PaymentPrices struct {
+	Submit         uint64            // Price of every submitted transaction.
+	SubmitPerByte  uint64            // Price of every byte of submitted BEEF, on top of Submit.
+	Lookup         uint64            // Price of a lookup question to a service without a price in LookupServices.
+	LookupServices map[string]uint64 // Price of a lookup question per lookup service.
+}
+
+// IsZero reports whether every request is free.
+func (p PaymentPrices) IsZero() bool {
+	for _, price := range p.LookupServices {
+		if price > 0 {
+			return false
+		}
+	}
+	return p.Submit == 0 && p.SubmitPerByte == 0 && p.Lookup == 0
+}
+
+// PaymentMiddlewareConfig defines configuration options of the payment middleware.
+type PaymentMiddlewareConfig struct {
+	Wallet   wallet.KeyOperations   // Wallet of the overlay node, the payments are locked by keys derived from its identity key.
+	Acceptor engine.PaymentAcceptor // Verifies, broadcasts and records the receipts of the payments.
+	Prices   PaymentPrices          // Prices of the requests.
+}
+
+// PaymentMiddleware returns a fiber.Handler charging for the submitted transactions and the lookup questions
+// following the BRC-105 payment flow.
+//
+// A priced request without a payment is answered with 402 Payment Required, carrying the price, the identity key
+// of the overlay node and a derivation prefix. The requester retries with the x-bsv-payment header holding the
+// AtomicBEEF of a transaction locking the price by a P2PKH of the key derived, following BRC-29, from the prefix
+// and a suffix of its choice. The payer is the identity key authenticated by the mutual authentication, see
+// engine.IdentityKeyFromContext, or the senderIdentityKey of the payment for unauthenticated requests.
+//
+// Once accepted by the engine, which verifies it against its ChainTracker, broadcasts it and records its receipt,
+// the payment is kept even if the request fails. A payment transaction pays for a single request.
+// It panics if the wallet or the acceptor is nil, or if the wallet is unable to provide its identity key.
+func PaymentMiddleware(cfg PaymentMiddlewareConfig) fiber.Handler {
+	if cfg.Wallet == nil {
+		panic("payment middleware requires a wallet")
+	}
+	if cfg.Acceptor == nil {
+		panic("payment middleware requires a payment acceptor")
+	}
+
+	identity, err := cfg.Wallet.GetPublicKey(context.Background(), wallet.GetPublicKeyArgs{IdentityKey: true}, paymentOriginator)
+	if err != nil {
+		panic(fmt.Sprintf("payment middleware failed to get the identity key of the wallet: %v", err))
+	}
+
+	p := &payments{cfg: cfg, identityKey: identity.PublicKey}
+	return func(c *fiber.Ctx) error {
+		price := p.price(c)
+		if price == 0 {
+			return c.Next()
+		}
+
+		header := c.Get(PaymentHeader)
+		if header == "" {
+			return p.requirePayment(c, price)
+		}
+
+		receipt, err := p.accept(c, header, price)
+		if err != nil {
+			return err
+		}
+		c.Set(PaymentSatoshisPaidHeader, strconv.FormatUint(receipt.Satoshis, 10))
+		return c.Next()
+	}
+}
+
+// payments holds the identity key and the configuration of the payment middleware.
+type payments struct {
+	cfg         PaymentMiddlewareConfig
+	identityKey *ec.PublicKey
+}
+
+// paymentHeader is the payment attached to a request by the x-bsv-payment header.
+type paymentHeader struct {
+	DerivationPrefix  string `json:"derivationPrefix"`
+	DerivationSuffix  string `json:"derivationSuffix"`
+	Transaction       string `json:"transaction"`
+	SenderIdentityKey string `json:"senderIdentityKey,omitempty"`
+}
+
+// price returns the price of the request, zero when it is free. The bodies which cannot be decoded are priced
+// as the cheapest request of their kind, as they are rejected by the handlers anyway.
+func (p *payments) price(c *fiber.Ctx) uint64 {
+	if c.Method() != fiber.MethodPost {
+		return 0
+	}
+
+	prices := p.cfg.Prices
+	switch c.Path() {
+	case paidSubmitPath:
+		return prices.Submit + prices.SubmitPerByte*uint64(len(c.Body()))
+
+	case paidSubmitBatchPath:
+		var body struct {
+			Beef         string `json:"beef"`
+			Transactions []struct {
+				Beef string `json:"beef"`
+			} `json:"transactions"`
+		}
+		if err := json.Unmarshal(c.Body(), &body); err != nil || len(body.Transactions) == 0 {
+			return prices.Submit
+		}
+		size := len(body.Beef) / 2
+		for _, tx := range body.Transactions {
+			size += len(tx.Beef) / 2
+		}
+		return prices.Submit*uint64(len(body.Transactions)) + prices.SubmitPerByte*uint64(size)
+
+	case paidLookupPath:
+		var body struct {
+			Service string `json:"service"`
+		}
+		if err := json.Unmarshal(c.Body(), &body); err == nil {
+			if price, ok := prices.LookupServices[body.Service]; ok {
+				return price
+			}
+		}
+		return prices.Lookup
+
+	default:
+		return 0
+	}
+}
+
+// requirePayment answers the request with the payment challenge of its price.
+func (p *payments) requirePayment(c *fiber.Ctx, price uint64) error {
+	prefix, err := utils.CreateNonce(c.UserContext(), p.cfg.Wallet, wallet.Counterparty{Type: wallet.CounterpartyTypeSelf})
+	if err != nil {
+		return NewPaymentChallengeError(err)
+	}
+
+	c.Set(PaymentVersionHeader, PaymentVersion)
+	c.Set(PaymentSatoshisRequiredHeader, strconv.FormatUint(price, 10))
+	c.Set(AuthIdentityKeyHeader, p.identityKey.ToDERHex())
+	c.Set(PaymentDerivationPrefixHeader, prefix)
+	return NewPaymentRequiredError(price)
+}
+
+// accept hands the payment attached to the request to the engine, once it is checked to be locked by the key
+// derived for the payer from a derivation prefix issued by the overlay node.
+func (p *payments) accept(c *fiber.Ctx, header string, price uint64) (*engine.PaymentReceipt, error) {
+	ctx := c.UserContext()
+
+	var payment paymentHeader
+	if err := json.Unmarshal([]byte(header), &payment); err != nil {
+		return nil, NewInvalidPaymentHeaderError(err)
+	}
+	beef, err := base64.StdEncoding.DecodeString(payment.Transaction)
+	if err != nil {
+		return nil, NewInvalidPaymentHeaderError(err)
+	}
+	if valid, err := utils.VerifyNonce(ctx, payment.DerivationPrefix, p.cfg.Wallet, wallet.Counterparty{Type: wallet.CounterpartyTypeSelf}); err != nil || !valid {
+		return nil, NewInvalidPaymentDerivationPrefixError()
+	}
+
+	payer, err := p.payer(ctx, payment)
+	if err != nil {
+		return nil, err
+	}
+	lockingScript, err := p.lockingScript(ctx, payer, payment)
+	if err != nil {
+		return nil, NewPaymentKeyDerivationError(err)
+	}
+
+	// The receipt outlives the request, while Fiber reuses the buffer backing the path.
+	receipt, err := p.cfg.Acceptor.AcceptPayment(ctx, &engine.Payment{
+		Transaction:      beef,
+		LockingScript:    lockingScript,
+		Satoshis:         price,
+		Route:            strings.Clone(c.Path()),
+		Payer:            payer.ToDERHex(),
+		DerivationPrefix: payment.DerivationPrefix,
+		DerivationSuffix: payment.DerivationSuffix,
+	})
+	if err != nil {
+		if catalogued, ok := app.NewCatalogError(err); ok {
+			return nil, catalogued
+		}
+		return nil, NewPaymentAcceptanceError(err)
+	}
+	return receipt, nil
+}
+
+// payer returns the identity key of the payer: the one authenticated by the mutual authentication,
+// or the sender identity key of the payment for unauthenticated requests.
+func (p *payments) payer(ctx context.Context, payment paymentHeader) (*ec.PublicKey, error) {
+	if identityKey, ok := engine.IdentityKeyFromContext(ctx); ok {
+		return identityKey, nil
+	}
+	if payment.SenderIdentityKey == "" {
+		return nil, NewUnknownPayerError(errors.New("payment without sender identity key from an unauthenticated requester"))
+	}
+	identityKey, err := ec.PublicKeyFromString(payment.SenderIdentityKey)
+	if err != nil {
+		return nil, NewUnknownPayerError(err)
+	}
+	return identityKey, nil
+}
+
+// lockingScript returns the P2PKH of the key the payment of the payer must be locked by.
+func (p *payments) lockingScript(ctx context.Context, payer *ec.PublicKey, payment paymentHeader) (*script.Script, error) {
+	derived, err := p.cfg.Wallet.GetPublicKey(ctx, wallet.GetPublicKeyArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID:   paymentProtocol,
+			KeyID:        payment.DerivationPrefix + " " + payment.DerivationSuffix,
+			Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: payer},
+		},
+		ForSelf: true,
+	}, paymentOriginator)
+	if err != nil {
+		return nil, err
+	}
+	address, err := script.NewAddressFromPublicKey(derived.PublicKey, true)
+	if err != nil {
+		return nil, err
+	}
+	return p2pkh.Lock(address)
+}
+
+// NewPaymentRequiredError returns an app.Error indicating that the request has to be paid for,
+// the payment challenge is carried by the headers of the response.
+func NewPaymentRequiredError(price uint64) app.Error {
+	str := fmt.Sprintf("Payment required: The request costs %d satoshis, please attach a payment following the headers of the response", price)
+	return app.NewPaymentRequiredError(str, str)
+}
+
+// NewInvalidPaymentHeaderError returns an app.Error indicating that the payment
+// attached to the request is malformed.
+func NewInvalidPaymentHeaderError(err error) app.Error {
+	return app.NewIncorrectInputError(err.Error(), "Unable to process the payment attached to the request. Please verify the x-bsv-payment header and try again.").
+		WithCode(app.ErrorCodeInvalidPayment)
+}
+
+// NewInvalidPaymentDerivationPrefixError returns an app.Error indicating that the derivation
+// prefix of the payment was not issued by the overlay node.
+func NewInvalidPaymentDerivationPrefixError() app.Error {
+	const str = "The derivation prefix of the payment was not issued by the overlay. Please request a new payment challenge and try again."
+	return app.NewIncorrectInputError(str, str).WithCode(app.ErrorCodeInvalidPayment)
+}
+
+// NewUnknownPayerError returns an app.Error indicating that the identity key of the payer,
+// needed to derive the key the payment is locked by, is unknown.
+func NewUnknownPayerError(err error) app.Error {
+	return app.NewIncorrectInputError(err.Error(), "Unable to identify the payer. Please authenticate the request or attach the sender identity key to the payment.").
+		WithCode(app.ErrorCodeInvalidPayment)
+}
+
+// NewPaymentChallengeError returns an app.Error indicating that the overlay node failed
+// to create the payment challenge of the request.
+func NewPaymentChallengeError(err error) app.Error {
+	return app.NewProviderFailureError(err.Error(), "Unable to create the payment challenge of the request. Please try again later.")
+}
+
+// NewPaymentKeyDerivationError returns an app.Error indicating that the overlay node failed
+// to derive the key the payment is locked by.
+func NewPaymentKeyDerivationError(err error) app.Error {
+	return app.NewProviderFailureError(err.Error(), "Unable to derive the key of the payment. Please try again later.")
+}
+
+// NewPaymentAcceptanceError returns an app.Error indicating that the engine failed
+// to accept the payment attached to the request.
+func NewPaymentAcceptanceError(err error) app.Error {
+	return app.NewProviderFailureError(err.Error(), "Unable to accept the payment attached to the request. Please try again later.")
+}
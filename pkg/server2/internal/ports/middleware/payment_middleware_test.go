@@ -0,0 +1,283 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/middleware"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/go-resty/resty/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentMiddleware_ShouldRequirePayment(t *testing.T) {
+	// given:
+	body := []byte("0123456789")
+	stub := testabilities.NewTestOverlayEngineStub(t)
+	serverKey := newPrivateKeyHex(t)
+	fixture := server2.NewServerTestFixture(t,
+		server2.WithEngine(stub),
+		server2.WithPaymentPrivateKey(serverKey),
+		server2.WithSubmitPrice(100, 2),
+	)
+
+	// when:
+	var actualResponse openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderContentType, fiber.MIMEOctetStream).
+		SetHeader("x-topics", "topic1").
+		SetBody(body).
+		SetError(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	require.Equal(t, fiber.StatusPaymentRequired, res.StatusCode())
+	require.Equal(t, app.ErrorTypePaymentRequired.String(), actualResponse.Code)
+	require.Equal(t, middleware.PaymentVersion, res.Header().Get(middleware.PaymentVersionHeader))
+	require.Equal(t, "120", res.Header().Get(middleware.PaymentSatoshisRequiredHeader))
+	require.Equal(t, newPublicKeyHex(t, serverKey), res.Header().Get(middleware.AuthIdentityKeyHeader))
+	require.NotEmpty(t, res.Header().Get(middleware.PaymentDerivationPrefixHeader))
+	stub.AssertProvidersState()
+}
+
+func TestPaymentMiddleware_ValidCase(t *testing.T) {
+	// given:
+	payer := newPaymentPayer(t)
+	stub := testabilities.NewTestOverlayEngineStub(t,
+		testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+			LookupQuestionCall: true,
+			Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "answer"},
+		})),
+		testabilities.WithPaymentAcceptorProvider(testabilities.NewPaymentAcceptorProviderMock(t, testabilities.PaymentAcceptorProviderMockExpectations{
+			AcceptPaymentCall: true,
+			Satoshis:          50,
+			Route:             "/api/v1/lookup",
+			Payer:             payer.key.PubKey().ToDERHex(),
+			LockingScript:     payer.expectedLockingScript,
+		})),
+	)
+	fixture := server2.NewServerTestFixture(t,
+		server2.WithEngine(stub),
+		server2.WithPaymentPrivateKey(newPrivateKeyHex(t)),
+		server2.WithLookupPrice(10),
+		server2.WithLookupServicePrice("test-service", 50),
+	)
+	challenge := payer.requestChallenge(fixture.Client())
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+		SetHeader(middleware.PaymentHeader, payer.pay(challenge)).
+		SetBody(lookupBody).
+		Post("/api/v1/lookup")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, "50", res.Header().Get(middleware.PaymentSatoshisPaidHeader))
+	stub.AssertProvidersState()
+}
+
+func TestPaymentMiddleware_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		header               func(payer *paymentPayer, challenge *resty.Response) string
+		expectations         testabilities.PaymentAcceptorProviderMockExpectations
+		expectedStatus       int
+		expectedResponseCode string
+	}{
+		"Payment header is not JSON": {
+			header:               func(*paymentPayer, *resty.Response) string { return "invalid" },
+			expectations:         testabilities.PaymentAcceptorProviderMockExpectations{AcceptPaymentCall: false},
+			expectedStatus:       fiber.StatusBadRequest,
+			expectedResponseCode: app.ErrorCodeInvalidPayment,
+		},
+		"Payment derivation prefix was not issued by the overlay": {
+			header: func(payer *paymentPayer, challenge *resty.Response) string {
+				challenge.Header().Set(middleware.PaymentDerivationPrefixHeader, randomBase64(payer.t))
+				return payer.pay(challenge)
+			},
+			expectations:         testabilities.PaymentAcceptorProviderMockExpectations{AcceptPaymentCall: false},
+			expectedStatus:       fiber.StatusBadRequest,
+			expectedResponseCode: app.ErrorCodeInvalidPayment,
+		},
+		"Payment of an unauthenticated requester without sender identity key": {
+			header: func(payer *paymentPayer, challenge *resty.Response) string {
+				payer.anonymous = true
+				return payer.pay(challenge)
+			},
+			expectations:         testabilities.PaymentAcceptorProviderMockExpectations{AcceptPaymentCall: false},
+			expectedStatus:       fiber.StatusBadRequest,
+			expectedResponseCode: app.ErrorCodeInvalidPayment,
+		},
+		"Payment below the price": {
+			header: func(payer *paymentPayer, challenge *resty.Response) string { return payer.pay(challenge) },
+			expectations: testabilities.PaymentAcceptorProviderMockExpectations{
+				AcceptPaymentCall: true,
+				Error:             fmt.Errorf("%w: 5 satoshis paid out of 10", engine.ErrInsufficientPayment),
+				Satoshis:          10,
+				Route:             "/api/v1/lookup",
+			},
+			expectedStatus:       fiber.StatusPaymentRequired,
+			expectedResponseCode: app.ErrorCodeInsufficientPayment,
+		},
+		"Payment already redeemed": {
+			header: func(payer *paymentPayer, challenge *resty.Response) string { return payer.pay(challenge) },
+			expectations: testabilities.PaymentAcceptorProviderMockExpectations{
+				AcceptPaymentCall: true,
+				Error:             engine.ErrPaymentAlreadyRedeemed,
+				Satoshis:          10,
+				Route:             "/api/v1/lookup",
+			},
+			expectedStatus:       fiber.StatusConflict,
+			expectedResponseCode: app.ErrorCodePaymentRedeemed,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			payer := newPaymentPayer(t)
+			if tc.expectations.AcceptPaymentCall {
+				tc.expectations.Payer = payer.key.PubKey().ToDERHex()
+			}
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithPaymentAcceptorProvider(
+				testabilities.NewPaymentAcceptorProviderMock(t, tc.expectations),
+			))
+			fixture := server2.NewServerTestFixture(t,
+				server2.WithEngine(stub),
+				server2.WithPaymentPrivateKey(newPrivateKeyHex(t)),
+				server2.WithLookupPrice(10),
+			)
+			challenge := payer.requestChallenge(fixture.Client())
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				SetHeader(middleware.PaymentHeader, tc.header(payer, challenge)).
+				SetBody(lookupBody).
+				SetError(&actualResponse).
+				Post("/api/v1/lookup")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			require.Equal(t, tc.expectedResponseCode, actualResponse.Code)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestPaymentMiddleware_FreeRequestsAreNotCharged(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		LookupQuestionCall: true,
+		Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "answer"},
+	})))
+	fixture := server2.NewServerTestFixture(t,
+		server2.WithEngine(stub),
+		server2.WithPaymentPrivateKey(newPrivateKeyHex(t)),
+		server2.WithSubmitPrice(100, 0),
+		server2.WithLookupPrice(10),
+		server2.WithLookupServicePrice("test-service", 0),
+	)
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+		SetBody(lookupBody).
+		Post("/api/v1/lookup")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Empty(t, res.Header().Get(middleware.PaymentSatoshisPaidHeader))
+	stub.AssertProvidersState()
+}
+
+// paymentPayer is a test requester paying for its lookup questions following the BRC-105 payment flow.
+type paymentPayer struct {
+	t                     *testing.T
+	key                   *ec.PrivateKey
+	wallet                *wallet.ProtoWallet
+	anonymous             bool
+	expectedLockingScript *script.Script
+}
+
+func newPaymentPayer(t *testing.T) *paymentPayer {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	w, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypePrivateKey, PrivateKey: key})
+	require.NoError(t, err)
+	return &paymentPayer{t: t, key: key, wallet: w, expectedLockingScript: &script.Script{}}
+}
+
+// requestChallenge sends the lookup question without a payment and returns the payment challenge of the overlay node.
+func (p *paymentPayer) requestChallenge(client *resty.Client) *resty.Response {
+	p.t.Helper()
+	res, _ := client.R().
+		SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+		SetBody(lookupBody).
+		Post("/api/v1/lookup")
+	require.Equal(p.t, fiber.StatusPaymentRequired, res.StatusCode())
+	return res
+}
+
+// pay returns the payment header answering the challenge, locked by the P2PKH of the key derived
+// for the overlay node from the derivation prefix of the challenge, and records that locking script
+// as the expected one.
+func (p *paymentPayer) pay(challenge *resty.Response) string {
+	p.t.Helper()
+	server, err := ec.PublicKeyFromString(challenge.Header().Get(middleware.AuthIdentityKeyHeader))
+	require.NoError(p.t, err)
+	prefix := challenge.Header().Get(middleware.PaymentDerivationPrefixHeader)
+	suffix := randomBase64(p.t)
+
+	derived, err := p.wallet.GetPublicKey(context.Background(), wallet.GetPublicKeyArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: "3241645161d8"},
+			KeyID:        prefix + " " + suffix,
+			Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: server},
+		},
+	}, "test")
+	require.NoError(p.t, err)
+	address, err := script.NewAddressFromPublicKey(derived.PublicKey, true)
+	require.NoError(p.t, err)
+	lockingScript, err := p2pkh.Lock(address)
+	require.NoError(p.t, err)
+	*p.expectedLockingScript = *lockingScript
+
+	payment := map[string]string{
+		"derivationPrefix": prefix,
+		"derivationSuffix": suffix,
+		"transaction":      base64.StdEncoding.EncodeToString([]byte("payment transaction")),
+	}
+	if !p.anonymous {
+		payment["senderIdentityKey"] = p.key.PubKey().ToDERHex()
+	}
+	header, err := json.Marshal(payment)
+	require.NoError(p.t, err)
+	return string(header)
+}
+
+func newPublicKeyHex(t *testing.T, privateKeyHex string) string {
+	t.Helper()
+	key, err := ec.PrivateKeyFromHex(privateKeyHex)
+	require.NoError(t, err)
+	return key.PubKey().ToDERHex()
+}
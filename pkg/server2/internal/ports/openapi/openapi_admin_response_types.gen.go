@@ -3,18 +3,213 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package openapi
 
+import (
+	"time"
+)
+
 // AdvertisementsSync defines model for AdvertisementsSync.
 type AdvertisementsSync struct {
 	Message string `json:"message"`
 }
 
+// DeleteWebhook defines model for DeleteWebhook.
+type DeleteWebhook struct {
+	// Id The ID of the deleted webhook
+	Id string `json:"id"`
+}
+
+// EvictOutput defines model for EvictOutput.
+type EvictOutput struct {
+	// CreatedAt Time at which the eviction was recorded in the audit log
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Evicted Outputs removed from storage, per topic
+	Evicted []EvictedOutput `json:"evicted"`
+}
+
+// EvictedOutput defines model for EvictedOutput.
+type EvictedOutput struct {
+	OutputIndex uint32 `json:"outputIndex"`
+	Topic       string `json:"topic"`
+	Txid        string `json:"txid"`
+}
+
+// HandleReorg defines model for HandleReorg.
+type HandleReorg struct {
+	// Checked Number of proven transactions whose merkle proofs were re-verified
+	Checked int `json:"checked"`
+
+	// Downgraded Transactions whose merkle proofs were orphaned and which were downgraded to unproven
+	Downgraded []string `json:"downgraded"`
+}
+
+// ListOutboxEntries defines model for ListOutboxEntries.
+type ListOutboxEntries struct {
+	Entries []OutboxEntry `json:"entries"`
+}
+
+// ListSyncCheckpoints defines model for ListSyncCheckpoints.
+type ListSyncCheckpoints struct {
+	Checkpoints []SyncCheckpoint `json:"checkpoints"`
+}
+
+// ListWebhookDeliveries defines model for ListWebhookDeliveries.
+type ListWebhookDeliveries struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// ListWebhooks defines model for ListWebhooks.
+type ListWebhooks struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// OutboxEntry defines model for OutboxEntry.
+type OutboxEntry struct {
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+
+	// Kind Either broadcast, to the network, or propagation, to the overlay nodes hosting the topics
+	Kind string `json:"kind"`
+
+	// LastError Why the last attempt failed, omitted once completed
+	LastError     *string   `json:"lastError,omitempty"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+
+	// Status One of pending, completed or failed
+	Status string `json:"status"`
+
+	// Topics The topics the transaction is propagated for, empty for broadcasts
+	Topics    []string  `json:"topics"`
+	Txid      string    `json:"txid"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// PruneHistory defines model for PruneHistory.
+type PruneHistory struct {
+	Reports []PruneReport `json:"reports"`
+}
+
+// PruneReport defines model for PruneReport.
+type PruneReport struct {
+	// DryRun Whether the outputs were only reported and not removed
+	DryRun bool `json:"dryRun"`
+
+	// Pruned Spent outputs removed from the history of the topic
+	Pruned []PrunedOutput `json:"pruned"`
+	Topic  string         `json:"topic"`
+}
+
+// PrunedOutput defines model for PrunedOutput.
+type PrunedOutput struct {
+	OutputIndex uint32 `json:"outputIndex"`
+
+	// Reason Rule of the retention policy the output is not retained by, one of unspent-only, ancestry-depth or spent-retention
+	Reason string `json:"reason"`
+	Txid   string `json:"txid"`
+}
+
+// RegisterWebhook defines model for RegisterWebhook.
+type RegisterWebhook struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+
+	// Secret The secret keying the X-Overlay-Signature header of the deliveries, only returned on registration
+	Secret string `json:"secret"`
+	Topic  string `json:"topic"`
+	Url    string `json:"url"`
+}
+
+// ResetSyncCheckpoints defines model for ResetSyncCheckpoints.
+type ResetSyncCheckpoints struct {
+	// Deleted Number of sync checkpoints deleted
+	Deleted int `json:"deleted"`
+}
+
 // StartGASPSync defines model for StartGASPSync.
 type StartGASPSync struct {
 	Message string `json:"message"`
 }
 
+// SyncCheckpoint defines model for SyncCheckpoint.
+type SyncCheckpoint struct {
+	Peer string `json:"peer"`
+
+	// Since Unix timestamp at which the last successful sync with the peer started
+	Since     uint32    `json:"since"`
+	Topic     string    `json:"topic"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Webhook A webhook receiving the admitted and spent outputs of a topic, its secret is never listed
+type Webhook struct {
+	// CreatedAt When the webhook was registered, omitted for the webhooks of the engine configuration
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	Id        string     `json:"id"`
+	Topic     string     `json:"topic"`
+	Url       string     `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// EventType Either output-admitted or output-spent
+	EventType string `json:"eventType"`
+
+	// Id The ID of the delivery, sent in the X-Overlay-Delivery header of every attempt
+	Id string `json:"id"`
+
+	// LastError Why the last attempt failed, omitted once delivered
+	LastError *string `json:"lastError,omitempty"`
+
+	// LastStatusCode The status code of the last attempt, omitted when no response was received
+	LastStatusCode *int      `json:"lastStatusCode,omitempty"`
+	NextAttemptAt  time.Time `json:"nextAttemptAt"`
+
+	// Payload The JSON payload posted to the webhook
+	Payload string `json:"payload"`
+
+	// Status One of pending, delivered or dead
+	Status    string    `json:"status"`
+	Topic     string    `json:"topic"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	WebhookId string    `json:"webhookId"`
+}
+
 // AdvertisementsSyncResponse defines model for AdvertisementsSyncResponse.
 type AdvertisementsSyncResponse = AdvertisementsSync
 
+// DeleteWebhookResponse defines model for DeleteWebhookResponse.
+type DeleteWebhookResponse = DeleteWebhook
+
+// EvictOutputResponse defines model for EvictOutputResponse.
+type EvictOutputResponse = EvictOutput
+
+// HandleReorgResponse defines model for HandleReorgResponse.
+type HandleReorgResponse = HandleReorg
+
+// ListOutboxEntriesResponse defines model for ListOutboxEntriesResponse.
+type ListOutboxEntriesResponse = ListOutboxEntries
+
+// ListSyncCheckpointsResponse defines model for ListSyncCheckpointsResponse.
+type ListSyncCheckpointsResponse = ListSyncCheckpoints
+
+// ListWebhookDeliveriesResponse defines model for ListWebhookDeliveriesResponse.
+type ListWebhookDeliveriesResponse = ListWebhookDeliveries
+
+// ListWebhooksResponse defines model for ListWebhooksResponse.
+type ListWebhooksResponse = ListWebhooks
+
+// PruneHistoryResponse defines model for PruneHistoryResponse.
+type PruneHistoryResponse = PruneHistory
+
+// RegisterWebhookResponse defines model for RegisterWebhookResponse.
+type RegisterWebhookResponse = RegisterWebhook
+
+// ResetSyncCheckpointsResponse defines model for ResetSyncCheckpointsResponse.
+type ResetSyncCheckpointsResponse = ResetSyncCheckpoints
+
 // StartGASPSyncResponse defines model for StartGASPSyncResponse.
 type StartGASPSyncResponse = StartGASPSync
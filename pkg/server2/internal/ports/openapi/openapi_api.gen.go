@@ -15,8 +15,25 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for ListOutboxEntriesParamsStatus.
+const (
+	ListOutboxEntriesParamsStatusCompleted ListOutboxEntriesParamsStatus = "completed"
+	ListOutboxEntriesParamsStatusFailed    ListOutboxEntriesParamsStatus = "failed"
+	ListOutboxEntriesParamsStatusPending   ListOutboxEntriesParamsStatus = "pending"
+)
+
+// Defines values for ListWebhookDeliveriesParamsStatus.
+const (
+	ListWebhookDeliveriesParamsStatusDead      ListWebhookDeliveriesParamsStatus = "dead"
+	ListWebhookDeliveriesParamsStatusDelivered ListWebhookDeliveriesParamsStatus = "delivered"
+	ListWebhookDeliveriesParamsStatusPending   ListWebhookDeliveriesParamsStatus = "pending"
+)
+
 // Error defines model for Error.
 type Error struct {
+	// Code Stable machine-readable error code, e.g. unknown-topic, invalid-beef, input-spent or not-found
+	Code string `json:"code"`
+
 	// Message Human-readable error message
 	Message string `json:"message"`
 }
@@ -24,6 +41,9 @@ type Error struct {
 // BadRequestResponse defines model for BadRequestResponse.
 type BadRequestResponse = Error
 
+// ConflictResponse defines model for ConflictResponse.
+type ConflictResponse = Error
+
 // InternalServerErrorResponse defines model for InternalServerErrorResponse.
 type InternalServerErrorResponse = Error
 
@@ -33,6 +53,120 @@ type NotFoundResponse = Error
 // RequestTimeoutResponse defines model for RequestTimeoutResponse.
 type RequestTimeoutResponse = Error
 
+// ServiceUnavailableResponse defines model for ServiceUnavailableResponse.
+type ServiceUnavailableResponse = Error
+
+// UnprocessableEntityResponse defines model for UnprocessableEntityResponse.
+type UnprocessableEntityResponse = Error
+
+// EvictOutputJSONBody defines parameters for EvictOutput.
+type EvictOutputJSONBody struct {
+	// Actor Who requested the eviction, recorded in the audit log
+	Actor string `json:"actor"`
+
+	// IncludeHistory Whether the outputs the evicted output was built from are evicted too
+	IncludeHistory *bool `json:"includeHistory,omitempty"`
+
+	// OutputIndex The index of the output to evict
+	OutputIndex uint32 `json:"outputIndex"`
+
+	// Reason Why the output is evicted, recorded in the audit log
+	Reason string `json:"reason"`
+
+	// Txid The ID of the transaction holding the output to evict
+	Txid string `json:"txid"`
+}
+
+// ImportTransactionsJSONBody defines parameters for ImportTransactions.
+type ImportTransactionsJSONBody struct {
+	// Beef BEEF in hexadecimal format shared by the transactions selected by their txid, typically holding their common ancestors
+	Beef *string `json:"beef,omitempty"`
+
+	// Transactions The transactions to submit, processed in dependency order
+	Transactions []struct {
+		// Beef The BEEF of the transaction in hexadecimal format, omitted when the transaction is selected from the shared BEEF
+		Beef *string `json:"beef,omitempty"`
+
+		// Topics The topics to submit the transaction to
+		Topics []string `json:"topics"`
+
+		// Txid The ID of the transaction in the shared BEEF, omitted when the transaction comes with its own BEEF
+		Txid *string `json:"txid,omitempty"`
+	} `json:"transactions"`
+}
+
+// ListOutboxEntriesParams defines parameters for ListOutboxEntries.
+type ListOutboxEntriesParams struct {
+	// Txid The hexadecimal ID of the transaction to list the broadcasts and propagations of, every transaction when omitted
+	Txid *string `form:"txid,omitempty" json:"txid,omitempty"`
+
+	// Status The status of the entries to list, every status when omitted
+	Status *ListOutboxEntriesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListOutboxEntriesParamsStatus defines parameters for ListOutboxEntries.
+type ListOutboxEntriesParamsStatus string
+
+// PruneHistoryParams defines parameters for PruneHistory.
+type PruneHistoryParams struct {
+	// Topic The topic to enforce the retention policy of, every topic with a retention policy when omitted
+	Topic *string `form:"topic,omitempty" json:"topic,omitempty"`
+
+	// DryRun Only report the outputs the retention policies no longer retain, without removing them
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// HandleReorgParams defines parameters for HandleReorg.
+type HandleReorgParams struct {
+	// FromHeight Height of the first block that may have been orphaned, the proofs of the transactions mined at or above it are re-verified
+	FromHeight uint32 `form:"fromHeight" json:"fromHeight"`
+}
+
+// ResetSyncCheckpointsParams defines parameters for ResetSyncCheckpoints.
+type ResetSyncCheckpointsParams struct {
+	// Topic The topic to reset the sync checkpoints of, every topic when omitted
+	Topic *string `form:"topic,omitempty" json:"topic,omitempty"`
+
+	// Peer The peer to reset the sync checkpoints of, every peer when omitted
+	Peer *string `form:"peer,omitempty" json:"peer,omitempty"`
+}
+
+// ListSyncCheckpointsParams defines parameters for ListSyncCheckpoints.
+type ListSyncCheckpointsParams struct {
+	// Topic The topic to list the sync checkpoints of, every topic when omitted
+	Topic *string `form:"topic,omitempty" json:"topic,omitempty"`
+}
+
+// DeleteWebhookParams defines parameters for DeleteWebhook.
+type DeleteWebhookParams struct {
+	// Id The ID of the registered webhook to delete, its pending deliveries are moved to the dead letters
+	Id string `form:"id" json:"id"`
+}
+
+// RegisterWebhookJSONBody defines parameters for RegisterWebhook.
+type RegisterWebhookJSONBody struct {
+	// Secret The secret keying the HMAC-SHA256 signature of the payloads, generated when omitted
+	Secret *string `json:"secret,omitempty"`
+
+	// Topic The topic whose admitted and spent outputs are delivered to the webhook
+	Topic string `json:"topic"`
+
+	// Url The http or https URL the signed JSON payloads are posted to
+	Url string `json:"url"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// WebhookId The webhook to list the deliveries of, every webhook when omitted
+	WebhookId *string `form:"webhookId,omitempty" json:"webhookId,omitempty"`
+
+	// Status The status of the deliveries to list, every status when omitted. The dead letters are listed with dead
+	Status *ListWebhookDeliveriesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListWebhookDeliveriesParamsStatus defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParamsStatus string
+
 // ArcIngestJSONBody defines parameters for ArcIngest.
 type ArcIngestJSONBody struct {
 	// BlockHeight Block height where the transaction was included
@@ -45,6 +179,18 @@ type ArcIngestJSONBody struct {
 	Txid string `json:"txid"`
 }
 
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// Topic The topics to stream the events of, every topic when omitted
+	Topic *[]string `form:"topic,omitempty" json:"topic,omitempty"`
+
+	// Cursor Cursor of the last event received, the retained events following it are streamed first
+	Cursor *uint64 `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// LastEventID Cursor of the last event received, sent by Server-Sent Events clients when reconnecting. The cursor query parameter takes precedence
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
 // GetLookupServiceProviderDocumentationParams defines parameters for GetLookupServiceProviderDocumentation.
 type GetLookupServiceProviderDocumentationParams struct {
 	// LookupService The name of the lookup service provider to retrieve documentation for
@@ -83,6 +229,27 @@ type RequestForeignGASPNodeParams struct {
 	XBSVTopic string `json:"X-BSV-Topic"`
 }
 
+// RequestSyncReplyJSONBody defines parameters for RequestSyncReply.
+type RequestSyncReplyJSONBody struct {
+	// UTXOList UTXOs known to the requester
+	UTXOList []struct {
+		// Txid Transaction ID in hexadecimal format
+		Txid string `json:"txid"`
+
+		// Vout Output index number
+		Vout uint32 `json:"vout"`
+	} `json:"UTXOList"`
+
+	// Since Timestamp from which the requester listed its UTXOs
+	Since uint32 `json:"since"`
+}
+
+// RequestSyncReplyParams defines parameters for RequestSyncReply.
+type RequestSyncReplyParams struct {
+	// XBSVTopic Topic identifier for the sync reply request
+	XBSVTopic string `json:"X-BSV-Topic"`
+}
+
 // RequestSyncResponseJSONBody defines parameters for RequestSyncResponse.
 type RequestSyncResponseJSONBody struct {
 	// Since Timestamp or sequence number from which to start synchronization
@@ -100,9 +267,80 @@ type RequestSyncResponseParams struct {
 
 // SubmitTransactionParams defines parameters for SubmitTransaction.
 type SubmitTransactionParams struct {
+	// Async Queue the transaction and return a job ID right away instead of waiting for the STEAK
+	Async *bool `form:"async,omitempty" json:"async,omitempty"`
+
+	// DryRun Return the STEAK the topic managers would produce without storing, broadcasting or propagating the transaction
+	DryRun  *bool    `form:"dryRun,omitempty" json:"dryRun,omitempty"`
 	XTopics []string `json:"x-topics"`
 }
 
+// GetSubmitJobStatusParams defines parameters for GetSubmitJobStatus.
+type GetSubmitJobStatusParams struct {
+	// JobId The job ID returned by an asynchronous submission
+	JobId string `form:"jobId" json:"jobId"`
+}
+
+// SubmitBatchJSONBody defines parameters for SubmitBatch.
+type SubmitBatchJSONBody struct {
+	// Beef BEEF in hexadecimal format shared by the transactions selected by their txid, typically holding their common ancestors
+	Beef *string `json:"beef,omitempty"`
+
+	// Transactions The transactions to submit, processed in dependency order
+	Transactions []struct {
+		// Beef The BEEF of the transaction in hexadecimal format, omitted when the transaction is selected from the shared BEEF
+		Beef *string `json:"beef,omitempty"`
+
+		// Topics The topics to submit the transaction to
+		Topics []string `json:"topics"`
+
+		// Txid The ID of the transaction in the shared BEEF, omitted when the transaction comes with its own BEEF
+		Txid *string `json:"txid,omitempty"`
+	} `json:"transactions"`
+}
+
+// SubmitGASPNodeJSONBody defines parameters for SubmitGASPNode.
+type SubmitGASPNodeJSONBody struct {
+	// AncillaryBeef The ancillary BEEF of the node
+	AncillaryBeef *[]byte `json:"ancillaryBeef,omitempty"`
+
+	// GraphID The graph ID in the format of "txID.outputIndex"
+	GraphID string `json:"graphID"`
+
+	// Inputs The inputs of the node
+	Inputs *map[string]interface{} `json:"inputs,omitempty"`
+
+	// OutputIndex The output index of the node
+	OutputIndex uint32 `json:"outputIndex"`
+
+	// OutputMetadata The metadata of the output
+	OutputMetadata *string `json:"outputMetadata,omitempty"`
+
+	// Proof The merkle proof of the transaction in hexadecimal format
+	Proof *string `json:"proof,omitempty"`
+
+	// RawTx The raw transaction of the node in hexadecimal format
+	RawTx string `json:"rawTx"`
+
+	// TxMetadata The metadata of the transaction
+	TxMetadata *string `json:"txMetadata,omitempty"`
+}
+
+// SubmitGASPNodeParams defines parameters for SubmitGASPNode.
+type SubmitGASPNodeParams struct {
+	// XBSVTopic Topic identifier of the graph the node belongs to
+	XBSVTopic string `json:"X-BSV-Topic"`
+}
+
+// EvictOutputJSONRequestBody defines body for EvictOutput for application/json ContentType.
+type EvictOutputJSONRequestBody EvictOutputJSONBody
+
+// ImportTransactionsJSONRequestBody defines body for ImportTransactions for application/json ContentType.
+type ImportTransactionsJSONRequestBody ImportTransactionsJSONBody
+
+// RegisterWebhookJSONRequestBody defines body for RegisterWebhook for application/json ContentType.
+type RegisterWebhookJSONRequestBody RegisterWebhookJSONBody
+
 // ArcIngestJSONRequestBody defines body for ArcIngest for application/json ContentType.
 type ArcIngestJSONRequestBody ArcIngestJSONBody
 
@@ -112,21 +350,66 @@ type LookupQuestionJSONRequestBody LookupQuestionJSONBody
 // RequestForeignGASPNodeJSONRequestBody defines body for RequestForeignGASPNode for application/json ContentType.
 type RequestForeignGASPNodeJSONRequestBody RequestForeignGASPNodeJSONBody
 
+// RequestSyncReplyJSONRequestBody defines body for RequestSyncReply for application/json ContentType.
+type RequestSyncReplyJSONRequestBody RequestSyncReplyJSONBody
+
 // RequestSyncResponseJSONRequestBody defines body for RequestSyncResponse for application/json ContentType.
 type RequestSyncResponseJSONRequestBody RequestSyncResponseJSONBody
 
+// SubmitBatchJSONRequestBody defines body for SubmitBatch for application/json ContentType.
+type SubmitBatchJSONRequestBody SubmitBatchJSONBody
+
+// SubmitGASPNodeJSONRequestBody defines body for SubmitGASPNode for application/json ContentType.
+type SubmitGASPNodeJSONRequestBody SubmitGASPNodeJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (POST /api/v1/admin/evictOutput)
+	EvictOutput(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/import)
+	ImportTransactions(c *fiber.Ctx) error
+
+	// (GET /api/v1/admin/outbox)
+	ListOutboxEntries(c *fiber.Ctx, params ListOutboxEntriesParams) error
+
+	// (POST /api/v1/admin/prune)
+	PruneHistory(c *fiber.Ctx, params PruneHistoryParams) error
+
+	// (POST /api/v1/admin/reorg)
+	HandleReorg(c *fiber.Ctx, params HandleReorgParams) error
+
 	// (POST /api/v1/admin/startGASPSync)
 	StartGASPSync(c *fiber.Ctx) error
 
 	// (POST /api/v1/admin/syncAdvertisements)
 	AdvertisementsSync(c *fiber.Ctx) error
 
+	// (DELETE /api/v1/admin/syncCheckpoints)
+	ResetSyncCheckpoints(c *fiber.Ctx, params ResetSyncCheckpointsParams) error
+
+	// (GET /api/v1/admin/syncCheckpoints)
+	ListSyncCheckpoints(c *fiber.Ctx, params ListSyncCheckpointsParams) error
+
+	// (DELETE /api/v1/admin/webhooks)
+	DeleteWebhook(c *fiber.Ctx, params DeleteWebhookParams) error
+
+	// (GET /api/v1/admin/webhooks)
+	ListWebhooks(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/webhooks)
+	RegisterWebhook(c *fiber.Ctx) error
+
+	// (GET /api/v1/admin/webhooks/deliveries)
+	ListWebhookDeliveries(c *fiber.Ctx, params ListWebhookDeliveriesParams) error
+
 	// (POST /api/v1/arc-ingest)
 	ArcIngest(c *fiber.Ctx) error
 
+	// (GET /api/v1/events)
+	StreamEvents(c *fiber.Ctx, params StreamEventsParams) error
+
 	// (GET /api/v1/getDocumentationForLookupServiceProvider)
 	GetLookupServiceProviderDocumentation(c *fiber.Ctx, params GetLookupServiceProviderDocumentationParams) error
 
@@ -145,11 +428,23 @@ type ServerInterface interface {
 	// (POST /api/v1/requestForeignGASPNode)
 	RequestForeignGASPNode(c *fiber.Ctx, params RequestForeignGASPNodeParams) error
 
+	// (POST /api/v1/requestSyncReply)
+	RequestSyncReply(c *fiber.Ctx, params RequestSyncReplyParams) error
+
 	// (POST /api/v1/requestSyncResponse)
 	RequestSyncResponse(c *fiber.Ctx, params RequestSyncResponseParams) error
 
 	// (POST /api/v1/submit)
 	SubmitTransaction(c *fiber.Ctx, params SubmitTransactionParams) error
+
+	// (GET /api/v1/submit/status)
+	GetSubmitJobStatus(c *fiber.Ctx, params GetSubmitJobStatusParams) error
+
+	// (POST /api/v1/submitBatch)
+	SubmitBatch(c *fiber.Ctx) error
+
+	// (POST /api/v1/submitGASPNode)
+	SubmitGASPNode(c *fiber.Ctx, params SubmitGASPNodeParams) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -159,6 +454,145 @@ type ServerInterfaceWrapper struct {
 	handlerMiddleware []fiber.Handler
 }
 
+// EvictOutput operation middleware
+func (siw *ServerInterfaceWrapper) EvictOutput(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.EvictOutput(c)
+}
+
+// ImportTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ImportTransactions(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ImportTransactions(c)
+}
+
+// ListOutboxEntries operation middleware
+func (siw *ServerInterfaceWrapper) ListOutboxEntries(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOutboxEntriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "txid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "txid", query, &params.Txid)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter txid")
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", query, &params.Status)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter status")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListOutboxEntries(c, params)
+}
+
+// PruneHistory operation middleware
+func (siw *ServerInterfaceWrapper) PruneHistory(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PruneHistoryParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "topic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "topic", query, &params.Topic)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topic")
+	}
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", query, &params.DryRun)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter dryRun")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.PruneHistory(c, params)
+}
+
+// HandleReorg operation middleware
+func (siw *ServerInterfaceWrapper) HandleReorg(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HandleReorgParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "fromHeight" -------------
+
+	if paramValue := c.Query("fromHeight"); paramValue != "" {
+
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid fromHeight must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "fromHeight", query, &params.FromHeight)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter fromHeight")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.HandleReorg(c, params)
+}
+
 // StartGASPSync operation middleware
 func (siw *ServerInterfaceWrapper) StartGASPSync(c *fiber.Ctx) error {
 
@@ -185,6 +619,176 @@ func (siw *ServerInterfaceWrapper) AdvertisementsSync(c *fiber.Ctx) error {
 	return siw.handler.AdvertisementsSync(c)
 }
 
+// ResetSyncCheckpoints operation middleware
+func (siw *ServerInterfaceWrapper) ResetSyncCheckpoints(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ResetSyncCheckpointsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "topic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "topic", query, &params.Topic)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topic")
+	}
+
+	// ------------- Optional query parameter "peer" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "peer", query, &params.Peer)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter peer")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ResetSyncCheckpoints(c, params)
+}
+
+// ListSyncCheckpoints operation middleware
+func (siw *ServerInterfaceWrapper) ListSyncCheckpoints(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSyncCheckpointsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "topic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "topic", query, &params.Topic)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topic")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListSyncCheckpoints(c, params)
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteWebhookParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "id" -------------
+
+	if paramValue := c.Query("id"); paramValue != "" {
+
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid id must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "id", query, &params.Id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter id")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.DeleteWebhook(c, params)
+}
+
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListWebhooks(c)
+}
+
+// RegisterWebhook operation middleware
+func (siw *ServerInterfaceWrapper) RegisterWebhook(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.RegisterWebhook(c)
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "webhookId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "webhookId", query, &params.WebhookId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter webhookId")
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", query, &params.Status)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter status")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListWebhookDeliveries(c, params)
+}
+
 // ArcIngest operation middleware
 func (siw *ServerInterfaceWrapper) ArcIngest(c *fiber.Ctx) error {
 
@@ -198,6 +802,59 @@ func (siw *ServerInterfaceWrapper) ArcIngest(c *fiber.Ctx) error {
 	return siw.handler.ArcIngest(c)
 }
 
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "topic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "topic", query, &params.Topic)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topic")
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", query, &params.Cursor)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter cursor")
+	}
+
+	headers := c.GetReqHeaders()
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "One or more topics are in an invalid format. Empty string values are not allowed.")
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.StreamEvents(c, params)
+}
+
 // GetLookupServiceProviderDocumentation operation middleware
 func (siw *ServerInterfaceWrapper) GetLookupServiceProviderDocumentation(c *fiber.Ctx) error {
 
@@ -346,6 +1003,41 @@ func (siw *ServerInterfaceWrapper) RequestForeignGASPNode(c *fiber.Ctx) error {
 	return siw.handler.RequestForeignGASPNode(c, params)
 }
 
+// RequestSyncReply operation middleware
+func (siw *ServerInterfaceWrapper) RequestSyncReply(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RequestSyncReplyParams
+
+	headers := c.GetReqHeaders()
+
+	// ------------- Required header parameter "X-BSV-Topic" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-BSV-Topic")]; found {
+		var XBSVTopic string
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-BSV-Topic", valueList[0], &XBSVTopic, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "One or more topics are in an invalid format. Empty string values are not allowed.")
+		}
+
+		params.XBSVTopic = XBSVTopic
+
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "The submitted request does not include required header: X-BSV-Topic.")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.RequestSyncReply(c, params)
+}
+
 // RequestSyncResponse operation middleware
 func (siw *ServerInterfaceWrapper) RequestSyncResponse(c *fiber.Ctx) error {
 
@@ -391,6 +1083,26 @@ func (siw *ServerInterfaceWrapper) SubmitTransaction(c *fiber.Ctx) error {
 	// Parameter object where we will unmarshal all parameters from the context
 	var params SubmitTransactionParams
 
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "async" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "async", query, &params.Async)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter async")
+	}
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", query, &params.DryRun)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter dryRun")
+	}
+
 	headers := c.GetReqHeaders()
 
 	// ------------- Required header parameter "x-topics" -------------
@@ -416,6 +1128,91 @@ func (siw *ServerInterfaceWrapper) SubmitTransaction(c *fiber.Ctx) error {
 	return siw.handler.SubmitTransaction(c, params)
 }
 
+// GetSubmitJobStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetSubmitJobStatus(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSubmitJobStatusParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "jobId" -------------
+
+	if paramValue := c.Query("jobId"); paramValue != "" {
+
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid jobId must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "jobId", query, &params.JobId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter jobId")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.GetSubmitJobStatus(c, params)
+}
+
+// SubmitBatch operation middleware
+func (siw *ServerInterfaceWrapper) SubmitBatch(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.SubmitBatch(c)
+}
+
+// SubmitGASPNode operation middleware
+func (siw *ServerInterfaceWrapper) SubmitGASPNode(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SubmitGASPNodeParams
+
+	headers := c.GetReqHeaders()
+
+	// ------------- Required header parameter "X-BSV-Topic" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-BSV-Topic")]; found {
+		var XBSVTopic string
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-BSV-Topic", valueList[0], &XBSVTopic, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "One or more topics are in an invalid format. Empty string values are not allowed.")
+		}
+
+		params.XBSVTopic = XBSVTopic
+
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "The submitted request does not include required header: X-BSV-Topic.")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.SubmitGASPNode(c, params)
+}
+
 // FiberServerOptions provides options for the Fiber server.
 type FiberServerOptions struct {
 	BaseURL           string
@@ -440,12 +1237,36 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 		router.Use(m)
 	}
 
+	router.Post(options.BaseURL+"/api/v1/admin/evictOutput", wrapper.EvictOutput)
+
+	router.Post(options.BaseURL+"/api/v1/admin/import", wrapper.ImportTransactions)
+
+	router.Get(options.BaseURL+"/api/v1/admin/outbox", wrapper.ListOutboxEntries)
+
+	router.Post(options.BaseURL+"/api/v1/admin/prune", wrapper.PruneHistory)
+
+	router.Post(options.BaseURL+"/api/v1/admin/reorg", wrapper.HandleReorg)
+
 	router.Post(options.BaseURL+"/api/v1/admin/startGASPSync", wrapper.StartGASPSync)
 
 	router.Post(options.BaseURL+"/api/v1/admin/syncAdvertisements", wrapper.AdvertisementsSync)
 
+	router.Delete(options.BaseURL+"/api/v1/admin/syncCheckpoints", wrapper.ResetSyncCheckpoints)
+
+	router.Get(options.BaseURL+"/api/v1/admin/syncCheckpoints", wrapper.ListSyncCheckpoints)
+
+	router.Delete(options.BaseURL+"/api/v1/admin/webhooks", wrapper.DeleteWebhook)
+
+	router.Get(options.BaseURL+"/api/v1/admin/webhooks", wrapper.ListWebhooks)
+
+	router.Post(options.BaseURL+"/api/v1/admin/webhooks", wrapper.RegisterWebhook)
+
+	router.Get(options.BaseURL+"/api/v1/admin/webhooks/deliveries", wrapper.ListWebhookDeliveries)
+
 	router.Post(options.BaseURL+"/api/v1/arc-ingest", wrapper.ArcIngest)
 
+	router.Get(options.BaseURL+"/api/v1/events", wrapper.StreamEvents)
+
 	router.Get(options.BaseURL+"/api/v1/getDocumentationForLookupServiceProvider", wrapper.GetLookupServiceProviderDocumentation)
 
 	router.Get(options.BaseURL+"/api/v1/getDocumentationForTopicManager", wrapper.GetTopicManagerDocumentation)
@@ -458,8 +1279,16 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Post(options.BaseURL+"/api/v1/requestForeignGASPNode", wrapper.RequestForeignGASPNode)
 
+	router.Post(options.BaseURL+"/api/v1/requestSyncReply", wrapper.RequestSyncReply)
+
 	router.Post(options.BaseURL+"/api/v1/requestSyncResponse", wrapper.RequestSyncResponse)
 
 	router.Post(options.BaseURL+"/api/v1/submit", wrapper.SubmitTransaction)
 
+	router.Get(options.BaseURL+"/api/v1/submit/status", wrapper.GetSubmitJobStatus)
+
+	router.Post(options.BaseURL+"/api/v1/submitBatch", wrapper.SubmitBatch)
+
+	router.Post(options.BaseURL+"/api/v1/submitGASPNode", wrapper.SubmitGASPNode)
+
 }
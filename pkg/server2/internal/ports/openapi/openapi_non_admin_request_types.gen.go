@@ -36,6 +36,21 @@ type RequestForeignGASPNodeBody struct {
 	TxID string `json:"txID"`
 }
 
+// RequestSyncReplyBody defines model for RequestSyncReplyBody.
+type RequestSyncReplyBody struct {
+	// UTXOList UTXOs known to the requester
+	UTXOList []struct {
+		// Txid Transaction ID in hexadecimal format
+		Txid string `json:"txid"`
+
+		// Vout Output index number
+		Vout uint32 `json:"vout"`
+	} `json:"UTXOList"`
+
+	// Since Timestamp from which the requester listed its UTXOs
+	Since uint32 `json:"since"`
+}
+
 // RequestSyncResponseBody defines model for RequestSyncResponseBody.
 type RequestSyncResponseBody struct {
 	// Since Timestamp or sequence number from which to start synchronization
@@ -44,3 +59,48 @@ type RequestSyncResponseBody struct {
 	// Version The version number of the GASP protocol
 	Version int `json:"version"`
 }
+
+// SubmitBatchBody defines model for SubmitBatchBody.
+type SubmitBatchBody struct {
+	// Beef BEEF in hexadecimal format shared by the transactions selected by their txid, typically holding their common ancestors
+	Beef *string `json:"beef,omitempty"`
+
+	// Transactions The transactions to submit, processed in dependency order
+	Transactions []struct {
+		// Beef The BEEF of the transaction in hexadecimal format, omitted when the transaction is selected from the shared BEEF
+		Beef *string `json:"beef,omitempty"`
+
+		// Topics The topics to submit the transaction to
+		Topics []string `json:"topics"`
+
+		// Txid The ID of the transaction in the shared BEEF, omitted when the transaction comes with its own BEEF
+		Txid *string `json:"txid,omitempty"`
+	} `json:"transactions"`
+}
+
+// SubmitGASPNodeBody defines model for SubmitGASPNodeBody.
+type SubmitGASPNodeBody struct {
+	// AncillaryBeef The ancillary BEEF of the node
+	AncillaryBeef *[]byte `json:"ancillaryBeef,omitempty"`
+
+	// GraphID The graph ID in the format of "txID.outputIndex"
+	GraphID string `json:"graphID"`
+
+	// Inputs The inputs of the node
+	Inputs *map[string]interface{} `json:"inputs,omitempty"`
+
+	// OutputIndex The output index of the node
+	OutputIndex uint32 `json:"outputIndex"`
+
+	// OutputMetadata The metadata of the output
+	OutputMetadata *string `json:"outputMetadata,omitempty"`
+
+	// Proof The merkle proof of the transaction in hexadecimal format
+	Proof *string `json:"proof,omitempty"`
+
+	// RawTx The raw transaction of the node in hexadecimal format
+	RawTx string `json:"rawTx"`
+
+	// TxMetadata The metadata of the transaction
+	TxMetadata *string `json:"txMetadata,omitempty"`
+}
@@ -3,6 +3,10 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package openapi
 
+import (
+	"time"
+)
+
 // AdmittanceInstructions defines model for AdmittanceInstructions.
 type AdmittanceInstructions struct {
 	AncillaryTxIDs []string `json:"ancillaryTxIDs"`
@@ -17,6 +21,36 @@ type ArcIngest struct {
 	Status  string `json:"status"`
 }
 
+// Event Data of a Server-Sent Event, the id of the event is its cursor and the event name its type
+type Event struct {
+	// BlockHeight Block height of the output, set for block-height-updated events and zero when downgraded by a reorg
+	BlockHeight *uint32 `json:"blockHeight,omitempty"`
+
+	// BlockIdx Index of the transaction within its block, set for block-height-updated events
+	BlockIdx  *uint64   `json:"blockIdx,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	Cursor    uint64    `json:"cursor"`
+
+	// Error Why the sync failed, set for failed sync-finished events
+	Error *string `json:"error,omitempty"`
+
+	// OutputIndex Index of the output, omitted for sync-finished events
+	OutputIndex *uint32 `json:"outputIndex,omitempty"`
+
+	// Peer Peer the topic was synced with, set for sync-finished events
+	Peer *string `json:"peer,omitempty"`
+
+	// SpendingTxid Transaction spending the output, set for output-spent events
+	SpendingTxid *string `json:"spendingTxid,omitempty"`
+	Topic        string  `json:"topic"`
+
+	// Txid Transaction of the output, omitted for sync-finished events
+	Txid *string `json:"txid,omitempty"`
+
+	// Type One of output-admitted, output-spent, output-evicted, block-height-updated or sync-finished
+	Type string `json:"type"`
+}
+
 // GASPNode A GASP node representation from the overlay engine
 type GASPNode struct {
 	// AncillaryBeef The ancillary beef of the GASP node
@@ -44,6 +78,12 @@ type GASPNode struct {
 	TxMetadata string `json:"txMetadata"`
 }
 
+// GASPNodeResponseData defines model for GASPNodeResponseData.
+type GASPNodeResponseData struct {
+	// Metadata Whether the metadata of the input is requested
+	Metadata bool `json:"metadata"`
+}
+
 // LookupAnswer defines model for LookupAnswer.
 type LookupAnswer struct {
 	Outputs []OutputListItem `json:"outputs"`
@@ -66,6 +106,11 @@ type OutputListItem struct {
 	OutputIndex uint32 `json:"outputIndex"`
 }
 
+// RequestSyncReply defines model for RequestSyncReply.
+type RequestSyncReply struct {
+	UTXOList []UTXOItem `json:"UTXOList"`
+}
+
 // RequestSyncRes defines model for RequestSyncRes.
 type RequestSyncRes struct {
 	UTXOList []UTXOItem `json:"UTXOList"`
@@ -86,6 +131,54 @@ type ServiceMetadata struct {
 	Version          string `json:"version"`
 }
 
+// SubmitBatch defines model for SubmitBatch.
+type SubmitBatch struct {
+	// Results The outcome of every transaction, in the order of the request
+	Results []SubmitBatchResult `json:"results"`
+}
+
+// SubmitBatchResult defines model for SubmitBatchResult.
+type SubmitBatchResult struct {
+	STEAK *STEAK          `json:"STEAK,omitempty"`
+	Error *SubmitJobError `json:"error,omitempty"`
+
+	// Txid The ID of the transaction, omitted when its BEEF cannot be parsed
+	Txid *string `json:"txid,omitempty"`
+}
+
+// SubmitGASPNode defines model for SubmitGASPNode.
+type SubmitGASPNode struct {
+	// RequestedInputs Inputs of the node to submit next, keyed by outpoint in the format of "txID.outputIndex"
+	RequestedInputs map[string]GASPNodeResponseData `json:"requestedInputs"`
+}
+
+// SubmitJob defines model for SubmitJob.
+type SubmitJob struct {
+	STEAK *STEAK `json:"STEAK,omitempty"`
+
+	// CompletedAt When the submission finished, omitted until completed or failed
+	CompletedAt *time.Time      `json:"completedAt,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	Error       *SubmitJobError `json:"error,omitempty"`
+	JobId       string          `json:"jobId"`
+
+	// StartedAt When the submission started being processed, omitted while queued
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	// Status One of queued, processing, completed or failed
+	Status string   `json:"status"`
+	Topics []string `json:"topics"`
+}
+
+// SubmitJobError defines model for SubmitJobError.
+type SubmitJobError struct {
+	// Code Machine-readable reason of the failure, such as unknown-topic, invalid-beef, invalid-transaction, missing-input or input-spent
+	Code string `json:"code"`
+
+	// Message Human-readable reason of the failure
+	Message string `json:"message"`
+}
+
 // SubmitTransaction defines model for SubmitTransaction.
 type SubmitTransaction struct {
 	STEAK STEAK `json:"STEAK"`
@@ -121,9 +214,24 @@ type MetadataResponse = Metadata
 // RequestForeignGASPNodeResponse A GASP node representation from the overlay engine
 type RequestForeignGASPNodeResponse = GASPNode
 
+// RequestSyncReplyResponse defines model for RequestSyncReplyResponse.
+type RequestSyncReplyResponse = RequestSyncReply
+
 // RequestSyncResResponse defines model for RequestSyncResResponse.
 type RequestSyncResResponse = RequestSyncRes
 
+// SubmitBatchResponse defines model for SubmitBatchResponse.
+type SubmitBatchResponse = SubmitBatch
+
+// SubmitGASPNodeResponse defines model for SubmitGASPNodeResponse.
+type SubmitGASPNodeResponse = SubmitGASPNode
+
+// SubmitJobStatusResponse defines model for SubmitJobStatusResponse.
+type SubmitJobStatusResponse = SubmitJob
+
+// SubmitTransactionJobResponse defines model for SubmitTransactionJobResponse.
+type SubmitTransactionJobResponse = SubmitJob
+
 // SubmitTransactionResponse defines model for SubmitTransactionResponse.
 type SubmitTransactionResponse = SubmitTransaction
 
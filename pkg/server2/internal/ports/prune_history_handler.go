@@ -0,0 +1,64 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PruneHistoryHandler is a Fiber-compatible HTTP handler that enforces the retention policies
+// of the topics. It acts as the adapter between HTTP requests and the application-layer PruneHistoryService.
+type PruneHistoryHandler struct {
+	service *app.PruneHistoryService
+}
+
+// Handle processes an HTTP POST request enforcing the retention policy of the topic given by the
+// topic query parameter, or of every topic with a retention policy when it is omitted. When the
+// dryRun query parameter is set, the outputs are only reported.
+//
+// On success, returns 200 OK with a report of the pruned outputs per topic.
+// On failure, returns an application error.
+func (h *PruneHistoryHandler) Handle(c *fiber.Ctx, params openapi.PruneHistoryParams) error {
+	dto := app.PruneHistoryDTO{}
+	if params.Topic != nil {
+		dto.Topic = *params.Topic
+	}
+	if params.DryRun != nil {
+		dto.DryRun = *params.DryRun
+	}
+
+	reports, err := h.service.PruneHistory(c.UserContext(), dto)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewPruneHistorySuccessResponse(reports))
+}
+
+// NewPruneHistoryHandler creates a new PruneHistoryHandler with the given provider.
+// If the provider is nil, it panics.
+func NewPruneHistoryHandler(provider app.PruneHistoryProvider) *PruneHistoryHandler {
+	return &PruneHistoryHandler{service: app.NewPruneHistoryService(provider)}
+}
+
+// NewPruneHistorySuccessResponse returns a PruneHistoryResponse
+// built from the reports of the pruned topics.
+func NewPruneHistorySuccessResponse(reports []app.PruneReportDTO) openapi.PruneHistoryResponse {
+	response := openapi.PruneHistoryResponse{Reports: make([]openapi.PruneReport, 0, len(reports))}
+	for _, report := range reports {
+		pruned := make([]openapi.PrunedOutput, 0, len(report.Pruned))
+		for _, output := range report.Pruned {
+			pruned = append(pruned, openapi.PrunedOutput{
+				Txid:        output.TxID,
+				OutputIndex: output.OutputIndex,
+				Reason:      output.Reason,
+			})
+		}
+		response.Reports = append(response.Reports, openapi.PruneReport{
+			Topic:  report.Topic,
+			DryRun: report.DryRun,
+			Pruned: pruned,
+		})
+	}
+	return response
+}
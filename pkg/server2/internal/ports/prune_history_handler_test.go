@@ -0,0 +1,103 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneHistoryHandler_InvalidCases(t *testing.T) {
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	tests := map[string]struct {
+		queryParams      map[string]string
+		expectations     testabilities.PruneHistoryProviderMockExpectations
+		expectedStatus   int
+		expectedResponse openapi.Error
+	}{
+		"Prune history service fails to handle the request": {
+			expectations: testabilities.PruneHistoryProviderMockExpectations{
+				PruneHistoryCall: true,
+				Error:            testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewPruneHistoryProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+		"Prune history service rejects a topic without retention policy": {
+			queryParams: map[string]string{"topic": "tm_test"},
+			expectations: testabilities.PruneHistoryProviderMockExpectations{
+				PruneHistoryCall: true,
+				Topic:            "tm_test",
+				Error:            engine.ErrNoRetentionPolicy,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewRetentionPolicyNotFoundError("tm_test")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithPruneHistoryProvider(testabilities.NewPruneHistoryProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParams(tc.queryParams).
+				SetError(&actualResponse).
+				Post("/api/v1/admin/prune")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestPruneHistoryHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	txid := chainhash.DoubleHashH([]byte("pruned"))
+	expectations := testabilities.PruneHistoryProviderMockExpectations{
+		PruneHistoryCall: true,
+		DryRun:           true,
+		Reports: []*engine.PruneReport{{
+			Topic:  "tm_test",
+			DryRun: true,
+			Pruned: []engine.PrunedOutput{{Outpoint: transaction.Outpoint{Txid: txid, Index: 1}, Reason: engine.PruneReasonUnspentOnly}},
+		}},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithPruneHistoryProvider(testabilities.NewPruneHistoryProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.PruneHistory
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("dryRun", "true").
+		SetResult(&actualResponse).
+		Post("/api/v1/admin/prune")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewPruneHistorySuccessResponse([]app.PruneReportDTO{{
+		Topic:  "tm_test",
+		DryRun: true,
+		Pruned: []app.PrunedOutputDTO{{TxID: txid.String(), OutputIndex: 1, Reason: "unspent-only"}},
+	}}), actualResponse)
+	stub.AssertProvidersState()
+}
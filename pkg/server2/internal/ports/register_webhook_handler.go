@@ -0,0 +1,56 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterWebhookHandler is a Fiber-compatible HTTP handler that registers webhooks in
+// the overlay engine. It acts as the adapter between HTTP requests and the
+// application-layer RegisterWebhookService.
+type RegisterWebhookHandler struct {
+	service *app.RegisterWebhookService
+}
+
+// Handle processes an HTTP POST request registering the webhook described in the JSON body,
+// conforming to the RegisterWebhookJSONBody OpenAPI definition.
+//
+// On success, returns 200 OK with the registered webhook including its secret.
+// On failure, returns a request parsing or service-level error.
+func (h *RegisterWebhookHandler) Handle(c *fiber.Ctx) error {
+	var body openapi.RegisterWebhookJSONBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	dto := app.RegisterWebhookDTO{Topic: body.Topic, URL: body.Url}
+	if body.Secret != nil {
+		dto.Secret = *body.Secret
+	}
+
+	webhook, err := h.service.RegisterWebhook(c.UserContext(), dto)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewRegisterWebhookSuccessResponse(webhook))
+}
+
+// NewRegisterWebhookHandler creates a new RegisterWebhookHandler with the given provider.
+// If the provider is nil, it panics.
+func NewRegisterWebhookHandler(provider app.RegisterWebhookProvider) *RegisterWebhookHandler {
+	return &RegisterWebhookHandler{service: app.NewRegisterWebhookService(provider)}
+}
+
+// NewRegisterWebhookSuccessResponse converts the webhook DTO into a
+// RegisterWebhookResponse object compatible with the OpenAPI specification.
+func NewRegisterWebhookSuccessResponse(webhook app.WebhookDTO) openapi.RegisterWebhookResponse {
+	return openapi.RegisterWebhookResponse{
+		Id:        webhook.ID,
+		Topic:     webhook.Topic,
+		Url:       webhook.URL,
+		Secret:    webhook.Secret,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
@@ -0,0 +1,113 @@
+package ports_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterWebhookHandler_InvalidCases(t *testing.T) {
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	invalidWebhookError := fmt.Errorf("%w: unsupported URL scheme ftp", engine.ErrInvalidWebhook)
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.RegisterWebhookProviderMockExpectations
+		expectedStatus   int
+		expectedResponse openapi.Error
+	}{
+		"Register webhook service rejects a request without URL": {
+			body:             map[string]any{"topic": "tm_test"},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("url")),
+		},
+		"Register webhook service rejects an invalid webhook": {
+			body: map[string]any{"topic": "tm_test", "url": "ftp://example.com/hook"},
+			expectations: testabilities.RegisterWebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				Topic:               "tm_test",
+				URL:                 "ftp://example.com/hook",
+				Error:               invalidWebhookError,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewInvalidWebhookError(invalidWebhookError)),
+		},
+		"Register webhook service fails to handle the request": {
+			body: map[string]any{"topic": "tm_test", "url": "https://example.com/hook"},
+			expectations: testabilities.RegisterWebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				Topic:               "tm_test",
+				URL:                 "https://example.com/hook",
+				Error:               testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewRegisterWebhookProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithRegisterWebhookProvider(testabilities.NewRegisterWebhookProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetError(&actualResponse).
+				Post("/api/v1/admin/webhooks")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestRegisterWebhookHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	expectations := testabilities.RegisterWebhookProviderMockExpectations{
+		RegisterWebhookCall: true,
+		Topic:               "tm_test",
+		URL:                 "https://example.com/hook",
+		Secret:              "secret",
+		Webhook:             &engine.Webhook{ID: "id", Topic: "tm_test", URL: "https://example.com/hook", Secret: "secret", CreatedAt: createdAt},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithRegisterWebhookProvider(testabilities.NewRegisterWebhookProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.RegisterWebhook
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetBody(map[string]any{"topic": "tm_test", "url": "https://example.com/hook", "secret": "secret"}).
+		SetResult(&actualResponse).
+		Post("/api/v1/admin/webhooks")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewRegisterWebhookSuccessResponse(app.WebhookDTO{
+		ID:        "id",
+		Topic:     "tm_test",
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		CreatedAt: createdAt,
+	}), actualResponse)
+	stub.AssertProvidersState()
+}
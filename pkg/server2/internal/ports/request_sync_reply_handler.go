@@ -0,0 +1,66 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestSyncReplyHandler is a Fiber-compatible HTTP handler that processes
+// requests to reply to the initial sync response of a foreign peer.
+// It acts as the adapter between HTTP requests and the application-layer
+// RequestSyncReplyService.
+type RequestSyncReplyHandler struct {
+	service *app.RequestSyncReplyService
+}
+
+// Handle processes an HTTP POST request to fetch sync reply data.
+// It expects a JSON request body matching the RequestSyncReplyJSONRequestBody OpenAPI schema,
+// and requires the topic to be passed as an X-BSV-Topic header parameter.
+//
+// On success, returns 200 OK with the list of UTXOs the requester did not list.
+// On failure, returns a request parsing or application error.
+func (h *RequestSyncReplyHandler) Handle(c *fiber.Ctx, params openapi.RequestSyncReplyParams) error {
+	var body openapi.RequestSyncReplyJSONRequestBody
+
+	err := c.BodyParser(&body)
+	if err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	utxos := make([]app.OutpointDTO, 0, len(body.UTXOList))
+	for _, utxo := range body.UTXOList {
+		utxos = append(utxos, app.OutpointDTO{TxID: utxo.Txid, OutputIndex: utxo.Vout})
+	}
+
+	dto, err := h.service.RequestSyncReply(c.Context(), app.NewTopic(params.XBSVTopic), utxos, app.NewSince(body.Since))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewRequestSyncReplySuccessResponse(dto))
+}
+
+// NewRequestSyncReplyHandler constructs a new RequestSyncReplyHandler
+// with the provided application-level RequestSyncReplyProvider.
+//
+// Panics if the provider is nil.
+func NewRequestSyncReplyHandler(provider app.RequestSyncReplyProvider) *RequestSyncReplyHandler {
+	return &RequestSyncReplyHandler{service: app.NewRequestSyncReplyService(provider)}
+}
+
+// NewRequestSyncReplySuccessResponse converts a RequestSyncReplyDTO into a
+// RequestSyncReplyResponse object compatible with the OpenAPI specification.
+func NewRequestSyncReplySuccessResponse(reply *app.RequestSyncReplyDTO) *openapi.RequestSyncReplyResponse {
+	utxos := make([]openapi.UTXOItem, 0)
+	if reply != nil {
+		for _, utxo := range reply.UTXOList {
+			utxos = append(utxos, openapi.UTXOItem{
+				Txid: utxo.TxID,
+				Vout: int(utxo.OutputIndex),
+			})
+		}
+	}
+
+	return &openapi.RequestSyncReplyResponse{UTXOList: utxos}
+}
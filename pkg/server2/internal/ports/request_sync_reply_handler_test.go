@@ -0,0 +1,141 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestSyncReplyHandler_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		payload            any
+		headers            map[string]string
+		expectations       testabilities.RequestSyncReplyProviderMockExpectations
+		expectedStatusCode int
+		expectedResponse   openapi.Error
+	}{
+		"Request sync reply handler fails due to missing topic header": {
+			payload: openapi.RequestSyncReplyBody{Since: testabilities.DefaultSince},
+			headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+			expectedStatusCode: fiber.StatusBadRequest,
+			expectations: testabilities.RequestSyncReplyProviderMockExpectations{
+				ProvideForeignSyncReplyCall: false,
+			},
+			expectedResponse: openapi.Error{Code: app.ErrorTypeIncorrectInput.String(), Message: "The submitted request does not include required header: X-BSV-Topic."},
+		},
+		"Request sync reply handler fails due to invalid JSON": {
+			payload: "INVALID_JSON",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-BSV-Topic":  testabilities.DefaultTopic,
+			},
+			expectedStatusCode: fiber.StatusInternalServerError,
+			expectations: testabilities.RequestSyncReplyProviderMockExpectations{
+				ProvideForeignSyncReplyCall: false,
+			},
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, ports.NewRequestBodyParserError(testabilities.ErrTestNoopOpFailure)),
+		},
+		"Request sync reply handler fails due to provider error": {
+			payload: openapi.RequestSyncReplyBody{Since: testabilities.DefaultSince},
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-BSV-Topic":  testabilities.DefaultTopic,
+			},
+			expectations: testabilities.RequestSyncReplyProviderMockExpectations{
+				Error:                       testabilities.ErrTestNoopOpFailure,
+				ProvideForeignSyncReplyCall: true,
+				InitialResponse: &core.GASPInitialResponse{
+					UTXOList: []*transaction.Outpoint{},
+					Since:    testabilities.DefaultSince,
+				},
+				Topic: testabilities.DefaultTopic,
+			},
+			expectedStatusCode: fiber.StatusInternalServerError,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewRequestSyncReplyProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithRequestSyncReplyProvider(
+				testabilities.NewRequestSyncReplyProviderMock(t, tc.expectations),
+			))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+			// when:
+			var actualResponse openapi.Error
+
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(tc.headers).
+				SetBody(tc.payload).
+				SetError(&actualResponse).
+				Post("/api/v1/requestSyncReply")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestRequestSyncReplyHandler_ValidCase(t *testing.T) {
+	// given:
+	known := &transaction.Outpoint{
+		Txid:  *testabilities.DummyTxHash(t, "03895fb984362a4196bc9931629318fcbb2aeba7c6293638119ea653fa31d119"),
+		Index: 0,
+	}
+	expectations := testabilities.RequestSyncReplyProviderMockExpectations{
+		ProvideForeignSyncReplyCall: true,
+		InitialResponse: &core.GASPInitialResponse{
+			UTXOList: []*transaction.Outpoint{known},
+			Since:    testabilities.DefaultSince,
+		},
+		Topic: testabilities.DefaultTopic,
+		Reply: &core.GASPInitialReply{
+			UTXOList: []*transaction.Outpoint{
+				{
+					Txid:  *testabilities.DummyTxHash(t, "27c8f37851aabc468d3dbb6bf0789dc398a602dcb897ca04e7815d939d621595"),
+					Index: 1,
+				},
+			},
+		},
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithRequestSyncReplyProvider(
+		testabilities.NewRequestSyncReplyProviderMock(t, expectations),
+	))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+	expectedResponse := ports.NewRequestSyncReplySuccessResponse(app.NewRequestSyncReplyDTO(expectations.Reply))
+
+	// when:
+	var actualResponse openapi.RequestSyncReplyResponse
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			"Content-Type": "application/json",
+			"X-BSV-Topic":  testabilities.DefaultTopic,
+		}).
+		SetBody(map[string]any{
+			"UTXOList": []map[string]any{{"txid": known.Txid.String(), "vout": known.Index}},
+			"since":    testabilities.DefaultSince,
+		}).
+		SetResult(&actualResponse).
+		Post("/api/v1/requestSyncReply")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, expectedResponse, &actualResponse)
+	stub.AssertProvidersState()
+}
@@ -31,7 +31,7 @@ func TestRequestSyncResponseHandler_InvalidCases(t *testing.T) {
 			expectations: testabilities.RequestSyncResponseProviderMockExpectations{
 				ProvideForeignSyncResponseCall: false,
 			},
-			expectedResponse: openapi.Error{Message: "The submitted request does not include required header: X-BSV-Topic."},
+			expectedResponse: openapi.Error{Code: app.ErrorTypeIncorrectInput.String(), Message: "The submitted request does not include required header: X-BSV-Topic."},
 		},
 		"Request sync response handler fails due to invalid JSON": {
 			payload: "INVALID_JSON",
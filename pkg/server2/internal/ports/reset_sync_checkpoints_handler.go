@@ -0,0 +1,48 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ResetSyncCheckpointsHandler is a Fiber-compatible HTTP handler that resets
+// the GASP sync checkpoints of the overlay engine. It acts as the adapter between
+// HTTP requests and the application-layer ResetSyncCheckpointsService.
+type ResetSyncCheckpointsHandler struct {
+	service *app.ResetSyncCheckpointsService
+}
+
+// Handle processes an HTTP DELETE request resetting the sync checkpoints matching
+// the optional topic and peer query parameters. Omitted parameters match everything.
+//
+// On success, returns 200 OK with the number of deleted checkpoints.
+// On failure, returns an application error.
+func (h *ResetSyncCheckpointsHandler) Handle(c *fiber.Ctx, params openapi.ResetSyncCheckpointsParams) error {
+	var topic, peer string
+	if params.Topic != nil {
+		topic = *params.Topic
+	}
+	if params.Peer != nil {
+		peer = *params.Peer
+	}
+
+	deleted, err := h.service.ResetSyncCheckpoints(c.UserContext(), topic, peer)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewResetSyncCheckpointsSuccessResponse(deleted))
+}
+
+// NewResetSyncCheckpointsHandler creates a new ResetSyncCheckpointsHandler with the given provider.
+// If the provider is nil, it panics.
+func NewResetSyncCheckpointsHandler(provider app.ResetSyncCheckpointsProvider) *ResetSyncCheckpointsHandler {
+	return &ResetSyncCheckpointsHandler{service: app.NewResetSyncCheckpointsService(provider)}
+}
+
+// NewResetSyncCheckpointsSuccessResponse returns a ResetSyncCheckpointsResponse
+// reporting the number of deleted checkpoints.
+func NewResetSyncCheckpointsSuccessResponse(deleted int) openapi.ResetSyncCheckpointsResponse {
+	return openapi.ResetSyncCheckpointsResponse{Deleted: deleted}
+}
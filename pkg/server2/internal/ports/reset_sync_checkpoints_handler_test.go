@@ -0,0 +1,68 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetSyncCheckpointsHandler_InvalidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	expectations := testabilities.ResetSyncCheckpointsProviderMockExpectations{
+		ResetSyncCheckpointsCall: true,
+		Error:                    testabilities.ErrTestNoopOpFailure,
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithResetSyncCheckpointsProvider(testabilities.NewResetSyncCheckpointsProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+	expectedResponse := testabilities.NewTestOpenapiErrorResponse(t, app.NewResetSyncCheckpointsProviderError(testabilities.ErrTestNoopOpFailure))
+
+	// when:
+	var actualResponse openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetError(&actualResponse).
+		Delete("/api/v1/admin/syncCheckpoints")
+
+	// then:
+	require.Equal(t, fiber.StatusInternalServerError, res.StatusCode())
+	require.Equal(t, expectedResponse, actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestResetSyncCheckpointsHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "428e1f07-79b6-4901-b0a0-ec1fe815331b"
+	checkpoint := testabilities.DefaultSyncCheckpoint
+	expectations := testabilities.ResetSyncCheckpointsProviderMockExpectations{
+		ResetSyncCheckpointsCall: true,
+		Topic:                    checkpoint.Topic,
+		Peer:                     checkpoint.Peer,
+		Deleted:                  1,
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithResetSyncCheckpointsProvider(testabilities.NewResetSyncCheckpointsProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub), server2.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.ResetSyncCheckpoints
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParams(map[string]string{"topic": checkpoint.Topic, "peer": checkpoint.Peer}).
+		SetResult(&actualResponse).
+		Delete("/api/v1/admin/syncCheckpoints")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewResetSyncCheckpointsSuccessResponse(1), actualResponse)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,121 @@
+package ports
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// StreamEventsHeartbeatInterval is how often a comment is written to an idle event stream,
+// so proxies do not close the connection and a client gone away is noticed.
+const StreamEventsHeartbeatInterval = 15 * time.Second
+
+// StreamEventsHandler is a Fiber-compatible HTTP handler that streams the events published by the
+// overlay engine as Server-Sent Events. It acts as the adapter between HTTP requests and the
+// application-layer StreamEventsService.
+type StreamEventsHandler struct {
+	service *app.StreamEventsService
+}
+
+// Handle processes an HTTP GET request streaming the events of the topics given by the topic query
+// parameters, or of every topic when none is given. The events following the cursor query parameter,
+// or the Last-Event-ID header sent by reconnecting clients, are streamed first.
+//
+// On success, returns 200 OK and streams every event as a Server-Sent Event whose id is the cursor
+// of the event, whose name is its type and whose data is the JSON Event OpenAPI schema, until the
+// client disconnects or the engine shuts down.
+// On failure, returns an application error.
+func (h *StreamEventsHandler) Handle(c *fiber.Ctx, params openapi.StreamEventsParams) error {
+	dto := app.StreamEventsDTO{Cursor: params.Cursor}
+	if params.Topic != nil {
+		dto.Topics = *params.Topic
+	}
+	if dto.Cursor == nil && params.LastEventID != nil && *params.LastEventID != "" {
+		cursor, err := strconv.ParseUint(*params.LastEventID, 10, 64)
+		if err != nil {
+			return app.NewIncorrectInputWithFieldError(fiber.HeaderLastEventID)
+		}
+		dto.Cursor = &cursor
+	}
+
+	sub, err := h.service.Subscribe(dto)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+		writeEventStream(w, sub, StreamEventsHeartbeatInterval)
+	})
+	return nil
+}
+
+// writeEventStream writes the events of the subscription until it ends or the client goes away.
+func writeEventStream(w *bufio.Writer, sub *engine.EventSubscription, heartbeat time.Duration) {
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(NewStreamEventsSuccessResponse(app.NewEventDTO(event)))
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Cursor, event.Type, data)
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// NewStreamEventsHandler creates a new StreamEventsHandler with the given provider.
+// If the provider is nil, it panics.
+func NewStreamEventsHandler(provider app.StreamEventsProvider) *StreamEventsHandler {
+	return &StreamEventsHandler{service: app.NewStreamEventsService(provider)}
+}
+
+// NewStreamEventsSuccessResponse returns the data of the Server-Sent Event of an event,
+// omitting the fields the type of the event does not set.
+func NewStreamEventsSuccessResponse(dto app.EventDTO) openapi.Event {
+	response := openapi.Event{
+		Cursor:    dto.Cursor,
+		Type:      dto.Type,
+		Topic:     dto.Topic,
+		CreatedAt: dto.CreatedAt,
+	}
+	if dto.TxID != "" {
+		response.Txid = &dto.TxID
+		response.OutputIndex = &dto.OutputIndex
+	}
+	if dto.SpendingTxID != "" {
+		response.SpendingTxid = &dto.SpendingTxID
+	}
+	if dto.Type == string(engine.EventBlockHeightUpdated) {
+		response.BlockHeight = &dto.BlockHeight
+		response.BlockIdx = &dto.BlockIdx
+	}
+	if dto.Peer != "" {
+		response.Peer = &dto.Peer
+	}
+	if dto.Error != "" {
+		response.Error = &dto.Error
+	}
+	return response
+}
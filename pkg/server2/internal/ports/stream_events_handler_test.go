@@ -0,0 +1,127 @@
+package ports_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamEventsHandler_InvalidCases(t *testing.T) {
+	cursor := uint64(7)
+	tests := map[string]struct {
+		queryParams      map[string]string
+		headers          map[string]string
+		expectations     testabilities.StreamEventsProviderMockExpectations
+		expectedStatus   int
+		expectedResponse openapi.Error
+	}{
+		"Stream events service fails to handle the request": {
+			expectations: testabilities.StreamEventsProviderMockExpectations{
+				SubscribeEventsCall: true,
+				Error:               testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewStreamEventsProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+		"Stream events service rejects an expired cursor": {
+			queryParams: map[string]string{"cursor": "7"},
+			expectations: testabilities.StreamEventsProviderMockExpectations{
+				SubscribeEventsCall: true,
+				Cursor:              &cursor,
+				Error:               engine.ErrEventCursorExpired,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewEventCursorExpiredError(cursor)),
+		},
+		"Stream events handler rejects an invalid Last-Event-ID header": {
+			headers: map[string]string{fiber.HeaderLastEventID: "not-a-cursor"},
+			expectations: testabilities.StreamEventsProviderMockExpectations{
+				SubscribeEventsCall: false,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError(fiber.HeaderLastEventID)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithStreamEventsProvider(testabilities.NewStreamEventsProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetQueryParams(tc.queryParams).
+				SetHeaders(tc.headers).
+				SetError(&actualResponse).
+				Get("/api/v1/events")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestStreamEventsHandler_ValidCase(t *testing.T) {
+	// given:
+	txid := chainhash.DoubleHashH([]byte("admitted"))
+	spendingTxid := chainhash.DoubleHashH([]byte("spending"))
+	cursor := uint64(1)
+	expectations := testabilities.StreamEventsProviderMockExpectations{
+		SubscribeEventsCall: true,
+		Topics:              []string{"tm_test"},
+		Cursor:              &cursor,
+		Events: []engine.Event{
+			{Type: engine.EventOutputAdmitted, Topic: "tm_test", Outpoint: transaction.Outpoint{Txid: txid, Index: 1}},
+			{Type: engine.EventOutputAdmitted, Topic: "tm_other", Outpoint: transaction.Outpoint{Txid: txid, Index: 2}},
+			{Type: engine.EventOutputSpent, Topic: "tm_test", Outpoint: transaction.Outpoint{Txid: txid, Index: 1}, SpendingTxid: &spendingTxid},
+		},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithStreamEventsProvider(testabilities.NewStreamEventsProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetQueryParam("topic", "tm_test").
+		SetHeader(fiber.HeaderLastEventID, "1").
+		Get("/api/v1/events")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, "text/event-stream", res.Header().Get(fiber.HeaderContentType))
+
+	var event openapi.Event
+	var data string
+	_, err := fmt.Sscanf(res.String(), "id: 3\nevent: output-spent\ndata: %s\n\n", &data)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(data), &event))
+
+	outputIndex := uint32(1)
+	txidStr, spendingTxidStr := txid.String(), spendingTxid.String()
+	require.Equal(t, openapi.Event{
+		Cursor:       3,
+		Type:         "output-spent",
+		Topic:        "tm_test",
+		Txid:         &txidStr,
+		OutputIndex:  &outputIndex,
+		SpendingTxid: &spendingTxidStr,
+		CreatedAt:    event.CreatedAt,
+	}, event)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,79 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SubmitBatchHandler is a Fiber-compatible HTTP handler that processes batches of transactions
+// submitted at once. It acts as the interface adapter between HTTP input and application-layer
+// logic provided by SubmitBatchService.
+type SubmitBatchHandler struct {
+	service *app.SubmitBatchService
+}
+
+// Handle processes an HTTP POST request submitting a batch of transactions.
+// It expects a JSON body conforming to the SubmitBatchJSONBody OpenAPI definition.
+//
+// On success, returns a 200 OK response with the outcome of every transaction, even when some of
+// them were rejected. On failure, returns a request parsing or service-level error.
+func (h *SubmitBatchHandler) Handle(c *fiber.Ctx) error {
+	var body openapi.SubmitBatchJSONBody
+
+	err := c.BodyParser(&body)
+	if err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	results, err := h.service.SubmitBatch(c.UserContext(), NewSubmitBatchDTO(body))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewSubmitBatchSuccessResponse(results))
+}
+
+// NewSubmitBatchHandler creates a new SubmitBatchHandler with the given provider.
+// It panics if the provider is nil.
+func NewSubmitBatchHandler(provider app.SubmitBatchProvider) *SubmitBatchHandler {
+	return &SubmitBatchHandler{service: app.NewSubmitBatchService(provider)}
+}
+
+// NewSubmitBatchDTO converts the OpenAPI-compatible batch into its application-layer representation.
+func NewSubmitBatchDTO(body openapi.SubmitBatchJSONBody) app.SubmitBatchDTO {
+	dto := app.SubmitBatchDTO{Transactions: make([]app.SubmitBatchTransactionDTO, 0, len(body.Transactions))}
+	if body.Beef != nil {
+		dto.BEEF = *body.Beef
+	}
+	for _, tx := range body.Transactions {
+		transaction := app.SubmitBatchTransactionDTO{Topics: tx.Topics}
+		if tx.Beef != nil {
+			transaction.BEEF = *tx.Beef
+		}
+		if tx.Txid != nil {
+			transaction.TxID = *tx.Txid
+		}
+		dto.Transactions = append(dto.Transactions, transaction)
+	}
+	return dto
+}
+
+// NewSubmitBatchSuccessResponse converts the outcome of the transactions of a batch into an
+// OpenAPI-compatible SubmitBatchResponse.
+func NewSubmitBatchSuccessResponse(results []app.SubmitBatchResultDTO) openapi.SubmitBatchResponse {
+	response := openapi.SubmitBatchResponse{Results: make([]openapi.SubmitBatchResult, 0, len(results))}
+	for _, result := range results {
+		var item openapi.SubmitBatchResult
+		if result.TxID != "" {
+			item.Txid = &result.TxID
+		}
+		if result.Steak != nil {
+			item.STEAK = &NewSubmitTransactionSuccessResponse(result.Steak).STEAK
+		}
+		if result.ErrorCode != "" {
+			item.Error = &openapi.SubmitJobError{Code: result.ErrorCode, Message: result.ErrorMessage}
+		}
+		response.Results = append(response.Results, item)
+	}
+	return response
+}
@@ -0,0 +1,120 @@
+package ports_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitBatchHandler_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		expectedStatusCode int
+		payload            any
+		expectedResponse   openapi.Error
+		expectations       testabilities.SubmitBatchProviderMockExpectations
+	}{
+		"Malformed request body content in the HTTP request": {
+			expectedStatusCode: fiber.StatusInternalServerError,
+			payload:            `{invalid json`,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, ports.NewRequestBodyParserError(testabilities.ErrTestNoopOpFailure)),
+			expectations: testabilities.SubmitBatchProviderMockExpectations{
+				SubmitBatchCall: false,
+			},
+		},
+		"Empty batch in the HTTP request": {
+			expectedStatusCode: fiber.StatusBadRequest,
+			payload:            openapi.SubmitBatchJSONBody{},
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("transactions")),
+			expectations: testabilities.SubmitBatchProviderMockExpectations{
+				SubmitBatchCall: false,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitBatchProvider(testabilities.NewSubmitBatchProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+			// when:
+			var actualResponse openapi.Error
+
+			res, _ := fixture.Client().
+				R().
+				SetHeader("Content-Type", "application/json").
+				SetBody(tc.payload).
+				SetError(&actualResponse).
+				Post("/api/v1/submitBatch")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestSubmitBatchHandler_ValidCase(t *testing.T) {
+	// given:
+	beef := testabilities.DummyTxBEEF(t)
+	txid := testabilities.DummyTxHash(t, "03895fb984362a4196bc9931629318fcbb2aeba7c6293638119ea653fa31d119")
+	steak := overlay.Steak{"tm_test": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}
+	expectations := testabilities.SubmitBatchProviderMockExpectations{
+		SubmitBatchCall: true,
+		TaggedBEEFs: []overlay.TaggedBEEF{
+			{Beef: beef, Topics: []string{"tm_test"}},
+			{Beef: beef, Topics: []string{"tm_other"}},
+		},
+		Results: []*engine.SubmitBatchResult{
+			{Txid: txid, Steak: steak},
+			{Txid: txid, Err: engine.ErrUnknownTopic},
+		},
+	}
+
+	expectedResponse := ports.NewSubmitBatchSuccessResponse([]app.SubmitBatchResultDTO{
+		{TxID: txid.String(), Steak: &steak},
+		{
+			TxID:         txid.String(),
+			ErrorCode:    "unknown-topic",
+			ErrorMessage: "The requested topic or lookup service is not hosted by the overlay.",
+		},
+	})
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitBatchProvider(testabilities.NewSubmitBatchProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+	// when:
+	var actualResponse openapi.SubmitBatchResponse
+
+	encoded := hex.EncodeToString(beef)
+	res, _ := fixture.Client().
+		R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(openapi.SubmitBatchJSONBody{
+			Transactions: []struct {
+				Beef   *string  `json:"beef,omitempty"`
+				Topics []string `json:"topics"`
+				Txid   *string  `json:"txid,omitempty"`
+			}{
+				{Beef: &encoded, Topics: []string{"tm_test"}},
+				{Beef: &encoded, Topics: []string{"tm_other"}},
+			},
+		}).
+		SetResult(&actualResponse).
+		Post("/api/v1/submitBatch")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, expectedResponse, actualResponse)
+	stub.AssertProvidersState()
+}
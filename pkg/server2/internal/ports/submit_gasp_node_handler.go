@@ -0,0 +1,86 @@
+package ports
+
+import (
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SubmitGASPNodeHandler is a Fiber-compatible HTTP handler that processes
+// GASP nodes pushed by foreign peers. It acts as the interface adapter between
+// HTTP input and application-layer logic provided by SubmitGASPNodeService.
+type SubmitGASPNodeHandler struct {
+	service *app.SubmitGASPNodeService
+}
+
+// Handle processes an HTTP POST request submitting a GASP node.
+// It expects a JSON body conforming to the SubmitGASPNodeJSONBody OpenAPI definition,
+// along with an X-BSV-Topic header passed via params.
+//
+// On success, returns a 200 OK response with the inputs of the node to submit next.
+// On failure, returns a request parsing or service-level error.
+func (h *SubmitGASPNodeHandler) Handle(c *fiber.Ctx, params openapi.SubmitGASPNodeParams) error {
+	var body openapi.SubmitGASPNodeJSONBody
+
+	err := c.BodyParser(&body)
+	if err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	dto := app.SubmitGASPNodeDTO{
+		GraphID:     body.GraphID,
+		RawTx:       body.RawTx,
+		OutputIndex: body.OutputIndex,
+		Topic:       params.XBSVTopic,
+	}
+	if body.Proof != nil {
+		dto.Proof = *body.Proof
+	}
+	if body.TxMetadata != nil {
+		dto.TxMetadata = *body.TxMetadata
+	}
+	if body.OutputMetadata != nil {
+		dto.OutputMetadata = *body.OutputMetadata
+	}
+	if body.AncillaryBeef != nil {
+		dto.AncillaryBeef = *body.AncillaryBeef
+	}
+	if body.Inputs != nil {
+		dto.Inputs = make(map[string]string, len(*body.Inputs))
+		for id, input := range *body.Inputs {
+			if fields, ok := input.(map[string]any); ok {
+				hash, _ := fields["hash"].(string)
+				dto.Inputs[id] = hash
+			}
+		}
+	}
+
+	response, err := h.service.SubmitGASPNode(c.Context(), dto)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewSubmitGASPNodeSuccessResponse(response))
+}
+
+// NewSubmitGASPNodeHandler constructs a new SubmitGASPNodeHandler
+// using the given SubmitGASPNodeProvider to instantiate the underlying service.
+//
+// Panics if the provider is nil.
+func NewSubmitGASPNodeHandler(provider app.SubmitGASPNodeProvider) *SubmitGASPNodeHandler {
+	return &SubmitGASPNodeHandler{service: app.NewSubmitGASPNodeService(provider)}
+}
+
+// NewSubmitGASPNodeSuccessResponse converts a core.GASPNodeResponse into a
+// SubmitGASPNodeResponse object compatible with the OpenAPI specification.
+// A nil response means that no further inputs are needed.
+func NewSubmitGASPNodeSuccessResponse(response *core.GASPNodeResponse) openapi.SubmitGASPNodeResponse {
+	requested := make(map[string]openapi.GASPNodeResponseData)
+	if response != nil {
+		for outpoint, data := range response.RequestedInputs {
+			requested[outpoint] = openapi.GASPNodeResponseData{Metadata: data != nil && data.Metadata}
+		}
+	}
+	return openapi.SubmitGASPNodeResponse{RequestedInputs: requested}
+}
@@ -0,0 +1,136 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitGASPNodeHandler_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		payload            any
+		headers            map[string]string
+		expectations       testabilities.SubmitGASPNodeProviderMockExpectations
+		expectedStatusCode int
+		expectedResponse   openapi.Error
+	}{
+		"Submit GASP node service fails to handle the request - internal error": {
+			payload: openapi.SubmitGASPNodeBody{
+				GraphID:     testabilities.DefaultValidGraphID,
+				RawTx:       testabilities.DefaultValidRawTx,
+				OutputIndex: testabilities.DefaultValidOutputIndex,
+			},
+			headers: map[string]string{
+				fiber.HeaderContentType: fiber.MIMEApplicationJSON,
+				"X-BSV-Topic":           testabilities.DefaultValidTopic,
+			},
+			expectations: testabilities.SubmitGASPNodeProviderMockExpectations{
+				SubmitForeignGASPNodeCall: true,
+				Error:                     testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatusCode: fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t,
+				app.NewSubmitGASPNodeProviderError(testabilities.ErrTestNoopOpFailure),
+			),
+		},
+		"Malformed request body content in the HTTP request": {
+			payload: "INVALID_JSON",
+			headers: map[string]string{
+				fiber.HeaderContentType: fiber.MIMEApplicationJSON,
+				"X-BSV-Topic":           testabilities.DefaultValidTopic,
+			},
+			expectations: testabilities.SubmitGASPNodeProviderMockExpectations{
+				SubmitForeignGASPNodeCall: false,
+			},
+			expectedStatusCode: fiber.StatusInternalServerError,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, ports.NewRequestBodyParserError(testabilities.ErrTestNoopOpFailure)),
+		},
+		"Missing topic header in the HTTP request": {
+			payload: openapi.SubmitGASPNodeBody{
+				GraphID: testabilities.DefaultValidGraphID,
+				RawTx:   testabilities.DefaultValidRawTx,
+			},
+			headers: map[string]string{
+				fiber.HeaderContentType: fiber.MIMEApplicationJSON,
+			},
+			expectations: testabilities.SubmitGASPNodeProviderMockExpectations{
+				SubmitForeignGASPNodeCall: false,
+			},
+			expectedStatusCode: fiber.StatusBadRequest,
+			expectedResponse:   openapi.Error{Code: app.ErrorTypeIncorrectInput.String(), Message: "The submitted request does not include required header: X-BSV-Topic."},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitGASPNodeProvider(
+				testabilities.NewSubmitGASPNodeProviderMock(t, tc.expectations),
+			))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+			// when:
+			var actualResponse openapi.BadRequestResponse
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(tc.headers).
+				SetBody(tc.payload).
+				SetError(&actualResponse).
+				Post("/api/v1/submitGASPNode")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			require.Equal(t, &tc.expectedResponse, &actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestSubmitGASPNodeHandler_ValidCase(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitGASPNodeProviderMockExpectations{
+		SubmitForeignGASPNodeCall: true,
+		Response: &core.GASPNodeResponse{
+			RequestedInputs: map[string]*core.GASPNodeResponseData{
+				testabilities.DefaultValidGraphID: {Metadata: true},
+			},
+		},
+	}
+	mock := testabilities.NewSubmitGASPNodeProviderMock(t, expectations)
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitGASPNodeProvider(mock))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+	expectedResponse := ports.NewSubmitGASPNodeSuccessResponse(expectations.Response)
+
+	// when:
+	var actualResponse openapi.SubmitGASPNodeResponse
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			"X-BSV-Topic":           testabilities.DefaultValidTopic,
+			fiber.HeaderContentType: fiber.MIMEApplicationJSON,
+		}).
+		SetBody(map[string]any{
+			"graphID":     testabilities.DefaultValidGraphID,
+			"rawTx":       testabilities.DefaultValidRawTx,
+			"outputIndex": testabilities.DefaultValidOutputIndex,
+			"proof":       nil,
+			"inputs":      map[string]any{"input": map[string]any{"hash": "hash"}},
+		}).
+		SetResult(&actualResponse).
+		Post("/api/v1/submitGASPNode")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, expectedResponse, actualResponse)
+	stub.AssertProvidersState()
+
+	require.Nil(t, mock.Node().Proof)
+	require.Equal(t, map[string]*core.GASPInput{"input": {Hash: "hash"}}, mock.Node().Inputs)
+}
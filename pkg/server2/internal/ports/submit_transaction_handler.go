@@ -1,6 +1,9 @@
 package ports
 
 import (
+	"bytes"
+	"strings"
+
 	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
 	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
 	"github.com/bsv-blockchain/go-sdk/overlay"
@@ -15,14 +18,44 @@ const XTopicsHeader = "x-topics"
 // It validates the request body and headers, delegates transaction submission to the service layer,
 // and returns a response formatted according to the OpenAPI specification.
 type SubmitTransactionHandler struct {
-	service *app.SubmitTransactionService
+	service      *app.SubmitTransactionService
+	asyncService *app.SubmitTransactionAsyncService
 }
 
 // Handle processes an HTTP request to submit a transaction.
 // It expects the `x-topics` header to be present and valid.
-// On success, it returns HTTP 200 OK with a STEAK response (openapi.SubmitTransactionResponse).
+// On success, it returns HTTP 200 OK with a STEAK response (openapi.SubmitTransactionResponse),
+// or HTTP 202 Accepted with the queued job (openapi.SubmitTransactionJobResponse) when the `async`
+// query parameter is set. When the `dryRun` query parameter is set, the STEAK is only previewed
+// and nothing is stored, broadcast or propagated; a dry run cannot be queued.
 // If an error occurs during transaction submission, it returns the corresponding application error.
 func (s *SubmitTransactionHandler) Handle(c *fiber.Ctx, params openapi.SubmitTransactionParams) error {
+	async := params.Async != nil && *params.Async
+	if params.DryRun != nil && *params.DryRun {
+		if async {
+			return NewAsyncDryRunError()
+		}
+		steak, err := s.service.DryRunTransaction(c.UserContext(), params.XTopics, c.Body()...)
+		if err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusOK).JSON(NewSubmitTransactionSuccessResponse(steak))
+	}
+
+	if async {
+		// The queued submission outlives the request, while Fiber reuses the buffers
+		// backing the body and the headers for the next requests.
+		topics := make([]string, 0, len(params.XTopics))
+		for _, topic := range params.XTopics {
+			topics = append(topics, strings.Clone(topic))
+		}
+		job, err := s.asyncService.SubmitTransactionAsync(c.UserContext(), topics, bytes.Clone(c.Body())...)
+		if err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusAccepted).JSON(NewSubmitJobResponse(job))
+	}
+
 	steak, err := s.service.SubmitTransaction(c.UserContext(), params.XTopics, c.Body()...)
 	if err != nil {
 		return err
@@ -30,10 +63,22 @@ func (s *SubmitTransactionHandler) Handle(c *fiber.Ctx, params openapi.SubmitTra
 	return c.Status(fiber.StatusOK).JSON(NewSubmitTransactionSuccessResponse(steak))
 }
 
-// NewSubmitTransactionHandler creates a new SubmitTransactionHandler with the given provider.
-// It panics if the provider is nil.
-func NewSubmitTransactionHandler(provider app.SubmitTransactionProvider) *SubmitTransactionHandler {
-	return &SubmitTransactionHandler{service: app.NewSubmitTransactionService(provider)}
+// NewSubmitTransactionHandler creates a new SubmitTransactionHandler with the given providers.
+// It panics if any of the providers is nil.
+func NewSubmitTransactionHandler(provider app.SubmitTransactionProvider, asyncProvider app.SubmitTransactionAsyncProvider) *SubmitTransactionHandler {
+	return &SubmitTransactionHandler{
+		service:      app.NewSubmitTransactionService(provider),
+		asyncService: app.NewSubmitTransactionAsyncService(asyncProvider),
+	}
+}
+
+// NewAsyncDryRunError returns an app.Error indicating that both the `async` and `dryRun`
+// query parameters were set, while a dry run is always answered right away.
+func NewAsyncDryRunError() app.Error {
+	return app.NewIncorrectInputError(
+		"async and dryRun query parameters cannot be combined",
+		"A dry run cannot be queued. Please set either the async or the dryRun query parameter and try again.",
+	)
 }
 
 // NewSubmitTransactionSuccessResponse converts the internal STEAK data structure
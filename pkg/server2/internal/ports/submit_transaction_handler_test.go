@@ -1,9 +1,12 @@
 package ports_test
 
 import (
+	"bytes"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
 	"github.com/4chain-ag/go-overlay-services/pkg/server2"
 	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
 	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports"
@@ -46,6 +49,7 @@ func TestSubmitTransactionHandler_InvalidCases(t *testing.T) {
 				fiber.HeaderContentType: fiber.MIMEOctetStream,
 			},
 			expectedResponse: openapi.Error{
+				Code:    app.ErrorTypeIncorrectInput.String(),
 				Message: "The submitted request does not include required header: x-topics.",
 			},
 			expectations: testabilities.SubmitTransactionProviderMockExpectations{
@@ -90,10 +94,76 @@ func TestSubmitTransactionHandler_InvalidCases(t *testing.T) {
 	}
 }
 
+func TestSubmitTransactionHandler_EngineErrorCases(t *testing.T) {
+	tests := map[string]struct {
+		err                error
+		expectedStatusCode int
+		expectedCode       string
+	}{
+		"Unknown topic is reported as not found": {
+			err:                engine.ErrUnknownTopic,
+			expectedStatusCode: fiber.StatusNotFound,
+			expectedCode:       app.ErrorCodeUnknownTopic,
+		},
+		"Invalid BEEF is reported as a bad request": {
+			err:                engine.ErrInvalidBeef,
+			expectedStatusCode: fiber.StatusBadRequest,
+			expectedCode:       app.ErrorCodeInvalidBEEF,
+		},
+		"Invalid transaction is reported as unprocessable": {
+			err:                engine.ErrInvalidTransaction,
+			expectedStatusCode: fiber.StatusUnprocessableEntity,
+			expectedCode:       app.ErrorCodeInvalidTransaction,
+		},
+		"Missing input is reported as unprocessable": {
+			err:                engine.ErrMissingInput,
+			expectedStatusCode: fiber.StatusUnprocessableEntity,
+			expectedCode:       app.ErrorCodeMissingInput,
+		},
+		"Spent input is reported as a conflict": {
+			err:                engine.ErrInputSpent,
+			expectedStatusCode: fiber.StatusConflict,
+			expectedCode:       app.ErrorCodeInputSpent,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			expectations := testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				Error:      tc.err,
+			}
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+			// when:
+			var actualResponse openapi.Error
+
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(map[string]string{
+					fiber.HeaderContentType: fiber.MIMEOctetStream,
+					ports.XTopicsHeader:     "topic1",
+				}).
+				SetBody("test transaction body").
+				SetError(&actualResponse).
+				Post("/api/v1/submit")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			require.Equal(t, tc.expectedCode, actualResponse.Code)
+			require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitTransactionProviderError(tc.err)), actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
 func TestSubmitTransactionHandler_ValidCase(t *testing.T) {
 	// given:
 	expectations := testabilities.SubmitTransactionProviderMockExpectations{
 		SubmitCall: true,
+		SubmitMode: engine.SubmitModeCurrent,
 		STEAK: &overlay.Steak{
 			"test": &overlay.AdmittanceInstructions{
 				OutputsToAdmit: []uint32{1},
@@ -126,3 +196,185 @@ func TestSubmitTransactionHandler_ValidCase(t *testing.T) {
 	require.Equal(t, expectedResponse, &actualResponse)
 	stub.AssertProvidersState()
 }
+
+func TestSubmitTransactionHandler_Async_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		expectedStatusCode int
+		expectedResponse   openapi.Error
+		expectations       testabilities.SubmitTransactionAsyncProviderMockExpectations
+	}{
+		"Submit transaction async service fails to queue the transaction - queue full": {
+			expectedStatusCode: fiber.StatusServiceUnavailable,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitQueueFullError(engine.ErrSubmitQueueFull)),
+			expectations: testabilities.SubmitTransactionAsyncProviderMockExpectations{
+				SubmitAsyncCall: true,
+				Topics:          []string{"topic1", "topic2"},
+				Error:           engine.ErrSubmitQueueFull,
+			},
+		},
+		"Submit transaction async service fails to queue the transaction - internal error": {
+			expectedStatusCode: fiber.StatusInternalServerError,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitTransactionProviderError(testabilities.ErrTestNoopOpFailure)),
+			expectations: testabilities.SubmitTransactionAsyncProviderMockExpectations{
+				SubmitAsyncCall: true,
+				Topics:          []string{"topic1", "topic2"},
+				Error:           testabilities.ErrTestNoopOpFailure,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionAsyncProvider(testabilities.NewSubmitTransactionAsyncProviderMock(t, tc.expectations)))
+			fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+			// when:
+			var actualResponse openapi.Error
+
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderContentType, fiber.MIMEOctetStream).
+				SetHeader(ports.XTopicsHeader, "topic1,topic2").
+				SetQueryParam("async", "true").
+				SetBody("test transaction body").
+				SetError(&actualResponse).
+				Post("/api/v1/submit")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestSubmitTransactionHandler_Async_ValidCase(t *testing.T) {
+	// given:
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	expectations := testabilities.SubmitTransactionAsyncProviderMockExpectations{
+		SubmitAsyncCall: true,
+		Topics:          []string{"topic1", "topic2"},
+		Job: &engine.SubmitJob{
+			ID:        "job",
+			Status:    engine.SubmitJobQueued,
+			Topics:    []string{"topic1", "topic2"},
+			CreatedAt: createdAt,
+		},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionAsyncProvider(testabilities.NewSubmitTransactionAsyncProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+	// when:
+	var actualResponse openapi.SubmitJob
+
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderContentType, fiber.MIMEOctetStream).
+		SetHeader(ports.XTopicsHeader, "topic1,topic2").
+		SetQueryParam("async", "true").
+		SetBody("test transaction body").
+		SetResult(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	require.Equal(t, fiber.StatusAccepted, res.StatusCode())
+	require.Equal(t, openapi.SubmitJob{
+		JobId:     "job",
+		Status:    "queued",
+		Topics:    []string{"topic1", "topic2"},
+		CreatedAt: createdAt,
+	}, actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestSubmitTransactionHandler_Async_ShouldQueueCopyOfRequestBody(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitTransactionAsyncProviderMockExpectations{
+		SubmitAsyncCall: true,
+		Topics:          []string{"topic1", "topic2"},
+		Beefs:           [][]byte{[]byte("first transaction body"), []byte("other transaction body")},
+		Job:             &engine.SubmitJob{ID: "job", Status: engine.SubmitJobQueued},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionAsyncProvider(testabilities.NewSubmitTransactionAsyncProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+	// when:
+	for _, body := range expectations.Beefs {
+		res, _ := fixture.Client().
+			R().
+			SetHeader(fiber.HeaderContentType, fiber.MIMEOctetStream).
+			SetHeader(ports.XTopicsHeader, "topic1,topic2").
+			SetQueryParam("async", "true").
+			SetBody(bytes.Clone(body)).
+			Post("/api/v1/submit")
+		require.Equal(t, fiber.StatusAccepted, res.StatusCode())
+	}
+
+	// then:
+	stub.AssertProvidersState()
+}
+
+func TestSubmitTransactionHandler_DryRun_InvalidCase_AsyncSet(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+	// when:
+	var actualResponse openapi.Error
+
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			fiber.HeaderContentType: fiber.MIMEOctetStream,
+			ports.XTopicsHeader:     "topic1,topic2",
+		}).
+		SetQueryParam("async", "true").
+		SetQueryParam("dryRun", "true").
+		SetBody("test transaction body").
+		SetError(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	require.Equal(t, fiber.StatusBadRequest, res.StatusCode())
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, ports.NewAsyncDryRunError()), actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestSubmitTransactionHandler_DryRun_ValidCase(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitTransactionProviderMockExpectations{
+		SubmitCall: true,
+		SubmitMode: engine.SubmitModeDryRun,
+		STEAK: &overlay.Steak{
+			"test": &overlay.AdmittanceInstructions{
+				OutputsToAdmit: []uint32{1},
+			},
+		},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, expectations)))
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(stub))
+
+	// when:
+	var actualResponse openapi.SubmitTransactionResponse
+
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			fiber.HeaderContentType: fiber.MIMEOctetStream,
+			ports.XTopicsHeader:     "topic1,topic2",
+		}).
+		SetQueryParam("dryRun", "true").
+		SetBody("test transaction body").
+		SetResult(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewSubmitTransactionSuccessResponse(expectations.STEAK), &actualResponse)
+	stub.AssertProvidersState()
+}
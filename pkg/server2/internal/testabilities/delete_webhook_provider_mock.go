@@ -0,0 +1,52 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// DeleteWebhookProviderMockExpectations defines the expected behavior of the DeleteWebhookProviderMock during a test.
+type DeleteWebhookProviderMockExpectations struct {
+	// Error is the error to return from DeleteWebhook.
+	Error error
+
+	// ID is the ID DeleteWebhook is expected to be called with.
+	ID string
+
+	// DeleteWebhookCall indicates whether DeleteWebhook is expected to be called during the test.
+	DeleteWebhookCall bool
+}
+
+// DeleteWebhookProviderMock is a mock implementation of a webhook deletion provider,
+// used for testing the behavior of components that depend on deleting webhooks.
+type DeleteWebhookProviderMock struct {
+	t            *testing.T
+	expectations DeleteWebhookProviderMockExpectations
+	called       bool   // Tracks whether DeleteWebhook was called
+	id           string // Stores the ID passed to DeleteWebhook
+}
+
+// DeleteWebhook records the call and returns the predefined error.
+func (m *DeleteWebhookProviderMock) DeleteWebhook(ctx context.Context, id string) error {
+	m.t.Helper()
+	m.called = true
+	m.id = id
+	return m.expectations.Error
+}
+
+// AssertCalled verifies that DeleteWebhook was called as expected and with the expected ID.
+func (m *DeleteWebhookProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.DeleteWebhookCall, m.called, "Discrepancy between expected and actual DeleteWebhook call")
+	require.Equal(m.t, m.expectations.ID, m.id, "Discrepancy between expected and actual ID")
+}
+
+// NewDeleteWebhookProviderMock creates a new instance of DeleteWebhookProviderMock with the given expectations.
+func NewDeleteWebhookProviderMock(t *testing.T, expectations DeleteWebhookProviderMockExpectations) *DeleteWebhookProviderMock {
+	return &DeleteWebhookProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,79 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// EvictOutputProviderMockExpectations defines the expected behavior of the EvictOutputProviderMock during a test.
+type EvictOutputProviderMockExpectations struct {
+	// Error is the error to return from EvictOutput.
+	Error error
+
+	// Eviction is the recorded eviction to return from EvictOutput.
+	Eviction *engine.Eviction
+
+	// Outpoint is the outpoint EvictOutput is expected to be called with.
+	Outpoint *transaction.Outpoint
+
+	// IncludeHistory is the history flag EvictOutput is expected to be called with.
+	IncludeHistory bool
+
+	// Actor is the actor EvictOutput is expected to be called with.
+	Actor string
+
+	// Reason is the reason EvictOutput is expected to be called with.
+	Reason string
+
+	// EvictOutputCall indicates whether the EvictOutput method is expected to be called during the test.
+	EvictOutputCall bool
+}
+
+// EvictOutputProviderMock is a mock implementation of an output eviction provider,
+// used for testing the behavior of components that depend on evicting outputs.
+type EvictOutputProviderMock struct {
+	t              *testing.T
+	expectations   EvictOutputProviderMockExpectations
+	called         bool                  // Tracks whether EvictOutput was called
+	outpoint       *transaction.Outpoint // Stores the outpoint passed to EvictOutput
+	includeHistory bool                  // Stores the history flag passed to EvictOutput
+	actor          string                // Stores the actor passed to EvictOutput
+	reason         string                // Stores the reason passed to EvictOutput
+}
+
+// EvictOutput records the call and returns the predefined eviction or error.
+func (m *EvictOutputProviderMock) EvictOutput(ctx context.Context, outpoint *transaction.Outpoint, includeHistory bool, actor, reason string) (*engine.Eviction, error) {
+	m.t.Helper()
+	m.called = true
+	m.outpoint = outpoint
+	m.includeHistory = includeHistory
+	m.actor = actor
+	m.reason = reason
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Eviction, nil
+}
+
+// AssertCalled verifies that EvictOutput was called as expected and with the expected arguments.
+func (m *EvictOutputProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.EvictOutputCall, m.called, "Discrepancy between expected and actual EvictOutput call")
+	require.Equal(m.t, m.expectations.Outpoint, m.outpoint, "Discrepancy between expected and actual Outpoint")
+	require.Equal(m.t, m.expectations.IncludeHistory, m.includeHistory, "Discrepancy between expected and actual IncludeHistory")
+	require.Equal(m.t, m.expectations.Actor, m.actor, "Discrepancy between expected and actual Actor")
+	require.Equal(m.t, m.expectations.Reason, m.reason, "Discrepancy between expected and actual Reason")
+}
+
+// NewEvictOutputProviderMock creates a new instance of EvictOutputProviderMock with the given expectations.
+func NewEvictOutputProviderMock(t *testing.T, expectations EvictOutputProviderMockExpectations) *EvictOutputProviderMock {
+	return &EvictOutputProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,60 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// HandleReorgProviderMockExpectations defines the expected behavior of the HandleReorgProviderMock during a test.
+type HandleReorgProviderMockExpectations struct {
+	// Error is the error to return from HandleReorg.
+	Error error
+
+	// Result is the outcome to return from HandleReorg.
+	Result *engine.ReorgResult
+
+	// FromHeight is the block height HandleReorg is expected to be called with.
+	FromHeight uint32
+
+	// HandleReorgCall indicates whether the HandleReorg method is expected to be called during the test.
+	HandleReorgCall bool
+}
+
+// HandleReorgProviderMock is a mock implementation of a reorg handling provider,
+// used for testing the behavior of components that depend on re-verifying merkle proofs.
+type HandleReorgProviderMock struct {
+	t            *testing.T
+	expectations HandleReorgProviderMockExpectations
+	called       bool   // Tracks whether HandleReorg was called
+	fromHeight   uint32 // Stores the block height passed to HandleReorg
+}
+
+// HandleReorg records the call and returns the predefined outcome or error.
+func (m *HandleReorgProviderMock) HandleReorg(ctx context.Context, fromHeight uint32) (*engine.ReorgResult, error) {
+	m.t.Helper()
+	m.called = true
+	m.fromHeight = fromHeight
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Result, nil
+}
+
+// AssertCalled verifies that HandleReorg was called as expected and with the expected block height.
+func (m *HandleReorgProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.HandleReorgCall, m.called, "Discrepancy between expected and actual HandleReorg call")
+	require.Equal(m.t, m.expectations.FromHeight, m.fromHeight, "Discrepancy between expected and actual FromHeight")
+}
+
+// NewHandleReorgProviderMock creates a new instance of HandleReorgProviderMock with the given expectations.
+func NewHandleReorgProviderMock(t *testing.T, expectations HandleReorgProviderMockExpectations) *HandleReorgProviderMock {
+	return &HandleReorgProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,64 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+// ListOutboxEntriesProviderMockExpectations defines the expected behavior of the ListOutboxEntriesProviderMock during a test.
+type ListOutboxEntriesProviderMockExpectations struct {
+	// Error is the error to return from ListOutboxEntries.
+	Error error
+
+	// Entries are the entries to return from ListOutboxEntries.
+	Entries []*engine.OutboxEntry
+
+	// TxID and Status are the filters ListOutboxEntries is expected to be called with.
+	TxID   *chainhash.Hash
+	Status engine.OutboxStatus
+
+	// ListOutboxEntriesCall indicates whether ListOutboxEntries is expected to be called during the test.
+	ListOutboxEntriesCall bool
+}
+
+// ListOutboxEntriesProviderMock is a mock implementation of an outbox listing provider,
+// used for testing the behavior of components that depend on listing the broadcasts and propagations.
+type ListOutboxEntriesProviderMock struct {
+	t            *testing.T
+	expectations ListOutboxEntriesProviderMockExpectations
+	called       bool                // Tracks whether ListOutboxEntries was called
+	txid         *chainhash.Hash     // Stores the txid passed to ListOutboxEntries
+	status       engine.OutboxStatus // Stores the status passed to ListOutboxEntries
+}
+
+// ListOutboxEntries records the call and returns the predefined entries or error.
+func (m *ListOutboxEntriesProviderMock) ListOutboxEntries(ctx context.Context, txid *chainhash.Hash, status engine.OutboxStatus) ([]*engine.OutboxEntry, error) {
+	m.t.Helper()
+	m.called = true
+	m.txid, m.status = txid, status
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Entries, nil
+}
+
+// AssertCalled verifies that ListOutboxEntries was called as expected and with the expected filters.
+func (m *ListOutboxEntriesProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ListOutboxEntriesCall, m.called, "Discrepancy between expected and actual ListOutboxEntries call")
+	require.Equal(m.t, m.expectations.TxID, m.txid, "Discrepancy between expected and actual TxID")
+	require.Equal(m.t, m.expectations.Status, m.status, "Discrepancy between expected and actual Status")
+}
+
+// NewListOutboxEntriesProviderMock creates a new instance of ListOutboxEntriesProviderMock with the given expectations.
+func NewListOutboxEntriesProviderMock(t *testing.T, expectations ListOutboxEntriesProviderMockExpectations) *ListOutboxEntriesProviderMock {
+	return &ListOutboxEntriesProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,69 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// DefaultSyncCheckpoint is the sync checkpoint returned by default from ListSyncCheckpointsProviderMock.
+var DefaultSyncCheckpoint = &engine.SyncCheckpoint{
+	Topic:     "tm_test",
+	Peer:      "https://peer.example.com",
+	Since:     1700000000,
+	UpdatedAt: time.Date(2023, time.November, 14, 22, 13, 20, 0, time.UTC),
+}
+
+// ListSyncCheckpointsProviderMockExpectations defines the expected behavior of the ListSyncCheckpointsProviderMock during a test.
+type ListSyncCheckpointsProviderMockExpectations struct {
+	// Error is the error to return from ListSyncCheckpoints.
+	Error error
+
+	// Checkpoints are the checkpoints to return from ListSyncCheckpoints.
+	Checkpoints []*engine.SyncCheckpoint
+
+	// Topic is the topic ListSyncCheckpoints is expected to be called with.
+	Topic string
+
+	// ListSyncCheckpointsCall indicates whether the ListSyncCheckpoints method is expected to be called during the test.
+	ListSyncCheckpointsCall bool
+}
+
+// ListSyncCheckpointsProviderMock is a mock implementation of a sync checkpoints list provider,
+// used for testing the behavior of components that depend on listing sync checkpoints.
+type ListSyncCheckpointsProviderMock struct {
+	t            *testing.T
+	expectations ListSyncCheckpointsProviderMockExpectations
+	called       bool   // Tracks whether ListSyncCheckpoints was called
+	topic        string // Stores the topic passed to ListSyncCheckpoints
+}
+
+// ListSyncCheckpoints records the call and returns the predefined checkpoints or error.
+func (m *ListSyncCheckpointsProviderMock) ListSyncCheckpoints(ctx context.Context, topic string) ([]*engine.SyncCheckpoint, error) {
+	m.t.Helper()
+	m.called = true
+	m.topic = topic
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Checkpoints, nil
+}
+
+// AssertCalled verifies that ListSyncCheckpoints was called as expected and with the expected topic.
+func (m *ListSyncCheckpointsProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ListSyncCheckpointsCall, m.called, "Discrepancy between expected and actual ListSyncCheckpoints call")
+	require.Equal(m.t, m.expectations.Topic, m.topic, "Discrepancy between expected and actual Topic")
+}
+
+// NewListSyncCheckpointsProviderMock creates a new instance of ListSyncCheckpointsProviderMock with the given expectations.
+func NewListSyncCheckpointsProviderMock(t *testing.T, expectations ListSyncCheckpointsProviderMockExpectations) *ListSyncCheckpointsProviderMock {
+	return &ListSyncCheckpointsProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,63 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// ListWebhookDeliveriesProviderMockExpectations defines the expected behavior of the ListWebhookDeliveriesProviderMock during a test.
+type ListWebhookDeliveriesProviderMockExpectations struct {
+	// Error is the error to return from ListWebhookDeliveries.
+	Error error
+
+	// Deliveries are the deliveries to return from ListWebhookDeliveries.
+	Deliveries []*engine.WebhookDelivery
+
+	// WebhookID and Status are the filters ListWebhookDeliveries is expected to be called with.
+	WebhookID string
+	Status    engine.WebhookDeliveryStatus
+
+	// ListWebhookDeliveriesCall indicates whether ListWebhookDeliveries is expected to be called during the test.
+	ListWebhookDeliveriesCall bool
+}
+
+// ListWebhookDeliveriesProviderMock is a mock implementation of a webhook delivery log provider,
+// used for testing the behavior of components that depend on listing the webhook deliveries.
+type ListWebhookDeliveriesProviderMock struct {
+	t            *testing.T
+	expectations ListWebhookDeliveriesProviderMockExpectations
+	called       bool                         // Tracks whether ListWebhookDeliveries was called
+	webhookID    string                       // Stores the webhook ID passed to ListWebhookDeliveries
+	status       engine.WebhookDeliveryStatus // Stores the status passed to ListWebhookDeliveries
+}
+
+// ListWebhookDeliveries records the call and returns the predefined deliveries or error.
+func (m *ListWebhookDeliveriesProviderMock) ListWebhookDeliveries(ctx context.Context, webhookID string, status engine.WebhookDeliveryStatus) ([]*engine.WebhookDelivery, error) {
+	m.t.Helper()
+	m.called = true
+	m.webhookID, m.status = webhookID, status
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Deliveries, nil
+}
+
+// AssertCalled verifies that ListWebhookDeliveries was called as expected and with the expected filters.
+func (m *ListWebhookDeliveriesProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ListWebhookDeliveriesCall, m.called, "Discrepancy between expected and actual ListWebhookDeliveries call")
+	require.Equal(m.t, m.expectations.WebhookID, m.webhookID, "Discrepancy between expected and actual WebhookID")
+	require.Equal(m.t, m.expectations.Status, m.status, "Discrepancy between expected and actual Status")
+}
+
+// NewListWebhookDeliveriesProviderMock creates a new instance of ListWebhookDeliveriesProviderMock with the given expectations.
+func NewListWebhookDeliveriesProviderMock(t *testing.T, expectations ListWebhookDeliveriesProviderMockExpectations) *ListWebhookDeliveriesProviderMock {
+	return &ListWebhookDeliveriesProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,54 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// ListWebhooksProviderMockExpectations defines the expected behavior of the ListWebhooksProviderMock during a test.
+type ListWebhooksProviderMockExpectations struct {
+	// Error is the error to return from ListWebhooks.
+	Error error
+
+	// Webhooks are the webhooks to return from ListWebhooks.
+	Webhooks []*engine.Webhook
+
+	// ListWebhooksCall indicates whether ListWebhooks is expected to be called during the test.
+	ListWebhooksCall bool
+}
+
+// ListWebhooksProviderMock is a mock implementation of a webhook listing provider,
+// used for testing the behavior of components that depend on listing the webhooks.
+type ListWebhooksProviderMock struct {
+	t            *testing.T
+	expectations ListWebhooksProviderMockExpectations
+	called       bool // Tracks whether ListWebhooks was called
+}
+
+// ListWebhooks records the call and returns the predefined webhooks or error.
+func (m *ListWebhooksProviderMock) ListWebhooks(ctx context.Context) ([]*engine.Webhook, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Webhooks, nil
+}
+
+// AssertCalled verifies that ListWebhooks was called as expected.
+func (m *ListWebhooksProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ListWebhooksCall, m.called, "Discrepancy between expected and actual ListWebhooks call")
+}
+
+// NewListWebhooksProviderMock creates a new instance of ListWebhooksProviderMock with the given expectations.
+func NewListWebhooksProviderMock(t *testing.T, expectations ListWebhooksProviderMockExpectations) *ListWebhooksProviderMock {
+	return &ListWebhooksProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
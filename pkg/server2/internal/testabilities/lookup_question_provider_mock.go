@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,6 +15,9 @@ type LookupQuestionProviderMockExpectations struct {
 	LookupQuestionCall bool
 	Error              error
 	Answer             *lookup.LookupAnswer
+
+	// IdentityKey, when set, is the identity key of the authenticated caller expected in the context of the lookup.
+	IdentityKey *ec.PublicKey
 }
 
 // LookupQuestionProviderMock is a mock implementation for testing the behavior of a LookupQuestionProvider.
@@ -27,6 +32,12 @@ func (m *LookupQuestionProviderMock) Lookup(ctx context.Context, question *looku
 	m.t.Helper()
 	m.called = true
 
+	if m.expectations.IdentityKey != nil {
+		identityKey, ok := engine.IdentityKeyFromContext(ctx)
+		require.True(m.t, ok, "Expected the identity key of the caller in the lookup context")
+		require.Equal(m.t, m.expectations.IdentityKey.ToDERHex(), identityKey.ToDERHex())
+	}
+
 	if m.expectations.Error != nil {
 		return nil, m.expectations.Error
 	}
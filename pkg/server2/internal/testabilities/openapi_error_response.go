@@ -8,10 +8,11 @@ import (
 )
 
 // NewTestOpenapiErrorResponse creates an openapi.Error response from the given app.Error,
-// primarily for use in tests. It sets the error message to the error's slug.
+// primarily for use in tests. It sets the error code to the error's code and the error message to the error's slug.
 func NewTestOpenapiErrorResponse(t *testing.T, err app.Error) openapi.Error {
 	t.Helper()
 	return openapi.Error{
+		Code:    err.Code(),
 		Message: err.Slug(),
 	}
 }
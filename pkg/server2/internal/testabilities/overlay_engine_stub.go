@@ -96,6 +96,125 @@ type RequestSyncResponseProvider interface {
 	ProviderStateAsserter
 }
 
+// RequestSyncReplyProvider extends app.RequestSyncReplyProvider with the ability
+// to assert whether it was called during a test.
+type RequestSyncReplyProvider interface {
+	app.RequestSyncReplyProvider
+	ProviderStateAsserter
+}
+
+// SubmitGASPNodeProvider extends app.SubmitGASPNodeProvider with the ability
+// to assert whether it was called during a test.
+type SubmitGASPNodeProvider interface {
+	app.SubmitGASPNodeProvider
+	ProviderStateAsserter
+}
+
+// ListSyncCheckpointsProvider extends app.ListSyncCheckpointsProvider with the ability
+// to assert whether it was called during a test.
+type ListSyncCheckpointsProvider interface {
+	app.ListSyncCheckpointsProvider
+	ProviderStateAsserter
+}
+
+// ResetSyncCheckpointsProvider extends app.ResetSyncCheckpointsProvider with the ability
+// to assert whether it was called during a test.
+type ResetSyncCheckpointsProvider interface {
+	app.ResetSyncCheckpointsProvider
+	ProviderStateAsserter
+}
+
+// HandleReorgProvider extends app.HandleReorgProvider with the ability
+// to assert whether it was called during a test.
+type HandleReorgProvider interface {
+	app.HandleReorgProvider
+	ProviderStateAsserter
+}
+
+// EvictOutputProvider extends app.EvictOutputProvider with the ability
+// to assert whether it was called during a test.
+type EvictOutputProvider interface {
+	app.EvictOutputProvider
+	ProviderStateAsserter
+}
+
+// PruneHistoryProvider extends app.PruneHistoryProvider with the ability
+// to assert whether it was called during a test.
+type PruneHistoryProvider interface {
+	app.PruneHistoryProvider
+	ProviderStateAsserter
+}
+
+// StreamEventsProvider extends app.StreamEventsProvider with the ability
+// to assert whether it was called during a test.
+type StreamEventsProvider interface {
+	app.StreamEventsProvider
+	ProviderStateAsserter
+}
+
+// ListWebhooksProvider extends app.ListWebhooksProvider with the ability
+// to assert whether it was called during a test.
+type ListWebhooksProvider interface {
+	app.ListWebhooksProvider
+	ProviderStateAsserter
+}
+
+// RegisterWebhookProvider extends app.RegisterWebhookProvider with the ability
+// to assert whether it was called during a test.
+type RegisterWebhookProvider interface {
+	app.RegisterWebhookProvider
+	ProviderStateAsserter
+}
+
+// DeleteWebhookProvider extends app.DeleteWebhookProvider with the ability
+// to assert whether it was called during a test.
+type DeleteWebhookProvider interface {
+	app.DeleteWebhookProvider
+	ProviderStateAsserter
+}
+
+// ListWebhookDeliveriesProvider extends app.ListWebhookDeliveriesProvider with the ability
+// to assert whether it was called during a test.
+type ListWebhookDeliveriesProvider interface {
+	app.ListWebhookDeliveriesProvider
+	ProviderStateAsserter
+}
+
+// ListOutboxEntriesProvider extends app.ListOutboxEntriesProvider with the ability
+// to assert whether it was called during a test.
+type ListOutboxEntriesProvider interface {
+	app.ListOutboxEntriesProvider
+	ProviderStateAsserter
+}
+
+// SubmitTransactionAsyncProvider extends app.SubmitTransactionAsyncProvider with the ability
+// to assert whether it was called during a test.
+type SubmitTransactionAsyncProvider interface {
+	app.SubmitTransactionAsyncProvider
+	ProviderStateAsserter
+}
+
+// SubmitJobStatusProvider extends app.SubmitJobStatusProvider with the ability
+// to assert whether it was called during a test.
+type SubmitJobStatusProvider interface {
+	app.SubmitJobStatusProvider
+	ProviderStateAsserter
+}
+
+// SubmitBatchProvider extends app.SubmitBatchProvider with the ability
+// to assert whether it was called during a test.
+type SubmitBatchProvider interface {
+	app.SubmitBatchProvider
+	ProviderStateAsserter
+}
+
+// PaymentAcceptorProvider extends engine.PaymentAcceptor with the ability
+// to assert whether it was called during a test.
+type PaymentAcceptorProvider interface {
+	engine.PaymentAcceptor
+	ProviderStateAsserter
+}
+
 // TestOverlayEngineStubOption is a functional option type used to configure a TestOverlayEngineStub.
 // It allows setting custom behaviors for different parts of the TestOverlayEngineStub.
 type TestOverlayEngineStubOption func(*TestOverlayEngineStub)
@@ -188,6 +307,142 @@ func WithRequestSyncResponseProvider(provider RequestSyncResponseProvider) TestO
 	}
 }
 
+// WithRequestSyncReplyProvider allows setting a custom RequestSyncReplyProvider in a TestOverlayEngineStub.
+// This can be used to mock sync reply behavior during tests.
+func WithRequestSyncReplyProvider(provider RequestSyncReplyProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.requestSyncReplyProvider = provider
+	}
+}
+
+// WithSubmitGASPNodeProvider allows setting a custom SubmitGASPNodeProvider in a TestOverlayEngineStub.
+// This can be used to mock GASP node submission behavior during tests.
+func WithSubmitGASPNodeProvider(provider SubmitGASPNodeProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.submitGASPNodeProvider = provider
+	}
+}
+
+// WithListSyncCheckpointsProvider allows setting a custom ListSyncCheckpointsProvider in a TestOverlayEngineStub.
+// This can be used to mock sync checkpoints listing behavior during tests.
+func WithListSyncCheckpointsProvider(provider ListSyncCheckpointsProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.listSyncCheckpointsProvider = provider
+	}
+}
+
+// WithResetSyncCheckpointsProvider allows setting a custom ResetSyncCheckpointsProvider in a TestOverlayEngineStub.
+// This can be used to mock sync checkpoints reset behavior during tests.
+func WithResetSyncCheckpointsProvider(provider ResetSyncCheckpointsProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.resetSyncCheckpointsProvider = provider
+	}
+}
+
+// WithHandleReorgProvider allows setting a custom HandleReorgProvider in a TestOverlayEngineStub.
+// This can be used to mock the re-verification of merkle proofs after a reorg during tests.
+func WithHandleReorgProvider(provider HandleReorgProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.handleReorgProvider = provider
+	}
+}
+
+// WithEvictOutputProvider allows setting a custom EvictOutputProvider in a TestOverlayEngineStub.
+// This can be used to mock output eviction behavior during tests.
+func WithEvictOutputProvider(provider EvictOutputProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.evictOutputProvider = provider
+	}
+}
+
+// WithPruneHistoryProvider allows setting a custom PruneHistoryProvider in a TestOverlayEngineStub.
+// This can be used to mock the enforcement of the retention policies during tests.
+func WithPruneHistoryProvider(provider PruneHistoryProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.pruneHistoryProvider = provider
+	}
+}
+
+// WithStreamEventsProvider allows setting a custom StreamEventsProvider in a TestOverlayEngineStub.
+// This can be used to mock the subscriptions to the events of the engine during tests.
+func WithStreamEventsProvider(provider StreamEventsProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.streamEventsProvider = provider
+	}
+}
+
+// WithListWebhooksProvider allows setting a custom ListWebhooksProvider in a TestOverlayEngineStub.
+// This can be used to mock the listing of the webhooks during tests.
+func WithListWebhooksProvider(provider ListWebhooksProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.listWebhooksProvider = provider
+	}
+}
+
+// WithRegisterWebhookProvider allows setting a custom RegisterWebhookProvider in a TestOverlayEngineStub.
+// This can be used to mock the registration of webhooks during tests.
+func WithRegisterWebhookProvider(provider RegisterWebhookProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.registerWebhookProvider = provider
+	}
+}
+
+// WithDeleteWebhookProvider allows setting a custom DeleteWebhookProvider in a TestOverlayEngineStub.
+// This can be used to mock the deletion of webhooks during tests.
+func WithDeleteWebhookProvider(provider DeleteWebhookProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.deleteWebhookProvider = provider
+	}
+}
+
+// WithListWebhookDeliveriesProvider allows setting a custom ListWebhookDeliveriesProvider in a TestOverlayEngineStub.
+// This can be used to mock the listing of the webhook deliveries during tests.
+func WithListWebhookDeliveriesProvider(provider ListWebhookDeliveriesProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.listWebhookDeliveriesProvider = provider
+	}
+}
+
+// WithListOutboxEntriesProvider allows setting a custom ListOutboxEntriesProvider in a TestOverlayEngineStub.
+// This can be used to mock the listing of the broadcasts and propagations during tests.
+func WithListOutboxEntriesProvider(provider ListOutboxEntriesProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.listOutboxEntriesProvider = provider
+	}
+}
+
+// WithSubmitTransactionAsyncProvider allows setting a custom SubmitTransactionAsyncProvider in a TestOverlayEngineStub.
+// This can be used to mock the queueing of asynchronous submissions during tests.
+func WithSubmitTransactionAsyncProvider(provider SubmitTransactionAsyncProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.submitTransactionAsyncProvider = provider
+	}
+}
+
+// WithSubmitJobStatusProvider allows setting a custom SubmitJobStatusProvider in a TestOverlayEngineStub.
+// This can be used to mock the status of asynchronous submissions during tests.
+func WithSubmitJobStatusProvider(provider SubmitJobStatusProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.submitJobStatusProvider = provider
+	}
+}
+
+// WithSubmitBatchProvider allows setting a custom SubmitBatchProvider in a TestOverlayEngineStub.
+// This can be used to mock the submission of batches of transactions during tests.
+func WithSubmitBatchProvider(provider SubmitBatchProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.submitBatchProvider = provider
+	}
+}
+
+// WithPaymentAcceptorProvider allows setting a custom PaymentAcceptorProvider in a TestOverlayEngineStub.
+// This can be used to mock payment acceptance behavior during tests.
+func WithPaymentAcceptorProvider(provider PaymentAcceptorProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.paymentAcceptorProvider = provider
+	}
+}
+
 // TestOverlayEngineStub is a test implementation of the engine.OverlayEngineProvider interface.
 // It is used to mock engine behavior in unit tests, allowing the simulation of various engine actions
 // like submitting transactions and synchronizing advertisements.
@@ -203,6 +458,23 @@ type TestOverlayEngineStub struct {
 	syncAdvertisementsProvider        SyncAdvertisementsProvider
 	requestForeignGASPNodeProvider    RequestForeignGASPNodeProvider
 	requestSyncResponseProvider       RequestSyncResponseProvider
+	requestSyncReplyProvider          RequestSyncReplyProvider
+	submitGASPNodeProvider            SubmitGASPNodeProvider
+	listSyncCheckpointsProvider       ListSyncCheckpointsProvider
+	resetSyncCheckpointsProvider      ResetSyncCheckpointsProvider
+	handleReorgProvider               HandleReorgProvider
+	evictOutputProvider               EvictOutputProvider
+	pruneHistoryProvider              PruneHistoryProvider
+	streamEventsProvider              StreamEventsProvider
+	listWebhooksProvider              ListWebhooksProvider
+	registerWebhookProvider           RegisterWebhookProvider
+	deleteWebhookProvider             DeleteWebhookProvider
+	listWebhookDeliveriesProvider     ListWebhookDeliveriesProvider
+	listOutboxEntriesProvider         ListOutboxEntriesProvider
+	submitTransactionAsyncProvider    SubmitTransactionAsyncProvider
+	submitJobStatusProvider           SubmitJobStatusProvider
+	submitBatchProvider               SubmitBatchProvider
+	paymentAcceptorProvider           PaymentAcceptorProvider
 	arcIngestProvider                 ARCIngestProvider
 }
 
@@ -265,6 +537,104 @@ func (s *TestOverlayEngineStub) ProvideForeignSyncResponse(ctx context.Context,
 	return s.requestSyncResponseProvider.ProvideForeignSyncResponse(ctx, initialRequess, topic)
 }
 
+// ProvideForeignSyncReply returns a foreign sync reply.
+// It calls the ProvideForeignSyncReply method of the configured RequestSyncReplyProvider.
+func (s *TestOverlayEngineStub) ProvideForeignSyncReply(ctx context.Context, initialResponse *core.GASPInitialResponse, topic string) (*core.GASPInitialReply, error) {
+	s.t.Helper()
+	return s.requestSyncReplyProvider.ProvideForeignSyncReply(ctx, initialResponse, topic)
+}
+
+// SubmitForeignGASPNode accepts a foreign GASP node.
+// It calls the SubmitForeignGASPNode method of the configured SubmitGASPNodeProvider.
+func (s *TestOverlayEngineStub) SubmitForeignGASPNode(ctx context.Context, node *core.GASPNode, topic string) (*core.GASPNodeResponse, error) {
+	s.t.Helper()
+	return s.submitGASPNodeProvider.SubmitForeignGASPNode(ctx, node, topic)
+}
+
+// ListSyncCheckpoints lists the GASP sync checkpoints.
+// It calls the ListSyncCheckpoints method of the configured ListSyncCheckpointsProvider.
+func (s *TestOverlayEngineStub) ListSyncCheckpoints(ctx context.Context, topic string) ([]*engine.SyncCheckpoint, error) {
+	s.t.Helper()
+	return s.listSyncCheckpointsProvider.ListSyncCheckpoints(ctx, topic)
+}
+
+// ResetSyncCheckpoints resets the GASP sync checkpoints.
+// It calls the ResetSyncCheckpoints method of the configured ResetSyncCheckpointsProvider.
+func (s *TestOverlayEngineStub) ResetSyncCheckpoints(ctx context.Context, topic, peer string) (int, error) {
+	s.t.Helper()
+	return s.resetSyncCheckpointsProvider.ResetSyncCheckpoints(ctx, topic, peer)
+}
+
+// HandleReorg re-verifies the stored merkle proofs after a chain reorganization.
+// It calls the HandleReorg method of the configured HandleReorgProvider.
+func (s *TestOverlayEngineStub) HandleReorg(ctx context.Context, fromHeight uint32) (*engine.ReorgResult, error) {
+	s.t.Helper()
+	return s.handleReorgProvider.HandleReorg(ctx, fromHeight)
+}
+
+// EvictOutput evicts an output from every topic.
+// It calls the EvictOutput method of the configured EvictOutputProvider.
+func (s *TestOverlayEngineStub) EvictOutput(ctx context.Context, outpoint *transaction.Outpoint, includeHistory bool, actor, reason string) (*engine.Eviction, error) {
+	s.t.Helper()
+	return s.evictOutputProvider.EvictOutput(ctx, outpoint, includeHistory, actor, reason)
+}
+
+// Prune enforces the retention policies of every topic.
+// It calls the Prune method of the configured PruneHistoryProvider.
+func (s *TestOverlayEngineStub) Prune(ctx context.Context, dryRun bool) ([]*engine.PruneReport, error) {
+	s.t.Helper()
+	return s.pruneHistoryProvider.Prune(ctx, dryRun)
+}
+
+// PruneTopic enforces the retention policy of a topic.
+// It calls the PruneTopic method of the configured PruneHistoryProvider.
+func (s *TestOverlayEngineStub) PruneTopic(ctx context.Context, topic string, dryRun bool) (*engine.PruneReport, error) {
+	s.t.Helper()
+	return s.pruneHistoryProvider.PruneTopic(ctx, topic, dryRun)
+}
+
+// SubscribeEvents subscribes to the events of the topics.
+// It calls the SubscribeEvents method of the configured StreamEventsProvider.
+func (s *TestOverlayEngineStub) SubscribeEvents(topics []string, after *uint64) (*engine.EventSubscription, error) {
+	s.t.Helper()
+	return s.streamEventsProvider.SubscribeEvents(topics, after)
+}
+
+// RegisterWebhook registers a webhook of the topic.
+// It calls the RegisterWebhook method of the configured RegisterWebhookProvider.
+func (s *TestOverlayEngineStub) RegisterWebhook(ctx context.Context, topic, url, secret string) (*engine.Webhook, error) {
+	s.t.Helper()
+	return s.registerWebhookProvider.RegisterWebhook(ctx, topic, url, secret)
+}
+
+// DeleteWebhook deletes a registered webhook.
+// It calls the DeleteWebhook method of the configured DeleteWebhookProvider.
+func (s *TestOverlayEngineStub) DeleteWebhook(ctx context.Context, id string) error {
+	s.t.Helper()
+	return s.deleteWebhookProvider.DeleteWebhook(ctx, id)
+}
+
+// ListWebhooks lists the webhooks.
+// It calls the ListWebhooks method of the configured ListWebhooksProvider.
+func (s *TestOverlayEngineStub) ListWebhooks(ctx context.Context) ([]*engine.Webhook, error) {
+	s.t.Helper()
+	return s.listWebhooksProvider.ListWebhooks(ctx)
+}
+
+// ListWebhookDeliveries lists the webhook deliveries.
+// It calls the ListWebhookDeliveries method of the configured ListWebhookDeliveriesProvider.
+func (s *TestOverlayEngineStub) ListWebhookDeliveries(ctx context.Context, webhookID string, status engine.WebhookDeliveryStatus) ([]*engine.WebhookDelivery, error) {
+	s.t.Helper()
+	return s.listWebhookDeliveriesProvider.ListWebhookDeliveries(ctx, webhookID, status)
+}
+
+// ListOutboxEntries lists the broadcasts and propagations of the submitted transactions.
+// It calls the ListOutboxEntries method of the configured ListOutboxEntriesProvider.
+func (s *TestOverlayEngineStub) ListOutboxEntries(ctx context.Context, txid *chainhash.Hash, status engine.OutboxStatus) ([]*engine.OutboxEntry, error) {
+	s.t.Helper()
+	return s.listOutboxEntriesProvider.ListOutboxEntries(ctx, txid, status)
+}
+
 // StartGASPSync starts the GASP synchronization process.
 // It calls the StartGASPSync method of the configured StartGASPSyncProvider.
 func (s *TestOverlayEngineStub) StartGASPSync(ctx context.Context) error {
@@ -279,6 +649,34 @@ func (s *TestOverlayEngineStub) Submit(ctx context.Context, taggedBEEF overlay.T
 	return s.submitTransactionProvider.Submit(ctx, taggedBEEF, mode, onSteakReady)
 }
 
+// SubmitAsync queues a transaction submission and returns its job or error.
+// It calls the SubmitAsync method of the configured SubmitTransactionAsyncProvider.
+func (s *TestOverlayEngineStub) SubmitAsync(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode engine.SumbitMode) (*engine.SubmitJob, error) {
+	s.t.Helper()
+	return s.submitTransactionAsyncProvider.SubmitAsync(ctx, taggedBEEF, mode)
+}
+
+// FindSubmitJob returns the job of an asynchronous transaction submission.
+// It calls the FindSubmitJob method of the configured SubmitJobStatusProvider.
+func (s *TestOverlayEngineStub) FindSubmitJob(ctx context.Context, id string) (*engine.SubmitJob, error) {
+	s.t.Helper()
+	return s.submitJobStatusProvider.FindSubmitJob(ctx, id)
+}
+
+// SubmitBatch processes a batch of transaction submissions and returns the outcome of every transaction.
+// It calls the SubmitBatch method of the configured SubmitBatchProvider.
+func (s *TestOverlayEngineStub) SubmitBatch(ctx context.Context, taggedBEEFs []overlay.TaggedBEEF, mode engine.SumbitMode) []*engine.SubmitBatchResult {
+	s.t.Helper()
+	return s.submitBatchProvider.SubmitBatch(ctx, taggedBEEFs, mode)
+}
+
+// AcceptPayment accepts the payment of a request to the overlay.
+// It calls the AcceptPayment method of the configured PaymentAcceptorProvider.
+func (s *TestOverlayEngineStub) AcceptPayment(ctx context.Context, payment *engine.Payment) (*engine.PaymentReceipt, error) {
+	s.t.Helper()
+	return s.paymentAcceptorProvider.AcceptPayment(ctx, payment)
+}
+
 // SyncAdvertisements synchronizes advertisements using the configured SyncAdvertisementsProvider.
 // It calls the SyncAdvertisements method of the provider and handles the result.
 func (s *TestOverlayEngineStub) SyncAdvertisements(ctx context.Context) error {
@@ -301,6 +699,23 @@ func (s *TestOverlayEngineStub) AssertProvidersState() {
 		s.startGASPSyncProvider,
 		s.requestForeignGASPNodeProvider,
 		s.requestSyncResponseProvider,
+		s.requestSyncReplyProvider,
+		s.submitGASPNodeProvider,
+		s.listSyncCheckpointsProvider,
+		s.resetSyncCheckpointsProvider,
+		s.handleReorgProvider,
+		s.evictOutputProvider,
+		s.pruneHistoryProvider,
+		s.streamEventsProvider,
+		s.listWebhooksProvider,
+		s.registerWebhookProvider,
+		s.deleteWebhookProvider,
+		s.listWebhookDeliveriesProvider,
+		s.listOutboxEntriesProvider,
+		s.submitTransactionAsyncProvider,
+		s.submitJobStatusProvider,
+		s.submitBatchProvider,
+		s.paymentAcceptorProvider,
 		s.arcIngestProvider,
 	}
 	for _, p := range providers {
@@ -323,6 +738,23 @@ func NewTestOverlayEngineStub(t *testing.T, opts ...TestOverlayEngineStubOption)
 		syncAdvertisementsProvider:        NewSyncAdvertisementsProviderMock(t, SyncAdvertisementsProviderMockExpectations{SyncAdvertisementsCall: false}),
 		requestForeignGASPNodeProvider:    NewRequestForeignGASPNodeProviderMock(t, RequestForeignGASPNodeProviderMockExpectations{ProvideForeignGASPNodeCall: false}),
 		requestSyncResponseProvider:       NewRequestSyncResponseProviderMock(t, RequestSyncResponseProviderMockExpectations{ProvideForeignSyncResponseCall: false}),
+		requestSyncReplyProvider:          NewRequestSyncReplyProviderMock(t, RequestSyncReplyProviderMockExpectations{ProvideForeignSyncReplyCall: false}),
+		submitGASPNodeProvider:            NewSubmitGASPNodeProviderMock(t, SubmitGASPNodeProviderMockExpectations{SubmitForeignGASPNodeCall: false}),
+		listSyncCheckpointsProvider:       NewListSyncCheckpointsProviderMock(t, ListSyncCheckpointsProviderMockExpectations{ListSyncCheckpointsCall: false}),
+		resetSyncCheckpointsProvider:      NewResetSyncCheckpointsProviderMock(t, ResetSyncCheckpointsProviderMockExpectations{ResetSyncCheckpointsCall: false}),
+		handleReorgProvider:               NewHandleReorgProviderMock(t, HandleReorgProviderMockExpectations{HandleReorgCall: false}),
+		evictOutputProvider:               NewEvictOutputProviderMock(t, EvictOutputProviderMockExpectations{EvictOutputCall: false}),
+		pruneHistoryProvider:              NewPruneHistoryProviderMock(t, PruneHistoryProviderMockExpectations{PruneHistoryCall: false}),
+		streamEventsProvider:              NewStreamEventsProviderMock(t, StreamEventsProviderMockExpectations{SubscribeEventsCall: false}),
+		listWebhooksProvider:              NewListWebhooksProviderMock(t, ListWebhooksProviderMockExpectations{ListWebhooksCall: false}),
+		registerWebhookProvider:           NewRegisterWebhookProviderMock(t, RegisterWebhookProviderMockExpectations{RegisterWebhookCall: false}),
+		deleteWebhookProvider:             NewDeleteWebhookProviderMock(t, DeleteWebhookProviderMockExpectations{DeleteWebhookCall: false}),
+		listWebhookDeliveriesProvider:     NewListWebhookDeliveriesProviderMock(t, ListWebhookDeliveriesProviderMockExpectations{ListWebhookDeliveriesCall: false}),
+		listOutboxEntriesProvider:         NewListOutboxEntriesProviderMock(t, ListOutboxEntriesProviderMockExpectations{ListOutboxEntriesCall: false}),
+		submitTransactionAsyncProvider:    NewSubmitTransactionAsyncProviderMock(t, SubmitTransactionAsyncProviderMockExpectations{SubmitAsyncCall: false}),
+		submitJobStatusProvider:           NewSubmitJobStatusProviderMock(t, SubmitJobStatusProviderMockExpectations{FindSubmitJobCall: false}),
+		submitBatchProvider:               NewSubmitBatchProviderMock(t, SubmitBatchProviderMockExpectations{SubmitBatchCall: false}),
+		paymentAcceptorProvider:           NewPaymentAcceptorProviderMock(t, PaymentAcceptorProviderMockExpectations{AcceptPaymentCall: false}),
 		arcIngestProvider:                 NewARCIngestProviderMock(t, ARCIngestProviderMockExpectations{HandleNewMerkleProofCall: false}),
 	}
 
@@ -0,0 +1,81 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/stretchr/testify/require"
+)
+
+// PaymentAcceptorProviderMockExpectations defines the expected behavior of the PaymentAcceptorProviderMock during a test.
+type PaymentAcceptorProviderMockExpectations struct {
+	// Error is the error to return from AcceptPayment.
+	Error error
+
+	// Satoshis is the price AcceptPayment is expected to be called with.
+	Satoshis uint64
+
+	// Route is the route AcceptPayment is expected to be called with.
+	Route string
+
+	// Payer is the hex-encoded identity key of the payer AcceptPayment is expected to be called with.
+	Payer string
+
+	// LockingScript is the locking script AcceptPayment is expected to be called with.
+	LockingScript *script.Script
+
+	// AcceptPaymentCall indicates whether the AcceptPayment method is expected to be called during the test.
+	AcceptPaymentCall bool
+}
+
+// PaymentAcceptorProviderMock is a mock implementation of a payment acceptor,
+// used for testing the behavior of components that depend on accepting payments.
+type PaymentAcceptorProviderMock struct {
+	t            *testing.T
+	expectations PaymentAcceptorProviderMockExpectations
+	called       bool            // Tracks whether AcceptPayment was called
+	payment      *engine.Payment // Stores the payment passed to AcceptPayment
+}
+
+// AcceptPayment records the call and returns a receipt of the price or the predefined error.
+func (m *PaymentAcceptorProviderMock) AcceptPayment(ctx context.Context, payment *engine.Payment) (*engine.PaymentReceipt, error) {
+	m.t.Helper()
+	m.called = true
+	m.payment = payment
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return &engine.PaymentReceipt{
+		Satoshis:         payment.Satoshis,
+		Route:            payment.Route,
+		Payer:            payment.Payer,
+		DerivationPrefix: payment.DerivationPrefix,
+		DerivationSuffix: payment.DerivationSuffix,
+	}, nil
+}
+
+// AssertCalled verifies that AcceptPayment was called as expected and with the expected payment.
+func (m *PaymentAcceptorProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.AcceptPaymentCall, m.called, "Discrepancy between expected and actual AcceptPayment call")
+	if !m.called {
+		return
+	}
+	require.Equal(m.t, m.expectations.Satoshis, m.payment.Satoshis, "Discrepancy between expected and actual Satoshis")
+	require.Equal(m.t, m.expectations.Route, m.payment.Route, "Discrepancy between expected and actual Route")
+	require.Equal(m.t, m.expectations.Payer, m.payment.Payer, "Discrepancy between expected and actual Payer")
+	if m.expectations.LockingScript != nil {
+		require.Equal(m.t, m.expectations.LockingScript, m.payment.LockingScript, "Discrepancy between expected and actual LockingScript")
+	}
+}
+
+// NewPaymentAcceptorProviderMock creates a new instance of PaymentAcceptorProviderMock with the given expectations.
+func NewPaymentAcceptorProviderMock(t *testing.T, expectations PaymentAcceptorProviderMockExpectations) *PaymentAcceptorProviderMock {
+	return &PaymentAcceptorProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
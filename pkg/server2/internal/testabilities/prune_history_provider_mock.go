@@ -0,0 +1,81 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// PruneHistoryProviderMockExpectations defines the expected behavior of the PruneHistoryProviderMock during a test.
+type PruneHistoryProviderMockExpectations struct {
+	// Error is the error to return from Prune or PruneTopic.
+	Error error
+
+	// Reports are the reports to return from Prune, the first one is returned from PruneTopic.
+	Reports []*engine.PruneReport
+
+	// Topic is the topic PruneTopic is expected to be called with, empty when Prune is expected instead.
+	Topic string
+
+	// DryRun is the dry-run mode Prune or PruneTopic is expected to be called with.
+	DryRun bool
+
+	// PruneHistoryCall indicates whether Prune or PruneTopic is expected to be called during the test.
+	PruneHistoryCall bool
+}
+
+// PruneHistoryProviderMock is a mock implementation of a history pruning provider,
+// used for testing the behavior of components that depend on enforcing the retention policies.
+type PruneHistoryProviderMock struct {
+	t            *testing.T
+	expectations PruneHistoryProviderMockExpectations
+	called       bool   // Tracks whether Prune or PruneTopic was called
+	topic        string // Stores the topic passed to PruneTopic
+	dryRun       bool   // Stores the dry-run mode passed to Prune or PruneTopic
+}
+
+// Prune records the call and returns the predefined reports or error.
+func (m *PruneHistoryProviderMock) Prune(ctx context.Context, dryRun bool) ([]*engine.PruneReport, error) {
+	m.t.Helper()
+	m.called = true
+	m.dryRun = dryRun
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Reports, nil
+}
+
+// PruneTopic records the call and returns the first predefined report or error.
+func (m *PruneHistoryProviderMock) PruneTopic(ctx context.Context, topic string, dryRun bool) (*engine.PruneReport, error) {
+	m.t.Helper()
+	m.called = true
+	m.topic = topic
+	m.dryRun = dryRun
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	if len(m.expectations.Reports) == 0 {
+		return &engine.PruneReport{Topic: topic, DryRun: dryRun}, nil
+	}
+	return m.expectations.Reports[0], nil
+}
+
+// AssertCalled verifies that Prune or PruneTopic was called as expected and with the expected arguments.
+func (m *PruneHistoryProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.PruneHistoryCall, m.called, "Discrepancy between expected and actual PruneHistory call")
+	require.Equal(m.t, m.expectations.Topic, m.topic, "Discrepancy between expected and actual Topic")
+	require.Equal(m.t, m.expectations.DryRun, m.dryRun, "Discrepancy between expected and actual DryRun")
+}
+
+// NewPruneHistoryProviderMock creates a new instance of PruneHistoryProviderMock with the given expectations.
+func NewPruneHistoryProviderMock(t *testing.T, expectations PruneHistoryProviderMockExpectations) *PruneHistoryProviderMock {
+	return &PruneHistoryProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,66 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// RegisterWebhookProviderMockExpectations defines the expected behavior of the RegisterWebhookProviderMock during a test.
+type RegisterWebhookProviderMockExpectations struct {
+	// Error is the error to return from RegisterWebhook.
+	Error error
+
+	// Webhook is the webhook to return from RegisterWebhook.
+	Webhook *engine.Webhook
+
+	// Topic, URL and Secret are the arguments RegisterWebhook is expected to be called with.
+	Topic  string
+	URL    string
+	Secret string
+
+	// RegisterWebhookCall indicates whether RegisterWebhook is expected to be called during the test.
+	RegisterWebhookCall bool
+}
+
+// RegisterWebhookProviderMock is a mock implementation of a webhook registration provider,
+// used for testing the behavior of components that depend on registering webhooks.
+type RegisterWebhookProviderMock struct {
+	t            *testing.T
+	expectations RegisterWebhookProviderMockExpectations
+	called       bool   // Tracks whether RegisterWebhook was called
+	topic        string // Stores the topic passed to RegisterWebhook
+	url          string // Stores the URL passed to RegisterWebhook
+	secret       string // Stores the secret passed to RegisterWebhook
+}
+
+// RegisterWebhook records the call and returns the predefined webhook or error.
+func (m *RegisterWebhookProviderMock) RegisterWebhook(ctx context.Context, topic, url, secret string) (*engine.Webhook, error) {
+	m.t.Helper()
+	m.called = true
+	m.topic, m.url, m.secret = topic, url, secret
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Webhook, nil
+}
+
+// AssertCalled verifies that RegisterWebhook was called as expected and with the expected arguments.
+func (m *RegisterWebhookProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.RegisterWebhookCall, m.called, "Discrepancy between expected and actual RegisterWebhook call")
+	require.Equal(m.t, m.expectations.Topic, m.topic, "Discrepancy between expected and actual Topic")
+	require.Equal(m.t, m.expectations.URL, m.url, "Discrepancy between expected and actual URL")
+	require.Equal(m.t, m.expectations.Secret, m.secret, "Discrepancy between expected and actual Secret")
+}
+
+// NewRegisterWebhookProviderMock creates a new instance of RegisterWebhookProviderMock with the given expectations.
+func NewRegisterWebhookProviderMock(t *testing.T, expectations RegisterWebhookProviderMockExpectations) *RegisterWebhookProviderMock {
+	return &RegisterWebhookProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,63 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/stretchr/testify/require"
+)
+
+// RequestSyncReplyProviderMockExpectations defines mock expectations.
+type RequestSyncReplyProviderMockExpectations struct {
+	Error                       error
+	Reply                       *core.GASPInitialReply
+	ProvideForeignSyncReplyCall bool
+	InitialResponse             *core.GASPInitialResponse
+	Topic                       string
+}
+
+// RequestSyncReplyProviderMock is a test double that implements the
+// behavior of a RequestSyncReplyProvider. It records call data and
+// validates expectations defined via RequestSyncReplyProviderMockExpectations.
+type RequestSyncReplyProviderMock struct {
+	t               *testing.T
+	expectations    RequestSyncReplyProviderMockExpectations
+	called          bool                      // Tracks whether ProvideForeignSyncReply was called
+	topic           string                    // Stores the topic passed to ProvideForeignSyncReply
+	initialResponse *core.GASPInitialResponse // Stores the response passed to ProvideForeignSyncReply
+}
+
+// ProvideForeignSyncReply simulates the behavior of a real provider.
+// It captures input values and returns either the expected mock reply or error.
+func (m *RequestSyncReplyProviderMock) ProvideForeignSyncReply(ctx context.Context, initialResponse *core.GASPInitialResponse, topic string) (*core.GASPInitialReply, error) {
+	m.t.Helper()
+	m.called = true
+	m.topic = topic
+	m.initialResponse = initialResponse
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+
+	return m.expectations.Reply, nil
+}
+
+// AssertCalled verifies that ProvideForeignSyncReply was called as expected.
+// It compares the actual call data (topic and initial response) with the expected values
+// and fails the test if discrepancies are found.
+func (m *RequestSyncReplyProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ProvideForeignSyncReplyCall, m.called, "Discrepancy between expected and actual ProvideForeignSyncReplyCall")
+	require.Equal(m.t, m.expectations.InitialResponse, m.initialResponse, "Discrepancy between expected and actual InitialResponse")
+	require.Equal(m.t, m.expectations.Topic, m.topic, "Discrepancy between expected and actual Topic")
+}
+
+// NewRequestSyncReplyProviderMock constructs a new RequestSyncReplyProviderMock
+// with predefined expectations.
+func NewRequestSyncReplyProviderMock(t *testing.T, expectations RequestSyncReplyProviderMockExpectations) *RequestSyncReplyProviderMock {
+	return &RequestSyncReplyProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,65 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ResetSyncCheckpointsProviderMockExpectations defines the expected behavior of the ResetSyncCheckpointsProviderMock during a test.
+type ResetSyncCheckpointsProviderMockExpectations struct {
+	// Error is the error to return from ResetSyncCheckpoints.
+	Error error
+
+	// Deleted is the number of deleted checkpoints to return from ResetSyncCheckpoints.
+	Deleted int
+
+	// Topic is the topic ResetSyncCheckpoints is expected to be called with.
+	Topic string
+
+	// Peer is the peer ResetSyncCheckpoints is expected to be called with.
+	Peer string
+
+	// ResetSyncCheckpointsCall indicates whether the ResetSyncCheckpoints method is expected to be called during the test.
+	ResetSyncCheckpointsCall bool
+}
+
+// ResetSyncCheckpointsProviderMock is a mock implementation of a sync checkpoints reset provider,
+// used for testing the behavior of components that depend on resetting sync checkpoints.
+type ResetSyncCheckpointsProviderMock struct {
+	t            *testing.T
+	expectations ResetSyncCheckpointsProviderMockExpectations
+	called       bool   // Tracks whether ResetSyncCheckpoints was called
+	topic        string // Stores the topic passed to ResetSyncCheckpoints
+	peer         string // Stores the peer passed to ResetSyncCheckpoints
+}
+
+// ResetSyncCheckpoints records the call and returns the predefined number of deleted checkpoints or error.
+func (m *ResetSyncCheckpointsProviderMock) ResetSyncCheckpoints(ctx context.Context, topic, peer string) (int, error) {
+	m.t.Helper()
+	m.called = true
+	m.topic = topic
+	m.peer = peer
+
+	if m.expectations.Error != nil {
+		return 0, m.expectations.Error
+	}
+	return m.expectations.Deleted, nil
+}
+
+// AssertCalled verifies that ResetSyncCheckpoints was called as expected and with the expected topic and peer.
+func (m *ResetSyncCheckpointsProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ResetSyncCheckpointsCall, m.called, "Discrepancy between expected and actual ResetSyncCheckpoints call")
+	require.Equal(m.t, m.expectations.Topic, m.topic, "Discrepancy between expected and actual Topic")
+	require.Equal(m.t, m.expectations.Peer, m.peer, "Discrepancy between expected and actual Peer")
+}
+
+// NewResetSyncCheckpointsProviderMock creates a new instance of ResetSyncCheckpointsProviderMock with the given expectations.
+func NewResetSyncCheckpointsProviderMock(t *testing.T, expectations ResetSyncCheckpointsProviderMockExpectations) *ResetSyncCheckpointsProviderMock {
+	return &ResetSyncCheckpointsProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
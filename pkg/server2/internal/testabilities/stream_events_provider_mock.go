@@ -0,0 +1,78 @@
+package testabilities
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// StreamEventsProviderMockExpectations defines the expected behavior of the StreamEventsProviderMock during a test.
+type StreamEventsProviderMockExpectations struct {
+	// Error is the error to return from SubscribeEvents.
+	Error error
+
+	// Events are the events published before subscribing. The subscription delivers those
+	// matching its topics and cursor, and then ends.
+	Events []engine.Event
+
+	// Topics are the topics SubscribeEvents is expected to be called with.
+	Topics []string
+
+	// Cursor is the cursor SubscribeEvents is expected to be called with.
+	Cursor *uint64
+
+	// SubscribeEventsCall indicates whether SubscribeEvents is expected to be called during the test.
+	SubscribeEventsCall bool
+}
+
+// StreamEventsProviderMock is a mock implementation of an event subscription provider,
+// used for testing the behavior of components that depend on streaming the events of the engine.
+type StreamEventsProviderMock struct {
+	t            *testing.T
+	expectations StreamEventsProviderMockExpectations
+	called       bool     // Tracks whether SubscribeEvents was called
+	topics       []string // Stores the topics passed to SubscribeEvents
+	cursor       *uint64  // Stores the cursor passed to SubscribeEvents
+}
+
+// SubscribeEvents records the call and returns the predefined error, or a subscription
+// delivering the predefined events which ends once they are consumed.
+func (m *StreamEventsProviderMock) SubscribeEvents(topics []string, after *uint64) (*engine.EventSubscription, error) {
+	m.t.Helper()
+	m.called = true
+	m.topics = topics
+	m.cursor = after
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+
+	bus := engine.NewEventBus(len(m.expectations.Events))
+	bus.Publish(m.expectations.Events...)
+	if after == nil {
+		after = new(uint64)
+	}
+	sub, err := bus.Subscribe(topics, after)
+	if err != nil {
+		return nil, err
+	}
+	bus.Close()
+	return sub, nil
+}
+
+// AssertCalled verifies that SubscribeEvents was called as expected and with the expected arguments.
+func (m *StreamEventsProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.SubscribeEventsCall, m.called, "Discrepancy between expected and actual SubscribeEvents call")
+	require.Equal(m.t, m.expectations.Topics, m.topics, "Discrepancy between expected and actual Topics")
+	require.Equal(m.t, m.expectations.Cursor, m.cursor, "Discrepancy between expected and actual Cursor")
+}
+
+// NewStreamEventsProviderMock creates a new instance of StreamEventsProviderMock with the given expectations.
+func NewStreamEventsProviderMock(t *testing.T, expectations StreamEventsProviderMockExpectations) *StreamEventsProviderMock {
+	return &StreamEventsProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,65 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// SubmitBatchProviderMockExpectations defines the expected behavior of the SubmitBatchProviderMock during a test.
+type SubmitBatchProviderMockExpectations struct {
+	// Results are the results to return from SubmitBatch.
+	Results []*engine.SubmitBatchResult
+
+	// TaggedBEEFs are the tagged BEEFs SubmitBatch is expected to be called with.
+	TaggedBEEFs []overlay.TaggedBEEF
+
+	// SubmitMode is the mode SubmitBatch is expected to be called with, SubmitModeCurrent when empty.
+	SubmitMode engine.SumbitMode
+
+	// SubmitBatchCall indicates whether SubmitBatch is expected to be called during the test.
+	SubmitBatchCall bool
+}
+
+// SubmitBatchProviderMock is a mock implementation of a batch submission provider,
+// used for testing the behavior of components that depend on submitting batches of transactions.
+type SubmitBatchProviderMock struct {
+	t            *testing.T
+	expectations SubmitBatchProviderMockExpectations
+	called       bool                 // Tracks whether SubmitBatch was called
+	taggedBEEFs  []overlay.TaggedBEEF // Stores the tagged BEEFs passed to SubmitBatch
+	mode         engine.SumbitMode    // Stores the SubmitMode passed to SubmitBatch
+}
+
+// SubmitBatch records the call and returns the predefined results.
+func (m *SubmitBatchProviderMock) SubmitBatch(ctx context.Context, taggedBEEFs []overlay.TaggedBEEF, mode engine.SumbitMode) []*engine.SubmitBatchResult {
+	m.t.Helper()
+	m.called = true
+	m.taggedBEEFs, m.mode = taggedBEEFs, mode
+	return m.expectations.Results
+}
+
+// AssertCalled verifies that SubmitBatch was called as expected and with the expected tagged BEEFs in the expected mode.
+func (m *SubmitBatchProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.SubmitBatchCall, m.called, "Discrepancy between expected and actual SubmitBatch call")
+	require.Equal(m.t, m.expectations.TaggedBEEFs, m.taggedBEEFs, "Discrepancy between expected and actual TaggedBEEFs")
+	if m.called {
+		mode := m.expectations.SubmitMode
+		if mode == "" {
+			mode = engine.SubmitModeCurrent
+		}
+		require.Equal(m.t, mode, m.mode, "Discrepancy between expected and actual SubmitMode")
+	}
+}
+
+// NewSubmitBatchProviderMock creates a new instance of SubmitBatchProviderMock with the given expectations.
+func NewSubmitBatchProviderMock(t *testing.T, expectations SubmitBatchProviderMockExpectations) *SubmitBatchProviderMock {
+	return &SubmitBatchProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,68 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/app"
+	"github.com/stretchr/testify/require"
+)
+
+// DefaultValidRawTx is the hex of a minimal transaction used in SubmitGASPNode tests.
+const DefaultValidRawTx = "01000000000100000000000000000000000000"
+
+// SubmitGASPNodeDefaultDTO provides a default DTO for SubmitGASPNode tests.
+var SubmitGASPNodeDefaultDTO = app.SubmitGASPNodeDTO{
+	GraphID:     DefaultValidGraphID,
+	RawTx:       DefaultValidRawTx,
+	OutputIndex: DefaultValidOutputIndex,
+	Topic:       DefaultValidTopic,
+}
+
+// SubmitGASPNodeProviderMockExpectations defines the expected behavior of the mock provider.
+type SubmitGASPNodeProviderMockExpectations struct {
+	Error                     error
+	Response                  *core.GASPNodeResponse
+	SubmitForeignGASPNodeCall bool
+}
+
+// SubmitGASPNodeProviderMock is a mock implementation for testing.
+type SubmitGASPNodeProviderMock struct {
+	t            *testing.T
+	expectations SubmitGASPNodeProviderMockExpectations
+	called       bool
+	node         *core.GASPNode
+}
+
+// SubmitForeignGASPNode mocks the SubmitForeignGASPNode method.
+func (m *SubmitGASPNodeProviderMock) SubmitForeignGASPNode(ctx context.Context, node *core.GASPNode, topic string) (*core.GASPNodeResponse, error) {
+	m.t.Helper()
+	m.called = true
+	m.node = node
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+
+	return m.expectations.Response, nil
+}
+
+// Node returns the node passed to SubmitForeignGASPNode.
+func (m *SubmitGASPNodeProviderMock) Node() *core.GASPNode {
+	return m.node
+}
+
+// AssertCalled verifies the method was called as expected.
+func (m *SubmitGASPNodeProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.SubmitForeignGASPNodeCall, m.called, "Discrepancy between expected and actual SubmitForeignGASPNode call")
+}
+
+// NewSubmitGASPNodeProviderMock creates a new mock provider.
+func NewSubmitGASPNodeProviderMock(t *testing.T, expectations SubmitGASPNodeProviderMockExpectations) *SubmitGASPNodeProviderMock {
+	return &SubmitGASPNodeProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
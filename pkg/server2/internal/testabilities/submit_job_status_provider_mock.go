@@ -0,0 +1,60 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// SubmitJobStatusProviderMockExpectations defines the expected behavior of the SubmitJobStatusProviderMock during a test.
+type SubmitJobStatusProviderMockExpectations struct {
+	// Error is the error to return from FindSubmitJob.
+	Error error
+
+	// Job is the job to return from FindSubmitJob.
+	Job *engine.SubmitJob
+
+	// JobID is the job ID FindSubmitJob is expected to be called with.
+	JobID string
+
+	// FindSubmitJobCall indicates whether FindSubmitJob is expected to be called during the test.
+	FindSubmitJobCall bool
+}
+
+// SubmitJobStatusProviderMock is a mock implementation of a submission job lookup provider,
+// used for testing the behavior of components that depend on the status of the asynchronous submissions.
+type SubmitJobStatusProviderMock struct {
+	t            *testing.T
+	expectations SubmitJobStatusProviderMockExpectations
+	called       bool   // Tracks whether FindSubmitJob was called
+	jobID        string // Stores the job ID passed to FindSubmitJob
+}
+
+// FindSubmitJob records the call and returns the predefined job or error.
+func (m *SubmitJobStatusProviderMock) FindSubmitJob(ctx context.Context, id string) (*engine.SubmitJob, error) {
+	m.t.Helper()
+	m.called = true
+	m.jobID = id
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Job, nil
+}
+
+// AssertCalled verifies that FindSubmitJob was called as expected and with the expected job ID.
+func (m *SubmitJobStatusProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.FindSubmitJobCall, m.called, "Discrepancy between expected and actual FindSubmitJob call")
+	require.Equal(m.t, m.expectations.JobID, m.jobID, "Discrepancy between expected and actual JobID")
+}
+
+// NewSubmitJobStatusProviderMock creates a new instance of SubmitJobStatusProviderMock with the given expectations.
+func NewSubmitJobStatusProviderMock(t *testing.T, expectations SubmitJobStatusProviderMockExpectations) *SubmitJobStatusProviderMock {
+	return &SubmitJobStatusProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
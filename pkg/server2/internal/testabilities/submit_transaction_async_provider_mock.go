@@ -0,0 +1,78 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// SubmitTransactionAsyncProviderMockExpectations defines the expected behavior of the SubmitTransactionAsyncProviderMock during a test.
+type SubmitTransactionAsyncProviderMockExpectations struct {
+	// Error is the error to return from SubmitAsync.
+	Error error
+
+	// Job is the job to return from SubmitAsync.
+	Job *engine.SubmitJob
+
+	// Topics are the topics SubmitAsync is expected to be called with.
+	Topics []string
+
+	// Beefs are the BEEFs SubmitAsync is expected to be called with, in the order of the calls.
+	// They are checked when AssertCalled runs, so a BEEF changed after being queued is noticed. Not checked when nil.
+	Beefs [][]byte
+
+	// SubmitAsyncCall indicates whether SubmitAsync is expected to be called during the test.
+	SubmitAsyncCall bool
+}
+
+// SubmitTransactionAsyncProviderMock is a mock implementation of an asynchronous transaction submission provider,
+// used for testing the behavior of components that depend on queueing transactions.
+type SubmitTransactionAsyncProviderMock struct {
+	t            *testing.T
+	expectations SubmitTransactionAsyncProviderMockExpectations
+	called       bool                 // Tracks whether SubmitAsync was called
+	taggedBEEFs  []overlay.TaggedBEEF // Stores the TaggedBEEFs passed to SubmitAsync
+	mode         engine.SumbitMode    // Stores the SubmitMode passed to SubmitAsync
+}
+
+// SubmitAsync records the call and returns the predefined job or error.
+func (m *SubmitTransactionAsyncProviderMock) SubmitAsync(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode engine.SumbitMode) (*engine.SubmitJob, error) {
+	m.t.Helper()
+	m.called = true
+	m.taggedBEEFs, m.mode = append(m.taggedBEEFs, taggedBEEF), mode
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Job, nil
+}
+
+// AssertCalled verifies that SubmitAsync was called as expected and with the expected topics in the current mode.
+func (m *SubmitTransactionAsyncProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.SubmitAsyncCall, m.called, "Discrepancy between expected and actual SubmitAsync call")
+	if m.called {
+		for _, taggedBEEF := range m.taggedBEEFs {
+			require.Equal(m.t, m.expectations.Topics, taggedBEEF.Topics, "Discrepancy between expected and actual Topics")
+		}
+		require.Equal(m.t, engine.SubmitModeCurrent, m.mode, "Discrepancy between expected and actual SubmitMode")
+	}
+	if m.expectations.Beefs != nil {
+		beefs := make([][]byte, 0, len(m.taggedBEEFs))
+		for _, taggedBEEF := range m.taggedBEEFs {
+			beefs = append(beefs, taggedBEEF.Beef)
+		}
+		require.Equal(m.t, m.expectations.Beefs, beefs, "Discrepancy between expected and actual Beefs")
+	}
+}
+
+// NewSubmitTransactionAsyncProviderMock creates a new instance of SubmitTransactionAsyncProviderMock with the given expectations.
+func NewSubmitTransactionAsyncProviderMock(t *testing.T, expectations SubmitTransactionAsyncProviderMockExpectations) *SubmitTransactionAsyncProviderMock {
+	return &SubmitTransactionAsyncProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
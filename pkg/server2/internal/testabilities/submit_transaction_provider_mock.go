@@ -23,6 +23,9 @@ type SubmitTransactionProviderMockExpectations struct {
 
 	// TriggerCallbackAfter specifies the duration after which the callback should be invoked.
 	TriggerCallbackAfter time.Duration
+
+	// SubmitMode is the mode Submit is expected to be called with, not checked when empty.
+	SubmitMode engine.SumbitMode
 }
 
 // DefaultSubmitTransactionProviderMockExpectations provides default expectations for SubmitTransactionProviderMock,
@@ -77,10 +80,13 @@ func (s *SubmitTransactionProviderMock) Submit(ctx context.Context, taggedBEEF o
 	return overlay.Steak{}, nil
 }
 
-// AssertCalled verifies that the Submit method was called if it was expected to be.
+// AssertCalled verifies that the Submit method was called if it was expected to be, in the expected mode.
 func (s *SubmitTransactionProviderMock) AssertCalled() {
 	s.t.Helper()
 	require.Equal(s.t, s.expectations.SubmitCall, s.called, "Discrepancy between expected and actual Submit call")
+	if s.called && s.expectations.SubmitMode != "" {
+		require.Equal(s.t, s.expectations.SubmitMode, s.calledSubmitMode, "Discrepancy between expected and actual SubmitMode")
+	}
 }
 
 // NewSubmitTransactionProviderMock creates a new instance of SubmitTransactionProviderMock with the given expectations.
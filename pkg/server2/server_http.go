@@ -2,7 +2,10 @@ package server2
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
@@ -11,6 +14,8 @@ import (
 	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/decorators"
 	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/middleware"
 	"github.com/4chain-ag/go-overlay-services/pkg/server2/internal/ports/openapi"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/monitor"
 	"github.com/google/uuid"
@@ -51,6 +56,47 @@ type Config struct {
 
 	// ARCCallbackToken is the token for authenticating ARC callback requests.
 	ARCCallbackToken string `mapstructure:"arc_callback_token"`
+
+	// MutualAuthPrivateKey is the hex-encoded private key of the identity of the overlay node in the
+	// BRC-103/104 mutual authentication of the public endpoints. Mutual authentication is disabled when empty.
+	MutualAuthPrivateKey string `mapstructure:"mutual_auth_private_key"`
+
+	// MutualAuthAllowUnauthenticated lets the requests without mutual authentication through anonymously
+	// when mutual authentication is enabled, instead of rejecting them.
+	MutualAuthAllowUnauthenticated bool `mapstructure:"mutual_auth_allow_unauthenticated"`
+
+	// MutualAuthSessionTimeout is the idle time after which a peer must repeat the mutual authentication handshake.
+	// Defaults to middleware.DefaultMutualAuthSessionTimeout when zero.
+	MutualAuthSessionTimeout time.Duration `mapstructure:"mutual_auth_session_timeout"`
+
+	// MutualAuthMaxSessions caps the mutual authentication sessions kept by the server, the least recently used
+	// one being dropped for a new one. Defaults to middleware.DefaultMutualAuthMaxSessions when zero.
+	MutualAuthMaxSessions int `mapstructure:"mutual_auth_max_sessions"`
+
+	// MutualAuthMaxSessionsPerIdentity caps the mutual authentication sessions kept per identity key of the peers.
+	// Defaults to middleware.DefaultMutualAuthMaxSessionsPerIdentity when zero.
+	MutualAuthMaxSessionsPerIdentity int `mapstructure:"mutual_auth_max_sessions_per_identity"`
+
+	// MutualAuthMaxPendingSessions caps the mutual authentication sessions awaiting their first signed request,
+	// the oldest one being dropped for a new one. Defaults to middleware.DefaultMutualAuthMaxPendingSessions when zero.
+	MutualAuthMaxPendingSessions int `mapstructure:"mutual_auth_max_pending_sessions"`
+
+	// PaymentPrivateKey is the hex-encoded private key the payments of the priced requests are derived from.
+	// Defaults to MutualAuthPrivateKey when empty.
+	PaymentPrivateKey string `mapstructure:"payment_private_key"`
+
+	// SubmitPrice is the price, in satoshis, of every submitted transaction. Submissions are free when zero.
+	SubmitPrice uint64 `mapstructure:"submit_price"`
+
+	// SubmitPricePerByte is the price, in satoshis, of every byte of submitted BEEF, on top of SubmitPrice.
+	SubmitPricePerByte uint64 `mapstructure:"submit_price_per_byte"`
+
+	// LookupPrice is the price, in satoshis, of a lookup question to a service without a price in LookupServicePrices.
+	// Lookups are free when zero.
+	LookupPrice uint64 `mapstructure:"lookup_price"`
+
+	// LookupServicePrices is the price, in satoshis, of a lookup question per lookup service.
+	LookupServicePrices map[string]uint64 `mapstructure:"lookup_service_prices"`
 }
 
 // DefaultConfig provides a default configuration with reasonable values for local development.
@@ -87,6 +133,92 @@ func WithARCCallbackToken(token string) ServerOption {
 	}
 }
 
+// WithMutualAuthPrivateKey enables the BRC-103/104 mutual authentication of the public endpoints, using the
+// hex-encoded private key as the identity of the overlay node. The identity key of the authenticated callers
+// is carried by the context passed to the engine, see engine.IdentityKeyFromContext.
+// It returns a ServerOption that applies this configuration to ServerHTTP.
+func WithMutualAuthPrivateKey(privateKey string) ServerOption {
+	return func(s *ServerHTTP) {
+		s.cfg.MutualAuthPrivateKey = privateKey
+	}
+}
+
+// WithMutualAuthAllowUnauthenticated sets whether the requests without mutual authentication are handled
+// anonymously instead of being rejected, when mutual authentication is enabled.
+// It returns a ServerOption that applies this configuration to ServerHTTP.
+func WithMutualAuthAllowUnauthenticated(allow bool) ServerOption {
+	return func(s *ServerHTTP) {
+		s.cfg.MutualAuthAllowUnauthenticated = allow
+	}
+}
+
+// WithMutualAuthSessionTimeout sets the idle time after which a peer must repeat the mutual authentication handshake.
+// It returns a ServerOption that applies this configuration to ServerHTTP.
+func WithMutualAuthSessionTimeout(timeout time.Duration) ServerOption {
+	return func(s *ServerHTTP) {
+		s.cfg.MutualAuthSessionTimeout = timeout
+	}
+}
+
+// WithMutualAuthMaxSessions caps the mutual authentication sessions kept in total and per identity key of the peers.
+// It returns a ServerOption that applies this configuration to ServerHTTP.
+func WithMutualAuthMaxSessions(total, perIdentity int) ServerOption {
+	return func(s *ServerHTTP) {
+		s.cfg.MutualAuthMaxSessions = total
+		s.cfg.MutualAuthMaxSessionsPerIdentity = perIdentity
+	}
+}
+
+// WithMutualAuthMaxPendingSessions caps the mutual authentication sessions awaiting their first signed request.
+// It returns a ServerOption that applies this configuration to ServerHTTP.
+func WithMutualAuthMaxPendingSessions(pending int) ServerOption {
+	return func(s *ServerHTTP) {
+		s.cfg.MutualAuthMaxPendingSessions = pending
+	}
+}
+
+// WithPaymentPrivateKey sets the hex-encoded private key the payments of the priced requests are derived from,
+// when it differs from the mutual authentication private key.
+// It returns a ServerOption that applies this configuration to ServerHTTP.
+func WithPaymentPrivateKey(privateKey string) ServerOption {
+	return func(s *ServerHTTP) {
+		s.cfg.PaymentPrivateKey = privateKey
+	}
+}
+
+// WithSubmitPrice charges, in satoshis, for every submitted transaction and for every byte of submitted BEEF.
+// Payments follow the BRC-105 flow and are accepted by the engine, which must implement engine.PaymentAcceptor.
+// It returns a ServerOption that applies this configuration to ServerHTTP.
+func WithSubmitPrice(price, pricePerByte uint64) ServerOption {
+	return func(s *ServerHTTP) {
+		s.cfg.SubmitPrice = price
+		s.cfg.SubmitPricePerByte = pricePerByte
+	}
+}
+
+// WithLookupPrice charges, in satoshis, for every lookup question to a service without a price of its own.
+// Payments follow the BRC-105 flow and are accepted by the engine, which must implement engine.PaymentAcceptor.
+// It returns a ServerOption that applies this configuration to ServerHTTP.
+func WithLookupPrice(price uint64) ServerOption {
+	return func(s *ServerHTTP) {
+		s.cfg.LookupPrice = price
+	}
+}
+
+// WithLookupServicePrice charges, in satoshis, for every lookup question to the lookup service,
+// overriding the price set by WithLookupPrice. A zero price makes the lookup service free.
+// It returns a ServerOption that applies this configuration to ServerHTTP.
+func WithLookupServicePrice(service string, price uint64) ServerOption {
+	return func(s *ServerHTTP) {
+		prices := make(map[string]uint64, len(s.cfg.LookupServicePrices)+1)
+		for name, p := range s.cfg.LookupServicePrices {
+			prices[name] = p
+		}
+		prices[service] = price
+		s.cfg.LookupServicePrices = prices
+	}
+}
+
 // WithMiddleware adds a Fiber middleware handler to the HTTP server configuration.
 // It returns a ServerOption that appends the given middleware to the server's middleware stack.
 func WithMiddleware(f fiber.Handler) ServerOption {
@@ -142,6 +274,12 @@ type ServerHTTP struct {
 	app        *fiber.App      // app is the Fiber application instance serving HTTP requests.
 	middleware []fiber.Handler // middleware is a list of Fiber middleware functions to be applied globally.
 	engine     engine.OverlayEngineProvider
+
+	// schedulerMu guards stopScheduler, cancelling the background tasks of the engine,
+	// and schedulerDone, closed once every background task stopped.
+	schedulerMu   sync.Mutex
+	stopScheduler context.CancelFunc
+	schedulerDone chan struct{}
 }
 
 // SocketAddr builds the address string for binding.
@@ -150,15 +288,73 @@ func (s *ServerHTTP) SocketAddr() string {
 }
 
 // ListenAndServe starts the HTTP server and begins listening on the configured socket address.
+// When the engine implements engine.GASPSyncScheduler or engine.HistoryPruner, its background GASP
+// sync or history pruning runs until the context is done or the server is shut down.
 // It blocks until the server is stopped or an error occurs.
 func (s *ServerHTTP) ListenAndServe(ctx context.Context) error {
+	s.startScheduler(ctx)
 	return s.app.Listen(s.SocketAddr())
 }
 
 // Shutdown gracefully shuts down the HTTP server using the provided context,
 // allowing ongoing requests to complete within the context's deadline.
+// The engine is closed first, ending the event streams which would otherwise keep their requests open.
+// It also stops the background tasks of the engine, waiting for them within the same deadline.
 func (s *ServerHTTP) Shutdown(ctx context.Context) error {
-	return s.app.ShutdownWithContext(ctx)
+	if closer, ok := s.engine.(engine.Closer); ok {
+		closer.Close()
+	}
+	err := s.app.ShutdownWithContext(ctx)
+	s.schedulerMu.Lock()
+	stop, done := s.stopScheduler, s.schedulerDone
+	s.schedulerMu.Unlock()
+	if stop != nil {
+		stop()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		}
+	}
+	return err
+}
+
+// startScheduler runs the background GASP sync, history pruning, webhook delivery and outbox retries
+// of the engine, when it supports them.
+func (s *ServerHTTP) startScheduler(ctx context.Context) {
+	var tasks []func(context.Context)
+	if scheduler, ok := s.engine.(engine.GASPSyncScheduler); ok {
+		tasks = append(tasks, scheduler.RunGASPSyncScheduler)
+	}
+	if pruner, ok := s.engine.(engine.HistoryPruner); ok {
+		tasks = append(tasks, pruner.RunHistoryPruner)
+	}
+	if dispatcher, ok := s.engine.(engine.WebhookDispatcher); ok {
+		tasks = append(tasks, dispatcher.RunWebhookDispatcher)
+	}
+	if dispatcher, ok := s.engine.(engine.OutboxDispatcher); ok {
+		tasks = append(tasks, dispatcher.RunOutboxDispatcher)
+	}
+	if len(tasks) == 0 {
+		return
+	}
+	ctx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	s.schedulerMu.Lock()
+	s.stopScheduler, s.schedulerDone = stop, done
+	s.schedulerMu.Unlock()
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for _, task := range tasks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				task(ctx)
+			}()
+		}
+		wg.Wait()
+	}()
 }
 
 // New creates and configures a new instance of ServerHTTP.
@@ -182,14 +378,30 @@ func New(opts ...ServerOption) *ServerHTTP {
 		Scheme:        "Bearer ",
 	})
 
+	globalMiddleware := middleware.BasicMiddlewareGroup(middleware.BasicMiddlewareGroupConfig{
+		EnableStackTrace: true,
+		OctetStreamLimit: srv.cfg.OctetStreamLimit,
+	})
+	if srv.cfg.MutualAuthPrivateKey != "" {
+		globalMiddleware = append(globalMiddleware, middleware.MutualAuthMiddleware(middleware.MutualAuthMiddlewareConfig{
+			Wallet:                 newIdentityWallet(srv.cfg.MutualAuthPrivateKey),
+			AllowUnauthenticated:   srv.cfg.MutualAuthAllowUnauthenticated,
+			SessionTimeout:         srv.cfg.MutualAuthSessionTimeout,
+			MaxSessions:            srv.cfg.MutualAuthMaxSessions,
+			MaxSessionsPerIdentity: srv.cfg.MutualAuthMaxSessionsPerIdentity,
+			MaxPendingSessions:     srv.cfg.MutualAuthMaxPendingSessions,
+			Next:                   skipMutualAuth,
+		}))
+	}
+	if prices := srv.paymentPrices(); !prices.IsZero() {
+		globalMiddleware = append(globalMiddleware, srv.newPaymentMiddleware(prices))
+	}
+
 	openapi.RegisterHandlersWithOptions(srv.app, registry, openapi.FiberServerOptions{
 		HandlerMiddleware: []fiber.Handler{
 			middleware.BearerTokenAuthorizationMiddleware(srv.cfg.AdminBearerToken),
 		},
-		GlobalMiddleware: middleware.BasicMiddlewareGroup(middleware.BasicMiddlewareGroupConfig{
-			EnableStackTrace: true,
-			OctetStreamLimit: srv.cfg.OctetStreamLimit,
-		}),
+		GlobalMiddleware: globalMiddleware,
 	})
 
 	srv.app.Get("/metrics", monitor.New(monitor.Config{Title: "Overlay-services API"}))
@@ -197,6 +409,59 @@ func New(opts ...ServerOption) *ServerHTTP {
 	return srv
 }
 
+// newIdentityWallet returns the wallet holding the identity of the overlay node in the mutual authentication
+// and the payments. It panics if the private key is not a valid hex-encoded private key.
+func newIdentityWallet(privateKey string) wallet.KeyOperations {
+	key, err := ec.PrivateKeyFromHex(privateKey)
+	if err != nil {
+		panic(fmt.Sprintf("invalid identity private key: %v", err))
+	}
+	w, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypePrivateKey, PrivateKey: key})
+	if err != nil {
+		panic(fmt.Sprintf("identity wallet creation failed: %v", err))
+	}
+	return w
+}
+
+// paymentPrices returns the prices of the requests to the overlay set in the configuration.
+func (s *ServerHTTP) paymentPrices() middleware.PaymentPrices {
+	return middleware.PaymentPrices{
+		Submit:         s.cfg.SubmitPrice,
+		SubmitPerByte:  s.cfg.SubmitPricePerByte,
+		Lookup:         s.cfg.LookupPrice,
+		LookupServices: s.cfg.LookupServicePrices,
+	}
+}
+
+// newPaymentMiddleware returns the middleware charging the prices of the requests, paid to the payment private key
+// or else to the mutual authentication private key. It panics if neither key is set or if the engine does not
+// implement engine.PaymentAcceptor.
+func (s *ServerHTTP) newPaymentMiddleware(prices middleware.PaymentPrices) fiber.Handler {
+	privateKey := s.cfg.PaymentPrivateKey
+	if privateKey == "" {
+		privateKey = s.cfg.MutualAuthPrivateKey
+	}
+	if privateKey == "" {
+		panic("priced requests require a payment or mutual auth private key")
+	}
+	acceptor, ok := s.engine.(engine.PaymentAcceptor)
+	if !ok {
+		panic("priced requests require an engine implementing engine.PaymentAcceptor")
+	}
+	return middleware.PaymentMiddleware(middleware.PaymentMiddlewareConfig{
+		Wallet:   newIdentityWallet(privateKey),
+		Acceptor: acceptor,
+		Prices:   prices,
+	})
+}
+
+// skipMutualAuth reports whether the request is exempt from mutual authentication: the admin endpoints are
+// protected by the admin bearer token, the ARC callbacks by the ARC callback token, and the metrics are local.
+func skipMutualAuth(c *fiber.Ctx) bool {
+	path := c.Path()
+	return strings.HasPrefix(path, "/api/v1/admin/") || path == "/api/v1/arc-ingest" || path == "/metrics"
+}
+
 // newFiberApp creates and returns a new instance of a fiber.App with the provided configuration and middleware.
 // The app is configured with case-sensitive routing, strict routing, custom server headers, and read timeout settings.
 // Additionally, any provided middleware handlers are applied to the app.
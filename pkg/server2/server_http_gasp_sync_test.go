@@ -0,0 +1,112 @@
+package server2_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/advertiser"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/advertiser/walletadvertiser"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/discovery"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/gasp/core"
+	"github.com/4chain-ag/go-overlay-services/pkg/core/storage/memstorage"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// newSHIPEngine returns an engine hosting tm_ship that has admitted an advertisement of the topic.
+func newSHIPEngine(t *testing.T, topic string) (*engine.Engine, *memstorage.Storage) {
+	t.Helper()
+
+	storage := memstorage.New()
+	e := engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{advertiser.SHIPTopic: discovery.NewSHIPTopicManager()},
+		Storage:  storage,
+	})
+
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	wallet, err := walletadvertiser.NewLocalWallet(key)
+	require.NoError(t, err)
+	adv, err := walletadvertiser.New(context.Background(), walletadvertiser.Config{
+		Wallet:     wallet,
+		Storage:    storage,
+		HostingURL: "https://overlay.example.com",
+	})
+	require.NoError(t, err)
+
+	taggedBEEF, err := adv.CreateAdvertisements([]*advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: topic},
+	})
+	require.NoError(t, err)
+	_, err = e.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+	return e, storage
+}
+
+func findUTXOs(t *testing.T, storage engine.Storage) []transaction.Outpoint {
+	t.Helper()
+
+	outputs, err := storage.FindUTXOsForTopic(context.Background(), advertiser.SHIPTopic, 0, false)
+	require.NoError(t, err)
+	outpoints := make([]transaction.Outpoint, 0, len(outputs))
+	for _, output := range outputs {
+		outpoints = append(outpoints, output.Outpoint)
+	}
+	return outpoints
+}
+
+func TestServerHTTP_GASPSync_ShouldExchangeUTXOsBothWays_WhenBidirectional(t *testing.T) {
+	// given:
+	local, localStorage := newSHIPEngine(t, "tm_local")
+	peer, peerStorage := newSHIPEngine(t, "tm_peer")
+	expected := append(findUTXOs(t, localStorage), findUTXOs(t, peerStorage)...)
+
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(peer))
+	gasp := core.NewGASP(core.GASPParams{
+		Storage: engine.NewOverlayGASPStorage(advertiser.SHIPTopic, local, nil),
+		Remote: &engine.OverlayGASPRemote{
+			EndpointUrl: "http://peer.example.com/api/v1",
+			Topic:       advertiser.SHIPTopic,
+			HttpClient:  fixture.Client().GetClient(),
+		},
+		Unidirectional: false,
+	})
+
+	// when:
+	err := gasp.Sync(t.Context())
+
+	// then:
+	require.NoError(t, err)
+	require.ElementsMatch(t, expected, findUTXOs(t, localStorage))
+	require.ElementsMatch(t, expected, findUTXOs(t, peerStorage))
+}
+
+func TestServerHTTP_GASPSync_ShouldOnlyPull_WhenUnidirectional(t *testing.T) {
+	// given:
+	local, localStorage := newSHIPEngine(t, "tm_local")
+	peer, peerStorage := newSHIPEngine(t, "tm_peer")
+	peerUTXOs := findUTXOs(t, peerStorage)
+
+	fixture := server2.NewServerTestFixture(t, server2.WithEngine(peer))
+	gasp := core.NewGASP(core.GASPParams{
+		Storage: engine.NewOverlayGASPStorage(advertiser.SHIPTopic, local, nil),
+		Remote: &engine.OverlayGASPRemote{
+			EndpointUrl: "http://peer.example.com/api/v1",
+			Topic:       advertiser.SHIPTopic,
+			HttpClient:  fixture.Client().GetClient(),
+		},
+		Unidirectional: true,
+	})
+
+	// when:
+	err := gasp.Sync(t.Context())
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, findUTXOs(t, localStorage), 2)
+	require.ElementsMatch(t, peerUTXOs, findUTXOs(t, peerStorage))
+}
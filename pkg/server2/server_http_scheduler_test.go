@@ -0,0 +1,71 @@
+package server2_test
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/stretchr/testify/require"
+)
+
+// schedulingEngine is an engine whose background GASP sync reports when it starts and stops.
+type schedulingEngine struct {
+	engine.OverlayEngineProvider
+	started chan struct{}
+	stopped chan struct{}
+}
+
+func (s *schedulingEngine) RunGASPSyncScheduler(ctx context.Context) {
+	close(s.started)
+	<-ctx.Done()
+	close(s.stopped)
+}
+
+// freePort returns a TCP port that is free to listen on.
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestServerHTTP_Shutdown_ShouldStopGASPSyncScheduler(t *testing.T) {
+	// given:
+	cfg := server2.DefaultConfig
+	cfg.Port = freePort(t)
+	scheduler := &schedulingEngine{started: make(chan struct{}), stopped: make(chan struct{})}
+	srv := server2.New(server2.WithConfig(cfg), server2.WithEngine(scheduler))
+
+	served := make(chan error, 1)
+	go func() { served <- srv.ListenAndServe(context.Background()) }()
+	<-scheduler.started
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", net.JoinHostPort(cfg.Addr, strconv.Itoa(cfg.Port)))
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// when:
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	err := srv.Shutdown(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.NoError(t, <-served)
+
+	select {
+	case <-scheduler.stopped:
+	default:
+		t.Fatal("GASP sync scheduler was not stopped by the shutdown")
+	}
+}
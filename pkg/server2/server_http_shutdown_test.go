@@ -0,0 +1,78 @@
+package server2_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-overlay-services/pkg/core/engine"
+	"github.com/4chain-ag/go-overlay-services/pkg/server2"
+	"github.com/stretchr/testify/require"
+)
+
+// streamingEngine is an engine whose events are handed to subscribers by an event bus, and which
+// reports when a subscription was made.
+type streamingEngine struct {
+	engine.OverlayEngineProvider
+	bus        *engine.EventBus
+	subscribed chan struct{}
+}
+
+func (s *streamingEngine) SubscribeEvents(topics []string, after *uint64) (*engine.EventSubscription, error) {
+	defer close(s.subscribed)
+	return s.bus.Subscribe(topics, after)
+}
+
+func (s *streamingEngine) Close() {
+	s.bus.Close()
+}
+
+func TestServerHTTP_Shutdown_ShouldEndEventStreams(t *testing.T) {
+	// given:
+	cfg := server2.DefaultConfig
+	cfg.Port = freePort(t)
+	streaming := &streamingEngine{bus: engine.NewEventBus(engine.DefaultEventBufferSize), subscribed: make(chan struct{})}
+	srv := server2.New(server2.WithConfig(cfg), server2.WithEngine(streaming))
+
+	served := make(chan error, 1)
+	go func() { served <- srv.ListenAndServe(context.Background()) }()
+
+	streamed := make(chan error, 1)
+	go func() {
+		url := fmt.Sprintf("http://%s:%d/api/v1/events", cfg.Addr, cfg.Port)
+		var res *http.Response
+		var err error
+		for range 100 {
+			if res, err = http.Get(url); err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil {
+			streamed <- err
+			return
+		}
+		defer func() { _ = res.Body.Close() }()
+		_, err = io.Copy(io.Discard, res.Body)
+		streamed <- err
+	}()
+
+	select {
+	case <-streaming.subscribed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("The event stream was not opened")
+	}
+
+	// when:
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	err := srv.Shutdown(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.NoError(t, <-served)
+	require.NoError(t, <-streamed)
+}
@@ -44,6 +44,11 @@ func (f *ServerTestFixture) Client() *resty.Client {
 	return c
 }
 
+// RoundTripper returns the in-memory transport of the fixture, for the clients other than Client.
+func (f *ServerTestFixture) RoundTripper() http.RoundTripper {
+	return f.roundTripper
+}
+
 // NewServerTestFixture creates a new test fixture with a fully initialized server instance
 // and a custom in-memory HTTP round tripper. Panics if server initialization fails.
 func NewServerTestFixture(t *testing.T, opts ...ServerOption) *ServerTestFixture {